@@ -0,0 +1,91 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// AuthenticationConfig contains settings of the built-in authentication of api users.
+type AuthenticationConfig struct {
+	// TrustProxyHeader enables authentication by x-webauth-user header set by authenticating reverse proxy.
+	TrustProxyHeader bool
+	// OIDC contains settings of the login with OpenID Connect provider.
+	OIDC OIDCConfig
+	// Session contains settings of the sessions of users logged in with OpenID Connect provider.
+	Session SessionConfig
+	// APITokens contains settings of personal and team API tokens.
+	APITokens APITokensConfig
+}
+
+// OIDCConfig contains settings of the login with OpenID Connect authorization code flow.
+type OIDCConfig struct {
+	// Enabled turns on the /auth endpoints.
+	Enabled bool
+	// IssuerURL is used to discover endpoints of the provider, it must match issuer from the discovery document.
+	IssuerURL string
+	// ClientID and ClientSecret are the credentials of Moira registered in the provider.
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the url of /api/auth/callback endpoint as it is seen by browser.
+	RedirectURL string
+	// Scopes are requested in addition to openid scope.
+	Scopes []string
+	// UsernameClaim is the user info claim used as login of the user.
+	UsernameClaim string
+	// GroupsClaim is the user info claim containing list of groups of the user.
+	GroupsClaim string
+}
+
+// SessionConfig contains settings of the sessions of users logged in with OpenID Connect provider.
+type SessionConfig struct {
+	// TTL is the time after which user has to log in again.
+	TTL time.Duration
+	// CookieName is the name of cookie with session secret.
+	CookieName string
+	// SecureCookie restricts sending of session cookie to https connections.
+	SecureCookie bool
+}
+
+// APITokensConfig contains settings of personal and team API tokens.
+type APITokensConfig struct {
+	// Enabled turns on authentication by Authorization: Bearer header and the endpoints managing tokens.
+	Enabled bool
+	// MaxTTL limits lifetime of issued tokens, tokens without expiration can be issued if it is zero.
+	MaxTTL time.Duration
+}
+
+const (
+	// DefaultSessionTTL is the lifetime of session of user logged in with OpenID Connect provider.
+	DefaultSessionTTL = 24 * time.Hour
+	// DefaultSessionCookieName is the name of cookie with session secret.
+	DefaultSessionCookieName = "moira_session"
+	// DefaultOIDCUsernameClaim is the user info claim used as login of the user.
+	DefaultOIDCUsernameClaim = "preferred_username"
+	// DefaultOIDCGroupsClaim is the user info claim containing list of groups of the user.
+	DefaultOIDCGroupsClaim = "groups"
+
+	// APITokenPrefix helps to recognize leaked Moira API tokens, e.g. by secret scanners.
+	APITokenPrefix = "moira_"
+
+	secretSize = 32
+)
+
+// NewSecret generates random secret with the given prefix.
+func NewSecret(prefix string) (string, error) {
+	bytes := make([]byte, secretSize)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	return prefix + base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashSecret returns hash of secret, only hashes of API tokens and session secrets are stored in database.
+func HashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
@@ -3,9 +3,13 @@ package api
 // Authorization contains authorization configuration.
 type Authorization struct {
 	AdminList                  map[string]struct{}
+	AdminGroups                map[string]struct{}
 	Enabled                    bool
 	AllowedContactTypes        map[string]struct{}
 	LimitedChangeTriggerOwners map[string]struct{}
+	// ServiceAccountTeamID is set for requests authenticated by API token of the team, such requests have
+	// service account role in this team and no access to other teams.
+	ServiceAccountTeamID string
 }
 
 // IsEnabled returns true if auth is enabled and false otherwise.
@@ -24,6 +28,49 @@ func (auth *Authorization) IsAdmin(login string) bool {
 	return ok
 }
 
+// IsAdminGroupMember checks whether any of the given groups of OpenID Connect provider grants administrator role.
+func (auth *Authorization) IsAdminGroupMember(groups []string) bool {
+	for _, group := range groups {
+		if _, ok := auth.AdminGroups[group]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithAdmin returns copy of authorization in which the given user is or is not considered an administrator.
+// It is used to apply the role of the user determined during authentication to the rest of the request.
+func (auth *Authorization) WithAdmin(login string, isAdmin bool) *Authorization {
+	if auth.IsAdmin(login) == isAdmin {
+		return auth
+	}
+
+	adminList := make(map[string]struct{}, len(auth.AdminList)+1)
+	for admin := range auth.AdminList {
+		adminList[admin] = struct{}{}
+	}
+
+	if isAdmin {
+		adminList[login] = struct{}{}
+	} else {
+		delete(adminList, login)
+	}
+
+	result := *auth
+	result.AdminList = adminList
+
+	return &result
+}
+
+// WithServiceAccount returns copy of authorization in which the user acts as service account of the given team.
+func (auth *Authorization) WithServiceAccount(teamID string) *Authorization {
+	result := *auth
+	result.ServiceAccountTeamID = teamID
+
+	return &result
+}
+
 // The Role is an enumeration that represents the scope of user's permissions.
 type Role string
 
@@ -51,14 +51,43 @@ type Sentry struct {
 
 // Config for api configuration variables.
 type Config struct {
-	EnableCORS    bool
-	Listen        string
-	MetricsTTL    map[moira.ClusterKey]time.Duration
-	Flags         FeatureFlags
-	Authorization Authorization
-	Limits        LimitsConfig
+	EnableCORS     bool
+	Listen         string
+	MetricsTTL     map[moira.ClusterKey]time.Duration
+	Flags          FeatureFlags
+	Authorization  Authorization
+	Authentication AuthenticationConfig
+	Limits         LimitsConfig
+	IncidentSync   IncidentSyncConfig
+	Preview        NotificationPreviewConfig
 }
 
+// NotificationPreviewConfig contains the settings of messages rendered by dry-run of subscriptions.
+// They must be the same as the settings of notifier, so the messages are the same as sent ones.
+type NotificationPreviewConfig struct {
+	// FrontURI is the prefix of links to triggers.
+	FrontURI string
+	// Location is the timezone of time of events.
+	Location *time.Location
+	// DateTimeFormat is the format of time of events in mails.
+	DateTimeFormat string
+	// Senders are the settings of senders of notifier which messages are rendered with.
+	Senders []map[string]interface{}
+}
+
+// IncidentSyncConfig contains the settings of incoming webhooks of incident management tools (PagerDuty, OpsGenie).
+type IncidentSyncConfig struct {
+	// Enabled turns on the /incident endpoints.
+	Enabled bool
+	// Token must be passed in the token query parameter of the webhook url.
+	Token string
+	// AckMaintenance is the duration of the maintenance set when the incident is acknowledged.
+	AckMaintenance time.Duration
+}
+
+// DefaultIncidentAckMaintenance is the maintenance duration of the acknowledged incident.
+const DefaultIncidentAckMaintenance = 4 * time.Hour
+
 // WebConfig is container for web ui configuration parameters.
 type WebConfig struct {
 	SupportEmail         string                `json:"supportEmail,omitempty" example:"kontur.moira.alert@gmail.com"`
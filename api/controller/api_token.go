@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// CreateAPIToken issues new personal API token of the user or, if teamID is not empty, API token of the team.
+func CreateAPIToken(
+	dataBase moira.Database,
+	config api.APITokensConfig,
+	auth *api.Authorization,
+	userLogin, teamID string,
+	tokenRequest *dto.APITokenRequest,
+) (*dto.IssuedAPIToken, *api.ErrorResponse) {
+	token := moira.APIToken{
+		Name:      tokenRequest.Name,
+		User:      userLogin,
+		TeamID:    teamID,
+		Scopes:    tokenRequest.Scopes,
+		CreatedAt: time.Now().Unix(),
+		ExpiresAt: tokenRequest.ExpiresAt,
+	}
+
+	if token.HasScope(moira.APITokenScopeAdmin) {
+		if teamID != "" {
+			return nil, api.ErrorInvalidRequest(errors.New("team api token can not have admin scope"))
+		}
+
+		if !auth.IsAdmin(userLogin) {
+			return nil, api.ErrorForbidden("only administrators can issue api tokens with admin scope")
+		}
+	}
+
+	if config.MaxTTL > 0 {
+		maxExpiresAt := token.CreatedAt + int64(config.MaxTTL.Seconds())
+
+		if token.ExpiresAt == nil {
+			token.ExpiresAt = &maxExpiresAt
+		} else if *token.ExpiresAt > maxExpiresAt {
+			return nil, api.ErrorInvalidRequest(fmt.Errorf("api token can not be valid longer than %s", config.MaxTTL))
+		}
+	}
+
+	uuid4, err := uuid.NewV4()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	token.ID = uuid4.String()
+
+	secret, err := api.NewSecret(api.APITokenPrefix)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	token.Hash = api.HashSecret(secret)
+
+	if err = dataBase.SaveAPIToken(&token); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.IssuedAPIToken{
+		APIToken: dto.NewAPIToken(token),
+		Token:    secret,
+	}, nil
+}
+
+// GetUserAPITokens returns personal API tokens of the user.
+func GetUserAPITokens(dataBase moira.Database, userLogin string) (*dto.APITokenList, *api.ErrorResponse) {
+	tokens, err := dataBase.GetUserAPITokens(userLogin)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return newAPITokenList(tokens), nil
+}
+
+// GetTeamAPITokens returns API tokens of the team.
+func GetTeamAPITokens(dataBase moira.Database, teamID string) (*dto.APITokenList, *api.ErrorResponse) {
+	tokens, err := dataBase.GetTeamAPITokens(teamID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return newAPITokenList(tokens), nil
+}
+
+// RemoveUserAPIToken revokes personal API token of the user.
+func RemoveUserAPIToken(dataBase moira.Database, userLogin, tokenID string) *api.ErrorResponse {
+	return removeAPIToken(dataBase, tokenID, func(token moira.APIToken) bool {
+		return token.TeamID == "" && token.User == userLogin
+	})
+}
+
+// RemoveTeamAPIToken revokes API token of the team.
+func RemoveTeamAPIToken(dataBase moira.Database, teamID, tokenID string) *api.ErrorResponse {
+	return removeAPIToken(dataBase, tokenID, func(token moira.APIToken) bool {
+		return token.TeamID == teamID
+	})
+}
+
+func removeAPIToken(dataBase moira.Database, tokenID string, isOwned func(token moira.APIToken) bool) *api.ErrorResponse {
+	token, err := dataBase.GetAPIToken(tokenID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return api.ErrorNotFound(fmt.Sprintf("api token with ID '%s' does not exists", tokenID))
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	// Do not reveal existence of tokens of other users and teams.
+	if !isOwned(token) {
+		return api.ErrorNotFound(fmt.Sprintf("api token with ID '%s' does not exists", tokenID))
+	}
+
+	if err = dataBase.RemoveAPIToken(tokenID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+func newAPITokenList(tokens []*moira.APIToken) *dto.APITokenList {
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].CreatedAt != tokens[j].CreatedAt {
+			return tokens[i].CreatedAt < tokens[j].CreatedAt
+		}
+
+		return tokens[i].ID < tokens[j].ID
+	})
+
+	list := &dto.APITokenList{
+		List: make([]dto.APIToken, 0, len(tokens)),
+	}
+
+	for _, token := range tokens {
+		list.List = append(list.List, dto.NewAPIToken(*token))
+	}
+
+	return list
+}
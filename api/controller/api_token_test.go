@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIToken(t *testing.T) {
+	Convey("CreateAPIToken", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			user   = "user"
+			admin  = "admin"
+			teamID = "teamID"
+		)
+
+		auth := &api.Authorization{Enabled: true, AdminList: map[string]struct{}{admin: {}}}
+		config := api.APITokensConfig{Enabled: true}
+		tokenRequest := &dto.APITokenRequest{Name: "deploy", Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+
+		Convey("create personal token successfully", func() {
+			var saved *moira.APIToken
+			dataBase.EXPECT().SaveAPIToken(gomock.Any()).DoAndReturn(func(token *moira.APIToken) error {
+				saved = token
+				return nil
+			})
+
+			issued, err := CreateAPIToken(dataBase, config, auth, user, "", tokenRequest)
+			So(err, ShouldBeNil)
+			So(issued.ID, ShouldEqual, saved.ID)
+			So(issued.Token, ShouldStartWith, api.APITokenPrefix)
+			So(saved.Hash, ShouldEqual, api.HashSecret(issued.Token))
+			So(saved.User, ShouldEqual, user)
+			So(saved.TeamID, ShouldBeEmpty)
+			So(saved.ExpiresAt, ShouldBeNil)
+		})
+
+		Convey("create team token successfully", func() {
+			dataBase.EXPECT().SaveAPIToken(gomock.Any()).DoAndReturn(func(token *moira.APIToken) error {
+				So(token.TeamID, ShouldEqual, teamID)
+				So(token.User, ShouldEqual, user)
+				return nil
+			})
+
+			_, err := CreateAPIToken(dataBase, config, auth, user, teamID, tokenRequest)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("with max ttl", func() {
+			config.MaxTTL = time.Hour
+
+			Convey("token without expiration expires after max ttl", func() {
+				dataBase.EXPECT().SaveAPIToken(gomock.Any()).DoAndReturn(func(token *moira.APIToken) error {
+					So(*token.ExpiresAt, ShouldEqual, token.CreatedAt+int64(time.Hour.Seconds()))
+					return nil
+				})
+
+				_, err := CreateAPIToken(dataBase, config, auth, user, "", tokenRequest)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("token valid longer than max ttl is rejected", func() {
+				expiresAt := time.Now().Add(2 * time.Hour).Unix()
+				tokenRequest.ExpiresAt = &expiresAt
+
+				_, err := CreateAPIToken(dataBase, config, auth, user, "", tokenRequest)
+				So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("api token can not be valid longer than %s", config.MaxTTL)))
+			})
+		})
+
+		Convey("with admin scope", func() {
+			tokenRequest.Scopes = []moira.APITokenScope{moira.APITokenScopeRead, moira.APITokenScopeAdmin}
+
+			Convey("admin creates token successfully", func() {
+				dataBase.EXPECT().SaveAPIToken(gomock.Any()).Return(nil)
+
+				_, err := CreateAPIToken(dataBase, config, auth, admin, "", tokenRequest)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("user is forbidden", func() {
+				_, err := CreateAPIToken(dataBase, config, auth, user, "", tokenRequest)
+				So(err, ShouldResemble, api.ErrorForbidden("only administrators can issue api tokens with admin scope"))
+			})
+
+			Convey("team token is rejected", func() {
+				_, err := CreateAPIToken(dataBase, config, auth, admin, teamID, tokenRequest)
+				So(err, ShouldResemble, api.ErrorInvalidRequest(errors.New("team api token can not have admin scope")))
+			})
+		})
+
+		Convey("database error", func() {
+			dbErr := errors.New("db error")
+			dataBase.EXPECT().SaveAPIToken(gomock.Any()).Return(dbErr)
+
+			_, err := CreateAPIToken(dataBase, config, auth, user, "", tokenRequest)
+			So(err, ShouldResemble, api.ErrorInternalServer(dbErr))
+		})
+	})
+}
+
+func TestGetUserAPITokens(t *testing.T) {
+	Convey("GetUserAPITokens", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const user = "user"
+
+		Convey("tokens are sorted by creation time", func() {
+			tokens := []*moira.APIToken{
+				{ID: "second", Name: "second", User: user, CreatedAt: 2, Hash: "hash2"},
+				{ID: "first", Name: "first", User: user, CreatedAt: 1, Hash: "hash1"},
+			}
+			dataBase.EXPECT().GetUserAPITokens(user).Return(tokens, nil)
+
+			list, err := GetUserAPITokens(dataBase, user)
+			So(err, ShouldBeNil)
+			So(list.List, ShouldHaveLength, 2)
+			So(list.List[0].ID, ShouldEqual, "first")
+			So(list.List[1].ID, ShouldEqual, "second")
+		})
+
+		Convey("database error", func() {
+			dbErr := errors.New("db error")
+			dataBase.EXPECT().GetUserAPITokens(user).Return(nil, dbErr)
+
+			_, err := GetUserAPITokens(dataBase, user)
+			So(err, ShouldResemble, api.ErrorInternalServer(dbErr))
+		})
+	})
+}
+
+func TestRemoveAPIToken(t *testing.T) {
+	Convey("RemoveAPIToken", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			user    = "user"
+			teamID  = "teamID"
+			tokenID = "tokenID"
+		)
+
+		notFound := api.ErrorNotFound(fmt.Sprintf("api token with ID '%s' does not exists", tokenID))
+
+		Convey("remove personal token successfully", func() {
+			dataBase.EXPECT().GetAPIToken(tokenID).Return(moira.APIToken{ID: tokenID, User: user}, nil)
+			dataBase.EXPECT().RemoveAPIToken(tokenID).Return(nil)
+
+			So(RemoveUserAPIToken(dataBase, user, tokenID), ShouldBeNil)
+		})
+
+		Convey("token of other user is not found", func() {
+			dataBase.EXPECT().GetAPIToken(tokenID).Return(moira.APIToken{ID: tokenID, User: "other"}, nil)
+
+			So(RemoveUserAPIToken(dataBase, user, tokenID), ShouldResemble, notFound)
+		})
+
+		Convey("team token can not be removed as personal", func() {
+			dataBase.EXPECT().GetAPIToken(tokenID).Return(moira.APIToken{ID: tokenID, User: user, TeamID: teamID}, nil)
+
+			So(RemoveUserAPIToken(dataBase, user, tokenID), ShouldResemble, notFound)
+		})
+
+		Convey("remove team token successfully", func() {
+			dataBase.EXPECT().GetAPIToken(tokenID).Return(moira.APIToken{ID: tokenID, User: "other", TeamID: teamID}, nil)
+			dataBase.EXPECT().RemoveAPIToken(tokenID).Return(nil)
+
+			So(RemoveTeamAPIToken(dataBase, teamID, tokenID), ShouldBeNil)
+		})
+
+		Convey("missing token is not found", func() {
+			dataBase.EXPECT().GetAPIToken(tokenID).Return(moira.APIToken{}, database.ErrNil)
+
+			So(RemoveTeamAPIToken(dataBase, teamID, tokenID), ShouldResemble, notFound)
+		})
+	})
+}
+
+func TestCreateAuthSession(t *testing.T) {
+	Convey("CreateAuthSession", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		Convey("session is saved by hash of the secret", func() {
+			var savedHash string
+			dataBase.EXPECT().SaveAuthSession(gomock.Any(), gomock.Any()).DoAndReturn(func(hash string, session moira.AuthSession) error {
+				savedHash = hash
+				So(session.Login, ShouldEqual, "user")
+				So(session.Groups, ShouldResemble, []string{"admins"})
+				return nil
+			})
+
+			secret, expiresAt, err := CreateAuthSession(dataBase, "user", []string{"admins"}, time.Hour)
+			So(err, ShouldBeNil)
+			So(strings.TrimSpace(secret), ShouldNotBeEmpty)
+			So(savedHash, ShouldEqual, api.HashSecret(secret))
+			So(expiresAt, ShouldHappenAfter, time.Now())
+		})
+	})
+}
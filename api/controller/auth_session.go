@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+)
+
+// CreateAuthSession creates session of the user logged in with OpenID Connect provider and returns secret of the session
+// which must be passed to the browser in cookie, as well as expiration time of the session.
+func CreateAuthSession(dataBase moira.Database, login string, groups []string, ttl time.Duration) (string, time.Time, *api.ErrorResponse) {
+	secret, err := api.NewSecret("")
+	if err != nil {
+		return "", time.Time{}, api.ErrorInternalServer(err)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+
+	session := moira.AuthSession{
+		Login:     login,
+		Groups:    groups,
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	if err = dataBase.SaveAuthSession(api.HashSecret(secret), session); err != nil {
+		return "", time.Time{}, api.ErrorInternalServer(err)
+	}
+
+	return secret, expiresAt, nil
+}
+
+// RemoveAuthSession removes session with the given secret, so the user has to log in again.
+func RemoveAuthSession(dataBase moira.Database, secret string) *api.ErrorResponse {
+	if err := dataBase.RemoveAuthSession(api.HashSecret(secret)); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
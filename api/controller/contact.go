@@ -60,13 +60,15 @@ func GetContactById(database moira.Database, contactID string) (*dto.ContactWith
 }
 
 // CreateContact creates new notification contact for current user.
+// requesterLogin is the login of the user who creates the contact, it differs from userLogin for team contacts.
 func CreateContact(
 	dataBase moira.Database,
 	auth *api.Authorization,
 	contactsTemplate []api.WebContact,
 	contact *dto.Contact,
 	userLogin,
-	teamID string,
+	teamID,
+	requesterLogin string,
 ) *api.ErrorResponse {
 	if !isAllowedToUseContactType(auth, userLogin, contact.Type) {
 		return api.ErrorInvalidRequest(ErrNotAllowedContactType)
@@ -85,6 +87,7 @@ func CreateContact(
 		Type:         contact.Type,
 		Value:        contact.Value,
 		ExtraMessage: contact.ExtraMessage,
+		BodyTemplate: contact.BodyTemplate,
 	}
 
 	if contactData.ID == "" {
@@ -109,6 +112,10 @@ func CreateContact(
 		return api.ErrorInvalidRequest(err)
 	}
 
+	if err := validateOnCallContact(dataBase, contactData, requesterLogin, auth); err != nil {
+		return err
+	}
+
 	if err := dataBase.SaveContact(&contactData); err != nil {
 		return api.ErrorInternalServer(err)
 	}
@@ -121,12 +128,14 @@ func CreateContact(
 }
 
 // UpdateContact updates notification contact for current user.
+// requesterLogin is the login of the user who updates the contact.
 func UpdateContact(
 	dataBase moira.Database,
 	auth *api.Authorization,
 	contactsTemplate []api.WebContact,
 	contactDTO dto.Contact,
 	contactData moira.ContactData,
+	requesterLogin string,
 ) (dto.Contact, *api.ErrorResponse) {
 	if !isAllowedToUseContactType(auth, contactDTO.User, contactDTO.Type) {
 		return contactDTO, api.ErrorInvalidRequest(ErrNotAllowedContactType)
@@ -136,6 +145,7 @@ func UpdateContact(
 	contactData.Value = contactDTO.Value
 	contactData.Name = contactDTO.Name
 	contactData.ExtraMessage = contactDTO.ExtraMessage
+	contactData.BodyTemplate = contactDTO.BodyTemplate
 
 	if contactDTO.User != "" || contactDTO.TeamID != "" {
 		contactData.User = contactDTO.User
@@ -146,6 +156,10 @@ func UpdateContact(
 		return contactDTO, api.ErrorInvalidRequest(err)
 	}
 
+	if err := validateOnCallContact(dataBase, contactData, requesterLogin, auth); err != nil {
+		return contactDTO, err
+	}
+
 	if err := dataBase.SaveContact(&contactData); err != nil {
 		return contactDTO, api.ErrorInternalServer(err)
 	}
@@ -268,12 +282,13 @@ func SendTestContactNotification(dataBase moira.Database, contactID string, wait
 	}
 }
 
-// CheckUserPermissionsForContact checks contact for existence and permissions for given user.
+// CheckUserPermissionsForContact checks contact for existence and that the given user has the given access to it.
 func CheckUserPermissionsForContact(
 	dataBase moira.Database,
 	contactID string,
 	userLogin string,
 	auth *api.Authorization,
+	access moira.TeamAccess,
 ) (moira.ContactData, *api.ErrorResponse) {
 	contactData, err := dataBase.GetContact(contactID)
 	if err != nil {
@@ -288,18 +303,25 @@ func CheckUserPermissionsForContact(
 		return contactData, nil
 	}
 
+	// Resources of the team are available according to the role in the team, even to the user who created them.
 	if contactData.Team != "" {
-		teamContainsUser, err := dataBase.IsTeamContainUser(contactData.Team, userLogin)
+		role, err := getUserTeamRole(dataBase, contactData.Team, userLogin, auth)
 		if err != nil {
 			return moira.ContactData{}, api.ErrorInternalServer(err)
 		}
 
-		if teamContainsUser {
-			return contactData, nil
+		if role == "" {
+			return moira.ContactData{}, api.ErrorForbidden("you are not permitted")
 		}
+
+		if !role.Allows(access) {
+			return moira.ContactData{}, api.ErrorForbidden(fmt.Sprintf("your role %s in the team does not permit this", role))
+		}
+
+		return contactData, nil
 	}
 
-	if contactData.User == userLogin {
+	if contactData.User == userLogin && auth.ServiceAccountTeamID == "" {
 		return contactData, nil
 	}
 
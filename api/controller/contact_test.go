@@ -167,7 +167,7 @@ func TestCreateContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 		})
@@ -187,7 +187,7 @@ func TestCreateContact(t *testing.T) {
 
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 			dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, &contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, &contact, userLogin, "", userLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 			So(contact.ID, ShouldResemble, contact.ID)
@@ -200,7 +200,7 @@ func TestCreateContact(t *testing.T) {
 				Type:  contactType,
 			}
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("contact with this ID already exists")))
 		})
 
@@ -212,7 +212,7 @@ func TestCreateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops! Can not write contact")
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, err)
-			expected := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			expected := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(expected, ShouldResemble, api.ErrorInternalServer(err))
 		})
 
@@ -229,7 +229,7 @@ func TestCreateContact(t *testing.T) {
 				Type:  contactType,
 			}
 			expectedErr := api.ErrorInvalidRequest(fmt.Errorf("contact value doesn't match regex: '%s'", "@yandex.ru"))
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldResemble, expectedErr)
 		})
 
@@ -247,7 +247,7 @@ func TestCreateContact(t *testing.T) {
 				Type:  notAllowedContactType,
 			}
 			expectedErr := api.ErrorInvalidRequest(ErrNotAllowedContactType)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldResemble, expectedErr)
 		})
 
@@ -274,7 +274,7 @@ func TestCreateContact(t *testing.T) {
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 			dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
 
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldBeNil)
 		})
 
@@ -285,7 +285,7 @@ func TestCreateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops! Can not write contact")
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(err)
-			expected := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			expected := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(expected, ShouldResemble, &api.ErrorResponse{
 				ErrorText:      err.Error(),
 				HTTPStatusCode: http.StatusInternalServerError,
@@ -303,7 +303,7 @@ func TestCreateContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(err, ShouldBeNil)
 			So(contact.TeamID, ShouldResemble, teamID)
 		})
@@ -323,7 +323,7 @@ func TestCreateContact(t *testing.T) {
 
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 			dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, &contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, &contact, "", teamID, userLogin)
 			So(err, ShouldBeNil)
 			So(contact.TeamID, ShouldResemble, teamID)
 			So(contact.ID, ShouldResemble, contact.ID)
@@ -346,7 +346,7 @@ func TestCreateContact(t *testing.T) {
 
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 			dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, &contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, &contact, "", teamID, userLogin)
 			So(err, ShouldBeNil)
 			So(contact.TeamID, ShouldResemble, teamID)
 			So(contact.Name, ShouldResemble, expectedContact.Name)
@@ -359,7 +359,7 @@ func TestCreateContact(t *testing.T) {
 				Type:  contactType,
 			}
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("contact with this ID already exists")))
 		})
 
@@ -371,7 +371,7 @@ func TestCreateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops! Can not write contact")
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, err)
-			expected := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			expected := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(expected, ShouldResemble, api.ErrorInternalServer(err))
 		})
 
@@ -382,7 +382,7 @@ func TestCreateContact(t *testing.T) {
 				Type:  notAllowedContactType,
 			}
 			expectedErr := api.ErrorInvalidRequest(ErrNotAllowedContactType)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(err, ShouldResemble, expectedErr)
 		})
 
@@ -409,7 +409,7 @@ func TestCreateContact(t *testing.T) {
 			dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 			dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
 
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(err, ShouldBeNil)
 		})
 
@@ -420,7 +420,7 @@ func TestCreateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops! Can not write contact")
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(err)
-			expected := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID)
+			expected := CreateContact(dataBase, auth, contactsTemplate, contact, "", teamID, userLogin)
 			So(expected, ShouldResemble, &api.ErrorResponse{
 				ErrorText:      err.Error(),
 				HTTPStatusCode: http.StatusInternalServerError,
@@ -464,7 +464,7 @@ func TestAdminsCreatesContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 		})
@@ -477,7 +477,7 @@ func TestAdminsCreatesContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "", adminLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, adminLogin)
 		})
@@ -490,7 +490,7 @@ func TestAdminsCreatesContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 		})
@@ -503,7 +503,7 @@ func TestAdminsCreatesContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "", adminLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 		})
@@ -516,13 +516,75 @@ func TestAdminsCreatesContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
-			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "")
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "", adminLogin)
 			So(err, ShouldBeNil)
 			So(contact.User, ShouldResemble, userLogin)
 		})
 	})
 }
 
+func TestCreateOnCallContact(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	defer mockCtrl.Finish()
+
+	const (
+		userLogin    = "user"
+		adminLogin   = "admin"
+		onCallTeamID = "onCallTeam"
+	)
+
+	auth := &api.Authorization{
+		Enabled:   true,
+		AdminList: map[string]struct{}{adminLogin: {}},
+		AllowedContactTypes: map[string]struct{}{
+			moira.OnCallContactType: {},
+		},
+	}
+
+	contactsTemplate := []api.WebContact{}
+
+	Convey("Create on-call contact", t, func() {
+		Convey("By member of the team", func() {
+			contact := &dto.Contact{Value: onCallTeamID, Type: moira.OnCallContactType}
+
+			dataBase.EXPECT().GetTeam(onCallTeamID).Return(moira.Team{ID: onCallTeamID}, nil).Times(2)
+			dataBase.EXPECT().IsTeamContainUser(onCallTeamID, userLogin).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(onCallTeamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}, nil)
+			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("By admin", func() {
+			contact := &dto.Contact{Value: onCallTeamID, Type: moira.OnCallContactType}
+
+			dataBase.EXPECT().GetTeam(onCallTeamID).Return(moira.Team{ID: onCallTeamID}, nil)
+			dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, adminLogin, "", adminLogin)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("By user who is not a member of the team", func() {
+			contact := &dto.Contact{Value: onCallTeamID, Type: moira.OnCallContactType}
+
+			dataBase.EXPECT().GetTeam(onCallTeamID).Return(moira.Team{ID: onCallTeamID}, nil).Times(2)
+			dataBase.EXPECT().IsTeamContainUser(onCallTeamID, userLogin).Return(false, nil)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
+			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
+		})
+
+		Convey("For team which does not exist", func() {
+			contact := &dto.Contact{Value: onCallTeamID, Type: moira.OnCallContactType}
+
+			dataBase.EXPECT().GetTeam(onCallTeamID).Return(moira.Team{}, database.ErrNil)
+			err := CreateContact(dataBase, auth, contactsTemplate, contact, userLogin, "", userLogin)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team %s of on-call contact does not exist", onCallTeamID)))
+		})
+	})
+}
+
 func TestUpdateContact(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
@@ -567,7 +629,7 @@ func TestUpdateContact(t *testing.T) {
 				User:  userLogin,
 			}
 			dataBase.EXPECT().SaveContact(&contact).Return(nil)
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 			So(err, ShouldBeNil)
 			So(expectedContact.User, ShouldResemble, userLogin)
 			So(expectedContact.ID, ShouldResemble, contactID)
@@ -591,7 +653,7 @@ func TestUpdateContact(t *testing.T) {
 				User:  newUser,
 			}
 			dataBase.EXPECT().SaveContact(&contact).Return(nil)
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 			So(err, ShouldBeNil)
 			So(expectedContact.User, ShouldResemble, newUser)
 			So(expectedContact.ID, ShouldResemble, contactID)
@@ -605,7 +667,7 @@ func TestUpdateContact(t *testing.T) {
 			}
 			expectedErr := api.ErrorInvalidRequest(ErrNotAllowedContactType)
 			contactID := uuid.Must(uuid.NewV4()).String()
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 			So(err, ShouldResemble, expectedErr)
 			So(expectedContact.User, ShouldResemble, contactDTO.User)
 			So(expectedContact.ID, ShouldResemble, contactDTO.ID)
@@ -626,7 +688,7 @@ func TestUpdateContact(t *testing.T) {
 			}
 			expectedErr := api.ErrorInvalidRequest(fmt.Errorf("contact value doesn't match regex: '%s'", "@yandex.ru"))
 			contactID := uuid.Must(uuid.NewV4()).String()
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 			So(err, ShouldResemble, expectedErr)
 			So(expectedContact.User, ShouldResemble, contactDTO.User)
 			So(expectedContact.ID, ShouldResemble, contactDTO.ID)
@@ -662,7 +724,7 @@ func TestUpdateContact(t *testing.T) {
 			}
 
 			dataBase.EXPECT().SaveContact(&contact).Return(nil)
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 			So(err, ShouldBeNil)
 			So(expectedContact.User, ShouldResemble, userLogin)
 			So(expectedContact.ID, ShouldResemble, contactID)
@@ -683,7 +745,7 @@ func TestUpdateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops")
 			dataBase.EXPECT().SaveContact(&contact).Return(err)
-			expectedContact, actual := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, contact)
+			expectedContact, actual := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, contact, userLogin)
 			So(actual, ShouldResemble, api.ErrorInternalServer(err))
 			So(expectedContact.User, ShouldResemble, contactDTO.User)
 			So(expectedContact.ID, ShouldResemble, contactDTO.ID)
@@ -704,7 +766,7 @@ func TestUpdateContact(t *testing.T) {
 				Team:  teamID,
 			}
 			dataBase.EXPECT().SaveContact(&contact).Return(nil)
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, Team: teamID})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, Team: teamID}, userLogin)
 			So(err, ShouldBeNil)
 			So(expectedContact.TeamID, ShouldResemble, teamID)
 			So(expectedContact.ID, ShouldResemble, contactID)
@@ -725,7 +787,7 @@ func TestUpdateContact(t *testing.T) {
 				Team:  newTeam,
 			}
 			dataBase.EXPECT().SaveContact(&contact).Return(nil)
-			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, Team: teamID})
+			expectedContact, err := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, moira.ContactData{ID: contactID, Team: teamID}, userLogin)
 			So(err, ShouldBeNil)
 			So(expectedContact.TeamID, ShouldResemble, newTeam)
 			So(expectedContact.ID, ShouldResemble, contactID)
@@ -745,7 +807,7 @@ func TestUpdateContact(t *testing.T) {
 			}
 			err := fmt.Errorf("oooops")
 			dataBase.EXPECT().SaveContact(&contact).Return(err)
-			expectedContact, actual := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, contact)
+			expectedContact, actual := UpdateContact(dataBase, auth, contactsTemplate, contactDTO, contact, userLogin)
 			So(actual, ShouldResemble, api.ErrorInternalServer(err))
 			So(expectedContact.TeamID, ShouldResemble, contactDTO.TeamID)
 			So(expectedContact.ID, ShouldResemble, contactDTO.ID)
@@ -983,14 +1045,14 @@ func TestCheckUserPermissionsForContact(t *testing.T) {
 
 	Convey("No contact", t, func() {
 		dataBase.EXPECT().GetContact(id).Return(moira.ContactData{}, database.ErrNil)
-		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("contact with ID '%s' does not exists", id)))
 		So(expectedContact, ShouldResemble, moira.ContactData{})
 	})
 
 	Convey("Different user", t, func() {
 		dataBase.EXPECT().GetContact(id).Return(moira.ContactData{User: "diffUser"}, nil)
-		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorForbidden("you are not permitted"))
 		So(expectedContact, ShouldResemble, moira.ContactData{})
 	})
@@ -998,7 +1060,7 @@ func TestCheckUserPermissionsForContact(t *testing.T) {
 	Convey("Has contact", t, func() {
 		actualContact := moira.ContactData{ID: id, User: userLogin}
 		dataBase.EXPECT().GetContact(id).Return(actualContact, nil)
-		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldBeNil)
 		So(expectedContact, ShouldResemble, actualContact)
 	})
@@ -1006,7 +1068,7 @@ func TestCheckUserPermissionsForContact(t *testing.T) {
 	Convey("Error get contact", t, func() {
 		err := fmt.Errorf("oooops! Can not read contact")
 		dataBase.EXPECT().GetContact(id).Return(moira.ContactData{User: userLogin}, err)
-		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+		expectedContact, expected := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorInternalServer(err))
 		So(expectedContact, ShouldResemble, moira.ContactData{})
 	})
@@ -1016,14 +1078,30 @@ func TestCheckUserPermissionsForContact(t *testing.T) {
 			expectedSub := moira.ContactData{ID: id, Team: teamID}
 			dataBase.EXPECT().GetContact(id).Return(expectedSub, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
-			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
+			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expectedSub)
+		})
+		Convey("Viewer can not change it", func() {
+			dataBase.EXPECT().GetContact(id).Return(moira.ContactData{ID: id, Team: teamID, User: userLogin}, nil)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}, nil)
+			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessEdit)
+			So(err, ShouldResemble, api.ErrorForbidden("your role viewer in the team does not permit this"))
+			So(actual, ShouldResemble, moira.ContactData{})
+		})
+		Convey("Service account of the team can change it", func() {
+			expectedSub := moira.ContactData{ID: id, Team: teamID}
+			dataBase.EXPECT().GetContact(id).Return(expectedSub, nil)
+			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth.WithServiceAccount(teamID), moira.TeamAccessEdit)
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, expectedSub)
 		})
 		Convey("User is not in team", func() {
 			dataBase.EXPECT().GetContact(id).Return(moira.ContactData{ID: id, Team: teamID}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, nil)
-			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
 			So(actual, ShouldResemble, moira.ContactData{})
 		})
@@ -1032,7 +1110,7 @@ func TestCheckUserPermissionsForContact(t *testing.T) {
 
 			dataBase.EXPECT().GetContact(id).Return(moira.ContactData{ID: id, Team: teamID}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, errReturned)
-			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth)
+			actual, err := CheckUserPermissionsForContact(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorInternalServer(errReturned))
 			So(actual, ShouldResemble, moira.ContactData{})
 		})
@@ -1052,7 +1130,7 @@ func TestCheckAdminPermissionsForContact(t *testing.T) {
 	Convey("Same user", t, func() {
 		expectedContact := moira.ContactData{ID: id, User: adminLogin}
 		dataBase.EXPECT().GetContact(id).Return(expectedContact, nil)
-		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedContact)
 	})
@@ -1060,7 +1138,7 @@ func TestCheckAdminPermissionsForContact(t *testing.T) {
 	Convey("Different user", t, func() {
 		expectedContact := moira.ContactData{ID: id, User: "diffUser"}
 		dataBase.EXPECT().GetContact(id).Return(expectedContact, nil)
-		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedContact)
 	})
@@ -1068,7 +1146,7 @@ func TestCheckAdminPermissionsForContact(t *testing.T) {
 	Convey("Team contact", t, func() {
 		expectedContact := moira.ContactData{ID: id, Team: teamID}
 		dataBase.EXPECT().GetContact(id).Return(expectedContact, nil)
-		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForContact(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedContact)
 	})
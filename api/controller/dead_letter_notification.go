@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetDeadLetterNotifications gets dead letter notifications from current page, if end==-1 && start==0 gets all of them.
+func GetDeadLetterNotifications(database moira.Database, start, end int64) (*dto.DeadLetterNotificationsList, *api.ErrorResponse) {
+	notifications, total, err := database.GetDeadLetterNotifications(start, end)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.DeadLetterNotificationsList{
+		List:  notifications,
+		Total: total,
+	}, nil
+}
+
+// GetDeadLetterNotification gets dead letter notification by its id.
+func GetDeadLetterNotification(dataBase moira.Database, id string) (*dto.DeadLetterNotification, *api.ErrorResponse) {
+	notification, errorResponse := getDeadLetterNotification(dataBase, id)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	return &dto.DeadLetterNotification{DeadLetterNotification: notification}, nil
+}
+
+// ReplayDeadLetterNotification schedules dead letter notification to be sent right now and removes it from dead letter queue.
+// If contactID is not empty, notification is sent to this contact instead of the original one.
+func ReplayDeadLetterNotification(dataBase moira.Database, id, contactID string) (*dto.DeadLetterReplayResponse, *api.ErrorResponse) {
+	deadLetter, errorResponse := getDeadLetterNotification(dataBase, id)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	notification := deadLetter.ToScheduledNotification(time.Now().Unix())
+
+	if contactID != "" {
+		contact, err := dataBase.GetContact(contactID)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				return nil, api.ErrorNotFound(fmt.Sprintf("contact with ID '%s' does not exists", contactID))
+			}
+
+			return nil, api.ErrorInternalServer(err)
+		}
+
+		notification.Contact = contact
+	}
+
+	if err := dataBase.AddNotification(notification); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	if _, err := dataBase.RemoveDeadLetterNotification(id); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.DeadLetterReplayResponse{Notification: notification}, nil
+}
+
+// DeleteDeadLetterNotification removes dead letter notification by its id.
+func DeleteDeadLetterNotification(database moira.Database, id string) (*dto.NotificationDeleteResponse, *api.ErrorResponse) {
+	result, err := database.RemoveDeadLetterNotification(id)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.NotificationDeleteResponse{Result: result}, nil
+}
+
+// DeleteAllDeadLetterNotifications purges dead letter queue.
+func DeleteAllDeadLetterNotifications(database moira.Database) *api.ErrorResponse {
+	if err := database.RemoveAllDeadLetterNotifications(); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+func getDeadLetterNotification(dataBase moira.Database, id string) (moira.DeadLetterNotification, *api.ErrorResponse) {
+	notification, err := dataBase.GetDeadLetterNotification(id)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return moira.DeadLetterNotification{}, api.ErrorNotFound(fmt.Sprintf("dead letter notification with ID '%s' does not exists", id))
+		}
+
+		return moira.DeadLetterNotification{}, api.ErrorInternalServer(err)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetDeadLetterNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Has dead letter notifications", t, func() {
+		notifications := []*moira.DeadLetterNotification{{ID: "1", Attempts: 10}, {ID: "2", Attempts: 1}}
+
+		dataBase.EXPECT().GetDeadLetterNotifications(int64(0), int64(-1)).Return(notifications, int64(2), nil)
+		list, err := GetDeadLetterNotifications(dataBase, 0, -1)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeadLetterNotificationsList{List: notifications, Total: 2})
+	})
+
+	Convey("Test error", t, func() {
+		expected := fmt.Errorf("oooops! Can not get dead letter notifications")
+
+		dataBase.EXPECT().GetDeadLetterNotifications(int64(0), int64(-1)).Return(nil, int64(0), expected)
+		list, err := GetDeadLetterNotifications(dataBase, 0, -1)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestGetDeadLetterNotification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Success", t, func() {
+		notification := moira.DeadLetterNotification{ID: "1", Error: "some error"}
+
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(notification, nil)
+		actual, err := GetDeadLetterNotification(dataBase, "1")
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &dto.DeadLetterNotification{DeadLetterNotification: notification})
+	})
+
+	Convey("Not found", t, func() {
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(moira.DeadLetterNotification{}, database.ErrNil)
+		actual, err := GetDeadLetterNotification(dataBase, "1")
+		So(err, ShouldResemble, api.ErrorNotFound("dead letter notification with ID '1' does not exists"))
+		So(actual, ShouldBeNil)
+	})
+}
+
+func TestReplayDeadLetterNotification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	originalContact := moira.ContactData{ID: "contact-1", Type: "slack", Value: "#alerts"}
+	deadLetter := moira.DeadLetterNotification{
+		ID: "1",
+		Notification: moira.ScheduledNotification{
+			Event:    moira.NotificationEvent{TriggerID: "trigger-1", Metric: "metric", State: moira.StateERROR},
+			Trigger:  moira.TriggerData{ID: "trigger-1"},
+			Contact:  originalContact,
+			SendFail: 10,
+		},
+		Attempts: 11,
+	}
+
+	Convey("Replay to the original contact", t, func() {
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(deadLetter, nil)
+		dataBase.EXPECT().AddNotification(gomock.Any()).DoAndReturn(func(notification *moira.ScheduledNotification) error {
+			So(notification.Contact, ShouldResemble, originalContact)
+			So(notification.Event, ShouldResemble, deadLetter.Notification.Event)
+			So(notification.SendFail, ShouldEqual, 0)
+			So(notification.Timestamp, ShouldBeGreaterThan, 0)
+
+			return nil
+		})
+		dataBase.EXPECT().RemoveDeadLetterNotification("1").Return(int64(1), nil)
+
+		response, err := ReplayDeadLetterNotification(dataBase, "1", "")
+		So(err, ShouldBeNil)
+		So(response.Notification.Contact, ShouldResemble, originalContact)
+	})
+
+	Convey("Replay to another contact", t, func() {
+		anotherContact := moira.ContactData{ID: "contact-2", Type: "mail", Value: "user@example.com"}
+
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(deadLetter, nil)
+		dataBase.EXPECT().GetContact(anotherContact.ID).Return(anotherContact, nil)
+		dataBase.EXPECT().AddNotification(gomock.Any()).Return(nil)
+		dataBase.EXPECT().RemoveDeadLetterNotification("1").Return(int64(1), nil)
+
+		response, err := ReplayDeadLetterNotification(dataBase, "1", anotherContact.ID)
+		So(err, ShouldBeNil)
+		So(response.Notification.Contact, ShouldResemble, anotherContact)
+	})
+
+	Convey("Replay to unknown contact", t, func() {
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(deadLetter, nil)
+		dataBase.EXPECT().GetContact("unknown").Return(moira.ContactData{}, database.ErrNil)
+
+		response, err := ReplayDeadLetterNotification(dataBase, "1", "unknown")
+		So(err, ShouldResemble, api.ErrorNotFound("contact with ID 'unknown' does not exists"))
+		So(response, ShouldBeNil)
+	})
+
+	Convey("Error on schedule notification", t, func() {
+		expected := fmt.Errorf("oooops! Can not add notification")
+
+		dataBase.EXPECT().GetDeadLetterNotification("1").Return(deadLetter, nil)
+		dataBase.EXPECT().AddNotification(gomock.Any()).Return(expected)
+
+		response, err := ReplayDeadLetterNotification(dataBase, "1", "")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(response, ShouldBeNil)
+	})
+}
+
+func TestDeleteDeadLetterNotification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Success", t, func() {
+		dataBase.EXPECT().RemoveDeadLetterNotification("1").Return(int64(1), nil)
+		actual, err := DeleteDeadLetterNotification(dataBase, "1")
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &dto.NotificationDeleteResponse{Result: 1})
+	})
+
+	Convey("Error delete", t, func() {
+		expected := fmt.Errorf("oooops! Can not remove dead letter notification")
+
+		dataBase.EXPECT().RemoveDeadLetterNotification("1").Return(int64(0), expected)
+		actual, err := DeleteDeadLetterNotification(dataBase, "1")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(actual, ShouldBeNil)
+	})
+}
+
+func TestDeleteAllDeadLetterNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Success", t, func() {
+		dataBase.EXPECT().RemoveAllDeadLetterNotifications().Return(nil)
+		err := DeleteAllDeadLetterNotifications(dataBase)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error delete", t, func() {
+		expected := fmt.Errorf("oooops! Can not purge dead letter queue")
+
+		dataBase.EXPECT().RemoveAllDeadLetterNotifications().Return(expected)
+		err := DeleteAllDeadLetterNotifications(dataBase)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
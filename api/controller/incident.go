@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// ApplyIncidentAction maps the action made on the incident in PagerDuty or OpsGenie to the maintenance
+// of the trigger metric, so the acknowledged incident stops notifying the other contacts.
+// Acknowledgement sets the maintenance for ackMaintenance, resolution or unacknowledgement removes it
+// only if it is still the maintenance set by acknowledgement, so the maintenance set by hand is kept.
+func ApplyIncidentAction(
+	dataBase moira.Database,
+	webhook dto.IncidentWebhook,
+	ackMaintenance time.Duration,
+	timeCallMaintenance int64,
+) *api.ErrorResponse {
+	action := webhook.GetAction()
+
+	switch action {
+	case dto.IncidentAcknowledged, dto.IncidentUnacknowledged, dto.IncidentResolved:
+	default:
+		return nil
+	}
+
+	incidentKey := webhook.GetIncidentKey()
+
+	triggerID, metric, err := moira.ParseIncidentKey(incidentKey)
+	if err != nil {
+		return api.ErrorInvalidRequest(err)
+	}
+
+	if action == dto.IncidentAcknowledged {
+		return acknowledgeIncident(dataBase, webhook, triggerID, metric != "", ackMaintenance, timeCallMaintenance)
+	}
+
+	return closeIncident(dataBase, webhook, triggerID, metric != "", timeCallMaintenance)
+}
+
+// acknowledgeIncident sets the maintenance of the trigger or its metric and records it for the incident.
+func acknowledgeIncident(
+	dataBase moira.Database,
+	webhook dto.IncidentWebhook,
+	triggerID string,
+	isMetricIncident bool,
+	ackMaintenance time.Duration,
+	timeCallMaintenance int64,
+) *api.ErrorResponse {
+	incidentKey := webhook.GetIncidentKey()
+	maintenance := timeCallMaintenance + int64(ackMaintenance.Seconds())
+
+	triggerMaintenance := dto.TriggerMaintenance{
+		Metrics: make(dto.MetricsMaintenance),
+	}
+
+	if isMetricIncident {
+		lastCheck, errResponse := getIncidentLastCheck(dataBase, triggerID)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		metric, errResponse := getIncidentMetric(lastCheck, triggerID, incidentKey)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		triggerMaintenance.Metrics[metric] = maintenance
+	} else {
+		triggerMaintenance.Trigger = &maintenance
+	}
+
+	if errResponse := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, webhook.GetUser(), timeCallMaintenance); errResponse != nil {
+		return errResponse
+	}
+
+	if err := dataBase.SetIncidentMaintenance(incidentKey, maintenance); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+// closeIncident removes the maintenance of the trigger or its metric if it is still the one acknowledgement of the incident set.
+func closeIncident(
+	dataBase moira.Database,
+	webhook dto.IncidentWebhook,
+	triggerID string,
+	isMetricIncident bool,
+	timeCallMaintenance int64,
+) *api.ErrorResponse {
+	incidentKey := webhook.GetIncidentKey()
+
+	ackMaintenance, err := dataBase.GetIncidentMaintenance(incidentKey)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			// The incident was not acknowledged or its maintenance is over, so there is nothing to remove
+			return nil
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	lastCheck, errResponse := getIncidentLastCheck(dataBase, triggerID)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	var maintenance int64
+
+	triggerMaintenance := dto.TriggerMaintenance{
+		Metrics: make(dto.MetricsMaintenance),
+	}
+
+	if isMetricIncident {
+		metric, errResponse := getIncidentMetric(lastCheck, triggerID, incidentKey)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		if lastCheck.Metrics[metric].Maintenance == ackMaintenance {
+			triggerMaintenance.Metrics[metric] = maintenance
+		}
+	} else if lastCheck.Maintenance == ackMaintenance {
+		triggerMaintenance.Trigger = &maintenance
+	}
+
+	if triggerMaintenance.Trigger != nil || len(triggerMaintenance.Metrics) > 0 {
+		if errResponse := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, webhook.GetUser(), timeCallMaintenance); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	if err := dataBase.RemoveIncidentMaintenance(incidentKey); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+func getIncidentLastCheck(dataBase moira.Database, triggerID string) (moira.CheckData, *api.ErrorResponse) {
+	lastCheck, err := dataBase.GetTriggerLastCheck(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return moira.CheckData{}, api.ErrorNotFound(fmt.Sprintf("trigger check with ID = '%s' does not exists", triggerID))
+		}
+
+		return moira.CheckData{}, api.ErrorInternalServer(err)
+	}
+
+	return lastCheck, nil
+}
+
+// getIncidentMetric finds the metric of the trigger by the incident key,
+// the last check is used because the metric may be hashed in the key.
+func getIncidentMetric(lastCheck moira.CheckData, triggerID, incidentKey string) (string, *api.ErrorResponse) {
+	for metric := range lastCheck.Metrics {
+		if moira.IncidentKey(triggerID, metric) == incidentKey {
+			return metric, nil
+		}
+	}
+
+	return "", api.ErrorNotFound(fmt.Sprintf("metric of incident '%s' does not exist", incidentKey))
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyIncidentAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	const (
+		triggerID           = "trigger-id"
+		metric              = "server.web1.cpu"
+		timeCallMaintenance = int64(1000)
+		ackMaintenance      = time.Hour
+	)
+
+	lastCheck := moira.CheckData{
+		Metrics: map[string]moira.MetricState{
+			metric: {State: moira.StateERROR},
+		},
+	}
+
+	Convey("Test applying incident action", t, func() {
+		Convey("Acknowledged PagerDuty incident sets metric maintenance", func() {
+			webhook := &dto.PagerDutyWebhook{Event: dto.PagerDutyWebhookEvent{
+				EventType: "incident.acknowledged",
+				Agent:     &dto.PagerDutyReference{Summary: "John"},
+				Data:      dto.PagerDutyIncident{IncidentKey: moira.IncidentKey(triggerID, metric)},
+			}}
+
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
+			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 30)
+			dataBase.EXPECT().ReleaseTriggerCheckLock(triggerID)
+			dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, map[string]int64{metric: timeCallMaintenance + 3600}, nil, "pagerduty:John", timeCallMaintenance).Return(nil)
+			dataBase.EXPECT().SetIncidentMaintenance(moira.IncidentKey(triggerID, metric), timeCallMaintenance+3600).Return(nil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Closed OpsGenie alert of the trigger removes trigger maintenance set by acknowledgement", func() {
+			incidentKey := moira.IncidentKey(triggerID, "")
+			webhook := &dto.OpsGenieWebhook{
+				Action: "Close",
+				Alert:  dto.OpsGenieAlert{Alias: incidentKey, Username: "john@example.com"},
+			}
+
+			var maintenance int64
+
+			dataBase.EXPECT().GetIncidentMaintenance(incidentKey).Return(timeCallMaintenance+3600, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{Maintenance: timeCallMaintenance + 3600}, nil)
+			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 30)
+			dataBase.EXPECT().ReleaseTriggerCheckLock(triggerID)
+			dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, map[string]int64{}, &maintenance, "opsgenie:john@example.com", timeCallMaintenance).Return(nil)
+			dataBase.EXPECT().RemoveIncidentMaintenance(incidentKey).Return(nil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Resolved PagerDuty incident keeps metric maintenance set by hand after acknowledgement", func() {
+			incidentKey := moira.IncidentKey(triggerID, metric)
+			webhook := &dto.PagerDutyWebhook{Event: dto.PagerDutyWebhookEvent{
+				EventType: "incident.resolved",
+				Data:      dto.PagerDutyIncident{IncidentKey: incidentKey},
+			}}
+
+			dataBase.EXPECT().GetIncidentMaintenance(incidentKey).Return(timeCallMaintenance+3600, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+				Metrics: map[string]moira.MetricState{
+					metric: {State: moira.StateERROR, Maintenance: timeCallMaintenance + 86400},
+				},
+			}, nil)
+			dataBase.EXPECT().RemoveIncidentMaintenance(incidentKey).Return(nil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Unacknowledged incident without recorded maintenance does nothing", func() {
+			incidentKey := moira.IncidentKey(triggerID, metric)
+			webhook := &dto.OpsGenieWebhook{Action: "UnAcknowledge", Alert: dto.OpsGenieAlert{Alias: incidentKey}}
+
+			dataBase.EXPECT().GetIncidentMaintenance(incidentKey).Return(int64(0), database.ErrNil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Incident of the hashed metric is found by the last check", func() {
+			longMetric := strings.Repeat("metric.", 50)
+			webhook := &dto.OpsGenieWebhook{
+				Action: "Acknowledge",
+				Alert:  dto.OpsGenieAlert{Alias: moira.IncidentKey(triggerID, longMetric)},
+			}
+
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+				Metrics: map[string]moira.MetricState{metric: {}, longMetric: {}},
+			}, nil)
+			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 30)
+			dataBase.EXPECT().ReleaseTriggerCheckLock(triggerID)
+			dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, map[string]int64{longMetric: timeCallMaintenance + 3600}, nil, "opsgenie", timeCallMaintenance).Return(nil)
+			dataBase.EXPECT().SetIncidentMaintenance(moira.IncidentKey(triggerID, longMetric), timeCallMaintenance+3600).Return(nil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Ignored action does nothing", func() {
+			webhook := &dto.PagerDutyWebhook{Event: dto.PagerDutyWebhookEvent{EventType: "incident.annotated"}}
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Foreign incident key is a bad request", func() {
+			webhook := &dto.OpsGenieWebhook{Action: "Acknowledge", Alert: dto.OpsGenieAlert{Alias: "some-alias"}}
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldNotBeNil)
+			So(err.HTTPStatusCode, ShouldEqual, 400)
+		})
+
+		Convey("Incident of unknown trigger is not found", func() {
+			webhook := &dto.OpsGenieWebhook{Action: "Acknowledge", Alert: dto.OpsGenieAlert{Alias: moira.IncidentKey(triggerID, metric)}}
+
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+
+			err := ApplyIncidentAction(dataBase, webhook, ackMaintenance, timeCallMaintenance)
+			So(err, ShouldResemble, api.ErrorNotFound("trigger check with ID = 'trigger-id' does not exists"))
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetMaintenanceWindows returns all scheduled maintenance windows.
+func GetMaintenanceWindows(dataBase moira.Database) (*dto.MaintenanceWindowList, *api.ErrorResponse) {
+	windows, err := dataBase.GetMaintenanceWindows()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	list := &dto.MaintenanceWindowList{
+		List: make([]dto.MaintenanceWindow, 0, len(windows)),
+	}
+
+	for _, window := range windows {
+		list.List = append(list.List, dto.MaintenanceWindow(*window))
+	}
+
+	return list, nil
+}
+
+// CreateMaintenanceWindow schedules new maintenance window, checker applies it to targeted triggers and metrics automatically.
+// User must have permissions for all targeted triggers, only admins can target triggers by tags and metrics by patterns.
+func CreateMaintenanceWindow(
+	dataBase moira.Database,
+	window *dto.MaintenanceWindow,
+	userLogin string,
+	auth *api.Authorization,
+	createdAt int64,
+) *api.ErrorResponse {
+	if (len(window.Tags) != 0 || len(window.Patterns) != 0) && auth.IsEnabled() && !auth.IsAdmin(userLogin) {
+		return api.ErrorForbidden("only admins can target triggers by tags and metrics by patterns")
+	}
+
+	for _, triggerID := range window.TriggerIDs {
+		if _, err := dataBase.GetTrigger(triggerID); err != nil {
+			if errors.Is(err, database.ErrNil) {
+				return api.ErrorInvalidRequest(fmt.Errorf("trigger %s does not exist", triggerID))
+			}
+
+			return api.ErrorInternalServer(err)
+		}
+
+		if errorResponse := CheckUserPermissionsForTrigger(dataBase, triggerID, userLogin, auth); errorResponse != nil {
+			return errorResponse
+		}
+	}
+
+	uuid4, err := uuid.NewV4()
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	window.ID = uuid4.String()
+	window.CreatedBy = userLogin
+	window.CreatedAt = createdAt
+
+	if err = dataBase.SaveMaintenanceWindow((*moira.MaintenanceWindow)(window)); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+// RemoveMaintenanceWindow cancels maintenance window. Maintenance of triggers and metrics set by current occurrence
+// of the window is stopped too, unless it was changed by user since. Only creator of the window and admins can cancel it.
+func RemoveMaintenanceWindow(dataBase moira.Database, windowID, userLogin string, auth *api.Authorization, timeCallMaintenance int64) *api.ErrorResponse {
+	window, err := dataBase.GetMaintenanceWindow(windowID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return api.ErrorNotFound(fmt.Sprintf("maintenance window with ID '%s' does not exists", windowID))
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	if window.CreatedBy != userLogin && auth.IsEnabled() && !auth.IsAdmin(userLogin) {
+		return api.ErrorForbidden("only creator of the maintenance window and admins can cancel it")
+	}
+
+	if err := dataBase.RemoveMaintenanceWindow(windowID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	if occurrence, ok := window.GetOccurrence(timeCallMaintenance); ok {
+		return stopMaintenanceOccurrence(dataBase, occurrence, userLogin, timeCallMaintenance)
+	}
+
+	return nil
+}
+
+// stopMaintenanceOccurrence stops maintenance of triggers and metrics which is still set by the occurrence,
+// checker marks such maintenance with creator of the window and start of the occurrence.
+func stopMaintenanceOccurrence(dataBase moira.Database, occurrence moira.MaintenanceOccurrence, userLogin string, timeCallMaintenance int64) *api.ErrorResponse {
+	triggerIDs, err := getMaintenanceWindowTriggerIDs(dataBase, occurrence.Window)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	stopped := int64(0)
+
+	for _, triggerID := range triggerIDs {
+		lastCheck, err := dataBase.GetTriggerLastCheck(triggerID)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+
+			return api.ErrorInternalServer(err)
+		}
+
+		triggerMaintenance := dto.TriggerMaintenance{Metrics: make(map[string]int64)}
+		if isSetByMaintenanceOccurrence(&lastCheck, occurrence) {
+			triggerMaintenance.Trigger = &stopped
+		}
+
+		for metric, metricState := range lastCheck.Metrics {
+			if isSetByMaintenanceOccurrence(&metricState, occurrence) {
+				triggerMaintenance.Metrics[metric] = stopped
+			}
+		}
+
+		if triggerMaintenance.Trigger == nil && len(triggerMaintenance.Metrics) == 0 {
+			continue
+		}
+
+		if apiErr := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, userLogin, timeCallMaintenance); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	return nil
+}
+
+// getMaintenanceWindowTriggerIDs returns ids of triggers which can be targeted by the window.
+// Metrics matching patterns of the window can belong to any trigger.
+func getMaintenanceWindowTriggerIDs(dataBase moira.Database, window *moira.MaintenanceWindow) ([]string, error) {
+	if len(window.Patterns) != 0 {
+		return dataBase.GetAllTriggerIDs()
+	}
+
+	triggerIDs := window.TriggerIDs
+
+	// Triggers must have all tags of the window, so it is enough to take triggers of any of them.
+	if len(window.Tags) != 0 {
+		tagTriggerIDs, err := dataBase.GetTagTriggerIDs(window.Tags[0])
+		if err != nil {
+			return nil, err
+		}
+
+		triggerIDs = append(slices.Clone(triggerIDs), tagTriggerIDs...)
+	}
+
+	return triggerIDs, nil
+}
+
+func isSetByMaintenanceOccurrence(state moira.MaintenanceCheck, occurrence moira.MaintenanceOccurrence) bool {
+	maintenanceInfo, maintenance := state.GetMaintenance()
+
+	return maintenance == occurrence.End &&
+		maintenanceInfo.StartUser != nil && *maintenanceInfo.StartUser == occurrence.Window.CreatedBy &&
+		maintenanceInfo.StartTime != nil && *maintenanceInfo.StartTime == occurrence.Start
+}
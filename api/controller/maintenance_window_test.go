@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMaintenanceWindows(t *testing.T) {
+	Convey("GetMaintenanceWindows", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		Convey("windows are returned", func() {
+			window := &moira.MaintenanceWindow{ID: "window1", Name: "backups", Start: 100, End: 200}
+			dataBase.EXPECT().GetMaintenanceWindows().Return([]*moira.MaintenanceWindow{window}, nil)
+
+			actual, err := GetMaintenanceWindows(dataBase)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, &dto.MaintenanceWindowList{List: []dto.MaintenanceWindow{dto.MaintenanceWindow(*window)}})
+		})
+
+		Convey("database error", func() {
+			errReturned := errors.New("test error")
+			dataBase.EXPECT().GetMaintenanceWindows().Return(nil, errReturned)
+
+			actual, err := GetMaintenanceWindows(dataBase)
+			So(err, ShouldResemble, api.ErrorInternalServer(errReturned))
+			So(actual, ShouldBeNil)
+		})
+	})
+}
+
+func TestCreateMaintenanceWindow(t *testing.T) {
+	Convey("CreateMaintenanceWindow", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			admin     = "admin"
+			userLogin = "user"
+			createdAt = int64(1000)
+		)
+
+		auth := &api.Authorization{Enabled: true, AdminList: map[string]struct{}{admin: {}}}
+
+		Convey("window is saved with generated id", func() {
+			window := &dto.MaintenanceWindow{Name: "release", Start: 2000, End: 3000, TriggerIDs: []string{"trigger1"}}
+			dataBase.EXPECT().GetTrigger("trigger1").Return(moira.Trigger{ID: "trigger1"}, nil).Times(2)
+			dataBase.EXPECT().SaveMaintenanceWindow(gomock.Any()).Return(nil)
+
+			err := CreateMaintenanceWindow(dataBase, window, userLogin, auth, createdAt)
+			So(err, ShouldBeNil)
+			So(window.ID, ShouldNotBeEmpty)
+			So(window.CreatedBy, ShouldEqual, userLogin)
+			So(window.CreatedAt, ShouldEqual, createdAt)
+		})
+
+		Convey("window targeting not existing trigger", func() {
+			window := &dto.MaintenanceWindow{Name: "release", Start: 2000, End: 3000, TriggerIDs: []string{"trigger1"}}
+			dataBase.EXPECT().GetTrigger("trigger1").Return(moira.Trigger{}, database.ErrNil)
+
+			err := CreateMaintenanceWindow(dataBase, window, userLogin, auth, createdAt)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger trigger1 does not exist")))
+		})
+
+		Convey("user without permissions for the trigger cannot target it", func() {
+			const teamID = "team1"
+
+			window := &dto.MaintenanceWindow{Name: "release", Start: 2000, End: 3000, TriggerIDs: []string{"trigger1"}}
+			dataBase.EXPECT().GetTrigger("trigger1").Return(moira.Trigger{ID: "trigger1", TeamID: teamID}, nil).Times(2)
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, nil)
+
+			err := CreateMaintenanceWindow(dataBase, window, userLogin, auth, createdAt)
+			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
+		})
+
+		Convey("window targeting tags and patterns", func() {
+			window := &dto.MaintenanceWindow{Name: "backups", Start: 2000, End: 3000, Tags: []string{"postgres"}, Patterns: []string{"db.*.lag"}}
+
+			Convey("is saved by admin", func() {
+				dataBase.EXPECT().SaveMaintenanceWindow(gomock.Any()).Return(nil)
+
+				err := CreateMaintenanceWindow(dataBase, window, admin, auth, createdAt)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("is saved by any user when auth is disabled", func() {
+				dataBase.EXPECT().SaveMaintenanceWindow(gomock.Any()).Return(nil)
+
+				err := CreateMaintenanceWindow(dataBase, window, userLogin, &api.Authorization{}, createdAt)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("is not saved by user", func() {
+				err := CreateMaintenanceWindow(dataBase, window, userLogin, auth, createdAt)
+				So(err, ShouldResemble, api.ErrorForbidden("only admins can target triggers by tags and metrics by patterns"))
+			})
+		})
+	})
+}
+
+func TestRemoveMaintenanceWindow(t *testing.T) {
+	Convey("RemoveMaintenanceWindow", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			windowID = "window1"
+			admin    = "admin"
+			creator  = "creator"
+			now      = int64(1704070000)
+		)
+
+		auth := &api.Authorization{Enabled: true, AdminList: map[string]struct{}{admin: {}}}
+		window := moira.MaintenanceWindow{ID: windowID, CreatedBy: creator}
+
+		Convey("window is removed by its creator", func() {
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(window, nil)
+			dataBase.EXPECT().RemoveMaintenanceWindow(windowID).Return(nil)
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, creator, auth, now), ShouldBeNil)
+		})
+
+		Convey("window is removed by admin", func() {
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(window, nil)
+			dataBase.EXPECT().RemoveMaintenanceWindow(windowID).Return(nil)
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, admin, auth, now), ShouldBeNil)
+		})
+
+		Convey("maintenance set by active occurrence is stopped", func() {
+			activeWindow := moira.MaintenanceWindow{
+				ID:         windowID,
+				Start:      1704067200,
+				End:        1704074400,
+				TriggerIDs: []string{"trigger1"},
+				Patterns:   []string{"db.*.lag"},
+				CreatedBy:  creator,
+			}
+			windowUser, windowStart := creator, activeWindow.Start
+			otherUser, otherStart := "other", int64(1704068000)
+
+			setByWindow := moira.MaintenanceInfo{StartUser: &windowUser, StartTime: &windowStart}
+			setByUser := moira.MaintenanceInfo{StartUser: &otherUser, StartTime: &otherStart}
+
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(activeWindow, nil)
+			dataBase.EXPECT().RemoveMaintenanceWindow(windowID).Return(nil)
+			dataBase.EXPECT().GetAllTriggerIDs().Return([]string{"trigger1", "trigger2"}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck("trigger1").Return(moira.CheckData{
+				Maintenance:     activeWindow.End,
+				MaintenanceInfo: setByWindow,
+				Metrics: map[string]moira.MetricState{
+					"db.1.lag": {Maintenance: activeWindow.End, MaintenanceInfo: setByWindow},
+					"db.2.lag": {Maintenance: activeWindow.End + 3600, MaintenanceInfo: setByUser},
+				},
+			}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck("trigger2").Return(moira.CheckData{
+				Maintenance:     activeWindow.End + 3600,
+				MaintenanceInfo: setByUser,
+			}, nil)
+
+			stopped := int64(0)
+			dataBase.EXPECT().AcquireTriggerCheckLock("trigger1", maxTriggerLockAttempts).Return(nil)
+			dataBase.EXPECT().SetTriggerCheckMaintenance("trigger1", map[string]int64{"db.1.lag": 0}, &stopped, admin, now).Return(nil)
+			dataBase.EXPECT().ReleaseTriggerCheckLock("trigger1")
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, admin, auth, now), ShouldBeNil)
+		})
+
+		Convey("maintenance is not stopped after occurrence has finished", func() {
+			finishedWindow := moira.MaintenanceWindow{ID: windowID, Start: 1704060000, End: 1704063600, TriggerIDs: []string{"trigger1"}, CreatedBy: creator}
+
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(finishedWindow, nil)
+			dataBase.EXPECT().RemoveMaintenanceWindow(windowID).Return(nil)
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, creator, auth, now), ShouldBeNil)
+		})
+
+		Convey("window is not removed by other user", func() {
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(window, nil)
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, "other", auth, now), ShouldResemble, api.ErrorForbidden("only creator of the maintenance window and admins can cancel it"))
+		})
+
+		Convey("window does not exist", func() {
+			dataBase.EXPECT().GetMaintenanceWindow(windowID).Return(moira.MaintenanceWindow{}, database.ErrNil)
+
+			So(RemoveMaintenanceWindow(dataBase, windowID, creator, auth, now), ShouldResemble, api.ErrorNotFound("maintenance window with ID 'window1' does not exists"))
+		})
+	})
+}
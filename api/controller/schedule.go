@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/ical"
+)
+
+// recurringEventsImportYears limits occurrences of recurring events imported as schedule exceptions.
+const recurringEventsImportYears = 1
+
+// ImportScheduleExceptions converts events of iCalendar from request into schedule exceptions.
+// Occurrences of recurring events are imported for the next year, recurring events with unsupported rules are skipped.
+// Exceptions are not saved, they are meant to be added to subscription or trigger schedule.
+func ImportScheduleExceptions(importRequest dto.ScheduleExceptionsImport) (*dto.ScheduleExceptionList, *api.ErrorResponse) {
+	location, err := time.LoadLocation(importRequest.Timezone)
+	if err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+
+	now := time.Now()
+
+	calendar, err := ical.Parse(strings.NewReader(importRequest.Calendar), location, now, now.AddDate(recurringEventsImportYears, 0, 0))
+	if err != nil {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("failed to parse calendar: %w", err))
+	}
+
+	exceptions := make([]moira.ScheduleException, 0, len(calendar.Events))
+	for _, event := range calendar.Events {
+		exceptions = append(exceptions, moira.ScheduleException{
+			Name: event.Summary,
+			From: event.Start.Unix(),
+			To:   event.End.Unix(),
+		})
+	}
+
+	return &dto.ScheduleExceptionList{List: exceptions, Skipped: calendar.Skipped}, nil
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/dto"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20240101
+DTEND;VALUE=DATE:20240102
+SUMMARY:New Year
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestImportScheduleExceptions(t *testing.T) {
+	Convey("ImportScheduleExceptions", t, func() {
+		// 2024-01-01 00:00 and 2024-01-02 00:00 in Berlin
+		expected := &dto.ScheduleExceptionList{
+			List:    []moira.ScheduleException{{Name: "New Year", From: 1704063600, To: 1704150000}},
+			Skipped: []string{},
+		}
+
+		Convey("calendar from request", func() {
+			actual, err := ImportScheduleExceptions(dto.ScheduleExceptionsImport{Calendar: testCalendar, Timezone: "Europe/Berlin"})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+		})
+
+		Convey("calendar with recurring events", func() {
+			calendar := "BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nRRULE:FREQ=YEARLY\nSUMMARY:New Year\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nRRULE:FREQ=WEEKLY;BYDAY=MO,FR\nSUMMARY:Planning\nEND:VEVENT\n"
+			now := time.Now()
+
+			actual, err := ImportScheduleExceptions(dto.ScheduleExceptionsImport{Calendar: calendar})
+			So(err, ShouldBeNil)
+			So(actual.Skipped, ShouldResemble, []string{"Planning"})
+			So(actual.List, ShouldHaveLength, 1)
+			So(actual.List[0].Name, ShouldEqual, "New Year")
+			So(actual.List[0].To, ShouldBeGreaterThan, now.Unix())
+			So(actual.List[0].From, ShouldBeLessThan, now.AddDate(recurringEventsImportYears, 0, 0).Unix())
+		})
+
+		Convey("invalid calendar", func() {
+			actual, err := ImportScheduleExceptions(dto.ScheduleExceptionsImport{Calendar: "BEGIN:VEVENT\nDTSTART:broken\nEND:VEVENT"})
+			So(err, ShouldNotBeNil)
+			So(err.HTTPStatusCode, ShouldEqual, http.StatusBadRequest)
+			So(actual, ShouldBeNil)
+		})
+	})
+}
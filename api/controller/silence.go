@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/silences"
+)
+
+// GetSilences returns all silences including expired ones, notifier removes silences expired a week ago.
+func GetSilences(dataBase moira.Database) (*dto.SilenceList, *api.ErrorResponse) {
+	silences, err := dataBase.GetSilences()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	list := &dto.SilenceList{
+		List: make([]dto.Silence, 0, len(silences)),
+	}
+
+	for _, silence := range silences {
+		list.List = append(list.List, dto.Silence(*silence))
+	}
+
+	return list, nil
+}
+
+// CreateSilence saves new silence, notifier stops sending notifications about matching events when silence starts.
+// User must have permissions for all triggers the silence is limited to, only admins can create silences not limited to triggers.
+func CreateSilence(dataBase moira.Database, silence *dto.Silence, userLogin string, auth *api.Authorization, createdAt int64) *api.ErrorResponse {
+	triggerIDs := (*moira.Silence)(silence).GetTriggerIDs()
+	if len(triggerIDs) == 0 && auth.IsEnabled() && !auth.IsAdmin(userLogin) {
+		return api.ErrorForbidden("only admins can create silences which are not limited to triggers by trigger_id matcher with operator \"=\"")
+	}
+
+	for _, triggerID := range triggerIDs {
+		if _, err := dataBase.GetTrigger(triggerID); err != nil {
+			if errors.Is(err, database.ErrNil) {
+				return api.ErrorInvalidRequest(fmt.Errorf("trigger with ID '%s' does not exists", triggerID))
+			}
+
+			return api.ErrorInternalServer(err)
+		}
+
+		if errorResponse := CheckUserPermissionsForTrigger(dataBase, triggerID, userLogin, auth); errorResponse != nil {
+			return errorResponse
+		}
+	}
+
+	uuid4, err := uuid.NewV4()
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	silence.ID = uuid4.String()
+	silence.CreatedBy = userLogin
+	silence.CreatedAt = createdAt
+
+	if err = dataBase.SaveSilence((*moira.Silence)(silence)); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+// RemoveSilence removes silence, notifications about matching events are sent again.
+// Only creator of the silence and admins can remove it.
+func RemoveSilence(dataBase moira.Database, silenceID, userLogin string, auth *api.Authorization) *api.ErrorResponse {
+	silence, err := dataBase.GetSilence(silenceID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return api.ErrorNotFound(fmt.Sprintf("silence with ID '%s' does not exists", silenceID))
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	if silence.CreatedBy != userLogin && auth.IsEnabled() && !auth.IsAdmin(userLogin) {
+		return api.ErrorForbidden("only creator of the silence and admins can remove it")
+	}
+
+	if err := dataBase.RemoveSilence(silenceID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	return nil
+}
+
+// GetTriggerSilences returns not expired silences which match the whole trigger or any of its metrics from the last check.
+func GetTriggerSilences(dataBase moira.Database, logger moira.Logger, triggerID string, now int64) (*dto.TriggerSilenceList, *api.ErrorResponse) {
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, api.ErrorNotFound("trigger not found")
+		}
+
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	lastCheck, err := dataBase.GetTriggerLastCheck(triggerID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	metrics := make([]string, 0, len(lastCheck.Metrics))
+	for metric := range lastCheck.Metrics {
+		metrics = append(metrics, metric)
+	}
+
+	sort.Strings(metrics)
+
+	allSilences, err := dataBase.GetSilences()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	list := &dto.TriggerSilenceList{
+		List: make([]dto.TriggerSilence, 0),
+	}
+
+	for _, silence := range allSilences {
+		if silence.ExpiresAt <= now {
+			continue
+		}
+
+		compiled, err := silences.Compile(logger, silence)
+		if err != nil {
+			return nil, api.ErrorInternalServer(fmt.Errorf("cannot compile silence '%s': %w", silence.ID, err))
+		}
+
+		triggerSilence := dto.TriggerSilence{
+			Silence: dto.Silence(*silence),
+			Metrics: make([]string, 0),
+		}
+
+		if !compiled.IsMatched(&trigger, "") {
+			for _, metric := range metrics {
+				if compiled.IsMatched(&trigger, metric) {
+					triggerSilence.Metrics = append(triggerSilence.Metrics, metric)
+				}
+			}
+
+			if len(triggerSilence.Metrics) == 0 {
+				continue
+			}
+		}
+
+		list.List = append(list.List, triggerSilence)
+	}
+
+	return list, nil
+}
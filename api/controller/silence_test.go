@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSilence(t *testing.T) {
+	Convey("CreateSilence", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			admin     = "admin"
+			user      = "user"
+			triggerID = "trigger1"
+		)
+
+		auth := &api.Authorization{Enabled: true, AdminList: map[string]struct{}{admin: {}}}
+
+		silence := &dto.Silence{
+			Matchers:  []moira.SilenceMatcher{{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"}},
+			Comment:   "hardware swap",
+			StartsAt:  1000,
+			ExpiresAt: 2000,
+		}
+
+		Convey("admin can create silence not limited to triggers", func() {
+			dataBase.EXPECT().SaveSilence(gomock.Any()).Return(nil)
+
+			err := CreateSilence(dataBase, silence, admin, auth, 900)
+			So(err, ShouldBeNil)
+			So(silence.ID, ShouldNotBeEmpty)
+			So(silence.CreatedBy, ShouldEqual, admin)
+			So(silence.CreatedAt, ShouldEqual, 900)
+		})
+
+		Convey("any user can create silence not limited to triggers when auth is disabled", func() {
+			dataBase.EXPECT().SaveSilence(gomock.Any()).Return(nil)
+
+			err := CreateSilence(dataBase, silence, user, &api.Authorization{}, 900)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("user cannot create silence not limited to triggers", func() {
+			err := CreateSilence(dataBase, silence, user, auth, 900)
+			So(err, ShouldResemble, api.ErrorForbidden("only admins can create silences which are not limited to triggers by trigger_id matcher with operator \"=\""))
+		})
+
+		Convey("silence limited to triggers", func() {
+			silence.Matchers = append(silence.Matchers, moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: triggerID})
+
+			Convey("user with permissions for the trigger can create silence", func() {
+				dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil).Times(2)
+				dataBase.EXPECT().SaveSilence(gomock.Any()).Return(nil)
+
+				err := CreateSilence(dataBase, silence, user, auth, 900)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("user without permissions for the trigger cannot create silence", func() {
+				const teamID = "team1"
+
+				dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, TeamID: teamID}, nil).Times(2)
+				dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+				dataBase.EXPECT().IsTeamContainUser(teamID, user).Return(false, nil)
+
+				err := CreateSilence(dataBase, silence, user, auth, 900)
+				So(err, ShouldNotBeNil)
+				So(err.HTTPStatusCode, ShouldEqual, http.StatusForbidden)
+			})
+
+			Convey("user with permissions for all triggers can create silence of several triggers", func() {
+				const otherTriggerID = "trigger2"
+
+				silence.Matchers = append(silence.Matchers, moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: otherTriggerID})
+
+				dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil).Times(2)
+				dataBase.EXPECT().GetTrigger(otherTriggerID).Return(moira.Trigger{ID: otherTriggerID}, nil).Times(2)
+				dataBase.EXPECT().SaveSilence(gomock.Any()).Return(nil)
+
+				err := CreateSilence(dataBase, silence, user, auth, 900)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("user without permissions for one of triggers cannot create silence of several triggers", func() {
+				const (
+					otherTriggerID = "trigger2"
+					teamID         = "team1"
+				)
+
+				silence.Matchers = append(silence.Matchers, moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: otherTriggerID})
+
+				dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil).Times(2)
+				dataBase.EXPECT().GetTrigger(otherTriggerID).Return(moira.Trigger{ID: otherTriggerID, TeamID: teamID}, nil).Times(2)
+				dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+				dataBase.EXPECT().IsTeamContainUser(teamID, user).Return(false, nil)
+
+				err := CreateSilence(dataBase, silence, user, auth, 900)
+				So(err, ShouldNotBeNil)
+				So(err.HTTPStatusCode, ShouldEqual, http.StatusForbidden)
+			})
+
+			Convey("silence of not existing trigger is not created", func() {
+				dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+
+				err := CreateSilence(dataBase, silence, user, auth, 900)
+				So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger with ID 'trigger1' does not exists")))
+			})
+		})
+	})
+}
+
+func TestRemoveSilence(t *testing.T) {
+	Convey("RemoveSilence", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			silenceID = "silence1"
+			admin     = "admin"
+			creator   = "creator"
+		)
+
+		auth := &api.Authorization{Enabled: true, AdminList: map[string]struct{}{admin: {}}}
+		silence := moira.Silence{ID: silenceID, CreatedBy: creator}
+
+		Convey("silence is removed by its creator", func() {
+			dataBase.EXPECT().GetSilence(silenceID).Return(silence, nil)
+			dataBase.EXPECT().RemoveSilence(silenceID).Return(nil)
+
+			So(RemoveSilence(dataBase, silenceID, creator, auth), ShouldBeNil)
+		})
+
+		Convey("silence is removed by admin", func() {
+			dataBase.EXPECT().GetSilence(silenceID).Return(silence, nil)
+			dataBase.EXPECT().RemoveSilence(silenceID).Return(nil)
+
+			So(RemoveSilence(dataBase, silenceID, admin, auth), ShouldBeNil)
+		})
+
+		Convey("silence is not removed by other user", func() {
+			dataBase.EXPECT().GetSilence(silenceID).Return(silence, nil)
+
+			So(RemoveSilence(dataBase, silenceID, "other", auth), ShouldResemble, api.ErrorForbidden("only creator of the silence and admins can remove it"))
+		})
+
+		Convey("silence does not exist", func() {
+			dataBase.EXPECT().GetSilence(silenceID).Return(moira.Silence{}, database.ErrNil)
+
+			So(RemoveSilence(dataBase, silenceID, creator, auth), ShouldResemble, api.ErrorNotFound("silence with ID 'silence1' does not exists"))
+		})
+	})
+}
+
+func TestGetTriggerSilences(t *testing.T) {
+	Convey("GetTriggerSilences", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+		logger, _ := logging.GetLogger("Test")
+
+		const (
+			triggerID = "trigger1"
+			now       = int64(1500)
+		)
+
+		trigger := moira.Trigger{ID: triggerID, Name: "Disk usage", Tags: []string{"disk"}}
+		lastCheck := moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"disk.used;host=web1": {},
+				"disk.used;host=web2": {},
+			},
+		}
+
+		wholeTrigger := &moira.Silence{
+			ID:        "silence1",
+			Matchers:  []moira.SilenceMatcher{{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"}},
+			ExpiresAt: 2000,
+		}
+		host := &moira.Silence{
+			ID:        "silence2",
+			Matchers:  []moira.SilenceMatcher{{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "web2"}},
+			ExpiresAt: 2000,
+		}
+		otherHost := &moira.Silence{
+			ID:        "silence3",
+			Matchers:  []moira.SilenceMatcher{{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "db1"}},
+			ExpiresAt: 2000,
+		}
+		expired := &moira.Silence{
+			ID:        "silence4",
+			Matchers:  wholeTrigger.Matchers,
+			ExpiresAt: 1000,
+		}
+
+		Convey("silences of the trigger and its metrics are returned", func() {
+			dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{wholeTrigger, host, otherHost, expired}, nil)
+
+			actual, err := GetTriggerSilences(dataBase, logger, triggerID, now)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, &dto.TriggerSilenceList{
+				List: []dto.TriggerSilence{
+					{Silence: dto.Silence(*wholeTrigger), Metrics: []string{}},
+					{Silence: dto.Silence(*host), Metrics: []string{"disk.used;host=web2"}},
+				},
+			})
+		})
+
+		Convey("trigger does not exist", func() {
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+
+			actual, err := GetTriggerSilences(dataBase, logger, triggerID, now)
+			So(err, ShouldResemble, api.ErrorNotFound("trigger not found"))
+			So(actual, ShouldBeNil)
+		})
+	})
+}
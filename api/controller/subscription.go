@@ -157,12 +157,13 @@ func SendTestNotification(database moira.Database, subscriptionID string) *api.E
 	return nil
 }
 
-// CheckUserPermissionsForSubscription checks subscription for existence and permissions for given user.
+// CheckUserPermissionsForSubscription checks subscription for existence and that the given user has the given access to it.
 func CheckUserPermissionsForSubscription(
 	dataBase moira.Database,
 	subscriptionID string,
 	userLogin string,
 	auth *api.Authorization,
+	access moira.TeamAccess,
 ) (moira.SubscriptionData, *api.ErrorResponse) {
 	subscription, err := dataBase.GetSubscription(subscriptionID)
 	if err != nil {
@@ -177,18 +178,25 @@ func CheckUserPermissionsForSubscription(
 		return subscription, nil
 	}
 
+	// Resources of the team are available according to the role in the team, even to the user who created them.
 	if subscription.TeamID != "" {
-		teamContainsUser, err := dataBase.IsTeamContainUser(subscription.TeamID, userLogin)
+		role, err := getUserTeamRole(dataBase, subscription.TeamID, userLogin, auth)
 		if err != nil {
 			return moira.SubscriptionData{}, api.ErrorInternalServer(err)
 		}
 
-		if teamContainsUser {
-			return subscription, nil
+		if role == "" {
+			return moira.SubscriptionData{}, api.ErrorForbidden("you are not permitted")
 		}
+
+		if !role.Allows(access) {
+			return moira.SubscriptionData{}, api.ErrorForbidden(fmt.Sprintf("your role %s in the team does not permit this", role))
+		}
+
+		return subscription, nil
 	}
 
-	if subscription.User == userLogin {
+	if subscription.User == userLogin && auth.ServiceAccountTeamID == "" {
 		return subscription, nil
 	}
 
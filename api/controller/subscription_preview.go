@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/senders/preview"
+)
+
+const (
+	// DefaultSubscriptionPreviewDays is the number of last days of events history which the volume of notifications is estimated by.
+	DefaultSubscriptionPreviewDays = 7
+	// MaxSubscriptionPreviewDays is the maximum number of days of events history which the volume of notifications can be estimated by.
+	MaxSubscriptionPreviewDays = 30
+
+	// maxSubscriptionPreviewTriggers is the maximum number of matched triggers which events are scanned by dry-run of subscription.
+	maxSubscriptionPreviewTriggers = 100
+	// maxSubscriptionPreviewTriggerEvents is the maximum number of the latest events of a trigger which are scanned by dry-run of subscription.
+	maxSubscriptionPreviewTriggerEvents int64 = 1000
+)
+
+// PreviewSubscription makes a dry-run of the subscription without sending anything.
+// It finds the triggers which tags match the subscription, estimates the volume of notifications by events of the triggers
+// for the last days and renders the messages about the latest event which the contacts of the subscription would get.
+// Not more than maxSubscriptionPreviewTriggers triggers and maxSubscriptionPreviewTriggerEvents events of each trigger are scanned,
+// the preview is marked as truncated if the limits are reached.
+func PreviewSubscription(
+	dataBase moira.Database,
+	renderer *preview.Renderer,
+	subscription *moira.SubscriptionData,
+	days int64,
+	now time.Time,
+) (*dto.SubscriptionPreview, *api.ErrorResponse) {
+	triggers, truncated, err := getSubscriptionTriggers(dataBase, subscription, maxSubscriptionPreviewTriggers)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	result := &dto.SubscriptionPreview{
+		Days:      days,
+		Truncated: truncated,
+		Triggers:  make([]dto.SubscriptionPreviewTrigger, 0, len(triggers)),
+		Messages:  make([]dto.SubscriptionPreviewMessage, 0, len(subscription.Contacts)),
+	}
+
+	from := strconv.FormatInt(now.Add(-time.Duration(days)*24*time.Hour).Unix(), 10)
+	to := strconv.FormatInt(now.Unix(), 10)
+
+	var (
+		latestEvent   *moira.NotificationEvent
+		latestTrigger *moira.Trigger
+	)
+
+	for _, trigger := range triggers {
+		events, err := dataBase.GetNotificationEvents(trigger.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to)
+		if err != nil {
+			return nil, api.ErrorInternalServer(err)
+		}
+
+		if int64(len(events)) == maxSubscriptionPreviewTriggerEvents {
+			result.Truncated = true
+		}
+
+		previewTrigger := dto.SubscriptionPreviewTrigger{
+			ID:   trigger.ID,
+			Name: trigger.Name,
+			Tags: trigger.Tags,
+		}
+
+		for _, event := range events {
+			if event.State == moira.StateTEST || subscription.MustIgnore(event) {
+				continue
+			}
+
+			previewTrigger.Events++
+
+			if !subscription.Schedule.IsScheduleAllows(event.Timestamp) {
+				result.DelayedEvents++
+			}
+
+			if latestEvent == nil || event.Timestamp > latestEvent.Timestamp {
+				latestEvent, latestTrigger = event, trigger
+			}
+		}
+
+		result.Events += previewTrigger.Events
+		result.Triggers = append(result.Triggers, previewTrigger)
+	}
+
+	sort.SliceStable(result.Triggers, func(i, j int) bool {
+		return result.Triggers[i].Events > result.Triggers[j].Events
+	})
+
+	contacts, err := dataBase.GetContacts(subscription.Contacts)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	if latestEvent == nil && len(triggers) != 0 {
+		latestEvent, latestTrigger = getPreviewEvent(triggers[0], now), triggers[0]
+	}
+
+	for _, contact := range contacts {
+		if contact == nil {
+			continue
+		}
+
+		result.NotificationsUpperBound += result.Events
+
+		message := dto.SubscriptionPreviewMessage{
+			ContactID:    contact.ID,
+			ContactType:  contact.Type,
+			ContactValue: contact.Value,
+		}
+
+		if latestEvent != nil {
+			message.Message, message.Supported, err = renderer.Render(*contact, latestTrigger.ToTriggerData(), moira.NotificationEvents{*latestEvent})
+			if err != nil {
+				message.Error = err.Error()
+			}
+		}
+
+		result.Messages = append(result.Messages, message)
+	}
+
+	if days != 0 {
+		result.NotificationsPerDayUpperBound = float64(result.NotificationsUpperBound) / float64(days)
+	}
+
+	return result, nil
+}
+
+// getSubscriptionTriggers returns up to limit triggers sorted by id which events the subscription gets, the same way as notifier matches them.
+// Triggers are loaded by batches of limit size, so not all triggers are loaded for subscriptions to any tags or to popular tags.
+// It returns true if the limit is reached before all triggers were checked, then the subscription may get events of more triggers.
+func getSubscriptionTriggers(dataBase moira.Database, subscription *moira.SubscriptionData, limit int) ([]*moira.Trigger, bool, error) {
+	var (
+		triggerIDs []string
+		err        error
+	)
+
+	switch {
+	case subscription.AnyTags:
+		triggerIDs, err = dataBase.GetAllTriggerIDs()
+	case len(subscription.Tags) != 0:
+		triggerIDs, err = dataBase.GetTagTriggerIDs(subscription.Tags[0])
+	}
+
+	if err != nil || len(triggerIDs) == 0 {
+		return nil, false, err
+	}
+
+	sort.Strings(triggerIDs)
+
+	matched := make([]*moira.Trigger, 0, min(limit, len(triggerIDs)))
+
+	for batchStart := 0; batchStart < len(triggerIDs); batchStart += limit {
+		if len(matched) == limit {
+			return matched, true, nil
+		}
+
+		triggers, err := dataBase.GetTriggers(triggerIDs[batchStart:min(batchStart+limit, len(triggerIDs))])
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, trigger := range triggers {
+			// Notifier does not process events of triggers without tags.
+			if trigger == nil || len(trigger.Tags) == 0 || !moira.Subset(subscription.Tags, trigger.Tags) {
+				continue
+			}
+
+			if len(matched) == limit {
+				return matched, true, nil
+			}
+
+			matched = append(matched, trigger)
+		}
+	}
+
+	return matched, false, nil
+}
+
+// getPreviewEvent returns event of the trigger which is used to render messages if the subscription got no events yet.
+func getPreviewEvent(trigger *moira.Trigger, now time.Time) *moira.NotificationEvent {
+	return &moira.NotificationEvent{
+		TriggerID: trigger.ID,
+		Metric:    "Test.metric.value",
+		Values:    map[string]float64{"t1": 1},
+		OldState:  moira.StateOK,
+		State:     moira.StateERROR,
+		Timestamp: now.Unix(),
+	}
+}
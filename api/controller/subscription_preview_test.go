@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/dto"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	"github.com/moira-alert/moira/senders/preview"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreviewSubscription(t *testing.T) {
+	Convey("PreviewSubscription", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+		renderer, err := preview.NewRenderer([]map[string]interface{}{
+			{"sender_type": "slack", "contact_type": "slack"},
+		}, "https://moira.example.com", time.UTC, "15:04 02.01.2006")
+		So(err, ShouldBeNil)
+
+		now := time.Unix(1704067200, 0)
+		from := strconv.FormatInt(now.Add(-7*24*time.Hour).Unix(), 10)
+		to := strconv.FormatInt(now.Unix(), 10)
+
+		subscription := &moira.SubscriptionData{
+			Tags:              []string{"disk", "server"},
+			Contacts:          []string{"contact1", "contact2"},
+			Schedule:          *moira.NewDefaultScheduleData(),
+			IgnoreRecoverings: true,
+		}
+
+		matched := &moira.Trigger{ID: "trigger1", Name: "Disk usage", Tags: []string{"disk", "server"}}
+		notMatched := &moira.Trigger{ID: "trigger2", Name: "Disk latency", Tags: []string{"disk"}}
+
+		slackContact := &moira.ContactData{ID: "contact1", Type: "slack", Value: "#alerts"}
+		webhookContact := &moira.ContactData{ID: "contact2", Type: "webhook", Value: "https://example.com"}
+
+		Convey("with events in history", func() {
+			events := []*moira.NotificationEvent{
+				{TriggerID: matched.ID, Metric: "disk.web1", OldState: moira.StateERROR, State: moira.StateOK, Timestamp: now.Unix() - 60},
+				{TriggerID: matched.ID, Metric: "disk.web1", OldState: moira.StateOK, State: moira.StateERROR, Timestamp: now.Unix() - 120},
+				{TriggerID: matched.ID, Metric: "disk.web2", OldState: moira.StateOK, State: moira.StateERROR, Timestamp: now.Unix() - 180},
+			}
+
+			dataBase.EXPECT().GetTagTriggerIDs("disk").Return([]string{notMatched.ID, matched.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{matched.ID, notMatched.ID}).Return([]*moira.Trigger{matched, notMatched}, nil)
+			dataBase.EXPECT().GetNotificationEvents(matched.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to).Return(events, nil)
+			dataBase.EXPECT().GetContacts(subscription.Contacts).Return([]*moira.ContactData{slackContact, webhookContact}, nil)
+
+			actual, err := PreviewSubscription(dataBase, renderer, subscription, 7, now)
+			So(err, ShouldBeNil)
+			So(actual.Days, ShouldEqual, 7)
+			So(actual.Triggers, ShouldResemble, []dto.SubscriptionPreviewTrigger{
+				{ID: matched.ID, Name: matched.Name, Tags: matched.Tags, Events: 2},
+			})
+			So(actual.Events, ShouldEqual, 2)
+			So(actual.DelayedEvents, ShouldEqual, 0)
+			So(actual.NotificationsUpperBound, ShouldEqual, 4)
+			So(actual.NotificationsPerDayUpperBound, ShouldAlmostEqual, 4.0/7)
+			So(actual.Truncated, ShouldBeFalse)
+			So(actual.Messages, ShouldHaveLength, 2)
+
+			So(actual.Messages[0].ContactID, ShouldEqual, slackContact.ID)
+			So(actual.Messages[0].Supported, ShouldBeTrue)
+			So(actual.Messages[0].Message, ShouldContainSubstring, "<https://moira.example.com/trigger/trigger1|Disk usage>")
+			So(actual.Messages[0].Message, ShouldContainSubstring, "disk.web1")
+
+			So(actual.Messages[1], ShouldResemble, dto.SubscriptionPreviewMessage{
+				ContactID:    webhookContact.ID,
+				ContactType:  webhookContact.Type,
+				ContactValue: webhookContact.Value,
+			})
+		})
+
+		Convey("without events in history message is rendered by test event", func() {
+			dataBase.EXPECT().GetTagTriggerIDs("disk").Return([]string{matched.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{matched.ID}).Return([]*moira.Trigger{matched}, nil)
+			dataBase.EXPECT().GetNotificationEvents(matched.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to).Return(nil, nil)
+			dataBase.EXPECT().GetContacts(subscription.Contacts).Return([]*moira.ContactData{slackContact, nil}, nil)
+
+			actual, err := PreviewSubscription(dataBase, renderer, subscription, 7, now)
+			So(err, ShouldBeNil)
+			So(actual.Events, ShouldEqual, 0)
+			So(actual.NotificationsUpperBound, ShouldEqual, 0)
+			So(actual.Messages, ShouldHaveLength, 1)
+			So(actual.Messages[0].Supported, ShouldBeTrue)
+			So(actual.Messages[0].Message, ShouldContainSubstring, "Test.metric.value")
+		})
+
+		Convey("with any tags all triggers are matched", func() {
+			subscription.Tags = nil
+			subscription.AnyTags = true
+
+			dataBase.EXPECT().GetAllTriggerIDs().Return([]string{matched.ID, notMatched.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{matched.ID, notMatched.ID}).Return([]*moira.Trigger{matched, notMatched}, nil)
+			dataBase.EXPECT().GetNotificationEvents(matched.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to).Return(nil, nil)
+			dataBase.EXPECT().GetNotificationEvents(notMatched.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to).Return([]*moira.NotificationEvent{
+				{TriggerID: notMatched.ID, Metric: "disk.web1", OldState: moira.StateOK, State: moira.StateWARN, Timestamp: now.Unix() - 60},
+			}, nil)
+			dataBase.EXPECT().GetContacts(subscription.Contacts).Return([]*moira.ContactData{slackContact}, nil)
+
+			actual, err := PreviewSubscription(dataBase, renderer, subscription, 7, now)
+			So(err, ShouldBeNil)
+			So(actual.Triggers, ShouldResemble, []dto.SubscriptionPreviewTrigger{
+				{ID: notMatched.ID, Name: notMatched.Name, Tags: notMatched.Tags, Events: 1},
+				{ID: matched.ID, Name: matched.Name, Tags: matched.Tags},
+			})
+			So(actual.NotificationsUpperBound, ShouldEqual, 1)
+		})
+
+		Convey("with too many events of trigger preview is truncated", func() {
+			events := make([]*moira.NotificationEvent, maxSubscriptionPreviewTriggerEvents)
+			for i := range events {
+				events[i] = &moira.NotificationEvent{TriggerID: matched.ID, Metric: "disk.web1", OldState: moira.StateOK, State: moira.StateERROR, Timestamp: now.Unix() - int64(i)}
+			}
+
+			dataBase.EXPECT().GetTagTriggerIDs("disk").Return([]string{matched.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{matched.ID}).Return([]*moira.Trigger{matched}, nil)
+			dataBase.EXPECT().GetNotificationEvents(matched.ID, zeroPage, maxSubscriptionPreviewTriggerEvents, from, to).Return(events, nil)
+			dataBase.EXPECT().GetContacts(subscription.Contacts).Return([]*moira.ContactData{slackContact}, nil)
+
+			actual, err := PreviewSubscription(dataBase, renderer, subscription, 7, now)
+			So(err, ShouldBeNil)
+			So(actual.Events, ShouldEqual, maxSubscriptionPreviewTriggerEvents)
+			So(actual.Truncated, ShouldBeTrue)
+		})
+	})
+}
+
+func TestGetSubscriptionTriggers(t *testing.T) {
+	Convey("getSubscriptionTriggers", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+		subscription := &moira.SubscriptionData{AnyTags: true}
+
+		trigger1 := &moira.Trigger{ID: "trigger1", Tags: []string{"disk"}}
+		trigger2 := &moira.Trigger{ID: "trigger2"}
+		trigger3 := &moira.Trigger{ID: "trigger3", Tags: []string{"cpu"}}
+		trigger4 := &moira.Trigger{ID: "trigger4", Tags: []string{"disk"}}
+
+		Convey("triggers are loaded by batches till the limit is reached", func() {
+			dataBase.EXPECT().GetAllTriggerIDs().Return([]string{trigger4.ID, trigger3.ID, trigger2.ID, trigger1.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{trigger1.ID, trigger2.ID}).Return([]*moira.Trigger{trigger1, trigger2}, nil)
+			dataBase.EXPECT().GetTriggers([]string{trigger3.ID, trigger4.ID}).Return([]*moira.Trigger{trigger3, trigger4}, nil)
+
+			triggers, truncated, err := getSubscriptionTriggers(dataBase, subscription, 2)
+			So(err, ShouldBeNil)
+			So(triggers, ShouldResemble, []*moira.Trigger{trigger1, trigger3})
+			So(truncated, ShouldBeTrue)
+		})
+
+		Convey("triggers are not truncated if all of them are checked", func() {
+			dataBase.EXPECT().GetAllTriggerIDs().Return([]string{trigger1.ID, trigger2.ID, trigger3.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{trigger1.ID, trigger2.ID}).Return([]*moira.Trigger{trigger1, trigger2}, nil)
+			dataBase.EXPECT().GetTriggers([]string{trigger3.ID}).Return([]*moira.Trigger{trigger3}, nil)
+
+			triggers, truncated, err := getSubscriptionTriggers(dataBase, subscription, 2)
+			So(err, ShouldBeNil)
+			So(triggers, ShouldResemble, []*moira.Trigger{trigger1, trigger3})
+			So(truncated, ShouldBeFalse)
+		})
+	})
+}
@@ -398,7 +398,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 
 	Convey("No subscription", t, func() {
 		dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, database.ErrNil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("subscription with ID '%s' does not exists", id)))
 		So(expectedSub, ShouldResemble, moira.SubscriptionData{})
 	})
@@ -406,7 +406,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 	Convey("Different user", t, func() {
 		actualSub := moira.SubscriptionData{User: "diffUser"}
 		dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorForbidden("you are not permitted"))
 		So(expectedSub, ShouldResemble, moira.SubscriptionData{})
 	})
@@ -414,7 +414,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 	Convey("Has subscription", t, func() {
 		actualSub := moira.SubscriptionData{ID: id, User: userLogin}
 		dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldBeNil)
 		So(expectedSub, ShouldResemble, actualSub)
 	})
@@ -422,7 +422,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 	Convey("Error get contact", t, func() {
 		err := fmt.Errorf("oooops! Can not read contact")
 		dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, err)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 		So(expected, ShouldResemble, api.ErrorInternalServer(err))
 		So(expectedSub, ShouldResemble, moira.SubscriptionData{})
 	})
@@ -432,14 +432,30 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 			expectedSub := moira.SubscriptionData{ID: id, TeamID: teamID}
 			dataBase.EXPECT().GetSubscription(id).Return(expectedSub, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
-			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
+			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expectedSub)
+		})
+		Convey("Viewer can not change it", func() {
+			dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id, TeamID: teamID, User: userLogin}, nil)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}, nil)
+			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessEdit)
+			So(err, ShouldResemble, api.ErrorForbidden("your role viewer in the team does not permit this"))
+			So(actual, ShouldResemble, moira.SubscriptionData{})
+		})
+		Convey("Service account of the team can change it", func() {
+			expectedSub := moira.SubscriptionData{ID: id, TeamID: teamID}
+			dataBase.EXPECT().GetSubscription(id).Return(expectedSub, nil)
+			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth.WithServiceAccount(teamID), moira.TeamAccessEdit)
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, expectedSub)
 		})
 		Convey("User is not in team", func() {
 			dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id, TeamID: teamID}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, nil)
-			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
 			So(actual, ShouldResemble, moira.SubscriptionData{})
 		})
@@ -448,7 +464,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 
 			dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id, TeamID: teamID}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, errReturned)
-			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth)
+			actual, err := CheckUserPermissionsForSubscription(dataBase, id, userLogin, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorInternalServer(errReturned))
 			So(actual, ShouldResemble, moira.SubscriptionData{})
 		})
@@ -468,7 +484,7 @@ func TestCheckAdminPermissionsForSubscription(t *testing.T) {
 	Convey("Same user", t, func() {
 		expectedSub := moira.SubscriptionData{ID: id, User: adminLogin}
 		dataBase.EXPECT().GetSubscription(id).Return(expectedSub, nil)
-		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedSub)
 	})
@@ -476,7 +492,7 @@ func TestCheckAdminPermissionsForSubscription(t *testing.T) {
 	Convey("Different user", t, func() {
 		expectedSub := moira.SubscriptionData{ID: id, User: "diffUser"}
 		dataBase.EXPECT().GetSubscription(id).Return(expectedSub, nil)
-		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedSub)
 	})
@@ -484,7 +500,7 @@ func TestCheckAdminPermissionsForSubscription(t *testing.T) {
 	Convey("Team contact", t, func() {
 		expectedSub := moira.SubscriptionData{ID: id, TeamID: teamID}
 		dataBase.EXPECT().GetSubscription(id).Return(expectedSub, nil)
-		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth)
+		actualContact, errorResponse := CheckUserPermissionsForSubscription(dataBase, id, adminLogin, auth, moira.TeamAccessRead)
 		So(errorResponse, ShouldBeNil)
 		So(actualContact, ShouldResemble, expectedSub)
 	})
@@ -18,6 +18,9 @@ import (
 
 const teamIDCreateRetries = 3
 
+// newTeamMemberRole is a role of users added to the team, owners can grant them another role.
+const newTeamMemberRole = moira.TeamRoleEditor
+
 // CreateTeam is a controller function that creates a new team in Moira.
 func CreateTeam(dataBase moira.Database, team dto.TeamModel, userID string) (dto.SaveTeamResponse, *api.ErrorResponse) {
 	var teamID string
@@ -78,6 +81,11 @@ func CreateTeam(dataBase moira.Database, team dto.TeamModel, userID string) (dto
 		return dto.SaveTeamResponse{}, api.ErrorInternalServer(fmt.Errorf("cannot save team users: %w", err))
 	}
 
+	err = dataBase.SaveTeamUserRole(teamID, userID, moira.TeamRoleOwner)
+	if err != nil {
+		return dto.SaveTeamResponse{}, api.ErrorInternalServer(fmt.Errorf("cannot save role of team owner: %w", err))
+	}
+
 	return dto.SaveTeamResponse{ID: teamID}, nil
 }
 
@@ -305,11 +313,41 @@ func SetTeamUsers(dataBase moira.Database, teamID string, allUsers []string) (dt
 		return dto.TeamMembers{}, apiError
 	}
 
+	newUsers := make([]string, 0)
+	for _, userID := range allUsers {
+		if !slices.Contains(existingUsers, userID) {
+			newUsers = append(newUsers, userID)
+		}
+	}
+
+	roles, err := dataBase.GetTeamUserRoles(teamID)
+	if err != nil {
+		return dto.TeamMembers{}, api.ErrorInternalServer(fmt.Errorf("cannot get team roles from database: %w", err))
+	}
+
+	// Users of a team without members become its owners.
+	newUsersRole := newTeamMemberRole
+	if len(existingUsers) == 0 {
+		newUsersRole = moira.TeamRoleOwner
+	}
+
+	for _, userID := range newUsers {
+		roles[userID] = newUsersRole
+	}
+
+	if apiError = checkTeamHasOwner(roles, allUsers); apiError != nil {
+		return dto.TeamMembers{}, apiError
+	}
+
 	err = dataBase.SaveTeamsAndUsers(teamID, allUsers, teamsMap)
 	if err != nil {
 		api.ErrorInternalServer(fmt.Errorf("cannot save users for team: %s %w", teamID, err))
 	}
 
+	if apiError = saveNewTeamMembersRoles(dataBase, teamID, newUsers, newUsersRole); apiError != nil {
+		return dto.TeamMembers{}, apiError
+	}
+
 	result := dto.TeamMembers{
 		Usernames: allUsers,
 	}
@@ -383,6 +421,10 @@ func AddTeamUsers(dataBase moira.Database, teamID string, newUsers []string) (dt
 		api.ErrorInternalServer(fmt.Errorf("cannot save users for team: %s %w", teamID, err))
 	}
 
+	if apiErr := saveNewTeamMembersRoles(dataBase, teamID, newUsers, newTeamMemberRole); apiErr != nil {
+		return dto.TeamMembers{}, apiErr
+	}
+
 	result := dto.TeamMembers{
 		Usernames: finalUsers,
 	}
@@ -390,9 +432,31 @@ func AddTeamUsers(dataBase moira.Database, teamID string, newUsers []string) (dt
 	return result, nil
 }
 
+// saveNewTeamMembersRoles assigns role to users who have just joined the team.
+func saveNewTeamMembersRoles(dataBase moira.Database, teamID string, newUsers []string, role moira.TeamRole) *api.ErrorResponse {
+	for _, userID := range newUsers {
+		if err := dataBase.SaveTeamUserRole(teamID, userID, role); err != nil {
+			return api.ErrorInternalServer(fmt.Errorf("cannot save role of team user: %s %w", userID, err))
+		}
+	}
+
+	return nil
+}
+
 // UpdateTeam is a controller function that updates an existing team in Moira.
 func UpdateTeam(dataBase moira.Database, teamID string, team dto.TeamModel) (dto.SaveTeamResponse, *api.ErrorResponse) {
-	err := dataBase.SaveTeam(teamID, team.ToMoiraTeam())
+	moiraTeam := team.ToMoiraTeam()
+
+	if team.RestrictTriggerChanges == nil {
+		existingTeam, err := dataBase.GetTeam(teamID)
+		if err != nil {
+			return dto.SaveTeamResponse{}, api.ErrorInternalServer(fmt.Errorf("cannot get team: %w", err))
+		}
+
+		moiraTeam.RestrictTriggerChanges = existingTeam.RestrictTriggerChanges
+	}
+
+	err := dataBase.SaveTeam(teamID, moiraTeam)
 	if err != nil {
 		if errors.Is(err, database.ErrTeamWithNameAlreadyExists) {
 			return dto.SaveTeamResponse{}, api.ErrorInvalidRequest(fmt.Errorf("cannot save team: %w", err))
@@ -493,6 +557,15 @@ func DeleteTeamUser(dataBase moira.Database, teamID string, removeUserID string)
 		teamsMap[userID] = userTeams
 	}
 
+	roles, err := dataBase.GetTeamUserRoles(teamID)
+	if err != nil {
+		return dto.TeamMembers{}, api.ErrorInternalServer(fmt.Errorf("cannot get team roles from database: %w", err))
+	}
+
+	if apiErr := checkTeamHasOwner(roles, finalUsers); apiErr != nil {
+		return dto.TeamMembers{}, apiErr
+	}
+
 	err = dataBase.SaveTeamsAndUsers(teamID, finalUsers, teamsMap)
 	if err != nil {
 		api.ErrorInternalServer(fmt.Errorf("cannot save users for team: %s %w", teamID, err))
@@ -518,10 +591,12 @@ func removeUserTeam(teams []string, teamID string) ([]string, error) {
 	return []string{}, fmt.Errorf("cannot find team in user teams: %s", teamID)
 }
 
+// CheckUserPermissionsForTeam checks team for existence and that role of the user in the team grants the given access.
 func CheckUserPermissionsForTeam(
 	dataBase moira.Database,
 	teamID, userID string,
 	auth *api.Authorization,
+	access moira.TeamAccess,
 ) *api.ErrorResponse {
 	if auth.IsAdmin(userID) {
 		return nil
@@ -536,15 +611,19 @@ func CheckUserPermissionsForTeam(
 		return api.ErrorInternalServer(err)
 	}
 
-	userIsTeamMember, err := dataBase.IsTeamContainUser(teamID, userID)
+	role, err := getUserTeamRole(dataBase, teamID, userID, auth)
 	if err != nil {
 		return api.ErrorInternalServer(err)
 	}
 
-	if !userIsTeamMember {
+	if role == "" {
 		return api.ErrorForbidden("you are not permitted to manipulate with this team")
 	}
 
+	if !role.Allows(access) {
+		return api.ErrorForbidden(fmt.Sprintf("your role %s in the team does not permit this", role))
+	}
+
 	return nil
 }
 
@@ -599,5 +678,12 @@ func GetTeamSettings(database moira.Database, teamID string) (dto.TeamSettings,
 		})
 	}
 
+	preferences, err := getTeamPreferences(database, teamID)
+	if err != nil {
+		return dto.TeamSettings{}, api.ErrorInternalServer(err)
+	}
+
+	teamSettings.Preferences = dto.TeamPreferences(preferences)
+
 	return teamSettings, nil
 }
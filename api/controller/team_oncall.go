@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetTeamOnCallSchedule is a controller function that returns on-call schedule of the team.
+func GetTeamOnCallSchedule(dataBase moira.Database, teamID string) (dto.TeamOnCallSchedule, *api.ErrorResponse) {
+	schedule, err := getTeamOnCallSchedule(dataBase, teamID)
+	if err != nil {
+		return dto.TeamOnCallSchedule{}, api.ErrorInternalServer(err)
+	}
+
+	return dto.TeamOnCallSchedule(schedule), nil
+}
+
+// UpdateTeamOnCallSchedule is a controller function that replaces on-call schedule of the team.
+// Only members of the team can be on-call.
+func UpdateTeamOnCallSchedule(dataBase moira.Database, teamID string, schedule dto.TeamOnCallSchedule) (dto.TeamOnCallSchedule, *api.ErrorResponse) {
+	teamUsers, err := dataBase.GetTeamUsers(teamID)
+	if err != nil {
+		return dto.TeamOnCallSchedule{}, api.ErrorInternalServer(fmt.Errorf("cannot get team users from database: %w", err))
+	}
+
+	members := make(map[string]struct{}, len(teamUsers))
+	for _, userID := range teamUsers {
+		members[userID] = struct{}{}
+	}
+
+	onCallUsers := make([]string, 0)
+	for _, rotation := range schedule.Rotations {
+		onCallUsers = append(onCallUsers, rotation.Participants...)
+	}
+
+	for _, override := range schedule.Overrides {
+		onCallUsers = append(onCallUsers, override.User)
+	}
+
+	for _, userID := range onCallUsers {
+		if _, ok := members[userID]; !ok {
+			return dto.TeamOnCallSchedule{}, api.ErrorInvalidRequest(fmt.Errorf("user %s is not a member of the team", userID))
+		}
+	}
+
+	if schedule.Rotations == nil {
+		schedule.Rotations = make([]moira.OnCallRotation, 0)
+	}
+
+	if err = dataBase.SaveTeamOnCallSchedule(teamID, moira.OnCallSchedule(schedule)); err != nil {
+		return dto.TeamOnCallSchedule{}, api.ErrorInternalServer(fmt.Errorf("cannot save team on-call schedule: %w", err))
+	}
+
+	return schedule, nil
+}
+
+// GetTeamOnCallShift is a controller function that returns the user who is on-call in the team at the given time.
+func GetTeamOnCallShift(dataBase moira.Database, teamID string, now time.Time) (dto.TeamOnCallShift, *api.ErrorResponse) {
+	schedule, err := getTeamOnCallSchedule(dataBase, teamID)
+	if err != nil {
+		return dto.TeamOnCallShift{}, api.ErrorInternalServer(err)
+	}
+
+	shift, ok := schedule.GetShift(now)
+	if !ok {
+		return dto.TeamOnCallShift{}, api.ErrorNotFound("nobody is on-call in the team")
+	}
+
+	return dto.TeamOnCallShift(shift), nil
+}
+
+// getTeamOnCallSchedule returns on-call schedule of the team, empty if it was never saved.
+func getTeamOnCallSchedule(dataBase moira.Database, teamID string) (moira.OnCallSchedule, error) {
+	schedule, err := dataBase.GetTeamOnCallSchedule(teamID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return moira.OnCallSchedule{}, fmt.Errorf("cannot get team on-call schedule from database: %w", err)
+	}
+
+	if schedule.Rotations == nil {
+		schedule.Rotations = make([]moira.OnCallRotation, 0)
+	}
+
+	return schedule, nil
+}
+
+// validateOnCallContact checks that the team which on-call contact refers to exists
+// and that the user who saves the contact has access to the team.
+func validateOnCallContact(dataBase moira.Database, contact moira.ContactData, userLogin string, auth *api.Authorization) *api.ErrorResponse {
+	if contact.Type != moira.OnCallContactType {
+		return nil
+	}
+
+	if _, err := dataBase.GetTeam(contact.Value); err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return api.ErrorInvalidRequest(fmt.Errorf("team %s of on-call contact does not exist", contact.Value))
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	return CheckUserPermissionsForTeam(dataBase, contact.Value, userLogin, auth, moira.TeamAccessRead)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateTeamOnCallSchedule(t *testing.T) {
+	Convey("UpdateTeamOnCallSchedule", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		schedule := dto.TeamOnCallSchedule{
+			Rotations: []moira.OnCallRotation{{Participants: []string{"user1", "user2"}, Start: 100, ShiftLength: 3600}},
+			Overrides: []moira.OnCallOverride{{User: "user2", From: 200, To: 300}},
+		}
+
+		Convey("members of the team are saved", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{"user1", "user2"}, nil)
+			dataBase.EXPECT().SaveTeamOnCallSchedule(teamID, moira.OnCallSchedule(schedule)).Return(nil)
+
+			actual, err := UpdateTeamOnCallSchedule(dataBase, teamID, schedule)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, schedule)
+		})
+
+		Convey("user out of the team can not be on-call", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{"user1"}, nil)
+
+			actual, err := UpdateTeamOnCallSchedule(dataBase, teamID, schedule)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(errors.New("user user2 is not a member of the team")))
+			So(actual, ShouldResemble, dto.TeamOnCallSchedule{})
+		})
+	})
+}
+
+func TestGetTeamOnCallShift(t *testing.T) {
+	Convey("GetTeamOnCallShift", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		Convey("user on-call by rotation", func() {
+			schedule := moira.OnCallSchedule{
+				Rotations: []moira.OnCallRotation{{Participants: []string{"user1", "user2"}, Start: 100, ShiftLength: 100}},
+			}
+			dataBase.EXPECT().GetTeamOnCallSchedule(teamID).Return(schedule, nil)
+
+			actual, err := GetTeamOnCallShift(dataBase, teamID, time.Unix(250, 0))
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamOnCallShift{User: "user2", From: 200, To: 300})
+		})
+
+		Convey("team without on-call schedule", func() {
+			dataBase.EXPECT().GetTeamOnCallSchedule(teamID).Return(moira.OnCallSchedule{}, database.ErrNil)
+
+			actual, err := GetTeamOnCallShift(dataBase, teamID, time.Unix(250, 0))
+			So(err, ShouldResemble, api.ErrorNotFound("nobody is on-call in the team"))
+			So(actual, ShouldResemble, dto.TeamOnCallShift{})
+		})
+	})
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetTeamPreferences is a controller function that returns team-wide settings of the team.
+func GetTeamPreferences(dataBase moira.Database, teamID string) (dto.TeamPreferences, *api.ErrorResponse) {
+	preferences, err := getTeamPreferences(dataBase, teamID)
+	if err != nil {
+		return dto.TeamPreferences{}, api.ErrorInternalServer(err)
+	}
+
+	return dto.TeamPreferences(preferences), nil
+}
+
+// UpdateTeamPreferences is a controller function that replaces team-wide settings of the team, except maintenance.
+func UpdateTeamPreferences(dataBase moira.Database, teamID string, preferences dto.TeamPreferences) (dto.TeamPreferences, *api.ErrorResponse) {
+	existingPreferences, err := getTeamPreferences(dataBase, teamID)
+	if err != nil {
+		return dto.TeamPreferences{}, api.ErrorInternalServer(err)
+	}
+
+	preferences.Maintenance = existingPreferences.Maintenance
+	preferences.MaintenanceInfo = existingPreferences.MaintenanceInfo
+
+	if err = dataBase.SaveTeamPreferences(teamID, moira.TeamPreferences(preferences)); err != nil {
+		return dto.TeamPreferences{}, api.ErrorInternalServer(fmt.Errorf("cannot save team preferences: %w", err))
+	}
+
+	return preferences, nil
+}
+
+// SetTeamMaintenance is a controller function that sets maintenance of all triggers of the team tagged with team tags.
+// Maintenance in the past ends team maintenance, but keeps maintenance of triggers that was changed since it was set by the team.
+func SetTeamMaintenance(dataBase moira.Database, teamID string, maintenance int64, userLogin string, timeCallMaintenance int64) (dto.TeamPreferences, *api.ErrorResponse) {
+	preferences, err := getTeamPreferences(dataBase, teamID)
+	if err != nil {
+		return dto.TeamPreferences{}, api.ErrorInternalServer(err)
+	}
+
+	if len(preferences.Tags) == 0 {
+		return dto.TeamPreferences{}, api.ErrorInvalidRequest(errors.New("team has no tags to set maintenance of triggers"))
+	}
+
+	if maintenance <= timeCallMaintenance {
+		maintenance = 0
+	}
+
+	triggerIDs := make([]string, 0)
+	// There are no triggers to end maintenance of if the team is not in maintenance.
+	if maintenance != 0 || preferences.Maintenance != 0 {
+		triggerIDs, err = getTeamTriggerIDs(dataBase, teamID, preferences.Tags)
+		if err != nil {
+			return dto.TeamPreferences{}, api.ErrorInternalServer(err)
+		}
+	}
+
+	for _, triggerID := range triggerIDs {
+		if maintenance == 0 {
+			lastCheck, err := dataBase.GetTriggerLastCheck(triggerID)
+			if err != nil {
+				if errors.Is(err, database.ErrNil) {
+					continue
+				}
+
+				return dto.TeamPreferences{}, api.ErrorInternalServer(err)
+			}
+
+			if lastCheck.Maintenance != preferences.Maintenance {
+				continue
+			}
+		}
+
+		triggerMaintenance := dto.TriggerMaintenance{Trigger: &maintenance}
+		if apiErr := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, userLogin, timeCallMaintenance); apiErr != nil {
+			return dto.TeamPreferences{}, apiErr
+		}
+	}
+
+	if maintenance == 0 {
+		preferences.MaintenanceInfo.Set(preferences.MaintenanceInfo.StartUser, preferences.MaintenanceInfo.StartTime, &userLogin, &timeCallMaintenance)
+	} else {
+		preferences.MaintenanceInfo.Set(&userLogin, &timeCallMaintenance, nil, nil)
+	}
+
+	preferences.Maintenance = maintenance
+
+	if err = dataBase.SaveTeamPreferences(teamID, preferences); err != nil {
+		return dto.TeamPreferences{}, api.ErrorInternalServer(fmt.Errorf("cannot save team preferences: %w", err))
+	}
+
+	return dto.TeamPreferences(preferences), nil
+}
+
+// ApplyTeamSubscriptionDefaults fills settings of new team subscription which are not specified in request with team defaults.
+// specifiedFields contains json names of subscription fields present in request.
+func ApplyTeamSubscriptionDefaults(dataBase moira.Database, teamID string, subscription *dto.Subscription, specifiedFields map[string]struct{}) *api.ErrorResponse {
+	preferences, err := getTeamPreferences(dataBase, teamID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+
+	defaults := preferences.SubscriptionDefaults
+
+	isSpecified := func(field string) bool {
+		_, ok := specifiedFields[field]
+		return ok
+	}
+
+	if defaults.Schedule != nil && !isSpecified("sched") {
+		subscription.Schedule = *defaults.Schedule
+	}
+
+	if defaults.PlottingTheme != "" && !isSpecified("plotting") {
+		subscription.Plotting.Theme = defaults.PlottingTheme
+	}
+
+	if defaults.ThrottlingEnabled != nil && !isSpecified("throttling") {
+		subscription.ThrottlingEnabled = *defaults.ThrottlingEnabled
+	}
+
+	if defaults.IgnoreWarnings != nil && !isSpecified("ignore_warnings") {
+		subscription.IgnoreWarnings = *defaults.IgnoreWarnings
+	}
+
+	return nil
+}
+
+// getTeamPreferences returns team-wide settings of the team, empty if they were never saved.
+func getTeamPreferences(dataBase moira.Database, teamID string) (moira.TeamPreferences, error) {
+	preferences, err := dataBase.GetTeamPreferences(teamID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return moira.TeamPreferences{}, fmt.Errorf("cannot get team preferences from database: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// getTeamTriggerIDs returns IDs of triggers of the team tagged with any of the team tags.
+// Triggers of other teams and personal triggers are left untouched, because team tags are set freely by its editors.
+func getTeamTriggerIDs(dataBase moira.Database, teamID string, tags []string) ([]string, error) {
+	taggedTriggerIDs := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, tag := range tags {
+		tagTriggerIDs, err := dataBase.GetTagTriggerIDs(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, triggerID := range tagTriggerIDs {
+			if _, ok := seen[triggerID]; ok {
+				continue
+			}
+
+			seen[triggerID] = struct{}{}
+			taggedTriggerIDs = append(taggedTriggerIDs, triggerID)
+		}
+	}
+
+	triggerIDs := make([]string, 0)
+	if len(taggedTriggerIDs) == 0 {
+		return triggerIDs, nil
+	}
+
+	triggers, err := dataBase.GetTriggers(taggedTriggerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trigger := range triggers {
+		if trigger != nil && trigger.TeamID == teamID {
+			triggerIDs = append(triggerIDs, trigger.ID)
+		}
+	}
+
+	return triggerIDs, nil
+}
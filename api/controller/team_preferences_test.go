@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTeamPreferences(t *testing.T) {
+	Convey("GetTeamPreferences", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		Convey("preferences were never saved", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, database.ErrNil)
+
+			actual, err := GetTeamPreferences(dataBase, teamID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamPreferences{})
+		})
+
+		Convey("database error", func() {
+			errReturned := errors.New("test error")
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, errReturned)
+
+			actual, err := GetTeamPreferences(dataBase, teamID)
+			So(err, ShouldResemble, api.ErrorInternalServer(fmt.Errorf("cannot get team preferences from database: %w", errReturned)))
+			So(actual, ShouldResemble, dto.TeamPreferences{})
+		})
+	})
+}
+
+func TestUpdateTeamPreferences(t *testing.T) {
+	Convey("UpdateTeamPreferences", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		user := "user"
+		startTime := int64(100)
+
+		existing := moira.TeamPreferences{
+			Tags:            []string{"old"},
+			Maintenance:     1000,
+			MaintenanceInfo: moira.MaintenanceInfo{StartUser: &user, StartTime: &startTime},
+		}
+
+		Convey("maintenance of the team is kept", func() {
+			preferences := dto.TeamPreferences{
+				Tags:        []string{"new"},
+				QuietHours:  []moira.QuietHoursPeriod{{From: 10, To: 20}},
+				Maintenance: 5000,
+			}
+
+			expected := moira.TeamPreferences{
+				Tags:            []string{"new"},
+				QuietHours:      []moira.QuietHoursPeriod{{From: 10, To: 20}},
+				Maintenance:     existing.Maintenance,
+				MaintenanceInfo: existing.MaintenanceInfo,
+			}
+
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(existing, nil)
+			dataBase.EXPECT().SaveTeamPreferences(teamID, expected).Return(nil)
+
+			actual, err := UpdateTeamPreferences(dataBase, teamID, preferences)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamPreferences(expected))
+		})
+	})
+}
+
+func TestSetTeamMaintenance(t *testing.T) {
+	Convey("SetTeamMaintenance", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			teamID     = "testTeam"
+			userLogin  = "user"
+			callTime   = int64(1000)
+			triggerID1 = "trigger1"
+			triggerID2 = "trigger2"
+		)
+
+		Convey("team without tags", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, database.ErrNil)
+
+			actual, err := SetTeamMaintenance(dataBase, teamID, 2000, userLogin, callTime)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(errors.New("team has no tags to set maintenance of triggers")))
+			So(actual, ShouldResemble, dto.TeamPreferences{})
+		})
+
+		Convey("start maintenance of tagged triggers of the team", func() {
+			maintenance := int64(2000)
+			preferences := moira.TeamPreferences{Tags: []string{"tag1", "tag2"}}
+
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+			dataBase.EXPECT().GetTagTriggerIDs("tag1").Return([]string{triggerID1}, nil)
+			dataBase.EXPECT().GetTagTriggerIDs("tag2").Return([]string{triggerID1, triggerID2, "trigger3", "trigger4"}, nil)
+			dataBase.EXPECT().GetTriggers([]string{triggerID1, triggerID2, "trigger3", "trigger4"}).Return([]*moira.Trigger{
+				{ID: triggerID1, TeamID: teamID},
+				{ID: triggerID2, TeamID: teamID},
+				{ID: "trigger3", TeamID: "anotherTeam"},
+				{ID: "trigger4"},
+			}, nil)
+
+			for _, triggerID := range []string{triggerID1, triggerID2} {
+				dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+				dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, nil, &maintenance, userLogin, callTime).Return(nil)
+				dataBase.EXPECT().ReleaseTriggerCheckLock(triggerID)
+			}
+
+			login, timestamp := userLogin, callTime
+			expected := moira.TeamPreferences{
+				Tags:            preferences.Tags,
+				Maintenance:     maintenance,
+				MaintenanceInfo: moira.MaintenanceInfo{StartUser: &login, StartTime: &timestamp},
+			}
+			dataBase.EXPECT().SaveTeamPreferences(teamID, expected).Return(nil)
+
+			actual, err := SetTeamMaintenance(dataBase, teamID, maintenance, userLogin, callTime)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamPreferences(expected))
+		})
+
+		Convey("end maintenance keeps triggers with changed maintenance", func() {
+			startUser, startTime := "another", int64(500)
+			preferences := moira.TeamPreferences{
+				Tags:            []string{"tag1"},
+				Maintenance:     2000,
+				MaintenanceInfo: moira.MaintenanceInfo{StartUser: &startUser, StartTime: &startTime},
+			}
+			zero := int64(0)
+
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+			dataBase.EXPECT().GetTagTriggerIDs("tag1").Return([]string{triggerID1, triggerID2, "trigger3", "trigger4"}, nil)
+			dataBase.EXPECT().GetTriggers([]string{triggerID1, triggerID2, "trigger3", "trigger4"}).Return([]*moira.Trigger{
+				{ID: triggerID1, TeamID: teamID},
+				{ID: triggerID2, TeamID: teamID},
+				{ID: "trigger3", TeamID: teamID},
+				nil,
+			}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID1).Return(moira.CheckData{Maintenance: 2000}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID2).Return(moira.CheckData{Maintenance: 3000}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck("trigger3").Return(moira.CheckData{}, database.ErrNil)
+			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID1, maxTriggerLockAttempts).Return(nil)
+			dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID1, nil, &zero, userLogin, callTime).Return(nil)
+			dataBase.EXPECT().ReleaseTriggerCheckLock(triggerID1)
+
+			login, timestamp := userLogin, callTime
+			expected := moira.TeamPreferences{
+				Tags: preferences.Tags,
+				MaintenanceInfo: moira.MaintenanceInfo{
+					StartUser: &startUser,
+					StartTime: &startTime,
+					StopUser:  &login,
+					StopTime:  &timestamp,
+				},
+			}
+			dataBase.EXPECT().SaveTeamPreferences(teamID, expected).Return(nil)
+
+			actual, err := SetTeamMaintenance(dataBase, teamID, callTime-1, userLogin, callTime)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamPreferences(expected))
+		})
+
+		Convey("end maintenance of team which is not in maintenance does not touch triggers", func() {
+			preferences := moira.TeamPreferences{Tags: []string{"tag1"}}
+
+			login, timestamp := userLogin, callTime
+			expected := moira.TeamPreferences{
+				Tags:            preferences.Tags,
+				MaintenanceInfo: moira.MaintenanceInfo{StopUser: &login, StopTime: &timestamp},
+			}
+
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+			dataBase.EXPECT().SaveTeamPreferences(teamID, expected).Return(nil)
+
+			actual, err := SetTeamMaintenance(dataBase, teamID, 0, userLogin, callTime)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamPreferences(expected))
+		})
+	})
+}
+
+func TestApplyTeamSubscriptionDefaults(t *testing.T) {
+	Convey("ApplyTeamSubscriptionDefaults", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		enabled := true
+		schedule := moira.ScheduleData{StartOffset: 60, EndOffset: 120}
+		preferences := moira.TeamPreferences{
+			SubscriptionDefaults: moira.TeamSubscriptionDefaults{
+				Schedule:          &schedule,
+				PlottingTheme:     "dark",
+				ThrottlingEnabled: &enabled,
+				IgnoreWarnings:    &enabled,
+			},
+		}
+
+		Convey("not specified fields are filled with defaults", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+
+			subscription := &dto.Subscription{}
+			err := ApplyTeamSubscriptionDefaults(dataBase, teamID, subscription, map[string]struct{}{})
+			So(err, ShouldBeNil)
+			So(subscription, ShouldResemble, &dto.Subscription{
+				Schedule:          schedule,
+				Plotting:          moira.PlottingData{Theme: "dark"},
+				ThrottlingEnabled: true,
+				IgnoreWarnings:    true,
+			})
+		})
+
+		Convey("specified fields are kept", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+
+			subscription := &dto.Subscription{Plotting: moira.PlottingData{Theme: "light"}}
+			specified := map[string]struct{}{"sched": {}, "throttling": {}, "ignore_warnings": {}, "plotting": {}}
+			err := ApplyTeamSubscriptionDefaults(dataBase, teamID, subscription, specified)
+			So(err, ShouldBeNil)
+			So(subscription, ShouldResemble, &dto.Subscription{Plotting: moira.PlottingData{Theme: "light"}})
+		})
+
+		Convey("specified plotting without theme is kept", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(preferences, nil)
+
+			subscription := &dto.Subscription{Plotting: moira.PlottingData{Enabled: true}}
+			err := ApplyTeamSubscriptionDefaults(dataBase, teamID, subscription, map[string]struct{}{"plotting": {}})
+			So(err, ShouldBeNil)
+			So(subscription.Plotting, ShouldResemble, moira.PlottingData{Enabled: true})
+		})
+
+		Convey("team without defaults", func() {
+			dataBase.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, database.ErrNil)
+
+			subscription := &dto.Subscription{}
+			err := ApplyTeamSubscriptionDefaults(dataBase, teamID, subscription, map[string]struct{}{})
+			So(err, ShouldBeNil)
+			So(subscription, ShouldResemble, &dto.Subscription{})
+		})
+	})
+}
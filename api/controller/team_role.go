@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+)
+
+// GetTeamRoles is a controller function that returns roles of all members of the team.
+func GetTeamRoles(dataBase moira.Database, teamID string) (dto.TeamRoles, *api.ErrorResponse) {
+	users, err := dataBase.GetTeamUsers(teamID)
+	if err != nil {
+		return dto.TeamRoles{}, api.ErrorInternalServer(fmt.Errorf("cannot get team users from database: %w", err))
+	}
+
+	roles, err := dataBase.GetTeamUserRoles(teamID)
+	if err != nil {
+		return dto.TeamRoles{}, api.ErrorInternalServer(fmt.Errorf("cannot get team roles from database: %w", err))
+	}
+
+	slices.Sort(users)
+
+	result := dto.TeamRoles{Roles: make([]dto.TeamMemberRole, 0, len(users))}
+	for _, userID := range users {
+		result.Roles = append(result.Roles, dto.TeamMemberRole{
+			Login: userID,
+			Role:  moira.MemberTeamRole(roles, userID),
+		})
+	}
+
+	return result, nil
+}
+
+// SetTeamUserRole is a controller function that changes role of the team member.
+func SetTeamUserRole(dataBase moira.Database, teamID, userID string, role moira.TeamRole) (dto.TeamMemberRole, *api.ErrorResponse) {
+	users, err := dataBase.GetTeamUsers(teamID)
+	if err != nil {
+		return dto.TeamMemberRole{}, api.ErrorInternalServer(fmt.Errorf("cannot get team users from database: %w", err))
+	}
+
+	if !slices.Contains(users, userID) {
+		return dto.TeamMemberRole{}, api.ErrorNotFound(fmt.Sprintf("user that you specified not found in this team: %s", userID))
+	}
+
+	roles, err := dataBase.GetTeamUserRoles(teamID)
+	if err != nil {
+		return dto.TeamMemberRole{}, api.ErrorInternalServer(fmt.Errorf("cannot get team roles from database: %w", err))
+	}
+
+	roles[userID] = role
+	if apiErr := checkTeamHasOwner(roles, users); apiErr != nil {
+		return dto.TeamMemberRole{}, apiErr
+	}
+
+	if err = dataBase.SaveTeamUserRole(teamID, userID, role); err != nil {
+		return dto.TeamMemberRole{}, api.ErrorInternalServer(fmt.Errorf("cannot save role of team user: %w", err))
+	}
+
+	return dto.TeamMemberRole{Login: userID, Role: role}, nil
+}
+
+// getUserTeamRole returns role of the user in the team or empty role if the user is not a member of the team.
+func getUserTeamRole(dataBase moira.Database, teamID, userID string, auth *api.Authorization) (moira.TeamRole, error) {
+	if auth.ServiceAccountTeamID != "" {
+		if auth.ServiceAccountTeamID == teamID {
+			return moira.TeamRoleServiceAccount, nil
+		}
+
+		return "", nil
+	}
+
+	isMember, err := dataBase.IsTeamContainUser(teamID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if !isMember {
+		return "", nil
+	}
+
+	roles, err := dataBase.GetTeamUserRoles(teamID)
+	if err != nil {
+		return "", err
+	}
+
+	return moira.MemberTeamRole(roles, userID), nil
+}
+
+// checkTeamHasOwner checks that at least one of the given team members is an owner, so the team can still be managed.
+func checkTeamHasOwner(roles map[string]moira.TeamRole, users []string) *api.ErrorResponse {
+	for _, userID := range users {
+		if moira.MemberTeamRole(roles, userID) == moira.TeamRoleOwner {
+			return nil
+		}
+	}
+
+	return api.ErrorInvalidRequest(fmt.Errorf("team must have at least one %s, members left: %s", moira.TeamRoleOwner, strings.Join(users, ", ")))
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTeamRoles(t *testing.T) {
+	Convey("GetTeamRoles", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		Convey("members without role are owners", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{"user2", "user1"}, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{"user2": moira.TeamRoleViewer}, nil)
+
+			actual, err := GetTeamRoles(dataBase, teamID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamRoles{Roles: []dto.TeamMemberRole{
+				{Login: "user1", Role: moira.TeamRoleOwner},
+				{Login: "user2", Role: moira.TeamRoleViewer},
+			}})
+		})
+
+		Convey("database error", func() {
+			errReturned := fmt.Errorf("test error")
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{"user1"}, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(nil, errReturned)
+
+			actual, err := GetTeamRoles(dataBase, teamID)
+			So(err, ShouldResemble, api.ErrorInternalServer(fmt.Errorf("cannot get team roles from database: %w", errReturned)))
+			So(actual, ShouldResemble, dto.TeamRoles{})
+		})
+	})
+}
+
+func TestSetTeamUserRole(t *testing.T) {
+	Convey("SetTeamUserRole", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const teamID = "testTeam"
+
+		const userID = "user1"
+
+		const userID2 = "user2"
+
+		Convey("set successfully", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID, userID2}, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID: moira.TeamRoleOwner}, nil)
+			dataBase.EXPECT().SaveTeamUserRole(teamID, userID2, moira.TeamRoleViewer).Return(nil)
+
+			actual, err := SetTeamUserRole(dataBase, teamID, userID2, moira.TeamRoleViewer)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, dto.TeamMemberRole{Login: userID2, Role: moira.TeamRoleViewer})
+		})
+
+		Convey("user is not a member of the team", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID}, nil)
+
+			actual, err := SetTeamUserRole(dataBase, teamID, userID2, moira.TeamRoleViewer)
+			So(err, ShouldResemble, api.ErrorNotFound("user that you specified not found in this team: user2"))
+			So(actual, ShouldResemble, dto.TeamMemberRole{})
+		})
+
+		Convey("last owner can not be demoted", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID, userID2}, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{
+				userID:  moira.TeamRoleOwner,
+				userID2: moira.TeamRoleEditor,
+			}, nil)
+
+			actual, err := SetTeamUserRole(dataBase, teamID, userID, moira.TeamRoleEditor)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team must have at least one owner, members left: user1, user2")))
+			So(actual, ShouldResemble, dto.TeamMemberRole{})
+		})
+	})
+}
@@ -37,6 +37,7 @@ func TestCreateTeam(t *testing.T) {
 			})
 			dataBase.EXPECT().GetUserTeams(user).Return([]string{ID}, nil)
 			dataBase.EXPECT().SaveTeamsAndUsers(gomock.Any(), []string{user}, gomock.Any()).Return(nil)
+			dataBase.EXPECT().SaveTeamUserRole(gomock.Any(), user, moira.TeamRoleOwner).Return(nil)
 			response, err := CreateTeam(dataBase, team, user)
 			So(response.ID, ShouldResemble, ID)
 			So(err, ShouldBeNil)
@@ -49,6 +50,7 @@ func TestCreateTeam(t *testing.T) {
 			dataBase.EXPECT().SaveTeam(teamID, team.ToMoiraTeam()).Return(nil)
 			dataBase.EXPECT().GetUserTeams(user).Return([]string{}, nil)
 			dataBase.EXPECT().SaveTeamsAndUsers(teamID, []string{user}, map[string][]string{user: {teamID}}).Return(nil)
+			dataBase.EXPECT().SaveTeamUserRole(teamID, user, moira.TeamRoleOwner).Return(nil)
 			response, err := CreateTeam(dataBase, team, user)
 			So(response.ID, ShouldResemble, teamID)
 			So(err, ShouldBeNil)
@@ -65,6 +67,7 @@ func TestCreateTeam(t *testing.T) {
 			})
 			dataBase.EXPECT().GetUserTeams(user).Return([]string{ID}, nil)
 			dataBase.EXPECT().SaveTeamsAndUsers(gomock.Any(), []string{user}, gomock.Any()).Return(nil)
+			dataBase.EXPECT().SaveTeamUserRole(gomock.Any(), user, moira.TeamRoleOwner).Return(nil)
 			response, err := CreateTeam(dataBase, team, user)
 			So(response.ID, ShouldResemble, ID)
 			So(err, ShouldBeNil)
@@ -505,16 +508,16 @@ func TestGetUserTeams(t *testing.T) {
 
 		teamsIDs := []string{teamID, teamID2}
 		teams := []dto.TeamModel{
-			{
+			dto.NewTeamModel(moira.Team{
 				ID:          teamID,
 				Name:        "team 1 name",
 				Description: "team 1 Description",
-			},
-			{
+			}),
+			dto.NewTeamModel(moira.Team{
 				ID:          teamID2,
 				Name:        "team 2 name",
 				Description: "team 2 Description",
-			},
+			}),
 		}
 
 		Convey("get successfully", func() {
@@ -607,6 +610,7 @@ func TestAddTeamUsers(t *testing.T) {
 						userID3: {teamID},
 					},
 				).Return(nil),
+				dataBase.EXPECT().SaveTeamUserRole(teamID, userID3, moira.TeamRoleEditor).Return(nil),
 			)
 
 			response, err := AddTeamUsers(dataBase, teamID, []string{userID3})
@@ -675,11 +679,44 @@ func TestUpdateTeam(t *testing.T) {
 		team := dto.TeamModel{Name: "testTeam", Description: "test team description"}
 
 		Convey("update successfully", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID}, nil)
 			dataBase.EXPECT().SaveTeam(teamID, team.ToMoiraTeam()).Return(nil)
 			response, err := UpdateTeam(dataBase, teamID, team)
 			So(response.ID, ShouldResemble, teamID)
 			So(err, ShouldBeNil)
 		})
+
+		Convey("keep restriction of trigger changes if it is not specified", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil)
+			dataBase.EXPECT().SaveTeam(teamID, moira.Team{
+				Name:                   team.Name,
+				Description:            team.Description,
+				RestrictTriggerChanges: true,
+			}).Return(nil)
+			response, err := UpdateTeam(dataBase, teamID, team)
+			So(response.ID, ShouldResemble, teamID)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("change restriction of trigger changes if it is specified", func() {
+			restrictTriggerChanges := false
+			team.RestrictTriggerChanges = &restrictTriggerChanges
+
+			dataBase.EXPECT().SaveTeam(teamID, moira.Team{
+				Name:        team.Name,
+				Description: team.Description,
+			}).Return(nil)
+			response, err := UpdateTeam(dataBase, teamID, team)
+			So(response.ID, ShouldResemble, teamID)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("error on getting team", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, errors.New("error"))
+			response, err := UpdateTeam(dataBase, teamID, team)
+			So(response, ShouldResemble, dto.SaveTeamResponse{})
+			So(err, ShouldResemble, api.ErrorInternalServer(fmt.Errorf("cannot get team: %w", errors.New("error"))))
+		})
 	})
 }
 
@@ -704,6 +741,7 @@ func TestDeleteTeamUser(t *testing.T) {
 				dataBase.EXPECT().GetUserTeams(userID).Return([]string{teamID, "team2"}, nil),
 				dataBase.EXPECT().GetUserTeams(userID2).Return([]string{teamID}, nil),
 				dataBase.EXPECT().GetUserTeams(userID3).Return([]string{teamID}, nil),
+				dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil),
 				dataBase.EXPECT().SaveTeamsAndUsers(teamID, []string{userID2, userID3}, map[string][]string{
 					userID:  {"team2"},
 					userID2: {teamID},
@@ -715,6 +753,21 @@ func TestDeleteTeamUser(t *testing.T) {
 			So(reply, ShouldResemble, dto.TeamMembers{Usernames: []string{userID2, userID3}})
 			So(err, ShouldBeNil)
 		})
+		Convey("removal of last owner", func() {
+			gomock.InOrder(
+				dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID, userID2}, nil),
+				dataBase.EXPECT().GetUserTeams(userID).Return([]string{teamID}, nil),
+				dataBase.EXPECT().GetUserTeams(userID2).Return([]string{teamID}, nil),
+				dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{
+					userID:  moira.TeamRoleOwner,
+					userID2: moira.TeamRoleViewer,
+				}, nil),
+			)
+
+			reply, err := DeleteTeamUser(dataBase, teamID, userID)
+			So(reply, ShouldResemble, dto.TeamMembers{})
+			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team must have at least one owner, members left: userID2")))
+		})
 		Convey("team does not have any users", func() {
 			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{}, database.ErrNil)
 			reply, err := DeleteTeamUser(dataBase, teamID, userID)
@@ -902,7 +955,9 @@ func TestSetTeamUsers(t *testing.T) {
 		Convey("Set to empty team", func() {
 			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{}, nil)
 			dataBase.EXPECT().GetUserTeams(userID1).Return(nil, database.ErrNil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
 			dataBase.EXPECT().SaveTeamsAndUsers(teamID, []string{userID1}, map[string][]string{userID1: {teamID}})
+			dataBase.EXPECT().SaveTeamUserRole(teamID, userID1, moira.TeamRoleOwner).Return(nil)
 			actual, err := SetTeamUsers(dataBase, teamID, []string{userID1})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, dto.TeamMembers{Usernames: []string{userID1}})
@@ -911,11 +966,22 @@ func TestSetTeamUsers(t *testing.T) {
 			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID1}, nil)
 			dataBase.EXPECT().GetUserTeams(userID1).Return([]string{teamID}, nil)
 			dataBase.EXPECT().GetUserTeams(userID2).Return(nil, database.ErrNil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
 			dataBase.EXPECT().SaveTeamsAndUsers(teamID, []string{userID1, userID2}, map[string][]string{userID1: {teamID}, userID2: {teamID}})
+			dataBase.EXPECT().SaveTeamUserRole(teamID, userID2, moira.TeamRoleEditor).Return(nil)
 			actual, err := SetTeamUsers(dataBase, teamID, []string{userID1, userID2})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, dto.TeamMembers{Usernames: []string{userID1, userID2}})
 		})
+		Convey("Replace the only owner", func() {
+			dataBase.EXPECT().GetTeamUsers(teamID).Return([]string{userID1}, nil)
+			dataBase.EXPECT().GetUserTeams(userID1).Return([]string{teamID}, nil)
+			dataBase.EXPECT().GetUserTeams(userID2).Return(nil, database.ErrNil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID1: moira.TeamRoleOwner}, nil)
+			actual, err := SetTeamUsers(dataBase, teamID, []string{userID2})
+			So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team must have at least one owner, members left: userID2")))
+			So(actual, ShouldResemble, dto.TeamMembers{})
+		})
 	})
 }
 
@@ -935,13 +1001,51 @@ func TestCheckUserPermissionsForTeam(t *testing.T) {
 		Convey("user in team", func() {
 			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
-			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessRead)
+			So(err, ShouldBeNil)
+		})
+		Convey("user without role is owner", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessManage)
 			So(err, ShouldBeNil)
 		})
+		Convey("editor can not manage team", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID: moira.TeamRoleEditor}, nil)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessManage)
+			So(err, ShouldResemble, api.ErrorForbidden("your role editor in the team does not permit this"))
+		})
+		Convey("viewer can not edit team resources", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID: moira.TeamRoleViewer}, nil)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessEdit)
+			So(err, ShouldResemble, api.ErrorForbidden("your role viewer in the team does not permit this"))
+		})
+		Convey("service account", func() {
+			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
+
+			Convey("can edit resources of its team", func() {
+				err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth.WithServiceAccount(teamID), moira.TeamAccessEdit)
+				So(err, ShouldBeNil)
+			})
+			Convey("can not manage its team", func() {
+				err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth.WithServiceAccount(teamID), moira.TeamAccessManage)
+				So(err, ShouldResemble, api.ErrorForbidden("your role service_account in the team does not permit this"))
+			})
+			Convey("has no access to other teams", func() {
+				err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth.WithServiceAccount("otherTeam"), moira.TeamAccessRead)
+				So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
+			})
+		})
 		Convey("user is not in team", func() {
 			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(false, nil)
-			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
 		})
 		Convey("error while checking user", func() {
@@ -949,18 +1053,18 @@ func TestCheckUserPermissionsForTeam(t *testing.T) {
 
 			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, nil)
 			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(false, returnErr)
-			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorInternalServer(returnErr))
 		})
 		Convey("error while getting team", func() {
 			returnErr := errors.New("returning error")
 			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, returnErr)
-			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorInternalServer(returnErr))
 		})
 		Convey("team is not exist", func() {
 			dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{}, database.ErrNil)
-			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth)
+			err := CheckUserPermissionsForTeam(dataBase, teamID, userID, auth, moira.TeamAccessRead)
 			So(err, ShouldResemble, api.ErrorNotFound("team with ID 'testTeam' does not exists"))
 		})
 	})
@@ -998,6 +1102,7 @@ func TestGetTeamSettings(t *testing.T) {
 		database.EXPECT().GetTeamContactIDs(teamID).Return(contactIDs, nil)
 		database.EXPECT().GetContacts(contactIDs).Return(contacts, nil)
 		database.EXPECT().GetContactsScore(contactIDs).Return(contactsScores, nil)
+		database.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, nil)
 
 		settings, err := GetTeamSettings(database, teamID)
 		So(err, ShouldBeNil)
@@ -1025,6 +1130,7 @@ func TestGetTeamSettings(t *testing.T) {
 		database.EXPECT().GetTeamContactIDs(teamID).Return(contactIDs, nil)
 		database.EXPECT().GetContacts(contactIDs).Return(contacts, nil)
 		database.EXPECT().GetContactsScore(contactIDs).Return(contactsScores, nil)
+		database.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{}, nil)
 
 		settings, err := GetTeamSettings(database, teamID)
 		So(err, ShouldBeNil)
@@ -1041,12 +1147,14 @@ func TestGetTeamSettings(t *testing.T) {
 		database.EXPECT().GetTeamContactIDs(teamID).Return([]string{}, nil)
 		database.EXPECT().GetContacts([]string{}).Return([]*moira.ContactData{}, nil)
 		database.EXPECT().GetContactsScore([]string{}).Return(map[string]*moira.ContactScore{}, nil)
+		database.EXPECT().GetTeamPreferences(teamID).Return(moira.TeamPreferences{Tags: []string{"team-tag"}}, nil)
 		settings, err := GetTeamSettings(database, teamID)
 		So(err, ShouldBeNil)
 		So(settings, ShouldResemble, dto.TeamSettings{
 			TeamID:        teamID,
 			Contacts:      make([]dto.TeamContactWithScore, 0),
 			Subscriptions: make([]moira.SubscriptionData, 0),
+			Preferences:   dto.TeamPreferences{Tags: []string{"team-tag"}},
 		})
 	})
 
@@ -137,6 +137,95 @@ func metricEvaluationRulesChanged(oldTrigger, newTrigger *moira.Trigger) bool {
 	return false
 }
 
+// CheckTriggerTeamAssignment checks that the user can assign the trigger to the team, which requires edit access to the team.
+// Empty triggerID means that the trigger is being created.
+func CheckTriggerTeamAssignment(dataBase moira.Database, triggerID, teamID, userLogin string, auth *api.Authorization) *api.ErrorResponse {
+	if triggerID != "" {
+		currentTrigger, err := dataBase.GetTrigger(triggerID)
+		if err != nil && !errors.Is(err, database.ErrNil) {
+			return api.ErrorInternalServer(err)
+		}
+
+		if err == nil && currentTrigger.TeamID == teamID {
+			return nil
+		}
+	}
+
+	return CheckUserPermissionsForTeam(dataBase, teamID, userLogin, auth, moira.TeamAccessEdit)
+}
+
+// CheckUserPermissionsForTrigger checks that the user can modify the trigger.
+// Triggers of the team which restricts trigger changes can be modified only by its members with edit access
+// and administrators, triggers of other teams can be modified by anyone, whatever role in the team the user has.
+func CheckUserPermissionsForTrigger(dataBase moira.Database, triggerID, userLogin string, auth *api.Authorization) *api.ErrorResponse {
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return api.ErrorInternalServer(err)
+	}
+
+	if trigger.TeamID == "" {
+		return nil
+	}
+
+	team, err := dataBase.GetTeam(trigger.TeamID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return api.ErrorInternalServer(fmt.Errorf("cannot get team from database: %w", err))
+	}
+
+	if team.RestrictTriggerChanges {
+		return CheckUserPermissionsForTeam(dataBase, trigger.TeamID, userLogin, auth, moira.TeamAccessEdit)
+	}
+
+	return nil
+}
+
+// SetTriggerTeam transfers the trigger to the team, empty teamID makes the trigger personal.
+func SetTriggerTeam(dataBase moira.Database, triggerID, teamID, userLogin string, auth *api.Authorization) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+	if !isTeamIDValid(teamID) {
+		return nil, api.ErrorInvalidRequest(errors.New(teamIDVaildationErrorMsg))
+	}
+
+	if teamID != "" {
+		if apiErr := CheckUserPermissionsForTeam(dataBase, teamID, userLogin, auth, moira.TeamAccessEdit); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	if err := dataBase.AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	defer dataBase.DeleteTriggerCheckLock(triggerID) //nolint
+
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' does not exists", triggerID))
+		}
+
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	trigger.TeamID = teamID
+	trigger.UpdatedBy = userLogin
+
+	if err = dataBase.SaveTrigger(triggerID, &trigger); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.SaveTriggerResponse{
+		ID:      triggerID,
+		Message: "trigger team updated",
+	}, nil
+}
+
 // GetTrigger gets trigger with his throttling - next allowed message time.
 func GetTrigger(dataBase moira.Database, triggerID string) (*dto.Trigger, *api.ErrorResponse) {
 	trigger, err := dataBase.GetTrigger(triggerID)
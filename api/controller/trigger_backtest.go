@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/checker"
+	"github.com/moira-alert/moira/database"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// DefaultTriggerBacktestStep is the default interval between checks of trigger during backtest in seconds.
+const DefaultTriggerBacktestStep = 60
+
+// BacktestTrigger runs the trigger against metrics of the time range without saving its state or sending notifications.
+// Backtest is stopped when the context is done, e.g. when client closes the request.
+func BacktestTrigger(
+	ctx context.Context,
+	dataBase moira.Database,
+	logger moira.Logger,
+	sourceProvider *metricSource.SourceProvider,
+	trigger *moira.Trigger,
+	from, to, step int64,
+) (*dto.TriggerBacktest, *api.ErrorResponse) {
+	if err := checker.ValidateBacktestRange(trigger.TriggerSource, from, to, step); err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+
+	result, err := checker.Backtest(ctx, trigger, dataBase, logger, sourceProvider, from, to, step)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return &dto.TriggerBacktest{
+		From:           from,
+		To:             to,
+		Step:           step,
+		BacktestResult: *result,
+	}, nil
+}
+
+// BacktestExistingTrigger runs the saved trigger against metrics of the time range, its saved state is not changed.
+func BacktestExistingTrigger(
+	ctx context.Context,
+	dataBase moira.Database,
+	logger moira.Logger,
+	sourceProvider *metricSource.SourceProvider,
+	triggerID string,
+	from, to, step int64,
+) (*dto.TriggerBacktest, *api.ErrorResponse) {
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, api.ErrorNotFound("trigger not found")
+		}
+
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	return BacktestTrigger(ctx, dataBase, logger, sourceProvider, &trigger, from, to, step)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/checker"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBacktestTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger, _ := logging.GetLogger("Test")
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	source := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+	sourceProvider := metricSource.CreateTestMetricSourceProvider(source, nil, nil)
+
+	const (
+		from int64 = 1704067200
+		to         = from + 600
+	)
+
+	errorValue := 90.0
+	trigger := moira.Trigger{
+		ID:            "trigger1",
+		Targets:       []string{"disk.*"},
+		ErrorValue:    &errorValue,
+		TriggerType:   moira.RisingTrigger,
+		TriggerSource: moira.GraphiteLocal,
+		ClusterId:     moira.DefaultCluster,
+	}
+
+	Convey("Test backtest of trigger", t, func() {
+		Convey("With invalid range", func() {
+			backtest, err := BacktestTrigger(context.Background(), dataBase, logger, sourceProvider, &trigger, to, from, DefaultTriggerBacktestStep)
+			So(backtest, ShouldBeNil)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(checker.ValidateBacktestRange(trigger.TriggerSource, to, from, DefaultTriggerBacktestStep)))
+		})
+
+		Convey("With remote source and too many checks", func() {
+			remoteTrigger := trigger
+			remoteTrigger.TriggerSource = moira.PrometheusRemote
+			remoteTo := from + (checker.MaxRemoteBacktestChecks+1)*DefaultTriggerBacktestStep
+
+			backtest, err := BacktestTrigger(context.Background(), dataBase, logger, sourceProvider, &remoteTrigger, from, remoteTo, DefaultTriggerBacktestStep)
+			So(backtest, ShouldBeNil)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(checker.ValidateBacktestRange(moira.PrometheusRemote, from, remoteTo, DefaultTriggerBacktestStep)))
+		})
+
+		Convey("With cancelled request", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			backtest, err := BacktestTrigger(ctx, dataBase, logger, sourceProvider, &trigger, from, to, DefaultTriggerBacktestStep)
+			So(backtest, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+			So(err.HTTPStatusCode, ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("With error from metric source", func() {
+			fetchErr := errors.New("source is unavailable")
+			source.EXPECT().Fetch("disk.*", gomock.Any(), gomock.Any(), true).Return(nil, fetchErr).Times(10)
+
+			backtest, err := BacktestTrigger(context.Background(), dataBase, logger, sourceProvider, &trigger, from, to, DefaultTriggerBacktestStep)
+			So(err, ShouldBeNil)
+			So(backtest.Checks, ShouldEqual, 10)
+			So(backtest.State, ShouldEqual, moira.StateEXCEPTION)
+			So(backtest.Transitions, ShouldHaveLength, 1)
+			So(backtest.Transitions[0].OldState, ShouldEqual, moira.StateOK)
+			So(backtest.Transitions[0].State, ShouldEqual, moira.StateEXCEPTION)
+		})
+
+		Convey("With metrics", func() {
+			source.EXPECT().Fetch("disk.*", gomock.Any(), gomock.Any(), true).Return(fetchResult, nil).Times(10)
+			fetchResult.EXPECT().GetMetricsData().Return([]metricSource.MetricData{
+				*metricSource.MakeMetricData("disk.web1", []float64{0}, 60, from),
+			}).Times(10)
+			fetchResult.EXPECT().GetPatternMetrics().Return([]string{}, nil).Times(10)
+
+			backtest, err := BacktestTrigger(context.Background(), dataBase, logger, sourceProvider, &trigger, from, to, DefaultTriggerBacktestStep)
+			So(err, ShouldBeNil)
+			So(backtest.From, ShouldEqual, from)
+			So(backtest.To, ShouldEqual, to)
+			So(backtest.Step, ShouldEqual, DefaultTriggerBacktestStep)
+			So(backtest.Checks, ShouldEqual, 10)
+			So(backtest.State, ShouldEqual, moira.StateOK)
+			So(backtest.Events, ShouldHaveLength, 1)
+			So(backtest.Events[0].State, ShouldEqual, moira.StateOK)
+			So(backtest.Events[0].OldState, ShouldEqual, moira.StateNODATA)
+		})
+	})
+}
+
+func TestBacktestExistingTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger, _ := logging.GetLogger("Test")
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	sourceProvider := metricSource.CreateTestMetricSourceProvider(nil, nil, nil)
+
+	const triggerID = "trigger1"
+
+	Convey("Test backtest of existing trigger", t, func() {
+		Convey("With missing trigger", func() {
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+
+			backtest, err := BacktestExistingTrigger(context.Background(), dataBase, logger, sourceProvider, triggerID, 0, 600, DefaultTriggerBacktestStep)
+			So(backtest, ShouldBeNil)
+			So(err, ShouldResemble, api.ErrorNotFound("trigger not found"))
+		})
+
+		Convey("With error from database", func() {
+			dbErr := errors.New("database is unavailable")
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, dbErr)
+
+			backtest, err := BacktestExistingTrigger(context.Background(), dataBase, logger, sourceProvider, triggerID, 0, 600, DefaultTriggerBacktestStep)
+			So(backtest, ShouldBeNil)
+			So(err, ShouldResemble, api.ErrorInternalServer(dbErr))
+		})
+	})
+}
@@ -562,6 +562,127 @@ func TestSetTriggerMaintenance(t *testing.T) {
 	})
 }
 
+func TestCheckUserPermissionsForTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+
+	const (
+		teamID = "testTeam"
+		userID = "userID"
+	)
+
+	auth := &api.Authorization{}
+
+	Convey("Personal trigger can be changed", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Trigger of the team without restriction", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, TeamID: teamID}, nil)
+		dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID}, nil)
+
+		Convey("can be changed by any user, role in the team is not checked", func() {
+			err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("Trigger of the team with restriction", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, TeamID: teamID}, nil)
+		dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+
+		Convey("can be changed by member of the team", func() {
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID: moira.TeamRoleEditor}, nil)
+			err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("can not be changed by viewer of the team", func() {
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+			dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userID: moira.TeamRoleViewer}, nil)
+			err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+			So(err, ShouldResemble, api.ErrorForbidden("your role viewer in the team does not permit this"))
+		})
+
+		Convey("can not be changed by other users", func() {
+			dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(false, nil)
+			err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+			So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
+		})
+	})
+
+	Convey("Missing trigger is reported by handler", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+		err := CheckUserPermissionsForTrigger(dataBase, triggerID, userID, auth)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestSetTriggerTeam(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+
+	const (
+		teamID = "testTeam"
+		userID = "userID"
+	)
+
+	auth := &api.Authorization{}
+
+	Convey("Transfer trigger to the team", t, func() {
+		dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID}, nil)
+		dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(true, nil)
+		dataBase.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{}, nil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, &moira.Trigger{ID: triggerID, TeamID: teamID, UpdatedBy: userID}).Return(nil)
+
+		response, err := SetTriggerTeam(dataBase, triggerID, teamID, userID, auth)
+		So(err, ShouldBeNil)
+		So(response, ShouldResemble, &dto.SaveTriggerResponse{ID: triggerID, Message: "trigger team updated"})
+	})
+
+	Convey("Transfer trigger to the team of other users", t, func() {
+		dataBase.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID}, nil)
+		dataBase.EXPECT().IsTeamContainUser(teamID, userID).Return(false, nil)
+
+		response, err := SetTriggerTeam(dataBase, triggerID, teamID, userID, auth)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to manipulate with this team"))
+		So(response, ShouldBeNil)
+	})
+
+	Convey("Make trigger personal", t, func() {
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, TeamID: teamID}, nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, &moira.Trigger{ID: triggerID, UpdatedBy: userID}).Return(nil)
+
+		response, err := SetTriggerTeam(dataBase, triggerID, "", userID, auth)
+		So(err, ShouldBeNil)
+		So(response, ShouldResemble, &dto.SaveTriggerResponse{ID: triggerID, Message: "trigger team updated"})
+	})
+
+	Convey("Trigger not found", t, func() {
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+
+		response, err := SetTriggerTeam(dataBase, triggerID, "", userID, auth)
+		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' does not exists", triggerID)))
+		So(response, ShouldBeNil)
+	})
+}
+
 func Test_metricEvaluationRulesChanged(t *testing.T) {
 	Convey("Test metricEvaluationRulesChanged", t, func() {
 		type testcase struct {
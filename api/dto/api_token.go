@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moira-alert/moira"
+)
+
+const apiTokenNameMaxSize = 100
+
+var (
+	errEmptyAPITokenName   = errors.New("api token name cannot be empty")
+	errEmptyAPITokenScopes = errors.New("api token must have at least one scope")
+)
+
+var availableAPITokenScopes = map[moira.APITokenScope]struct{}{
+	moira.APITokenScopeRead:  {},
+	moira.APITokenScopeWrite: {},
+	moira.APITokenScopeAdmin: {},
+}
+
+// APITokenRequest is a request to issue new API token.
+type APITokenRequest struct {
+	Name      string                `json:"name" binding:"required" example:"deploy script"`
+	Scopes    []moira.APITokenScope `json:"scopes" binding:"required" swaggertype:"array,string" example:"read,write"`
+	ExpiresAt *int64                `json:"expires_at,omitempty" example:"1735689600" format:"int64"`
+}
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (tokenRequest *APITokenRequest) Bind(request *http.Request) error {
+	if tokenRequest.Name == "" {
+		return errEmptyAPITokenName
+	}
+
+	if utf8.RuneCountInString(tokenRequest.Name) > apiTokenNameMaxSize {
+		return fmt.Errorf("api token name cannot be longer than %d characters", apiTokenNameMaxSize)
+	}
+
+	if len(tokenRequest.Scopes) == 0 {
+		return errEmptyAPITokenScopes
+	}
+
+	for _, scope := range tokenRequest.Scopes {
+		if _, ok := availableAPITokenScopes[scope]; !ok {
+			return fmt.Errorf("unknown api token scope '%s'", scope)
+		}
+	}
+
+	if tokenRequest.ExpiresAt != nil && *tokenRequest.ExpiresAt <= time.Now().Unix() {
+		return errors.New("api token expiration time must be in the future")
+	}
+
+	return nil
+}
+
+// APIToken represents API token in HTTP transfer, the secret of the token is never returned except on creation.
+type APIToken struct {
+	ID        string                `json:"id" binding:"required" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
+	Name      string                `json:"name" binding:"required" example:"deploy script"`
+	User      string                `json:"user" binding:"required" example:"john"`
+	TeamID    string                `json:"team_id,omitempty" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
+	Scopes    []moira.APITokenScope `json:"scopes" binding:"required" swaggertype:"array,string" example:"read,write"`
+	CreatedAt int64                 `json:"created_at" binding:"required" example:"1704067200" format:"int64"`
+	ExpiresAt *int64                `json:"expires_at,omitempty" example:"1735689600" format:"int64"`
+}
+
+// NewAPIToken creates APIToken from moira.APIToken hiding the hash of token secret.
+func NewAPIToken(token moira.APIToken) APIToken {
+	return APIToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		User:      token.User,
+		TeamID:    token.TeamID,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+// Render is a function that implements chi Renderer interface for APIToken.
+func (*APIToken) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// IssuedAPIToken is a response to API token creation, it is the only time when the secret of token is shown.
+type IssuedAPIToken struct {
+	APIToken
+	Token string `json:"token" binding:"required" example:"moira_0Jc8bB7dRm4jYdWcQ8p1ZVgqJX4p2oE0qfG2QbY5c3w"`
+}
+
+// Render is a function that implements chi Renderer interface for IssuedAPIToken.
+func (*IssuedAPIToken) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// APITokenList is a list of API tokens of a user or a team.
+type APITokenList struct {
+	List []APIToken `json:"list" binding:"required"`
+}
+
+// Render is a function that implements chi Renderer interface for APITokenList.
+func (*APITokenList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 )
 
 type ContactList struct {
@@ -24,6 +25,7 @@ type Contact struct {
 	User         string `json:"user,omitempty" example:""`
 	TeamID       string `json:"team_id,omitempty"`
 	ExtraMessage string `json:"extra_message,omitempty"`
+	BodyTemplate string `json:"body_template,omitempty"`
 }
 
 // NewContact init Contact with data from moira.ContactData.
@@ -36,6 +38,7 @@ func NewContact(data moira.ContactData) Contact {
 		User:         data.User,
 		TeamID:       data.Team,
 		ExtraMessage: data.ExtraMessage,
+		BodyTemplate: data.BodyTemplate,
 	}
 }
 
@@ -58,9 +61,30 @@ func (contact *Contact) Bind(r *http.Request) error {
 	if len(contact.ExtraMessage) > maxExtraMessageLen {
 		return fmt.Errorf("contact extra message must not be longer then %d characters long", maxExtraMessageLen)
 	}
+	if contact.BodyTemplate != "" {
+		if err := validateBodyTemplate(contact.BodyTemplate); err != nil {
+			return fmt.Errorf("contact body template is invalid: %w", err)
+		}
+	}
 	return nil
 }
 
+// validateBodyTemplate checks that the body template can be populated with webhook data of one sample event,
+// so templates using the first event or plot are accepted.
+func validateBodyTemplate(bodyTemplate string) error {
+	value := float64(0)
+	events := []templating.Event{{
+		Value:  &value,
+		Values: map[string]float64{"t1": value},
+	}}
+
+	populater := templating.NewWebhookBodyPopulater(&templating.Contact{}, &templating.Trigger{}, events, false, []string{""})
+
+	_, err := populater.Populate(bodyTemplate)
+
+	return err
+}
+
 // ContactNoisiness represents Contact with amount of events for this contact.
 type ContactNoisiness struct {
 	Contact
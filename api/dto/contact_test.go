@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateBodyTemplate(t *testing.T) {
+	Convey("Test validation of webhook body template", t, func() {
+		Convey("With template using the first event", func() {
+			So(validateBodyTemplate(`{"state": "{{ (index .Events 0).State }}", "metric": "{{ (index .Events 0).Metric }}"}`), ShouldBeNil)
+		})
+
+		Convey("With template iterating events and plots", func() {
+			So(validateBodyTemplate(`[{{ range $i, $e := .Events }}{{ if $i }},{{ end }}{{ $e.Value }}{{ end }}] {{ index .PlotURLs 0 }}`), ShouldBeNil)
+		})
+
+		Convey("With template of invalid syntax", func() {
+			So(validateBodyTemplate(`{{ .Events `), ShouldNotBeNil)
+		})
+
+		Convey("With template using unknown field", func() {
+			So(validateBodyTemplate(`{{ .Unknown }}`), ShouldNotBeNil)
+		})
+	})
+}
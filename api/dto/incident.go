@@ -0,0 +1,143 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// IncidentAction is the action made on the incident in the incident management tool.
+type IncidentAction string
+
+const (
+	// IncidentIgnored is any action which does not change the state of Moira.
+	IncidentIgnored IncidentAction = ""
+	// IncidentAcknowledged means that someone is working on the incident.
+	IncidentAcknowledged IncidentAction = "acknowledged"
+	// IncidentUnacknowledged means that the acknowledgement of the incident was cancelled.
+	IncidentUnacknowledged IncidentAction = "unacknowledged"
+	// IncidentResolved means that the incident was resolved or closed.
+	IncidentResolved IncidentAction = "resolved"
+)
+
+// IncidentWebhook is the incoming webhook of the incident management tool.
+type IncidentWebhook interface {
+	Bind(r *http.Request) error
+	// GetAction returns the action made on the incident.
+	GetAction() IncidentAction
+	// GetIncidentKey returns the key of the incident set by Moira sender.
+	GetIncidentKey() string
+	// GetUser returns the name of the user who made the action.
+	GetUser() string
+}
+
+// PagerDutyWebhook represents PagerDuty V3 webhook.
+type PagerDutyWebhook struct {
+	Event PagerDutyWebhookEvent `json:"event"`
+}
+
+// PagerDutyWebhookEvent represents the event of PagerDuty V3 webhook.
+type PagerDutyWebhookEvent struct {
+	EventType string              `json:"event_type" example:"incident.acknowledged"`
+	Agent     *PagerDutyReference `json:"agent,omitempty"`
+	Data      PagerDutyIncident   `json:"data"`
+}
+
+// PagerDutyReference represents the reference to PagerDuty object, e.g. the user.
+type PagerDutyReference struct {
+	Summary string `json:"summary" example:"John Doe"`
+}
+
+// PagerDutyIncident represents the incident of PagerDuty V3 webhook event.
+type PagerDutyIncident struct {
+	IncidentKey string `json:"incident_key" example:"moira/bcba82f5-48cf-44c0-b7d6-e1d32c64a88c/server.web1.cpu"`
+}
+
+const pagerDutySource = "pagerduty"
+
+// Bind checks PagerDutyWebhook.
+func (webhook *PagerDutyWebhook) Bind(*http.Request) error {
+	if webhook.Event.EventType == "" {
+		return fmt.Errorf("pagerduty webhook event type can not be empty")
+	}
+
+	return nil
+}
+
+// GetAction implements IncidentWebhook.
+func (webhook *PagerDutyWebhook) GetAction() IncidentAction {
+	switch webhook.Event.EventType {
+	case "incident.acknowledged":
+		return IncidentAcknowledged
+	case "incident.unacknowledged", "incident.reopened":
+		return IncidentUnacknowledged
+	case "incident.resolved":
+		return IncidentResolved
+	default:
+		return IncidentIgnored
+	}
+}
+
+// GetIncidentKey implements IncidentWebhook.
+func (webhook *PagerDutyWebhook) GetIncidentKey() string {
+	return webhook.Event.Data.IncidentKey
+}
+
+// GetUser implements IncidentWebhook.
+func (webhook *PagerDutyWebhook) GetUser() string {
+	if webhook.Event.Agent == nil || webhook.Event.Agent.Summary == "" {
+		return pagerDutySource
+	}
+
+	return pagerDutySource + ":" + webhook.Event.Agent.Summary
+}
+
+// OpsGenieWebhook represents OpsGenie outgoing webhook.
+type OpsGenieWebhook struct {
+	Action string        `json:"action" example:"Acknowledge"`
+	Alert  OpsGenieAlert `json:"alert"`
+}
+
+// OpsGenieAlert represents the alert of OpsGenie outgoing webhook.
+type OpsGenieAlert struct {
+	Alias    string `json:"alias" example:"moira/bcba82f5-48cf-44c0-b7d6-e1d32c64a88c/server.web1.cpu"`
+	Username string `json:"username" example:"john.doe@example.com"`
+}
+
+const opsGenieSource = "opsgenie"
+
+// Bind checks OpsGenieWebhook.
+func (webhook *OpsGenieWebhook) Bind(*http.Request) error {
+	if webhook.Action == "" {
+		return fmt.Errorf("opsgenie webhook action can not be empty")
+	}
+
+	return nil
+}
+
+// GetAction implements IncidentWebhook.
+func (webhook *OpsGenieWebhook) GetAction() IncidentAction {
+	switch webhook.Action {
+	case "Acknowledge":
+		return IncidentAcknowledged
+	case "UnAcknowledge":
+		return IncidentUnacknowledged
+	case "Close", "Delete":
+		return IncidentResolved
+	default:
+		return IncidentIgnored
+	}
+}
+
+// GetIncidentKey implements IncidentWebhook.
+func (webhook *OpsGenieWebhook) GetIncidentKey() string {
+	return webhook.Alert.Alias
+}
+
+// GetUser implements IncidentWebhook.
+func (webhook *OpsGenieWebhook) GetUser() string {
+	if webhook.Alert.Username == "" {
+		return opsGenieSource
+	}
+
+	return opsGenieSource + ":" + webhook.Alert.Username
+}
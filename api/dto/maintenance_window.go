@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// MaintenanceWindow is a structure that represents scheduled maintenance window in HTTP transfer.
+// Id, creator and creation time are set by server.
+type MaintenanceWindow moira.MaintenanceWindow
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (window *MaintenanceWindow) Bind(request *http.Request) error {
+	if window.Name == "" {
+		return errors.New("maintenance window name cannot be empty")
+	}
+
+	if window.Start >= window.End {
+		return fmt.Errorf("maintenance window must end after it starts: %d - %d", window.Start, window.End)
+	}
+
+	if len(window.TriggerIDs) == 0 && len(window.Tags) == 0 && len(window.Patterns) == 0 {
+		return errors.New("maintenance window must target trigger ids, tags or metric patterns")
+	}
+
+	for _, pattern := range window.Patterns {
+		if pattern == "" {
+			return errors.New("maintenance window metric pattern cannot be empty")
+		}
+	}
+
+	if window.Recurrence != nil {
+		if err := checkMaintenanceRecurrence(window.Start, window.End, window.Recurrence); err != nil {
+			return err
+		}
+	}
+
+	if (*moira.MaintenanceWindow)(window).IsFinished(time.Now().Unix()) {
+		return errors.New("maintenance window must end in the future")
+	}
+
+	return nil
+}
+
+func checkMaintenanceRecurrence(start, end int64, recurrence *moira.MaintenanceRecurrence) error {
+	days := recurrence.Frequency.GetDays()
+	if days == 0 {
+		return fmt.Errorf("unknown maintenance window frequency '%s'", recurrence.Frequency)
+	}
+
+	if end-start >= int64(days)*int64((24*time.Hour).Seconds()) {
+		return fmt.Errorf("%s maintenance window must be shorter than its period", recurrence.Frequency)
+	}
+
+	if _, err := recurrence.GetLocation(); err != nil {
+		return fmt.Errorf("unknown timezone %q: %w", recurrence.Timezone, err)
+	}
+
+	if recurrence.Until != 0 && recurrence.Until < start {
+		return fmt.Errorf("maintenance window must not stop recurring before it starts: %d - %d", start, recurrence.Until)
+	}
+
+	return nil
+}
+
+// Render is a function that implements chi Renderer interface for MaintenanceWindow.
+func (*MaintenanceWindow) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// MaintenanceWindowList is a list of scheduled maintenance windows.
+type MaintenanceWindowList struct {
+	List []MaintenanceWindow `json:"list" binding:"required"`
+}
+
+// Render is a function that implements chi Renderer interface for MaintenanceWindowList.
+func (*MaintenanceWindowList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
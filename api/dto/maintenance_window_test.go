@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMaintenanceWindowValidation(t *testing.T) {
+	Convey("Test maintenance window validation", t, func() {
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/maintenance-window", nil)
+
+		now := time.Now().Unix()
+		window := MaintenanceWindow{
+			Name:  "backups",
+			Start: now + 3600,
+			End:   now + 7200,
+			Tags:  []string{"postgres"},
+		}
+
+		Convey("with valid window", func() {
+			So(window.Bind(request), ShouldBeNil)
+		})
+
+		Convey("without targets", func() {
+			window.Tags = nil
+			So(window.Bind(request), ShouldResemble, errors.New("maintenance window must target trigger ids, tags or metric patterns"))
+		})
+
+		Convey("with end before start", func() {
+			window.End = window.Start
+			So(window.Bind(request), ShouldNotBeNil)
+		})
+
+		Convey("with end in the past", func() {
+			window.Start, window.End = now-7200, now-3600
+			So(window.Bind(request), ShouldResemble, errors.New("maintenance window must end in the future"))
+		})
+
+		Convey("with recurrence", func() {
+			window.Start, window.End = now-7200, now-3600
+			window.Recurrence = &moira.MaintenanceRecurrence{
+				Frequency: moira.MaintenanceFrequencyWeekly,
+				Timezone:  "Europe/Berlin",
+			}
+
+			Convey("past start is allowed", func() {
+				So(window.Bind(request), ShouldBeNil)
+			})
+
+			Convey("unknown frequency", func() {
+				window.Recurrence.Frequency = "monthly"
+				So(window.Bind(request), ShouldResemble, errors.New("unknown maintenance window frequency 'monthly'"))
+			})
+
+			Convey("window longer than period", func() {
+				window.Recurrence.Frequency = moira.MaintenanceFrequencyDaily
+				window.End = window.Start + 86400
+				So(window.Bind(request), ShouldResemble, errors.New("daily maintenance window must be shorter than its period"))
+			})
+
+			Convey("unknown timezone", func() {
+				window.Recurrence.Timezone = "Mars/Olympus"
+				So(window.Bind(request), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -23,3 +23,37 @@ type NotificationDeleteResponse struct {
 func (*NotificationDeleteResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
+
+type DeadLetterNotificationsList struct {
+	Total int64                           `json:"total" example:"0" format:"int64" binding:"required"`
+	List  []*moira.DeadLetterNotification `json:"list" binding:"required"`
+}
+
+func (*DeadLetterNotificationsList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type DeadLetterNotification struct {
+	moira.DeadLetterNotification
+}
+
+func (*DeadLetterNotification) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// DeadLetterReplayRequest is a request to schedule dead letter notification again, optionally to another contact.
+type DeadLetterReplayRequest struct {
+	ContactID string `json:"contact_id,omitempty" example:"1dd38765-c5be-418d-81fa-7a5f879c2315"`
+}
+
+func (*DeadLetterReplayRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+type DeadLetterReplayResponse struct {
+	Notification *moira.ScheduledNotification `json:"notification" binding:"required"`
+}
+
+func (*DeadLetterReplayResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
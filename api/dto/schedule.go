@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// maxCalendarSize is the maximum size of imported iCalendar data in bytes.
+const maxCalendarSize = 1 << 20
+
+// ScheduleExceptionsImport is a request to convert events of iCalendar into schedule exceptions.
+type ScheduleExceptionsImport struct {
+	// Calendar is iCalendar data.
+	Calendar string `json:"calendar" binding:"required" example:"BEGIN:VCALENDAR..."`
+	// Timezone is an IANA timezone name in which dates of all-day events are taken, UTC if it is empty.
+	Timezone string `json:"timezone,omitempty" example:"Europe/Berlin"`
+}
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (importRequest *ScheduleExceptionsImport) Bind(*http.Request) error {
+	if importRequest.Calendar == "" {
+		return errors.New("calendar must be set")
+	}
+
+	if len(importRequest.Calendar) > maxCalendarSize {
+		return fmt.Errorf("calendar cannot be larger than %d bytes", maxCalendarSize)
+	}
+
+	if _, err := time.LoadLocation(importRequest.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q: %w", importRequest.Timezone, err)
+	}
+
+	return nil
+}
+
+// ScheduleExceptionList is a list of schedule exceptions.
+type ScheduleExceptionList struct {
+	List []moira.ScheduleException `json:"list"`
+	// Skipped are names of recurring events which are not imported, as their recurrence rules are not supported.
+	Skipped []string `json:"skipped" example:"Sprint planning"`
+}
+
+// Render is a function that implements chi Renderer interface for ScheduleExceptionList.
+func (*ScheduleExceptionList) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
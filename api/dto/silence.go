@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// Silence is a structure that represents silence of notifications in HTTP transfer.
+// Id, creator and creation time are set by server, silence starts immediately if start time is not set.
+type Silence moira.Silence
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (silence *Silence) Bind(request *http.Request) error {
+	if len(silence.Matchers) == 0 {
+		return errors.New("silence must have at least one matcher")
+	}
+
+	for _, matcher := range silence.Matchers {
+		if err := matcher.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if silence.Comment == "" {
+		return errors.New("silence comment cannot be empty")
+	}
+
+	now := time.Now().Unix()
+	if silence.StartsAt == 0 {
+		silence.StartsAt = now
+	}
+
+	if silence.ExpiresAt <= silence.StartsAt {
+		return fmt.Errorf("silence must expire after it starts: %d - %d", silence.StartsAt, silence.ExpiresAt)
+	}
+
+	if silence.ExpiresAt <= now {
+		return errors.New("silence must expire in the future")
+	}
+
+	return nil
+}
+
+// Render is a function that implements chi Renderer interface for Silence.
+func (*Silence) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// SilenceList is a list of silences.
+type SilenceList struct {
+	List []Silence `json:"list" binding:"required"`
+}
+
+// Render is a function that implements chi Renderer interface for SilenceList.
+func (*SilenceList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// TriggerSilence is a silence which matches the trigger.
+type TriggerSilence struct {
+	Silence
+	// Metrics are metrics of the trigger matched by the silence, empty if the silence matches events of the whole trigger.
+	Metrics []string `json:"metrics" binding:"required"`
+}
+
+// TriggerSilenceList is a list of silences which match the trigger.
+type TriggerSilenceList struct {
+	List []TriggerSilence `json:"list" binding:"required"`
+}
+
+// Render is a function that implements chi Renderer interface for TriggerSilenceList.
+func (*TriggerSilenceList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
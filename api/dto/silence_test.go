@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSilenceValidation(t *testing.T) {
+	Convey("Test silence validation", t, func() {
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/silence", nil)
+
+		now := time.Now().Unix()
+		silence := Silence{
+			Matchers:  []moira.SilenceMatcher{{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "web1"}},
+			Comment:   "hardware swap",
+			ExpiresAt: now + 3600,
+		}
+
+		Convey("with valid silence it starts now", func() {
+			So(silence.Bind(request), ShouldBeNil)
+			So(silence.StartsAt, ShouldBeGreaterThanOrEqualTo, now)
+		})
+
+		Convey("without matchers", func() {
+			silence.Matchers = nil
+			So(silence.Bind(request), ShouldResemble, errors.New("silence must have at least one matcher"))
+		})
+
+		Convey("with invalid matcher", func() {
+			silence.Matchers[0].Label = ""
+			So(silence.Bind(request), ShouldNotBeNil)
+		})
+
+		Convey("without comment", func() {
+			silence.Comment = ""
+			So(silence.Bind(request), ShouldResemble, errors.New("silence comment cannot be empty"))
+		})
+
+		Convey("with expiry in the past", func() {
+			silence.StartsAt, silence.ExpiresAt = now-7200, now-3600
+			So(silence.Bind(request), ShouldResemble, errors.New("silence must expire in the future"))
+		})
+	})
+}
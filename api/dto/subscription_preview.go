@@ -0,0 +1,53 @@
+package dto
+
+import "net/http"
+
+// SubscriptionPreview is a result of dry-run of subscription, nothing is sent or saved during it.
+type SubscriptionPreview struct {
+	// Days is the number of last days of events history which the volume is estimated by.
+	Days int64 `json:"days" example:"7" format:"int64"`
+	// Triggers are the triggers which events the subscription gets.
+	Triggers []SubscriptionPreviewTrigger `json:"triggers"`
+	// Events is the number of events of the triggers which the subscription would not ignore.
+	Events int64 `json:"events" example:"42" format:"int64"`
+	// DelayedEvents is the number of the events which happened outside of the schedule, they are sent when the schedule allows.
+	DelayedEvents int64 `json:"delayed_events" example:"10" format:"int64"`
+	// NotificationsUpperBound is the number of the events multiplied by the number of contacts of the subscription.
+	// It is the upper bound of notifications: notifier sends events of a trigger which happen together in one message
+	// and throttles noisy triggers, so contacts usually get fewer messages.
+	NotificationsUpperBound int64 `json:"notifications_upper_bound" example:"84" format:"int64"`
+	// NotificationsPerDayUpperBound is the average upper bound of notifications per day.
+	NotificationsPerDayUpperBound float64 `json:"notifications_per_day_upper_bound" example:"12"`
+	// Truncated is true if the limits of dry-run are reached: not all matched triggers or not all events of a trigger
+	// are scanned, then the subscription may get more events than estimated.
+	Truncated bool `json:"truncated" example:"false"`
+	// Messages are the messages about the latest event which the contacts of the subscription would get.
+	Messages []SubscriptionPreviewMessage `json:"messages"`
+}
+
+// Render is a function that implements chi Renderer interface for SubscriptionPreview.
+func (*SubscriptionPreview) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+// SubscriptionPreviewTrigger is a trigger matching the tags of subscription.
+type SubscriptionPreviewTrigger struct {
+	ID   string   `json:"id" example:"292516ed-4924-4154-a62c-ebe312431fce"`
+	Name string   `json:"name" example:"Disk usage"`
+	Tags []string `json:"tags" example:"server,disk"`
+	// Events is the number of events of the trigger which the subscription would not ignore.
+	Events int64 `json:"events" example:"5" format:"int64"`
+}
+
+// SubscriptionPreviewMessage is a message which the contact would get.
+type SubscriptionPreviewMessage struct {
+	ContactID    string `json:"contact_id" example:"1dd38765-c5be-418d-81fa-7a5f879c2315"`
+	ContactType  string `json:"contact_type" example:"slack"`
+	ContactValue string `json:"contact_value" example:"#alerts"`
+	// Supported is false if messages of the contact type can not be previewed: no sender of the contact type is configured
+	// for previews or the sender does not send text messages, e.g. script or twilio voice.
+	Supported bool   `json:"supported" example:"true"`
+	Message   string `json:"message,omitempty" example:"*ERROR* Disk usage [server][disk]"`
+	// Error is the reason why the message of supported contact type is not rendered, e.g. invalid body template of the contact.
+	Error string `json:"error,omitempty" example:"template: body:1: unexpected \"}\" in operand"`
+}
@@ -136,7 +136,7 @@ type TreeOfProblems struct {
 // TargetVerification validates trigger targets.
 func TargetVerification(targets []string, ttl time.Duration, triggerSource moira.TriggerSource) ([]TreeOfProblems, error) {
 	switch triggerSource {
-	case moira.PrometheusRemote:
+	case moira.PrometheusRemote, moira.LokiRemote, moira.SQLRemote:
 		return []TreeOfProblems{{SyntaxOk: true}}, nil
 
 	case moira.GraphiteLocal, moira.GraphiteRemote:
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"unicode/utf8"
 
 	"github.com/moira-alert/moira/api/middleware"
@@ -18,14 +19,18 @@ type TeamModel struct {
 	ID          string `json:"id" binding:"required" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
 	Name        string `json:"name" binding:"required" example:"Infrastructure Team"`
 	Description string `json:"description" example:"Team that holds all members of infrastructure division"`
+	// RestrictTriggerChanges allows only members of the team and administrators to modify triggers of the team.
+	// It is left unchanged on update if not specified.
+	RestrictTriggerChanges *bool `json:"restrict_trigger_changes,omitempty" example:"false"`
 }
 
 // NewTeamModel is a constructor function that creates a new TeamModel using moira.Team.
 func NewTeamModel(team moira.Team) TeamModel {
 	return TeamModel{
-		ID:          team.ID,
-		Name:        team.Name,
-		Description: team.Description,
+		ID:                     team.ID,
+		Name:                   team.Name,
+		Description:            team.Description,
+		RestrictTriggerChanges: &team.RestrictTriggerChanges,
 	}
 }
 
@@ -56,9 +61,10 @@ func (TeamModel) Render(w http.ResponseWriter, r *http.Request) error {
 // ToMoiraTeam is a method that converts dto.Team to general moira.Team datatype.
 func (t TeamModel) ToMoiraTeam() moira.Team {
 	return moira.Team{
-		ID:          t.ID,
-		Name:        t.Name,
-		Description: t.Description,
+		ID:                     t.ID,
+		Name:                   t.Name,
+		Description:            t.Description,
+		RestrictTriggerChanges: t.RestrictTriggerChanges != nil && *t.RestrictTriggerChanges,
 	}
 }
 
@@ -101,11 +107,12 @@ func (TeamMembers) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// TeamSettings is a structure that contains info about team: contacts and subscriptions.
+// TeamSettings is a structure that contains info about team: contacts, subscriptions and team-wide preferences.
 type TeamSettings struct {
 	TeamID        string                   `json:"team_id" binding:"required" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
 	Contacts      []TeamContactWithScore   `json:"contacts" binding:"required"`
 	Subscriptions []moira.SubscriptionData `json:"subscriptions" binding:"required"`
+	Preferences   TeamPreferences          `json:"preferences" binding:"required"`
 }
 
 // Render is a function that implements chi Renderer interface for TeamSettings.
@@ -113,6 +120,93 @@ func (TeamSettings) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// TeamPreferences is a structure that represents team-wide settings in HTTP transfer.
+// Maintenance of the team is changed with separate request, so its fields are ignored on update.
+type TeamPreferences moira.TeamPreferences
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (preferences *TeamPreferences) Bind(request *http.Request) error {
+	if preferences.SubscriptionDefaults.Schedule != nil {
+		schedule, err := checkScheduleFilling(preferences.SubscriptionDefaults.Schedule)
+		if err != nil {
+			return err
+		}
+
+		preferences.SubscriptionDefaults.Schedule = schedule
+	}
+
+	for _, period := range preferences.QuietHours {
+		if period.From >= period.To {
+			return fmt.Errorf("quiet hours period must end after it starts: %d - %d", period.From, period.To)
+		}
+	}
+
+	preferences.Tags = normalizeTags(preferences.Tags)
+
+	return nil
+}
+
+// Render is a function that implements chi Renderer interface for TeamPreferences.
+func (*TeamPreferences) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// TeamMaintenance is a structure to set maintenance of triggers of the team tagged with team tags.
+type TeamMaintenance struct {
+	// Maintenance is a timestamp till which triggers are in maintenance, past timestamp ends maintenance.
+	Maintenance int64 `json:"maintenance" binding:"required" example:"1594225165" format:"int64"`
+}
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (*TeamMaintenance) Bind(*http.Request) error {
+	return nil
+}
+
+// TeamOnCallSchedule is a structure that represents on-call schedule of the team in HTTP transfer.
+type TeamOnCallSchedule moira.OnCallSchedule
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (schedule *TeamOnCallSchedule) Bind(request *http.Request) error {
+	for _, rotation := range schedule.Rotations {
+		if len(rotation.Participants) == 0 {
+			return fmt.Errorf("on-call rotation %q must have participants", rotation.Name)
+		}
+
+		if rotation.ShiftLength <= 0 {
+			return fmt.Errorf("on-call rotation %q must have positive shift length", rotation.Name)
+		}
+	}
+
+	for _, override := range schedule.Overrides {
+		if override.User == "" {
+			return errors.New("on-call override must have user")
+		}
+
+		if override.From >= override.To {
+			return fmt.Errorf("on-call override must end after it starts: %d - %d", override.From, override.To)
+		}
+	}
+
+	sort.SliceStable(schedule.Rotations, func(i, j int) bool {
+		return schedule.Rotations[i].Start < schedule.Rotations[j].Start
+	})
+
+	return nil
+}
+
+// Render is a function that implements chi Renderer interface for TeamOnCallSchedule.
+func (*TeamOnCallSchedule) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// TeamOnCallShift is a structure that represents the user who is on-call in the team now.
+type TeamOnCallShift moira.OnCallShift
+
+// Render is a function that implements chi Renderer interface for TeamOnCallShift.
+func (*TeamOnCallShift) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 // nolint TODO: Replace with dto.Contact after the next release.
 type TeamContact struct {
 	Type   string `json:"type" binding:"required" example:"mail"`
@@ -124,6 +218,7 @@ type TeamContact struct {
 	// This field is deprecated
 	Team         string `json:"team,omitempty"`
 	ExtraMessage string `json:"extra_message,omitempty"`
+	BodyTemplate string `json:"body_template,omitempty"`
 }
 
 // MakeTeamContact converts moira.ContactData to a TeamContact.
@@ -137,6 +232,7 @@ func MakeTeamContact(contact *moira.ContactData) TeamContact {
 		Type:         contact.Type,
 		Value:        contact.Value,
 		ExtraMessage: contact.ExtraMessage,
+		BodyTemplate: contact.BodyTemplate,
 	}
 }
 
@@ -177,3 +273,38 @@ func NewTeamsList(teams []moira.Team) TeamsList {
 		List: models,
 	}
 }
+
+// TeamMemberRole is a structure that represents role of the team member in HTTP transfer.
+type TeamMemberRole struct {
+	Login string         `json:"login" binding:"required" example:"anonymous"`
+	Role  moira.TeamRole `json:"role" binding:"required" example:"editor" swaggertype:"string"`
+}
+
+// Render is a function that implements chi Renderer interface for TeamMemberRole.
+func (TeamMemberRole) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// TeamRoles is a structure that represents roles of all team members in HTTP transfer.
+type TeamRoles struct {
+	Roles []TeamMemberRole `json:"roles" binding:"required"`
+}
+
+// Render is a function that implements chi Renderer interface for TeamRoles.
+func (TeamRoles) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// TeamRoleRequest is a request to change role of the team member.
+type TeamRoleRequest struct {
+	Role moira.TeamRole `json:"role" binding:"required" example:"viewer" swaggertype:"string" enums:"owner,editor,viewer"`
+}
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (request *TeamRoleRequest) Bind(r *http.Request) error {
+	if !request.Role.IsMemberRole() {
+		return fmt.Errorf("role must be one of %s, %s, %s", moira.TeamRoleOwner, moira.TeamRoleEditor, moira.TeamRoleViewer)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"net/http"
+
+	"github.com/moira-alert/moira/checker"
+)
+
+// TriggerBacktest is a result of run of trigger against historical data, nothing is saved or sent during it.
+type TriggerBacktest struct {
+	From int64 `json:"from" example:"1704067200" format:"int64"`
+	To   int64 `json:"to" example:"1704153600" format:"int64"`
+	Step int64 `json:"step" example:"60" format:"int64"`
+	checker.BacktestResult
+}
+
+// Render is a function that implements chi Renderer interface for TriggerBacktest.
+func (*TriggerBacktest) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
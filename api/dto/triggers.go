@@ -45,6 +45,9 @@ var (
 
 	// errNoAllowedDays is returned then all days disabled in moira.ScheduleData.
 	errNoAllowedDays = errors.New("no allowed days in trigger schedule")
+
+	// errMetricsTenantNotSupported is returned when metrics tenant is set for the trigger source without tenants.
+	errMetricsTenantNotSupported = errors.New("metrics tenant is supported only by prometheus_remote and loki_remote trigger sources")
 )
 
 // TODO(litleleprikon): Remove after https://github.com/moira-alert/moira/issues/550 will be resolved.
@@ -105,6 +108,8 @@ type TriggerModel struct {
 	TriggerSource moira.TriggerSource `json:"trigger_source" binding:"required" example:"graphite_local"`
 	// Shows the exact cluster from where the metrics are fetched
 	ClusterId moira.ClusterId `json:"cluster_id" binding:"required" example:"default"`
+	// Tenant of the multi-tenant metrics cluster, the default tenant of the cluster is used if empty
+	MetricsTenant string `json:"metrics_tenant,omitempty" example:"team-a"`
 	// If true, first event NODATA → OK will be omitted
 	MuteNewMetrics bool `json:"mute_new_metrics" binding:"required" example:"false"`
 	// A list of targets that have only alone metrics
@@ -143,6 +148,7 @@ func (model *TriggerModel) ToMoiraTrigger() *moira.Trigger {
 		Patterns:       model.Patterns,
 		TriggerSource:  model.TriggerSource,
 		ClusterId:      model.ClusterId,
+		MetricsTenant:  model.MetricsTenant,
 		MuteNewMetrics: model.MuteNewMetrics,
 		AloneMetrics:   model.AloneMetrics,
 		UpdatedBy:      model.UpdatedBy,
@@ -169,6 +175,7 @@ func CreateTriggerModel(trigger *moira.Trigger) TriggerModel {
 		IsRemote:       trigger.TriggerSource == moira.GraphiteRemote,
 		TriggerSource:  trigger.TriggerSource,
 		ClusterId:      trigger.ClusterId,
+		MetricsTenant:  trigger.MetricsTenant,
 		MuteNewMetrics: trigger.MuteNewMetrics,
 		AloneMetrics:   trigger.AloneMetrics,
 		CreatedAt:      getDateTime(trigger.CreatedAt),
@@ -239,9 +246,17 @@ func (trigger *Trigger) Bind(request *http.Request) error {
 	trigger.TriggerSource = trigger.TriggerSource.FillInIfNotSet(trigger.IsRemote)
 	trigger.ClusterId = trigger.ClusterId.FillInIfNotSet()
 
+	if trigger.MetricsTenant != "" && trigger.TriggerSource != moira.PrometheusRemote && trigger.TriggerSource != moira.LokiRemote {
+		return api.ErrInvalidRequestContent{ValidationError: errMetricsTenantNotSupported}
+	}
+
 	metricsSourceProvider := middleware.GetTriggerTargetsSourceProvider(request)
-	metricsSource, err := metricsSourceProvider.GetMetricSource(trigger.ClusterKey())
+	metricsSource, err := metricsSourceProvider.GetTenantMetricSource(trigger.ClusterKey(), trigger.MetricsTenant)
 	if err != nil {
+		if errors.Is(err, metricSource.ErrTenantNotAllowed) {
+			return api.ErrInvalidRequestContent{ValidationError: err}
+		}
+
 		return err
 	}
 
@@ -334,10 +349,45 @@ func checkScheduleFilling(gotSchedule *moira.ScheduleData) (*moira.ScheduleData,
 	newSchedule.TimezoneOffset = gotSchedule.TimezoneOffset
 	newSchedule.StartOffset = gotSchedule.StartOffset
 	newSchedule.EndOffset = gotSchedule.EndOffset
+	newSchedule.Timezone = gotSchedule.Timezone
+	newSchedule.Windows = gotSchedule.Windows
+	newSchedule.Exceptions = gotSchedule.Exceptions
+
+	if err := checkScheduleExtensions(newSchedule); err != nil {
+		return nil, err
+	}
 
 	return newSchedule, nil
 }
 
+// checkScheduleExtensions checks timezone name, time windows and exceptions of the schedule.
+func checkScheduleExtensions(schedule *moira.ScheduleData) error {
+	if schedule.Timezone != "" {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			return fmt.Errorf("unknown schedule timezone %q: %w", schedule.Timezone, err)
+		}
+	}
+
+	for _, window := range schedule.Windows {
+		if !isMinuteOfDay(window.StartOffset) || !isMinuteOfDay(window.EndOffset) {
+			return fmt.Errorf("schedule window offsets must be minutes of the day from 0 to %d: %d - %d",
+				moira.DefaultEndOffset, window.StartOffset, window.EndOffset)
+		}
+	}
+
+	for _, exception := range schedule.Exceptions {
+		if exception.From >= exception.To {
+			return fmt.Errorf("schedule exception must end after it starts: %d - %d", exception.From, exception.To)
+		}
+	}
+
+	return nil
+}
+
+func isMinuteOfDay(offset int64) bool {
+	return offset >= 0 && offset <= moira.DefaultEndOffset
+}
+
 func checkResolvedPatterns(trigger *Trigger) error {
 	for _, pattern := range trigger.Patterns {
 		// TODO(litleleprikon): Remove after https://github.com/moira-alert/moira/issues/550 will be resolved
@@ -391,6 +441,12 @@ func checkTTLSanity(trigger *Trigger, metricsSource metricSource.MetricSource) e
 
 		case moira.PrometheusRemote:
 			triggerType = "prometheus remote"
+
+		case moira.LokiRemote:
+			triggerType = "loki remote"
+
+		case moira.SQLRemote:
+			triggerType = "sql remote"
 		}
 
 		return fmt.Errorf("TTL for %s trigger can't be more than %d seconds", triggerType, maximumAllowedTTL)
@@ -624,3 +680,17 @@ type TriggerNoisinessList ListDTO[*TriggerNoisiness]
 func (*TriggerNoisinessList) Render(http.ResponseWriter, *http.Request) error {
 	return nil
 }
+
+// TriggerTeam is a structure to transfer the trigger to the team.
+type TriggerTeam struct {
+	TeamID string `json:"team_id" binding:"required" example:"d844f26b-4646-4fca-b43c-a871cc21169a"`
+}
+
+// Bind is a method that implements Binder interface from chi and checks that validity of data in request.
+func (t *TriggerTeam) Bind(*http.Request) error {
+	if t.TeamID == "" {
+		return errors.New("team_id cannot be empty")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -15,6 +16,8 @@ import (
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/middleware"
 	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/loki"
+	"github.com/moira-alert/moira/metric_source/retries"
 	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -274,6 +277,46 @@ func TestTriggerValidation(t *testing.T) {
 			})
 		})
 
+		Convey("Test metrics tenant of graphite trigger", func() {
+			trigger.Targets = []string{"some.test.series"}
+			trigger.TriggerType = moira.ExpressionTrigger
+			trigger.Expression = "OK"
+			trigger.MetricsTenant = "team-a"
+			tr := Trigger{trigger, throttling}
+
+			err := tr.Bind(request)
+			So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: errMetricsTenantNotSupported})
+		})
+
+		Convey("Test metrics tenant not allowed by cluster", func() {
+			lokiSource, err := loki.Create(&loki.Config{
+				URL:            "http://loki",
+				RequestTimeout: time.Second,
+				Tenant:         "default",
+				AllowedTenants: []string{"team-a"},
+				Retries: retries.Config{
+					InitialInterval: time.Millisecond,
+					Multiplier:      1,
+					MaxInterval:     time.Millisecond,
+					MaxRetriesCount: 1,
+				},
+			}, nil)
+			So(err, ShouldBeNil)
+
+			sourceProvider.RegisterSource(moira.MakeClusterKey(moira.LokiRemote, moira.DefaultCluster), lokiSource)
+
+			trigger.TriggerSource = moira.LokiRemote
+			trigger.Targets = []string{"sum(rate({app=\"web\"}[1m]))"}
+			trigger.TriggerType = moira.ExpressionTrigger
+			trigger.Expression = "OK"
+			trigger.MetricsTenant = "team-b"
+			tr := Trigger{trigger, throttling}
+
+			err = tr.Bind(request)
+			So(err, ShouldHaveSameTypeAs, api.ErrInvalidRequestContent{})
+			So(errors.Is(err.(api.ErrInvalidRequestContent).ValidationError, metricSource.ErrTenantNotAllowed), ShouldBeTrue)
+		})
+
 		Convey("Test patterns", func() {
 			localSource.EXPECT().GetMetricsTTLSeconds().Return(int64(3600)).AnyTimes()
 			localSource.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fetchResult, nil).AnyTimes()
@@ -739,6 +782,48 @@ func Test_checkScheduleFilling(t *testing.T) {
 			So(err, ShouldResemble, errNoAllowedDays)
 			So(gotSchedule, ShouldBeNil)
 		})
+
+		Convey("With timezone, windows and exceptions, they are kept", func() {
+			givenSchedule := moira.NewDefaultScheduleData()
+			givenSchedule.Timezone = "Europe/Berlin"
+			givenSchedule.Windows = []moira.ScheduleWindow{{StartOffset: 540, EndOffset: 720}, {StartOffset: 1320, EndOffset: 360}}
+			givenSchedule.Exceptions = []moira.ScheduleException{{Name: "holiday", From: 100, To: 200}}
+
+			gotSchedule, err := checkScheduleFilling(givenSchedule)
+
+			So(err, ShouldBeNil)
+			So(gotSchedule, ShouldResemble, givenSchedule)
+		})
+
+		Convey("With unknown timezone error returned", func() {
+			givenSchedule := moira.NewDefaultScheduleData()
+			givenSchedule.Timezone = "Mars/Olympus"
+
+			gotSchedule, err := checkScheduleFilling(givenSchedule)
+
+			So(err, ShouldNotBeNil)
+			So(gotSchedule, ShouldBeNil)
+		})
+
+		Convey("With window out of the day error returned", func() {
+			givenSchedule := moira.NewDefaultScheduleData()
+			givenSchedule.Windows = []moira.ScheduleWindow{{StartOffset: 540, EndOffset: 1440}}
+
+			gotSchedule, err := checkScheduleFilling(givenSchedule)
+
+			So(err, ShouldResemble, fmt.Errorf("schedule window offsets must be minutes of the day from 0 to 1439: 540 - 1440"))
+			So(gotSchedule, ShouldBeNil)
+		})
+
+		Convey("With exception ending before start error returned", func() {
+			givenSchedule := moira.NewDefaultScheduleData()
+			givenSchedule.Exceptions = []moira.ScheduleException{{From: 200, To: 100}}
+
+			gotSchedule, err := checkScheduleFilling(givenSchedule)
+
+			So(err, ShouldResemble, fmt.Errorf("schedule exception must end after it starts: 200 - 100"))
+			So(gotSchedule, ShouldBeNil)
+		})
 	})
 }
 
@@ -61,6 +61,15 @@ func ErrorNotFound(errorText string) *ErrorResponse {
 	}
 }
 
+// ErrorUnauthorized return 401 with given error text.
+func ErrorUnauthorized(errorText string) *ErrorResponse {
+	return &ErrorResponse{
+		HTTPStatusCode: http.StatusUnauthorized,
+		StatusText:     "Unauthorized",
+		ErrorText:      errorText,
+	}
+}
+
 // ErrorForbidden return 403 with given error text.
 func ErrorForbidden(errorText string) *ErrorResponse {
 	return &ErrorResponse{
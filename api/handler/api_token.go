@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func userAPITokens(config api.APITokensConfig) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/", getUserAPITokens)
+		router.Post("/", createUserAPIToken(config))
+		router.With(middleware.APITokenContext).Delete("/{tokenId}", deleteUserAPIToken)
+	}
+}
+
+func teamAPITokens(config api.APITokensConfig) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/", getTeamAPITokens)
+		router.Post("/", createTeamAPIToken(config))
+		router.With(middleware.APITokenContext).Delete("/{tokenId}", deleteTeamAPIToken)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get personal API tokens of the user
+//	@id			get-user-api-tokens
+//	@tags		user
+//	@produce	json
+//	@success	200	{object}	dto.APITokenList	"API tokens fetched successfully"
+//	@failure	422	{object}	api.ErrorResponse	"Render error"
+//	@failure	500	{object}	api.ErrorResponse	"Internal server error"
+//	@router		/user/tokens [get]
+func getUserAPITokens(writer http.ResponseWriter, request *http.Request) {
+	tokens, errorResponse := controller.GetUserAPITokens(database, middleware.GetLogin(request))
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, tokens); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Issue personal API token of the user, the secret of the token is returned only once
+//	@id			create-user-api-token
+//	@tags		user
+//	@accept		json
+//	@produce	json
+//	@param		token	body		dto.APITokenRequest	true	"API token to issue"
+//	@success	200		{object}	dto.IssuedAPIToken	"API token issued successfully"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/user/tokens [post]
+func createUserAPIToken(config api.APITokensConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		createAPIToken(writer, request, config, "")
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Revoke personal API token of the user
+//	@id			delete-user-api-token
+//	@tags		user
+//	@param		tokenId	path	string	true	"ID of the API token"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@success	200		"API token has been revoked"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/user/tokens/{tokenId} [delete]
+func deleteUserAPIToken(writer http.ResponseWriter, request *http.Request) {
+	errorResponse := controller.RemoveUserAPIToken(database, middleware.GetLogin(request), middleware.GetAPITokenID(request))
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get API tokens of the team
+//	@id			get-team-api-tokens
+//	@tags		team
+//	@produce	json
+//	@param		teamID	path		string				true	"ID of the team"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@success	200		{object}	dto.APITokenList	"API tokens fetched successfully"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/tokens [get]
+func getTeamAPITokens(writer http.ResponseWriter, request *http.Request) {
+	tokens, errorResponse := controller.GetTeamAPITokens(database, middleware.GetTeamID(request))
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, tokens); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Issue API token of the team, the secret of the token is returned only once
+//	@id			create-team-api-token
+//	@tags		team
+//	@accept		json
+//	@produce	json
+//	@param		teamID	path		string				true	"ID of the team"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@param		token	body		dto.APITokenRequest	true	"API token to issue"
+//	@success	200		{object}	dto.IssuedAPIToken	"API token issued successfully"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/tokens [post]
+func createTeamAPIToken(config api.APITokensConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		createAPIToken(writer, request, config, middleware.GetTeamID(request))
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Revoke API token of the team
+//	@id			delete-team-api-token
+//	@tags		team
+//	@param		teamID	path	string	true	"ID of the team"		default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@param		tokenId	path	string	true	"ID of the API token"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@success	200		"API token has been revoked"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/tokens/{tokenId} [delete]
+func deleteTeamAPIToken(writer http.ResponseWriter, request *http.Request) {
+	errorResponse := controller.RemoveTeamAPIToken(database, middleware.GetTeamID(request), middleware.GetAPITokenID(request))
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+	}
+}
+
+func createAPIToken(writer http.ResponseWriter, request *http.Request, config api.APITokensConfig, teamID string) {
+	tokenRequest := &dto.APITokenRequest{}
+	if err := render.Bind(request, tokenRequest); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	// Tokens can not be used to issue new tokens, otherwise leaked token could be prolonged forever.
+	if middleware.GetAPIToken(request) != nil {
+		render.Render(writer, request, api.ErrorForbidden("api tokens can not be issued with api token")) //nolint
+		return
+	}
+
+	token, errorResponse := controller.CreateAPIToken(
+		database,
+		config,
+		middleware.GetAuth(request),
+		middleware.GetLogin(request),
+		teamID,
+		tokenRequest,
+	)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, token); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/oidc"
+)
+
+const (
+	oidcStateCookieName = "moira_oidc_state"
+	oidcStateCookiePath = "/api/auth"
+	oidcStateTTL        = 10 * time.Minute
+	loginRedirectPath   = "/"
+)
+
+func authentication(provider *oidc.Provider, config api.SessionConfig) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/login", login(provider, config))
+		router.Get("/callback", loginCallback(provider, config))
+		router.Post("/logout", logout(config))
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Redirect to the login page of OpenID Connect provider
+//	@id			login
+//	@tags		user
+//	@success	302	"Redirect to the login page of OpenID Connect provider"
+//	@failure	500	{object}	api.ErrorResponse	"Internal server error"
+//	@router		/auth/login [get]
+func login(provider *oidc.Provider, config api.SessionConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		state, err := api.NewSecret("")
+		if err != nil {
+			render.Render(writer, request, api.ErrorInternalServer(err)) //nolint
+			return
+		}
+
+		verifier := provider.GenerateVerifier()
+
+		authCodeURL, err := provider.AuthCodeURL(request.Context(), state, verifier)
+		if err != nil {
+			render.Render(writer, request, api.ErrorInternalServer(err)) //nolint
+			return
+		}
+
+		// State and PKCE verifier are kept by browser until it returns from the provider.
+		http.SetCookie(writer, &http.Cookie{
+			Name:     oidcStateCookieName,
+			Value:    state + "." + verifier,
+			Path:     oidcStateCookiePath,
+			MaxAge:   int(oidcStateTTL.Seconds()),
+			HttpOnly: true,
+			Secure:   config.SecureCookie,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		http.Redirect(writer, request, authCodeURL, http.StatusFound)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Complete login with OpenID Connect provider and start session of the user
+//	@id			login-callback
+//	@tags		user
+//	@param		code	query	string	true	"Authorization code"
+//	@param		state	query	string	true	"State passed to the provider on login"
+//	@success	302		"Redirect to the web ui with session cookie"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	401		{object}	api.ErrorResponse	"Unauthorized"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/auth/callback [get]
+func loginCallback(provider *oidc.Provider, config api.SessionConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+
+		if providerError := query.Get("error"); providerError != "" {
+			render.Render(writer, request, api.ErrorUnauthorized(providerError+": "+query.Get("error_description"))) //nolint
+			return
+		}
+
+		stateCookie, err := request.Cookie(oidcStateCookieName)
+		if err != nil {
+			render.Render(writer, request, api.ErrorInvalidRequest(errors.New("login was not started"))) //nolint
+			return
+		}
+
+		http.SetCookie(writer, &http.Cookie{
+			Name:     oidcStateCookieName,
+			Path:     oidcStateCookiePath,
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+
+		state, verifier, _ := strings.Cut(stateCookie.Value, ".")
+		if subtle.ConstantTimeCompare([]byte(state), []byte(query.Get("state"))) != 1 {
+			render.Render(writer, request, api.ErrorInvalidRequest(errors.New("invalid login state"))) //nolint
+			return
+		}
+
+		userInfo, err := provider.Exchange(request.Context(), query.Get("code"), verifier)
+		if err != nil {
+			render.Render(writer, request, api.ErrorUnauthorized(err.Error())) //nolint
+			return
+		}
+
+		secret, expiresAt, errorResponse := controller.CreateAuthSession(database, userInfo.Login, userInfo.Groups, config.TTL)
+		if errorResponse != nil {
+			render.Render(writer, request, errorResponse) //nolint
+			return
+		}
+
+		http.SetCookie(writer, &http.Cookie{
+			Name:     config.CookieName,
+			Value:    secret,
+			Path:     "/",
+			Expires:  expiresAt,
+			HttpOnly: true,
+			Secure:   config.SecureCookie,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		http.Redirect(writer, request, loginRedirectPath, http.StatusFound)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Finish session of the user logged in with OpenID Connect provider
+//	@id			logout
+//	@tags		user
+//	@success	200	"User has logged out"
+//	@failure	500	{object}	api.ErrorResponse	"Internal server error"
+//	@router		/auth/logout [post]
+func logout(config api.SessionConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		cookie, err := request.Cookie(config.CookieName)
+		if err != nil {
+			return
+		}
+
+		if errorResponse := controller.RemoveAuthSession(database, cookie.Value); errorResponse != nil {
+			render.Render(writer, request, errorResponse) //nolint
+			return
+		}
+
+		http.SetCookie(writer, &http.Cookie{
+			Name:     config.CookieName,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   config.SecureCookie,
+		})
+	}
+}
@@ -116,6 +116,7 @@ func createNewContact(writer http.ResponseWriter, request *http.Request) {
 		contact,
 		userLogin,
 		contact.TeamID,
+		userLogin,
 	); err != nil {
 		render.Render(writer, request, err) //nolint
 		return
@@ -134,7 +135,7 @@ func contactFilter(next http.Handler) http.Handler {
 		userLogin := middleware.GetLogin(request)
 		auth := middleware.GetAuth(request)
 
-		contactData, err := controller.CheckUserPermissionsForContact(database, contactID, userLogin, auth)
+		contactData, err := controller.CheckUserPermissionsForContact(database, contactID, userLogin, auth, requestTeamAccess(request))
 		if err != nil {
 			render.Render(writer, request, err) //nolint
 			return
@@ -179,6 +180,7 @@ func updateContact(writer http.ResponseWriter, request *http.Request) {
 		contactsTemplate,
 		contactDTO,
 		contactData,
+		middleware.GetLogin(request),
 	)
 	if err != nil {
 		render.Render(writer, request, err) //nolint
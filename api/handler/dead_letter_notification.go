@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func deadLetterNotification(router chi.Router) {
+	router.Get("/", getDeadLetterNotifications)
+	router.Delete("/", deleteAllDeadLetterNotifications)
+	router.Route("/{deadLetterId}", func(router chi.Router) {
+		router.Use(middleware.DeadLetterContext)
+		router.Get("/", getDeadLetterNotification)
+		router.Delete("/", deleteDeadLetterNotification)
+		router.Post("/replay", replayDeadLetterNotification)
+	})
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Gets a paginated list of notifications which notifier failed to deliver, the newest first. All notifications are fetched if end = -1 and start = 0
+//	@id			get-dead-letter-notifications
+//	@tags		notification
+//	@produce	json
+//	@param		start	query		int								false	"Default Value: 0"	default(0)
+//	@param		end		query		int								false	"Default Value: -1"	default(-1)
+//	@success	200		{object}	dto.DeadLetterNotificationsList	"Dead letter notifications fetched successfully"
+//	@failure	400		{object}	api.ErrorResponse				"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse				"Forbidden"
+//	@failure	422		{object}	api.ErrorResponse				"Render error"
+//	@failure	500		{object}	api.ErrorResponse				"Internal server error"
+//	@router		/notification/dead-letter [get]
+func getDeadLetterNotifications(writer http.ResponseWriter, request *http.Request) {
+	urlValues, err := url.ParseQuery(request.URL.RawQuery)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	start, err := strconv.ParseInt(urlValues.Get("start"), 10, 64)
+	if err != nil {
+		start = 0
+	}
+
+	end, err := strconv.ParseInt(urlValues.Get("end"), 10, 64)
+	if err != nil {
+		end = -1
+	}
+
+	notifications, errorResponse := controller.GetDeadLetterNotifications(database, start, end)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, notifications); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get a notification which notifier failed to deliver by id
+//	@id			get-dead-letter-notification
+//	@tags		notification
+//	@produce	json
+//	@param		deadLetterId	path		string						true	"ID of the dead letter notification"	default(1dd38765-c5be-418d-81fa-7a5f879c2315)
+//	@success	200				{object}	dto.DeadLetterNotification	"Dead letter notification fetched successfully"
+//	@failure	400				{object}	api.ErrorResponse			"Bad request from client"
+//	@failure	403				{object}	api.ErrorResponse			"Forbidden"
+//	@failure	404				{object}	api.ErrorResponse			"Resource not found"
+//	@failure	422				{object}	api.ErrorResponse			"Render error"
+//	@failure	500				{object}	api.ErrorResponse			"Internal server error"
+//	@router		/notification/dead-letter/{deadLetterId} [get]
+func getDeadLetterNotification(writer http.ResponseWriter, request *http.Request) {
+	deadLetterID := middleware.GetDeadLetterID(request)
+
+	notification, errorResponse := controller.GetDeadLetterNotification(database, deadLetterID)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, notification); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Schedule a notification which notifier failed to deliver to be sent again, optionally to another contact
+//	@id			replay-dead-letter-notification
+//	@tags		notification
+//	@accept		json
+//	@produce	json
+//	@param		deadLetterId	path		string							true	"ID of the dead letter notification"	default(1dd38765-c5be-418d-81fa-7a5f879c2315)
+//	@param		replay			body		dto.DeadLetterReplayRequest		false	"Contact to replay notification to, the original contact is used if empty"
+//	@success	200				{object}	dto.DeadLetterReplayResponse	"Notification scheduled successfully"
+//	@failure	400				{object}	api.ErrorResponse				"Bad request from client"
+//	@failure	403				{object}	api.ErrorResponse				"Forbidden"
+//	@failure	404				{object}	api.ErrorResponse				"Resource not found"
+//	@failure	422				{object}	api.ErrorResponse				"Render error"
+//	@failure	500				{object}	api.ErrorResponse				"Internal server error"
+//	@router		/notification/dead-letter/{deadLetterId}/replay [post]
+func replayDeadLetterNotification(writer http.ResponseWriter, request *http.Request) {
+	deadLetterID := middleware.GetDeadLetterID(request)
+
+	replay := &dto.DeadLetterReplayRequest{}
+	if request.ContentLength != 0 {
+		if err := render.Bind(request, replay); err != nil {
+			render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+			return
+		}
+	}
+
+	response, errorResponse := controller.ReplayDeadLetterNotification(database, deadLetterID, replay.ContactID)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Delete a notification which notifier failed to deliver by id
+//	@id			delete-dead-letter-notification
+//	@tags		notification
+//	@produce	json
+//	@param		deadLetterId	path		string							true	"ID of the dead letter notification"	default(1dd38765-c5be-418d-81fa-7a5f879c2315)
+//	@success	200				{object}	dto.NotificationDeleteResponse	"Dead letter notification have been deleted"
+//	@failure	400				{object}	api.ErrorResponse				"Bad request from client"
+//	@failure	403				{object}	api.ErrorResponse				"Forbidden"
+//	@failure	422				{object}	api.ErrorResponse				"Render error"
+//	@failure	500				{object}	api.ErrorResponse				"Internal server error"
+//	@router		/notification/dead-letter/{deadLetterId} [delete]
+func deleteDeadLetterNotification(writer http.ResponseWriter, request *http.Request) {
+	deadLetterID := middleware.GetDeadLetterID(request)
+
+	response, errorResponse := controller.DeleteDeadLetterNotification(database, deadLetterID)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Purge all notifications which notifier failed to deliver
+//	@id			delete-all-dead-letter-notifications
+//	@tags		notification
+//	@produce	json
+//	@success	200	"Dead letter notifications have been deleted"
+//	@failure	403	{object}	api.ErrorResponse	"Forbidden"
+//	@failure	500	{object}	api.ErrorResponse	"Internal server error"
+//	@router		/notification/dead-letter [delete]
+func deleteAllDeadLetterNotifications(writer http.ResponseWriter, request *http.Request) {
+	if errorResponse := controller.DeleteAllDeadLetterNotifications(database); errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api"
 	moiramiddle "github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/api/oidc"
 	"github.com/moira-alert/moira/docs"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/notifier/selfstate"
+	"github.com/moira-alert/moira/senders/preview"
 	"github.com/rs/cors"
 )
 
@@ -50,11 +52,21 @@ func NewHandler(
 		checksConfig = *selfstateConfig
 	}
 
+	previewRenderer, err := preview.NewRenderer(apiConfig.Preview.Senders, apiConfig.Preview.FrontURI, apiConfig.Preview.Location, apiConfig.Preview.DateTimeFormat)
+	if err != nil {
+		log.Error().
+			Error(err).
+			Msg("Failed to configure notification previews, messages are not previewed")
+
+		previewRenderer = &preview.Renderer{}
+	}
+
 	contactsTemplateMiddleware := moiramiddle.ContactsTemplateContext(contactsTemplate)
 
 	router := chi.NewRouter()
 	router.Use(render.SetContentType(render.ContentTypeJSON))
-	router.Use(moiramiddle.UserContext)
+	router.Use(moiramiddle.AuthorizationContext(&apiConfig.Authorization))
+	router.Use(moiramiddle.Authentication(db, apiConfig.Authentication))
 	router.Use(moiramiddle.RequestLogger(log))
 	router.Use(middleware.NoCache)
 	router.Use(moiramiddle.LimitsContext(apiConfig.Limits))
@@ -112,32 +124,54 @@ func NewHandler(
 	//	@tag.name					teamContact
 	//	@tag.description			APIs for interacting with Moira contacts owned by certain team
 	//
+	//	@tag.name					maintenanceWindow
+	//	@tag.description			APIs for scheduling maintenance windows of triggers and metrics which checker applies automatically
+	//
+	//	@tag.name					silence
+	//	@tag.description			APIs for silencing notifications about events of triggers and metrics matching tags, names, metric patterns and labels
+	//
 	//	@tag.name					user
 	//	@tag.description			APIs for interacting with Moira users
 	router.Route("/api", func(router chi.Router) {
 		router.Use(moiramiddle.DatabaseContext(database))
-		router.Use(moiramiddle.AuthorizationContext(&apiConfig.Authorization))
 		router.Route("/health", health)
+		if apiConfig.Authentication.OIDC.Enabled {
+			provider := oidc.NewProvider(apiConfig.Authentication.OIDC)
+			router.Route("/auth", authentication(provider, apiConfig.Authentication.Session))
+		}
 		router.Route("/", func(router chi.Router) {
 			router.Use(moiramiddle.ReadOnlyMiddleware(apiConfig))
-			router.Get("/config", getWebConfig(webConfig))
-			router.Route("/user", user)
-			router.With(moiramiddle.Triggers(
-				apiConfig.MetricsTTL,
-			)).Route("/trigger", triggers(searchIndex))
-			router.Route("/tag", tag)
-			router.Route("/system-tag", systemTag)
-			router.Route("/pattern", pattern)
-			router.Route("/event", event)
-			router.Route("/subscription", subscription)
-			router.Route("/notification", notification)
-			router.With(contactsTemplateMiddleware).
-				Route("/teams", teams)
-			router.With(contactsTemplateMiddleware).
-				Route("/contact", func(router chi.Router) {
-					contact(router)
-					contactEvents(router)
+			if apiConfig.IncidentSync.Enabled {
+				router.Route("/incident", incident(apiConfig.IncidentSync))
+			}
+			router.Group(func(router chi.Router) {
+				router.Use(moiramiddle.RequireAuthentication)
+				router.Get("/config", getWebConfig(webConfig))
+				router.Route("/user", func(router chi.Router) {
+					user(router)
+					if apiConfig.Authentication.APITokens.Enabled {
+						router.Route("/tokens", userAPITokens(apiConfig.Authentication.APITokens))
+					}
 				})
+				router.With(moiramiddle.Triggers(
+					apiConfig.MetricsTTL,
+				)).Route("/trigger", triggers(searchIndex))
+				router.Route("/tag", tag)
+				router.Route("/system-tag", systemTag)
+				router.Route("/pattern", pattern)
+				router.Route("/event", event)
+				router.Route("/subscription", subscription(previewRenderer))
+				router.Route("/notification", notification)
+				router.Route("/maintenance-window", maintenanceWindow)
+				router.Route("/silence", silence)
+				router.With(contactsTemplateMiddleware).
+					Route("/teams", teams(apiConfig.Authentication.APITokens))
+				router.With(contactsTemplateMiddleware).
+					Route("/contact", func(router chi.Router) {
+						contact(router)
+						contactEvents(router)
+					})
+			})
 
 			router.Get("/swagger/*", httpSwagger.WrapHandler)
 			router.Get("/swagger/doc.json", func(w http.ResponseWriter, _ *http.Request) {
@@ -128,7 +128,10 @@ func TestAdminOnly(t *testing.T) {
 
 	adminLogin := "admin_login"
 	userLogin := "user_login"
-	config := &api.Config{Authorization: api.Authorization{Enabled: true, AdminList: map[string]struct{}{adminLogin: {}}}}
+	config := &api.Config{
+		Authorization:  api.Authorization{Enabled: true, AdminList: map[string]struct{}{adminLogin: {}}},
+		Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+	}
 	webConfig := &api.WebConfig{
 		SupportEmail: "test",
 		Contacts:     []api.WebContact{},
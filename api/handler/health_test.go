@@ -30,10 +30,13 @@ func TestSetHealthWithAuth(t *testing.T) {
 	logger, _ := zerolog_adapter.GetLogger("Test")
 
 	adminLogin := "admin_login"
-	config := &api.Config{Authorization: api.Authorization{
-		Enabled:   true,
-		AdminList: map[string]struct{}{adminLogin: {}},
-	}}
+	config := &api.Config{
+		Authorization: api.Authorization{
+			Enabled:   true,
+			AdminList: map[string]struct{}{adminLogin: {}},
+		},
+		Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+	}
 	webConfig := &api.WebConfig{
 		SupportEmail: "test",
 		Contacts:     []api.WebContact{},
@@ -94,10 +97,13 @@ func TestSetHealthForSourceWithAuth(t *testing.T) {
 	logger, _ := zerolog_adapter.GetLogger("Test")
 
 	adminLogin := "admin_login"
-	config := &api.Config{Authorization: api.Authorization{
-		Enabled:   true,
-		AdminList: map[string]struct{}{adminLogin: {}},
-	}}
+	config := &api.Config{
+		Authorization: api.Authorization{
+			Enabled:   true,
+			AdminList: map[string]struct{}{adminLogin: {}},
+		},
+		Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+	}
 	webConfig := &api.WebConfig{
 		SupportEmail: "test",
 		Contacts:     []api.WebContact{},
@@ -169,6 +175,7 @@ func TestGetSysSubscriptionsWithAuth(t *testing.T) {
 				Enabled:   true,
 				AdminList: map[string]struct{}{adminLogin: {}},
 			},
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
 		}
 		webConfig := &api.WebConfig{
 			SupportEmail: "test",
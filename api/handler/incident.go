@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func incident(config api.IncidentSyncConfig) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Use(middleware.IncidentWebhookTokenMiddleware(config.Token))
+		router.Post("/pagerduty", pagerDutyWebhook(config))
+		router.Post("/opsgenie", opsGenieWebhook(config))
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Apply acknowledgement or resolution of PagerDuty incident to the trigger metric maintenance
+//	@id			pagerduty-incident-webhook
+//	@tags		trigger
+//	@accept		json
+//	@param		token	query	string					true	"Incident webhook token"
+//	@param		webhook	body	dto.PagerDutyWebhook	true	"PagerDuty V3 webhook"
+//	@success	200		"Incident action has been applied"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/incident/pagerduty [post]
+func pagerDutyWebhook(config api.IncidentSyncConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		applyIncidentWebhook(writer, request, &dto.PagerDutyWebhook{}, config.AckMaintenance)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Apply acknowledgement or closing of OpsGenie alert to the trigger metric maintenance
+//	@id			opsgenie-incident-webhook
+//	@tags		trigger
+//	@accept		json
+//	@param		token	query	string				true	"Incident webhook token"
+//	@param		webhook	body	dto.OpsGenieWebhook	true	"OpsGenie webhook"
+//	@success	200		"Incident action has been applied"
+//	@failure	400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/incident/opsgenie [post]
+func opsGenieWebhook(config api.IncidentSyncConfig) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		applyIncidentWebhook(writer, request, &dto.OpsGenieWebhook{}, config.AckMaintenance)
+	}
+}
+
+func applyIncidentWebhook(writer http.ResponseWriter, request *http.Request, webhook dto.IncidentWebhook, ackMaintenance time.Duration) {
+	if err := render.Bind(request, webhook); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	err := controller.ApplyIncidentAction(database, webhook, ackMaintenance, time.Now().Unix())
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func maintenanceWindow(router chi.Router) {
+	router.Get("/", getMaintenanceWindows)
+	router.Post("/", createMaintenanceWindow)
+	router.With(middleware.MaintenanceWindowContext).Delete("/{maintenanceWindowId}", deleteMaintenanceWindow)
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get all scheduled maintenance windows
+//	@id			get-maintenance-windows
+//	@tags		maintenanceWindow
+//	@produce	json
+//	@success	200	{object}	dto.MaintenanceWindowList	"Maintenance windows fetched successfully"
+//	@failure	422	{object}	api.ErrorResponse			"Render error"
+//	@failure	500	{object}	api.ErrorResponse			"Internal server error"
+//	@router		/maintenance-window [get]
+func getMaintenanceWindows(writer http.ResponseWriter, request *http.Request) {
+	windows, errorResponse := controller.GetMaintenanceWindows(database)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, windows); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Schedule maintenance window of triggers and metrics, optionally recurring daily or weekly
+//	@id			create-maintenance-window
+//	@tags		maintenanceWindow
+//	@accept		json
+//	@produce	json
+//	@param		window	body		dto.MaintenanceWindow	true	"Maintenance window to schedule"
+//	@success	200		{object}	dto.MaintenanceWindow	"Maintenance window scheduled successfully"
+//	@failure	400		{object}	api.ErrorResponse		"Bad request from client"
+//	@failure	403		{object}	api.ErrorResponse		"Forbidden"
+//	@failure	422		{object}	api.ErrorResponse		"Render error"
+//	@failure	500		{object}	api.ErrorResponse		"Internal server error"
+//	@router		/maintenance-window [post]
+func createMaintenanceWindow(writer http.ResponseWriter, request *http.Request) {
+	window := &dto.MaintenanceWindow{}
+	if err := render.Bind(request, window); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	userLogin := middleware.GetLogin(request)
+	auth := middleware.GetAuth(request)
+
+	if errorResponse := controller.CreateMaintenanceWindow(database, window, userLogin, auth, time.Now().Unix()); errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, window); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Cancel maintenance window, maintenance set by its current occurrence is stopped
+//	@id			delete-maintenance-window
+//	@tags		maintenanceWindow
+//	@param		maintenanceWindowId	path	string	true	"ID of the maintenance window"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@success	200					"Maintenance window has been cancelled"
+//	@failure	400					{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403					{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404					{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500					{object}	api.ErrorResponse	"Internal server error"
+//	@router		/maintenance-window/{maintenanceWindowId} [delete]
+func deleteMaintenanceWindow(writer http.ResponseWriter, request *http.Request) {
+	errorResponse := controller.RemoveMaintenanceWindow(
+		database,
+		middleware.GetMaintenanceWindowID(request),
+		middleware.GetLogin(request),
+		middleware.GetAuth(request),
+		time.Now().Unix(),
+	)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+	}
+}
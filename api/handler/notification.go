@@ -22,6 +22,7 @@ func notification(router chi.Router) {
 		r.Delete("/", deleteNotification)
 		r.Delete("/all", deleteAllNotifications)
 		r.Delete("/filtered", deleteFilteredNotifications)
+		r.Route("/dead-letter", deadLetterNotification)
 	})
 }
 
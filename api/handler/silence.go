@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func silence(router chi.Router) {
+	router.Get("/", getSilences)
+	router.Post("/", createSilence)
+	router.With(middleware.SilenceContext).Delete("/{silenceId}", deleteSilence)
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get all silences including expired ones
+//	@id			get-silences
+//	@tags		silence
+//	@produce	json
+//	@success	200	{object}	dto.SilenceList		"Silences fetched successfully"
+//	@failure	422	{object}	api.ErrorResponse	"Render error"
+//	@failure	500	{object}	api.ErrorResponse	"Internal server error"
+//	@router		/silence [get]
+func getSilences(writer http.ResponseWriter, request *http.Request) {
+	silences, errorResponse := controller.GetSilences(database)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, silences); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary		Silence notifications about events of triggers and metrics matching all matchers till expiry
+//	@description	Several trigger_id matchers with operator "=" make a list of triggers, events of any of them match.
+//	@description	Users who are not admins must list triggers this way and have permissions for each of them.
+//	@id				create-silence
+//	@tags			silence
+//	@accept			json
+//	@produce		json
+//	@param			silence	body		dto.Silence			true	"Silence to create"
+//	@success		200		{object}	dto.Silence			"Silence created successfully"
+//	@failure		400		{object}	api.ErrorResponse	"Bad request from client"
+//	@failure		403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure		422		{object}	api.ErrorResponse	"Render error"
+//	@failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@router			/silence [post]
+func createSilence(writer http.ResponseWriter, request *http.Request) {
+	silence := &dto.Silence{}
+	if err := render.Bind(request, silence); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	userLogin := middleware.GetLogin(request)
+	auth := middleware.GetAuth(request)
+
+	if errorResponse := controller.CreateSilence(database, silence, userLogin, auth, time.Now().Unix()); errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, silence); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Remove silence, notifications about matching events are sent again
+//	@id			delete-silence
+//	@tags		silence
+//	@param		silenceId	path	string	true	"ID of the silence"	default(d5d98eb3-ee18-4f75-9364-244f67e23b54)
+//	@success	200			"Silence has been removed"
+//	@failure	400			{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse	"Resource not found"
+//	@failure	500			{object}	api.ErrorResponse	"Internal server error"
+//	@router		/silence/{silenceId} [delete]
+func deleteSilence(writer http.ResponseWriter, request *http.Request) {
+	errorResponse := controller.RemoveSilence(
+		database,
+		middleware.GetSilenceID(request),
+		middleware.GetLogin(request),
+		middleware.GetAuth(request),
+	)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get not expired silences which match the trigger or its metrics
+//	@id			get-trigger-silences
+//	@tags		trigger
+//	@produce	json
+//	@param		triggerID	path		string					true	"Trigger ID"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200			{object}	dto.TriggerSilenceList	"Silences of the trigger fetched successfully"
+//	@failure	404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse		"Render error"
+//	@failure	500			{object}	api.ErrorResponse		"Internal server error"
+//	@router		/trigger/{triggerID}/silences [get]
+func getTriggerSilences(writer http.ResponseWriter, request *http.Request) {
+	silences, errorResponse := controller.GetTriggerSilences(
+		database,
+		middleware.GetLoggerEntry(request),
+		middleware.GetTriggerID(request),
+		time.Now().Unix(),
+	)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, silences); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
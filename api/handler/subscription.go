@@ -3,7 +3,10 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
@@ -12,19 +15,25 @@ import (
 	"github.com/moira-alert/moira/api/controller"
 	"github.com/moira-alert/moira/api/dto"
 	"github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/senders/preview"
 )
 
-func subscription(router chi.Router) {
-	router.Get("/", getUserSubscriptions)
-	router.Put("/", createSubscription)
-	router.Route("/{subscriptionId}", func(router chi.Router) {
-		router.Use(middleware.SubscriptionContext)
-		router.Use(subscriptionFilter)
-		router.Get("/", getSubscription)
-		router.Put("/", updateSubscription)
-		router.Delete("/", removeSubscription)
-		router.Put("/test", sendTestNotification)
-	})
+func subscription(renderer *preview.Renderer) func(chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/", getUserSubscriptions)
+		router.Put("/", createSubscription)
+		router.Post("/preview", previewNewSubscription(renderer))
+		router.Post("/schedule/exceptions", importScheduleExceptions)
+		router.Route("/{subscriptionId}", func(router chi.Router) {
+			router.Use(middleware.SubscriptionContext)
+			router.Use(subscriptionFilter)
+			router.Get("/", getSubscription)
+			router.Put("/", updateSubscription)
+			router.Delete("/", removeSubscription)
+			router.Put("/test", sendTestNotification)
+			router.Get("/preview", previewSubscription(renderer))
+		})
+	}
 }
 
 // nolint: gofmt,goimports
@@ -104,7 +113,7 @@ func subscriptionFilter(next http.Handler) http.Handler {
 		userLogin := middleware.GetLogin(request)
 		auth := middleware.GetAuth(request)
 
-		subscriptionData, err := controller.CheckUserPermissionsForSubscription(database, subscriptionID, userLogin, auth)
+		subscriptionData, err := controller.CheckUserPermissionsForSubscription(database, subscriptionID, userLogin, auth, requestTeamAccess(request))
 		if err != nil {
 			render.Render(writer, request, err) //nolint
 			return
@@ -230,3 +239,117 @@ func sendTestNotification(writer http.ResponseWriter, request *http.Request) {
 		render.Render(writer, request, err) //nolint
 	}
 }
+
+// nolint: gofmt,goimports
+//
+//	@summary		Convert events of iCalendar into schedule exceptions, e.g. public holidays
+//	@description	Recurring events repeated daily, weekly, monthly or yearly are imported for the next year, other recurring events are skipped and listed in the response.
+//	@description	Calendar is accepted only as uploaded data and never downloaded by url, as requests to urls given by users could reach internal services (SSRF).
+//	@id				import-schedule-exceptions
+//	@tags			subscription
+//	@accept			json
+//	@produce		json
+//	@param			calendar	body		dto.ScheduleExceptionsImport	true	"iCalendar data"
+//	@success		200			{object}	dto.ScheduleExceptionList		"Schedule exceptions"
+//	@failure		400			{object}	api.ErrorResponse				"Bad request from client"
+//	@failure		422			{object}	api.ErrorResponse				"Render error"
+//	@router			/subscription/schedule/exceptions [post]
+func importScheduleExceptions(writer http.ResponseWriter, request *http.Request) {
+	importRequest := dto.ScheduleExceptionsImport{}
+	if err := render.Bind(request, &importRequest); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	exceptions, err := controller.ImportScheduleExceptions(importRequest)
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, exceptions); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Dry-run of a new subscription: matched triggers, estimated volume of notifications and messages to contacts, nothing is sent
+//	@id			preview-new-subscription
+//	@tags		subscription
+//	@accept		json
+//	@produce	json
+//	@param		subscription	body		dto.Subscription			true	"Subscription data"
+//	@param		days			query		int							false	"Number of last days of events history to estimate volume by"	default(7)
+//	@success	200				{object}	dto.SubscriptionPreview		"Subscription previewed successfully"
+//	@failure	400				{object}	api.ErrorResponse			"Bad request from client"
+//	@failure	403				{object}	api.ErrorResponse			"Forbidden"
+//	@failure	422				{object}	api.ErrorResponse			"Render error"
+//	@failure	500				{object}	api.ErrorResponse			"Internal server error"
+//	@router		/subscription/preview [post]
+func previewNewSubscription(renderer *preview.Renderer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		subscription := &dto.Subscription{}
+		if err := render.Bind(request, subscription); err != nil {
+			switch err.(type) { // nolint:errorlint
+			case dto.ErrProvidedContactsForbidden:
+				render.Render(writer, request, api.ErrorForbidden(err.Error())) //nolint
+			default:
+				render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+			}
+
+			return
+		}
+
+		subscriptionData := moira.SubscriptionData(*subscription)
+		renderSubscriptionPreview(writer, request, renderer, &subscriptionData)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Dry-run of a subscription: matched triggers, estimated volume of notifications and messages to contacts, nothing is sent
+//	@id			preview-subscription
+//	@tags		subscription
+//	@produce	json
+//	@param		subscriptionID	path		string						true	"ID of the subscription to preview"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		days			query		int							false	"Number of last days of events history to estimate volume by"	default(7)
+//	@success	200				{object}	dto.SubscriptionPreview		"Subscription previewed successfully"
+//	@failure	400				{object}	api.ErrorResponse			"Bad request from client"
+//	@failure	403				{object}	api.ErrorResponse			"Forbidden"
+//	@failure	404				{object}	api.ErrorResponse			"Resource not found"
+//	@failure	422				{object}	api.ErrorResponse			"Render error"
+//	@failure	500				{object}	api.ErrorResponse			"Internal server error"
+//	@router		/subscription/{subscriptionID}/preview [get]
+func previewSubscription(renderer *preview.Renderer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		subscriptionData := request.Context().Value(subscriptionKey).(moira.SubscriptionData)
+		renderSubscriptionPreview(writer, request, renderer, &subscriptionData)
+	}
+}
+
+func renderSubscriptionPreview(writer http.ResponseWriter, request *http.Request, renderer *preview.Renderer, subscription *moira.SubscriptionData) {
+	days := int64(controller.DefaultSubscriptionPreviewDays)
+
+	if rawDays := request.URL.Query().Get("days"); rawDays != "" {
+		var err error
+
+		days, err = strconv.ParseInt(rawDays, 10, 64)
+		if err != nil || days < 1 || days > controller.MaxSubscriptionPreviewDays {
+			render.Render(writer, request, api.ErrorInvalidRequest( //nolint
+				fmt.Errorf("days must be a number from 1 to %d", controller.MaxSubscriptionPreviewDays)))
+
+			return
+		}
+	}
+
+	subscriptionPreview, errorResponse := controller.PreviewSubscription(database, renderer, subscription, days, time.Now())
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, subscriptionPreview); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
@@ -6,48 +6,80 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/controller"
 	"github.com/moira-alert/moira/api/dto"
 	"github.com/moira-alert/moira/api/middleware"
 )
 
-func teams(router chi.Router) {
-	router.With(
-		middleware.AdminOnlyMiddleware(),
-		middleware.Paginate(getAllTeamsDefaultPage, getAllTeamsDefaultSize),
-		middleware.SearchTextContext(regexp.MustCompile(getAllTeamsDefaultRegexTemplate)),
-		middleware.SortOrderContext(api.AscSortOrder),
-	).Get("/all", searchTeams)
-	router.Get("/", getAllTeamsForUser)
-	router.Post("/", createTeam)
-	router.Route("/{teamId}", func(router chi.Router) {
-		router.Use(middleware.TeamContext)
-		router.Use(usersFilterForTeams)
-		router.Get("/", getTeam)
-		router.Patch("/", updateTeam)
-		router.Delete("/", deleteTeam)
-		router.Route("/users", func(router chi.Router) {
-			router.Get("/", getTeamUsers)
-			router.Put("/", setTeamUsers)
-			router.Post("/", addTeamUsers)
-			router.With(middleware.TeamUserIDContext).Delete("/{teamUserId}", deleteTeamUser)
+func teams(tokensConfig api.APITokensConfig) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.With(
+			middleware.AdminOnlyMiddleware(),
+			middleware.Paginate(getAllTeamsDefaultPage, getAllTeamsDefaultSize),
+			middleware.SearchTextContext(regexp.MustCompile(getAllTeamsDefaultRegexTemplate)),
+			middleware.SortOrderContext(api.AscSortOrder),
+		).Get("/all", searchTeams)
+		router.Get("/", getAllTeamsForUser)
+		router.Post("/", createTeam)
+		router.Route("/{teamId}", func(router chi.Router) {
+			router.Use(middleware.TeamContext)
+			router.Use(usersFilterForTeams)
+			router.Get("/", getTeam)
+			router.With(teamManagersFilter).Patch("/", updateTeam)
+			router.With(teamManagersFilter).Delete("/", deleteTeam)
+			router.Route("/users", func(router chi.Router) {
+				router.Get("/", getTeamUsers)
+				router.With(teamManagersFilter).Put("/", setTeamUsers)
+				router.With(teamManagersFilter).Post("/", addTeamUsers)
+				router.With(middleware.TeamUserIDContext, teamMemberRemovalFilter).Delete("/{teamUserId}", deleteTeamUser)
+			})
+			router.Route("/roles", func(router chi.Router) {
+				router.Get("/", getTeamRoles)
+				router.With(middleware.TeamUserIDContext, teamManagersFilter).Put("/{teamUserId}", setTeamUserRole)
+			})
+			router.Get("/settings", getTeamSettings)
+			router.Route("/preferences", teamPreferences)
+			router.Route("/oncall", teamOnCall)
+			router.With(teamEditorsFilter).Put("/maintenance", setTeamMaintenance)
+			router.With(teamEditorsFilter).Route("/subscriptions", teamSubscription)
+			router.With(teamEditorsFilter).Route("/contacts", teamContact)
+			if tokensConfig.Enabled {
+				router.With(teamManagersFilter).Route("/tokens", teamAPITokens(tokensConfig))
+			}
 		})
-		router.Get("/settings", getTeamSettings)
-		router.Route("/subscriptions", teamSubscription)
-		router.Route("/contacts", teamContact)
-	})
+	}
 }
 
-// usersFilterForTeams is middleware that checks that user exists in this.
+// usersFilterForTeams is middleware that checks that user is a member of the team.
 func usersFilterForTeams(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		userLogin := middleware.GetLogin(request)
-		teamID := middleware.GetTeamID(request)
-		auth := middleware.GetAuth(request)
+		if err := checkTeamAccess(request, moira.TeamAccessRead); err != nil {
+			render.Render(writer, request, err) //nolint
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
 
-		err := controller.CheckUserPermissionsForTeam(database, teamID, userLogin, auth)
-		if err != nil {
+// teamEditorsFilter is middleware that allows request only to members who can change resources of the team.
+func teamEditorsFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := checkTeamAccess(request, moira.TeamAccessEdit); err != nil {
+			render.Render(writer, request, err) //nolint
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// teamManagersFilter is middleware that allows request only to owners of the team.
+func teamManagersFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := checkTeamAccess(request, moira.TeamAccessManage); err != nil {
 			render.Render(writer, request, err) //nolint
 			return
 		}
@@ -56,6 +88,42 @@ func usersFilterForTeams(next http.Handler) http.Handler {
 	})
 }
 
+// teamMemberRemovalFilter is middleware that allows owners of the team to remove any member and other members to leave the team.
+func teamMemberRemovalFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		auth := middleware.GetAuth(request)
+		isLeaving := auth.ServiceAccountTeamID == "" && middleware.GetTeamUserID(request) == middleware.GetLogin(request)
+
+		if !isLeaving {
+			teamManagersFilter(next).ServeHTTP(writer, request)
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
+
+func checkTeamAccess(request *http.Request, access moira.TeamAccess) *api.ErrorResponse {
+	return controller.CheckUserPermissionsForTeam(
+		database,
+		middleware.GetTeamID(request),
+		middleware.GetLogin(request),
+		middleware.GetAuth(request),
+		access,
+	)
+}
+
+// requestTeamAccess returns access to resource of the team required by the request: reading requests require read access,
+// other requests require edit access.
+func requestTeamAccess(request *http.Request) moira.TeamAccess {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return moira.TeamAccessRead
+	default:
+		return moira.TeamAccessEdit
+	}
+}
+
 // nolint: gofmt,goimports
 //
 //	@summary	Create a new team
@@ -411,3 +479,70 @@ func getTeamSettings(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 }
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get roles of team members
+//	@id			get-team-roles
+//	@tags		team
+//	@produce	json
+//	@param		teamID	path		string				true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200		{object}	dto.TeamRoles		"Roles fetched successfully"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/roles [get]
+func getTeamRoles(writer http.ResponseWriter, request *http.Request) {
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.GetTeamRoles(database, teamID)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Change role of team member
+//	@id			set-team-user-role
+//	@tags		team
+//	@accept		json
+//	@produce	json
+//	@param		teamID		path		string					true	"ID of the team"										default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		teamUserID	path		string					true	"User login in methods related to teams manipulation"	default(anonymous)
+//	@param		role		body		dto.TeamRoleRequest		true	"New role of the user"
+//	@success	200			{object}	dto.TeamMemberRole		"Role changed successfully"
+//	@failure	400			{object}	api.ErrorResponse		"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse		"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse		"Render error"
+//	@failure	500			{object}	api.ErrorResponse		"Internal server error"
+//	@router		/teams/{teamID}/roles/{teamUserID} [put]
+func setTeamUserRole(writer http.ResponseWriter, request *http.Request) {
+	roleRequest := &dto.TeamRoleRequest{}
+	if err := render.Bind(request, roleRequest); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint:errcheck
+		return
+	}
+
+	teamID := middleware.GetTeamID(request)
+	userID := middleware.GetTeamUserID(request)
+
+	response, apiErr := controller.SetTeamUserRole(database, teamID, userID, roleRequest.Role)
+	if apiErr != nil {
+		render.Render(writer, request, apiErr) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
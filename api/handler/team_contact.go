@@ -49,6 +49,7 @@ func createNewTeamContact(writer http.ResponseWriter, request *http.Request) {
 		contact,
 		"",
 		teamID,
+		middleware.GetLogin(request),
 	); err != nil {
 		render.Render(writer, request, err) //nolint:errcheck
 		return
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func teamOnCall(router chi.Router) {
+	router.Get("/", getTeamOnCallSchedule)
+	router.With(teamEditorsFilter).Put("/", updateTeamOnCallSchedule)
+	router.Get("/current", getTeamOnCallShift)
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get on-call schedule of the team
+//	@id			get-team-oncall-schedule
+//	@tags		team
+//	@produce	json
+//	@param		teamID	path		string					true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200		{object}	dto.TeamOnCallSchedule	"On-call schedule of the team"
+//	@failure	403		{object}	api.ErrorResponse		"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse		"Render error"
+//	@failure	500		{object}	api.ErrorResponse		"Internal server error"
+//	@router		/teams/{teamID}/oncall [get]
+func getTeamOnCallSchedule(writer http.ResponseWriter, request *http.Request) {
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.GetTeamOnCallSchedule(database, teamID)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Replace on-call schedule of the team: rotations of team members and overrides
+//	@id			update-team-oncall-schedule
+//	@tags		team
+//	@accept		json
+//	@produce	json
+//	@param		teamID		path		string					true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		schedule	body		dto.TeamOnCallSchedule	true	"On-call schedule"
+//	@success	200			{object}	dto.TeamOnCallSchedule	"Updated on-call schedule"
+//	@failure	400			{object}	api.ErrorResponse		"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse		"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse		"Render error"
+//	@failure	500			{object}	api.ErrorResponse		"Internal server error"
+//	@router		/teams/{teamID}/oncall [put]
+func updateTeamOnCallSchedule(writer http.ResponseWriter, request *http.Request) {
+	schedule := dto.TeamOnCallSchedule{}
+	if err := render.Bind(request, &schedule); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint:errcheck
+		return
+	}
+
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.UpdateTeamOnCallSchedule(database, teamID, schedule)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get the user who is on-call in the team now and the time of the next handoff
+//	@id			get-team-oncall-current
+//	@tags		team
+//	@produce	json
+//	@param		teamID	path		string				true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200		{object}	dto.TeamOnCallShift	"Current on-call shift"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/oncall/current [get]
+func getTeamOnCallShift(writer http.ResponseWriter, request *http.Request) {
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.GetTeamOnCallShift(database, teamID, time.Now())
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func teamPreferences(router chi.Router) {
+	router.Get("/", getTeamPreferences)
+	router.With(teamEditorsFilter).Put("/", updateTeamPreferences)
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get team-wide settings: subscription defaults, quiet hours, tags and maintenance of the team
+//	@id			get-team-preferences
+//	@tags		team
+//	@produce	json
+//	@param		teamID	path		string				true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200		{object}	dto.TeamPreferences	"Team preferences"
+//	@failure	403		{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404		{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422		{object}	api.ErrorResponse	"Render error"
+//	@failure	500		{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/preferences [get]
+func getTeamPreferences(writer http.ResponseWriter, request *http.Request) {
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.GetTeamPreferences(database, teamID)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Update team-wide settings, maintenance of the team is not changed
+//	@id			update-team-preferences
+//	@tags		team
+//	@accept		json
+//	@produce	json
+//	@param		teamID		path		string				true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		preferences	body		dto.TeamPreferences	true	"Team preferences"
+//	@success	200			{object}	dto.TeamPreferences	"Updated team preferences"
+//	@failure	400			{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse	"Render error"
+//	@failure	500			{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/preferences [put]
+func updateTeamPreferences(writer http.ResponseWriter, request *http.Request) {
+	preferences := dto.TeamPreferences{}
+	if err := render.Bind(request, &preferences); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint:errcheck
+		return
+	}
+
+	teamID := middleware.GetTeamID(request)
+
+	response, err := controller.UpdateTeamPreferences(database, teamID, preferences)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Set maintenance of all triggers of the team tagged with team tags, past timestamp ends maintenance
+//	@id			set-team-maintenance
+//	@tags		team
+//	@accept		json
+//	@produce	json
+//	@param		teamID		path		string				true	"ID of the team"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		maintenance	body		dto.TeamMaintenance	true	"Maintenance of the team"
+//	@success	200			{object}	dto.TeamPreferences	"Team preferences with updated maintenance"
+//	@failure	400			{object}	api.ErrorResponse	"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse	"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse	"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse	"Render error"
+//	@failure	500			{object}	api.ErrorResponse	"Internal server error"
+//	@router		/teams/{teamID}/maintenance [put]
+func setTeamMaintenance(writer http.ResponseWriter, request *http.Request) {
+	maintenance := dto.TeamMaintenance{}
+	if err := render.Bind(request, &maintenance); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint:errcheck
+		return
+	}
+
+	teamID := middleware.GetTeamID(request)
+	userLogin := middleware.GetLogin(request)
+	timeCallMaintenance := time.Now().Unix()
+
+	response, err := controller.SetTeamMaintenance(database, teamID, maintenance.Maintenance, userLogin, timeCallMaintenance)
+	if err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
+	if err := render.Render(writer, request, &response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint:errcheck
+		return
+	}
+}
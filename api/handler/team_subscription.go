@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi"
@@ -33,6 +36,8 @@ func teamSubscription(router chi.Router) {
 //	@failure	500				{object}	api.ErrorResponse	"Internal server error"
 //	@router		/teams/{teamID}/subscriptions [post]
 func createTeamSubscription(writer http.ResponseWriter, request *http.Request) {
+	specifiedFields := getRequestBodyFields(request)
+
 	subscription := &dto.Subscription{}
 	if err := render.Bind(request, subscription); err != nil {
 		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint:errcheck
@@ -53,6 +58,11 @@ func createTeamSubscription(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	if err := controller.ApplyTeamSubscriptionDefaults(database, teamID, subscription, specifiedFields); err != nil {
+		render.Render(writer, request, err) //nolint:errcheck
+		return
+	}
+
 	if err := controller.CreateSubscription(database, auth, "", teamID, systemTags, subscription); err != nil {
 		render.Render(writer, request, err) //nolint:errcheck
 		return
@@ -63,3 +73,30 @@ func createTeamSubscription(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 }
+
+// getRequestBodyFields returns names of top-level fields of json request body and keeps body readable for binding.
+func getRequestBodyFields(request *http.Request) map[string]struct{} {
+	fields := make(map[string]struct{})
+	if request.Body == nil {
+		return fields
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return fields
+	}
+
+	// Malformed body is reported by binding.
+	rawFields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(body, &rawFields); err != nil {
+		return fields
+	}
+
+	for field := range rawFields {
+		fields[field] = struct{}{}
+	}
+
+	return fields
+}
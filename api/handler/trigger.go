@@ -20,26 +20,36 @@ import (
 func trigger(router chi.Router) {
 	router.Use(middleware.TriggerContext)
 	router.
-		With(limitedChangeTriggerOwnersMiddleware()).
+		With(limitedChangeTriggerOwnersMiddleware(), teamTriggerChangesMiddleware()).
 		Put("/", updateTrigger)
 	router.
 		With(middleware.TriggerContext, middleware.Populate(false)).
 		Get("/", getTrigger)
 	router.
-		With(limitedChangeTriggerOwnersMiddleware()).
+		With(limitedChangeTriggerOwnersMiddleware(), teamTriggerChangesMiddleware()).
 		Delete("/", removeTrigger)
 	router.Get("/state", getTriggerState)
 	router.Route("/throttling", func(router chi.Router) {
 		router.Get("/", getTriggerThrottling)
-		router.Delete("/", deleteThrottling)
+		router.With(teamTriggerChangesMiddleware()).Delete("/", deleteThrottling)
 	})
 	router.Route("/metrics", triggerMetrics)
-	router.Put("/setMaintenance", setTriggerMaintenance)
+	router.Get("/silences", getTriggerSilences)
+	router.With(teamTriggerChangesMiddleware()).Put("/setMaintenance", setTriggerMaintenance)
+	router.
+		With(limitedChangeTriggerOwnersMiddleware(), teamTriggerChangesMiddleware()).
+		Route("/team", func(router chi.Router) {
+			router.Put("/", setTriggerTeam)
+			router.Delete("/", removeTriggerTeam)
+		})
 	router.
 		With(middleware.DateRange("-1hour", "now")).
 		With(middleware.TargetName("t1")).
 		Get("/render", renderTrigger)
 	router.Get("/dump", triggerDump)
+	router.
+		With(middleware.DateRange(triggerBacktestDefaultFrom, triggerBacktestDefaultTo)).
+		Get("/backtest", backtestTrigger)
 }
 
 // nolint: gofmt,goimports
@@ -68,6 +78,14 @@ func updateTrigger(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	if trigger.TeamID != "" {
+		err = controller.CheckTriggerTeamAssignment(database, triggerID, trigger.TeamID, middleware.GetLogin(request), middleware.GetAuth(request))
+		if err != nil {
+			render.Render(writer, request, err) //nolint
+			return
+		}
+	}
+
 	var problems []dto.TreeOfProblems
 	if needValidate(request) {
 		problems, err = validateTargets(request, trigger)
@@ -194,6 +212,85 @@ func limitedChangeTriggerOwnersMiddleware() func(next http.Handler) http.Handler
 	}
 }
 
+// teamTriggerChangesMiddleware forbids team members without edit access to modify triggers of the team,
+// triggers of the team which restricts trigger changes can be modified only by its members and administrators.
+func teamTriggerChangesMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			db := middleware.GetDatabase(r)
+
+			err := controller.CheckUserPermissionsForTrigger(db, middleware.GetTriggerID(r), middleware.GetLogin(r), middleware.GetAuth(r))
+			if err != nil {
+				_ = render.Render(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Transfer trigger to the team
+//	@id			set-trigger-team
+//	@tags		trigger
+//	@accept		json
+//	@produce	json
+//	@param		triggerID	path		string					true	"Trigger ID"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param		team		body		dto.TriggerTeam			true	"Team which will own the trigger"
+//	@success	200			{object}	dto.SaveTriggerResponse	"Trigger transferred successfully"
+//	@failure	400			{object}	api.ErrorResponse		"Bad request from client"
+//	@failure	403			{object}	api.ErrorResponse		"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse		"Render error"
+//	@failure	500			{object}	api.ErrorResponse		"Internal server error"
+//	@router		/trigger/{triggerID}/team [put]
+func setTriggerTeam(writer http.ResponseWriter, request *http.Request) {
+	triggerTeam := &dto.TriggerTeam{}
+	if err := render.Bind(request, triggerTeam); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	response, err := controller.SetTriggerTeam(database, middleware.GetTriggerID(request), triggerTeam.TeamID, middleware.GetLogin(request), middleware.GetAuth(request))
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Remove trigger from its team, so it becomes personal
+//	@id			remove-trigger-team
+//	@tags		trigger
+//	@produce	json
+//	@param		triggerID	path		string					true	"Trigger ID"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200			{object}	dto.SaveTriggerResponse	"Trigger removed from the team successfully"
+//	@failure	403			{object}	api.ErrorResponse		"Forbidden"
+//	@failure	404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure	422			{object}	api.ErrorResponse		"Render error"
+//	@failure	500			{object}	api.ErrorResponse		"Internal server error"
+//	@router		/trigger/{triggerID}/team [delete]
+func removeTriggerTeam(writer http.ResponseWriter, request *http.Request) {
+	response, err := controller.SetTriggerTeam(database, middleware.GetTriggerID(request), "", middleware.GetLogin(request), middleware.GetAuth(request))
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+	}
+}
+
 // nolint: gofmt,goimports
 //
 //	@summary	Get an existing trigger
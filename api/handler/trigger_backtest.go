@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-graphite/carbonapi/date"
+
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+const (
+	triggerBacktestDefaultFrom = "-1day"
+	triggerBacktestDefaultTo   = "now"
+)
+
+// nolint: gofmt,goimports
+//
+//	@summary		Run new trigger against historical data
+//	@description	Trigger is checked every step since from till to like a new trigger, its state is not saved and no notifications are sent.
+//	@description	Backtest of trigger with remote source is limited to much fewer checks than with local source
+//	@id				backtest-new-trigger
+//	@tags			trigger
+//	@accept			json
+//	@produce		json
+//	@param			trigger	body		dto.Trigger				true	"Trigger data"
+//	@param			from	query		string					false	"Start time of backtest"				default(-1day)
+//	@param			to		query		string					false	"End time of backtest"					default(now)
+//	@param			step	query		int						false	"Interval between checks in seconds"	default(60)
+//	@success		200		{object}	dto.TriggerBacktest		"Trigger backtested successfully"
+//	@failure		400		{object}	api.ErrorResponse		"Bad request from client"
+//	@failure		422		{object}	api.ErrorResponse		"Render error"
+//	@failure		500		{object}	api.ErrorResponse		"Internal server error"
+//	@failure		503		{object}	api.ErrorResponse		"Remote server unavailable"
+//	@router			/trigger/backtest [post]
+func backtestNewTrigger(writer http.ResponseWriter, request *http.Request) {
+	from, to, step, err := getBacktestRange(request)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	trigger, errorResponse := getTriggerFromRequest(request)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	backtest, errorResponse := controller.BacktestTrigger(
+		request.Context(),
+		database,
+		middleware.GetLoggerEntry(request),
+		middleware.GetTriggerTargetsSourceProvider(request),
+		trigger.ToMoiraTrigger(),
+		from, to, step,
+	)
+	renderTriggerBacktest(writer, request, backtest, errorResponse)
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary		Run existing trigger against historical data
+//	@description	Trigger is checked every step since from till to like a new trigger, its saved state is not changed and no notifications are sent.
+//	@description	Backtest of trigger with remote source is limited to much fewer checks than with local source
+//	@id				backtest-trigger
+//	@tags			trigger
+//	@produce		json
+//	@param			triggerID	path		string					true	"Trigger ID"							default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param			from		query		string					false	"Start time of backtest"				default(-1day)
+//	@param			to			query		string					false	"End time of backtest"					default(now)
+//	@param			step		query		int						false	"Interval between checks in seconds"	default(60)
+//	@success		200			{object}	dto.TriggerBacktest		"Trigger backtested successfully"
+//	@failure		400			{object}	api.ErrorResponse		"Bad request from client"
+//	@failure		404			{object}	api.ErrorResponse		"Resource not found"
+//	@failure		422			{object}	api.ErrorResponse		"Render error"
+//	@failure		500			{object}	api.ErrorResponse		"Internal server error"
+//	@router			/trigger/{triggerID}/backtest [get]
+func backtestTrigger(writer http.ResponseWriter, request *http.Request) {
+	from, to, step, err := getBacktestRange(request)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	backtest, errorResponse := controller.BacktestExistingTrigger(
+		request.Context(),
+		database,
+		middleware.GetLoggerEntry(request),
+		middleware.GetTriggerTargetsSourceProvider(request),
+		middleware.GetTriggerID(request),
+		from, to, step,
+	)
+	renderTriggerBacktest(writer, request, backtest, errorResponse)
+}
+
+func renderTriggerBacktest(writer http.ResponseWriter, request *http.Request, backtest *dto.TriggerBacktest, errorResponse *api.ErrorResponse) {
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, backtest); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// getBacktestRange parses the time range of backtest aligned to minutes and the interval between checks.
+func getBacktestRange(request *http.Request) (from, to, step int64, err error) {
+	fromStr := middleware.GetFromStr(request)
+	toStr := middleware.GetToStr(request)
+
+	from = date.DateParamToEpoch(fromStr, "UTC", 0, time.UTC)
+	if from == 0 {
+		return 0, 0, 0, fmt.Errorf("can not parse from: %s", fromStr)
+	}
+
+	from -= from % 60 //nolint
+
+	to = date.DateParamToEpoch(toStr, "UTC", 0, time.UTC)
+	if to == 0 {
+		return 0, 0, 0, fmt.Errorf("can not parse to: %s", toStr)
+	}
+
+	step = controller.DefaultTriggerBacktestStep
+	if rawStep := request.URL.Query().Get("step"); rawStep != "" {
+		step, err = strconv.ParseInt(rawStep, 10, 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid step param: %w", err)
+		}
+	}
+
+	return from, to, step, nil
+}
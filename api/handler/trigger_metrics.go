@@ -17,8 +17,8 @@ import (
 
 func triggerMetrics(router chi.Router) {
 	router.With(middleware.DateRange("-10minutes", "now")).Get("/", getTriggerMetrics)
-	router.Delete("/", deleteTriggerMetric)
-	router.Delete("/nodata", deleteTriggerNodataMetrics)
+	router.With(teamTriggerChangesMiddleware()).Delete("/", deleteTriggerMetric)
+	router.With(teamTriggerChangesMiddleware()).Delete("/nodata", deleteTriggerNodataMetrics)
 }
 
 // nolint: gofmt,goimports
@@ -16,7 +16,9 @@ import (
 	"github.com/go-chi/render"
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/loki"
 	"github.com/moira-alert/moira/metric_source/remote"
+	"github.com/moira-alert/moira/metric_source/sqldb"
 
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/controller"
@@ -45,6 +47,9 @@ func triggers(searcher moira.Searcher) func(chi.Router) {
 
 		router.Put("/", createTrigger)
 		router.Put("/check", triggerCheck)
+		router.
+			With(middleware.DateRange(triggerBacktestDefaultFrom, triggerBacktestDefaultTo)).
+			Post("/backtest", backtestNewTrigger)
 		router.Route("/{triggerId}", trigger)
 		router.With(middleware.Paginate(0, 10)).With(middleware.Pager(false, "")).Get("/search", searchTriggers)
 		router.With(middleware.Pager(false, "")).Delete("/search/pager", deletePager)
@@ -156,6 +161,14 @@ func createTrigger(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	if trigger.TeamID != "" {
+		err = controller.CheckTriggerTeamAssignment(database, "", trigger.TeamID, middleware.GetLogin(request), middleware.GetAuth(request))
+		if err != nil {
+			render.Render(writer, request, err) //nolint
+			return
+		}
+	}
+
 	var problems []dto.TreeOfProblems
 	if needValidate(request) {
 		problems, err = validateTargets(request, trigger)
@@ -242,7 +255,7 @@ func getTriggerFromRequest(request *http.Request) (*dto.Trigger, *api.ErrorRespo
 			return nil, api.ErrorInvalidRequest(fmt.Errorf("invalid expression: %s", err.Error()))
 		case api.ErrInvalidRequestContent:
 			return nil, api.ErrorInvalidRequest(err)
-		case remote.ErrRemoteUnavailable:
+		case remote.ErrRemoteUnavailable, loki.ErrLokiUnavailable, sqldb.ErrDatabaseUnavailable:
 			response := api.ErrorRemoteServerUnavailable(err)
 			middleware.GetLoggerEntry(request).Error().
 				String("status", response.StatusText).
@@ -252,6 +265,10 @@ func getTriggerFromRequest(request *http.Request) (*dto.Trigger, *api.ErrorRespo
 			return nil, response
 		case remote.ErrRemoteTriggerResponse:
 			return nil, api.ErrorInvalidRequest(fmt.Errorf("error from graphite remote: %w", err))
+		case loki.ErrInvalidQuery:
+			return nil, api.ErrorInvalidRequest(fmt.Errorf("invalid loki targets: %w", err))
+		case sqldb.ErrInvalidQuery:
+			return nil, api.ErrorInvalidRequest(fmt.Errorf("invalid sql targets: %w", err))
 		case *json.UnmarshalTypeError:
 			return nil, api.ErrorInvalidRequest(fmt.Errorf("invalid payload: %s", err.Error()))
 		case *prometheus.Error:
@@ -301,7 +318,7 @@ func triggerCheck(writer http.ResponseWriter, request *http.Request) {
 
 			// Errors above are skipped because if there is an error from local source then it will be caught in
 			// dto.TargetVerification and will be explained in detail.
-		case remote.ErrRemoteUnavailable:
+		case remote.ErrRemoteUnavailable, loki.ErrLokiUnavailable, sqldb.ErrDatabaseUnavailable:
 			errRsp := api.ErrorRemoteServerUnavailable(err)
 			middleware.GetLoggerEntry(request).Error().
 				String("status", errRsp.StatusText).
@@ -313,6 +330,12 @@ func triggerCheck(writer http.ResponseWriter, request *http.Request) {
 		case remote.ErrRemoteTriggerResponse:
 			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("error from graphite remote: %w", err))) //nolint
 			return
+		case loki.ErrInvalidQuery:
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid loki targets: %w", err))) //nolint
+			return
+		case sqldb.ErrInvalidQuery:
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid sql targets: %w", err))) //nolint
+			return
 		case *prometheus.Error:
 			render.Render(writer, request, errorResponseOnPrometheusError(typedErr)) //nolint
 			return
@@ -358,6 +381,7 @@ func triggerCheck(writer http.ResponseWriter, request *http.Request) {
 //	@param			pagerID			query		string				false	"Pager ID"				default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
 //	@param			createdBy		query		string				false	"Created By"			default(moira.team)
 //	@param			teamID			query		string				false	"Search for triggers with this team ID"
+//	@param			ownership		query		string				false	"Search for triggers owned by a team or personal triggers"	Enums(team, personal)
 //	@success		200				{object}	dto.TriggersList	"Successfully fetched matching triggers"
 //	@failure		400				{object}	api.ErrorResponse	"Bad request from client"
 //	@failure		404				{object}	api.ErrorResponse	"Resource not found"
@@ -367,6 +391,12 @@ func triggerCheck(writer http.ResponseWriter, request *http.Request) {
 func searchTriggers(writer http.ResponseWriter, request *http.Request) {
 	request.ParseForm() //nolint
 
+	ownership := moira.TriggerOwnership(getStringParam(request, "ownership"))
+	if !ownership.IsValid() {
+		render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("unknown ownership filter: %s", ownership))) //nolint
+		return
+	}
+
 	searchOptions := moira.SearchOptions{
 		Page:         middleware.GetPage(request),
 		Size:         middleware.GetSize(request),
@@ -375,6 +405,7 @@ func searchTriggers(writer http.ResponseWriter, request *http.Request) {
 		SearchString: getStringParam(request, "text"),
 		CreatedBy:    getStringParam(request, "createdBy"),
 		TeamID:       getStringParam(request, "teamID"),
+		Ownership:    ownership,
 		CreatePager:  middleware.GetCreatePager(request),
 		PagerID:      middleware.GetPagerID(request),
 		PagerTTL:     middleware.GetLimits(request).Pager.TTL,
@@ -1046,7 +1046,10 @@ func TestRemoveTriggerHandler(t *testing.T) {
 			},
 		}
 		logger, _ := logging.GetLogger("Test")
-		config := &api.Config{Authorization: auth}
+		config := &api.Config{
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+		}
 		webConfig := &api.WebConfig{
 			SupportEmail: "test",
 			Contacts:     []api.WebContact{},
@@ -1057,7 +1060,7 @@ func TestRemoveTriggerHandler(t *testing.T) {
 
 		t.Run("And when success from DB, should return success", func(t *testing.T) {
 			mockDb.EXPECT().RemoveTrigger(triggerID).Return(nil)
-			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
 			mockDb.EXPECT().GetTriggerThrottling(triggerID)
 
 			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
@@ -1077,7 +1080,7 @@ func TestRemoveTriggerHandler(t *testing.T) {
 
 		t.Run("And when error while removing from DB, should return error", func(t *testing.T) {
 			mockDb.EXPECT().RemoveTrigger(triggerID).Return(errors.New("error"))
-			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
 			mockDb.EXPECT().GetTriggerThrottling(triggerID)
 
 			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
@@ -1107,7 +1110,10 @@ func TestRemoveTriggerHandler(t *testing.T) {
 			},
 		}
 		logger, _ := logging.GetLogger("Test")
-		config := &api.Config{Authorization: auth}
+		config := &api.Config{
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+		}
 		webConfig := &api.WebConfig{
 			SupportEmail: "test",
 			Contacts:     []api.WebContact{},
@@ -1118,7 +1124,7 @@ func TestRemoveTriggerHandler(t *testing.T) {
 
 		t.Run("When request from moira-admin, should be ok", func(t *testing.T) {
 			mockDb.EXPECT().RemoveTrigger(triggerID).Return(nil)
-			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
 			mockDb.EXPECT().GetTriggerThrottling(triggerID)
 
 			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
@@ -1138,7 +1144,7 @@ func TestRemoveTriggerHandler(t *testing.T) {
 
 		t.Run("When request from trigger-owner, should be ok", func(t *testing.T) {
 			mockDb.EXPECT().RemoveTrigger(triggerID).Return(nil)
-			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
 			mockDb.EXPECT().GetTriggerThrottling(triggerID)
 
 			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
@@ -1175,6 +1181,142 @@ func TestRemoveTriggerHandler(t *testing.T) {
 			require.Equal(t, http.StatusForbidden, response.StatusCode)
 		})
 	})
+
+	t.Run("When team of the trigger restricts changes", func(t *testing.T) {
+		const teamID = "team"
+
+		auth := api.Authorization{
+			Enabled: true,
+			AdminList: map[string]struct{}{
+				adminLogin: {},
+			},
+		}
+		logger, _ := logging.GetLogger("Test")
+		config := &api.Config{
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+		}
+		webConfig := &api.WebConfig{
+			SupportEmail: "test",
+			Contacts:     []api.WebContact{},
+		}
+		trigger := moira.Trigger{
+			TeamID:    teamID,
+			CreatedBy: ownerLogin,
+		}
+
+		t.Run("When request from member of the team, should be ok", func(t *testing.T) {
+			mockDb.EXPECT().RemoveTrigger(triggerID).Return(nil)
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
+			mockDb.EXPECT().GetTriggerThrottling(triggerID)
+			mockDb.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+			mockDb.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
+			mockDb.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleEditor}, nil)
+
+			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
+
+			responseWriter := httptest.NewRecorder()
+			testRequest := httptest.NewRequest(http.MethodDelete, "/api/trigger/"+triggerID, strings.NewReader(""))
+			testRequest.Header.Add("x-webauth-user", userLogin)
+			testRequest.Header.Add("content-type", "application/json")
+			testRequest = testRequest.WithContext(middleware.SetContextValueForTest(context.Background(), "auth", auth))
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+
+			require.Equal(t, http.StatusOK, response.StatusCode)
+		})
+
+		t.Run("When request from other-user, should be forbidden", func(t *testing.T) {
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
+			mockDb.EXPECT().GetTriggerThrottling(triggerID)
+			mockDb.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+			mockDb.EXPECT().IsTeamContainUser(teamID, userLogin).Return(false, nil)
+
+			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
+
+			responseWriter := httptest.NewRecorder()
+			testRequest := httptest.NewRequest(http.MethodDelete, "/api/trigger/"+triggerID, strings.NewReader(""))
+			testRequest.Header.Add("x-webauth-user", userLogin)
+			testRequest.Header.Add("content-type", "application/json")
+			testRequest = testRequest.WithContext(middleware.SetContextValueForTest(context.Background(), "auth", auth))
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+
+			require.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+
+	})
+
+	t.Run("When user is viewer of the team which restricts trigger changes", func(t *testing.T) {
+		const teamID = "team"
+
+		auth := api.Authorization{
+			Enabled: true,
+			AdminList: map[string]struct{}{
+				adminLogin: {},
+			},
+		}
+		logger, _ := logging.GetLogger("Test")
+		config := &api.Config{
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
+		}
+		webConfig := &api.WebConfig{
+			SupportEmail: "test",
+			Contacts:     []api.WebContact{},
+		}
+		trigger := moira.Trigger{
+			TeamID:    teamID,
+			CreatedBy: ownerLogin,
+		}
+
+		t.Run("When request from viewer, should be forbidden", func(t *testing.T) {
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil).Times(2)
+			mockDb.EXPECT().GetTriggerThrottling(triggerID)
+			mockDb.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+			mockDb.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
+			mockDb.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}, nil)
+
+			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
+
+			responseWriter := httptest.NewRecorder()
+			testRequest := httptest.NewRequest(http.MethodDelete, "/api/trigger/"+triggerID, strings.NewReader(""))
+			testRequest.Header.Add("x-webauth-user", userLogin)
+			testRequest.Header.Add("content-type", "application/json")
+			testRequest = testRequest.WithContext(middleware.SetContextValueForTest(context.Background(), "auth", auth))
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+
+			require.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+
+		t.Run("When viewer sets maintenance, should be forbidden", func(t *testing.T) {
+			mockDb.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+			mockDb.EXPECT().GetTeam(teamID).Return(moira.Team{ID: teamID, RestrictTriggerChanges: true}, nil).Times(2)
+			mockDb.EXPECT().IsTeamContainUser(teamID, userLogin).Return(true, nil)
+			mockDb.EXPECT().GetTeamUserRoles(teamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}, nil)
+
+			handler := NewHandler(mockDb, logger, nil, config, nil, webConfig, nil)
+
+			responseWriter := httptest.NewRecorder()
+			testRequest := httptest.NewRequest(http.MethodPut, "/api/trigger/"+triggerID+"/setMaintenance", strings.NewReader(`{"trigger":1}`))
+			testRequest.Header.Add("x-webauth-user", userLogin)
+			testRequest.Header.Add("content-type", "application/json")
+			testRequest = testRequest.WithContext(middleware.SetContextValueForTest(context.Background(), "auth", auth))
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+
+			require.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
 }
 
 func TestUpdateTriggerHandler(t *testing.T) {
@@ -1211,7 +1353,8 @@ func TestUpdateTriggerHandler(t *testing.T) {
 		}
 		logger, _ := logging.GetLogger("Test")
 		config := &api.Config{
-			Authorization: auth,
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
 			Limits: api.LimitsConfig{
 				Trigger: api.TriggerLimits{
 					MaxNameSize: 100,
@@ -1300,7 +1443,8 @@ func TestUpdateTriggerHandler(t *testing.T) {
 		}
 		logger, _ := logging.GetLogger("Test")
 		config := &api.Config{
-			Authorization: auth,
+			Authorization:  auth,
+			Authentication: api.AuthenticationConfig{TrustProxyHeader: true},
 			Limits: api.LimitsConfig{
 				Trigger: api.TriggerLimits{
 					MaxNameSize: 100,
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/database"
+)
+
+const (
+	webAuthUserHeader = "x-webauth-user"
+	bearerPrefix      = "Bearer "
+)
+
+var (
+	errInvalidAPIToken = errors.New("invalid api token")
+	errExpiredAPIToken = errors.New("api token has expired")
+)
+
+/*
+Authentication authenticates user of the request and sets login of the user and authorization to request context.
+
+Credentials are checked in the following order: API token from Authorization: Bearer header, session cookie
+of user logged in with OpenID Connect provider and, if TrustProxyHeader is set, x-webauth-user header of
+authenticating proxy. Requests without valid credentials are passed further anonymously, use RequireAuthentication
+to reject them. AuthorizationContext must be applied before this middleware.
+*/
+func Authentication(db moira.Database, config api.AuthenticationConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+			auth := GetAuth(request)
+
+			switch {
+			case config.APITokens.Enabled && strings.HasPrefix(request.Header.Get("Authorization"), bearerPrefix):
+				token, err := authenticateAPIToken(db, strings.TrimPrefix(request.Header.Get("Authorization"), bearerPrefix))
+				if err != nil {
+					ctx = context.WithValue(ctx, authErrorKey, err)
+					break
+				}
+
+				// Admin scope grants administrator role only while the user who issued the token is an administrator.
+				isAdmin := auth.IsAdmin(token.User) && token.HasScope(moira.APITokenScopeAdmin)
+				ctx = withUser(ctx, auth, token.User, isAdmin)
+				ctx = context.WithValue(ctx, apiTokenKey, token)
+
+				// API token of the team acts as service account of the team rather than as the user who issued it.
+				if token.TeamID != "" {
+					tokenAuth := ctx.Value(authKey).(*api.Authorization)
+					ctx = context.WithValue(ctx, authKey, tokenAuth.WithServiceAccount(token.TeamID))
+				}
+
+			case config.OIDC.Enabled && hasCookie(request, config.Session.CookieName):
+				cookie, _ := request.Cookie(config.Session.CookieName)
+
+				session, err := db.GetAuthSession(api.HashSecret(cookie.Value))
+				if err != nil {
+					if !errors.Is(err, database.ErrNil) {
+						ctx = context.WithValue(ctx, authErrorKey, fmt.Errorf("failed to get session: %w", err))
+					}
+
+					break
+				}
+
+				isAdmin := auth.IsAdmin(session.Login) || auth.IsAdminGroupMember(session.Groups)
+				ctx = withUser(ctx, auth, session.Login, isAdmin)
+
+			case config.TrustProxyHeader:
+				ctx = context.WithValue(ctx, loginKey, request.Header.Get(webAuthUserHeader))
+			}
+
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+// RequireAuthentication returns 401 if authorization is enabled and user of the request was not authenticated,
+// and 403 if the request authenticated by API token without write scope tries to change data.
+func RequireAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if GetAuth(request).IsEnabled() && !isAuthenticated(request) {
+			errorText := "Authentication required"
+			if err, ok := request.Context().Value(authErrorKey).(error); ok {
+				errorText = err.Error()
+			}
+
+			render.Render(writer, request, api.ErrorUnauthorized(errorText)) //nolint:errcheck
+			return
+		}
+
+		if token := GetAPIToken(request); token != nil && !isSafeMethod(request.Method) && !token.HasScope(moira.APITokenScopeWrite) {
+			render.Render(writer, request, api.ErrorForbidden("API token has no write scope")) //nolint:errcheck
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// GetAPIToken returns API token which was used to authenticate the request or nil if request was authenticated another way.
+func GetAPIToken(request *http.Request) *moira.APIToken {
+	token, _ := request.Context().Value(apiTokenKey).(*moira.APIToken)
+	return token
+}
+
+func authenticateAPIToken(db moira.Database, secret string) (*moira.APIToken, error) {
+	token, err := db.GetAPITokenByHash(api.HashSecret(strings.TrimSpace(secret)))
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, errInvalidAPIToken
+		}
+
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	if token.IsExpired(time.Now().Unix()) {
+		return nil, errExpiredAPIToken
+	}
+
+	// Team token stops working when the user who issued it leaves the team or can not manage the team anymore.
+	if token.TeamID != "" {
+		isMember, err := db.IsTeamContainUser(token.TeamID, token.User)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team of api token: %w", err)
+		}
+
+		if !isMember {
+			return nil, errInvalidAPIToken
+		}
+
+		roles, err := db.GetTeamUserRoles(token.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team roles of api token: %w", err)
+		}
+
+		if !moira.MemberTeamRole(roles, token.User).Allows(moira.TeamAccessManage) {
+			return nil, errInvalidAPIToken
+		}
+	}
+
+	return &token, nil
+}
+
+// withUser sets login of authenticated user to the context, as well as authorization granting or revoking administrator role of the user.
+func withUser(ctx context.Context, auth *api.Authorization, login string, isAdmin bool) context.Context {
+	ctx = context.WithValue(ctx, loginKey, login)
+
+	if auth.IsEnabled() {
+		ctx = context.WithValue(ctx, authKey, auth.WithAdmin(login, isAdmin))
+	}
+
+	return ctx
+}
+
+func isAuthenticated(request *http.Request) bool {
+	login, _ := request.Context().Value(loginKey).(string)
+	return login != ""
+}
+
+func hasCookie(request *http.Request, name string) bool {
+	_, err := request.Cookie(name)
+	return err == nil
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
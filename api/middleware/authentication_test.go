@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthentication(t *testing.T) {
+	Convey("Test authentication middleware", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		db := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+		const (
+			adminLogin  = "admin"
+			userLogin   = "user"
+			tokenSecret = "moira_secret"
+			cookieName  = "moira_session"
+		)
+
+		auth := &api.Authorization{
+			Enabled:     true,
+			AdminList:   map[string]struct{}{adminLogin: {}},
+			AdminGroups: map[string]struct{}{"admins": {}},
+		}
+		config := api.AuthenticationConfig{
+			TrustProxyHeader: true,
+			OIDC:             api.OIDCConfig{Enabled: true},
+			Session:          api.SessionConfig{CookieName: cookieName},
+			APITokens:        api.APITokensConfig{Enabled: true},
+		}
+
+		var (
+			gotLogin   string
+			gotIsAdmin bool
+			gotToken   *moira.APIToken
+		)
+
+		next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			gotLogin = GetLogin(request)
+			gotIsAdmin = GetAuth(request).IsAdmin(gotLogin)
+			gotToken = GetAPIToken(request)
+			writer.WriteHeader(http.StatusOK)
+		})
+
+		serve := func(request *http.Request) int {
+			request = request.WithContext(context.WithValue(request.Context(), authKey, auth))
+			recorder := httptest.NewRecorder()
+			Authentication(db, config)(RequireAuthentication(next)).ServeHTTP(recorder, request)
+
+			return recorder.Code
+		}
+
+		Convey("With valid API token", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+			request.Header.Set(webAuthUserHeader, adminLogin)
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, userLogin)
+			So(gotIsAdmin, ShouldBeFalse)
+			So(gotToken.ID, ShouldEqual, token.ID)
+		})
+
+		Convey("With API token of admin without admin scope, admin role should be revoked", func() {
+			token := moira.APIToken{ID: "token-id", User: adminLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, adminLogin)
+			So(gotIsAdmin, ShouldBeFalse)
+		})
+
+		Convey("With API token with admin scope of admin, admin role should be granted", func() {
+			token := moira.APIToken{ID: "token-id", User: adminLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead, moira.APITokenScopeAdmin}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, adminLogin)
+			So(gotIsAdmin, ShouldBeTrue)
+		})
+
+		Convey("With API token with admin scope of user who is no longer admin, admin role should not be granted", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead, moira.APITokenScopeAdmin}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, userLogin)
+			So(gotIsAdmin, ShouldBeFalse)
+		})
+
+		Convey("With API token without write scope, changing request should be forbidden", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodPut, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("With expired API token, should be unauthorized", func() {
+			expiresAt := time.Now().Add(-time.Minute).Unix()
+			token := moira.APIToken{ID: "token-id", User: userLogin, Scopes: []moira.APITokenScope{moira.APITokenScopeRead}, ExpiresAt: &expiresAt}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("With unknown API token, should be unauthorized", func() {
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(moira.APIToken{}, database.ErrNil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("With team API token of user who left the team, should be unauthorized", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, TeamID: "team-id", Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+			db.EXPECT().IsTeamContainUser(token.TeamID, userLogin).Return(false, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("With team API token of user who can not manage the team anymore, should be unauthorized", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, TeamID: "team-id", Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+			db.EXPECT().IsTeamContainUser(token.TeamID, userLogin).Return(true, nil)
+			db.EXPECT().GetTeamUserRoles(token.TeamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleEditor}, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("With team API token of team owner, should be authenticated", func() {
+			token := moira.APIToken{ID: "token-id", User: userLogin, TeamID: "team-id", Scopes: []moira.APITokenScope{moira.APITokenScopeRead}}
+			db.EXPECT().GetAPITokenByHash(api.HashSecret(tokenSecret)).Return(token, nil)
+			db.EXPECT().IsTeamContainUser(token.TeamID, userLogin).Return(true, nil)
+			db.EXPECT().GetTeamUserRoles(token.TeamID).Return(map[string]moira.TeamRole{userLogin: moira.TeamRoleOwner}, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set("Authorization", "Bearer "+tokenSecret)
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, userLogin)
+			So(gotToken.TeamID, ShouldEqual, token.TeamID)
+		})
+
+		Convey("With session of user from admin group, should be admin", func() {
+			session := moira.AuthSession{Login: userLogin, Groups: []string{"admins"}, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+			db.EXPECT().GetAuthSession(api.HashSecret("session-secret")).Return(session, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.AddCookie(&http.Cookie{Name: cookieName, Value: "session-secret"})
+
+			So(serve(request), ShouldEqual, http.StatusOK)
+			So(gotLogin, ShouldEqual, userLogin)
+			So(gotIsAdmin, ShouldBeTrue)
+			So(gotToken, ShouldBeNil)
+		})
+
+		Convey("With unknown session, should be unauthorized", func() {
+			db.EXPECT().GetAuthSession(api.HashSecret("session-secret")).Return(moira.AuthSession{}, database.ErrNil)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.AddCookie(&http.Cookie{Name: cookieName, Value: "session-secret"})
+
+			So(serve(request), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("With proxy header", func() {
+			request := httptest.NewRequest(http.MethodGet, "/api/trigger", http.NoBody)
+			request.Header.Set(webAuthUserHeader, adminLogin)
+
+			Convey("Trusted, should use login from header", func() {
+				So(serve(request), ShouldEqual, http.StatusOK)
+				So(gotLogin, ShouldEqual, adminLogin)
+				So(gotIsAdmin, ShouldBeTrue)
+			})
+
+			Convey("Not trusted, should be unauthorized", func() {
+				config.TrustProxyHeader = false
+
+				So(serve(request), ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	"github.com/go-chi/render"
@@ -25,3 +26,21 @@ func AdminOnlyMiddleware() func(next http.Handler) http.Handler {
 		return http.HandlerFunc(fn)
 	}
 }
+
+// IncidentWebhookTokenMiddleware returns 403 if the token query parameter of the request does not match the given token.
+func IncidentWebhookTokenMiddleware(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			requestToken := r.URL.Query().Get("token")
+
+			if token == "" || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+				render.Render(w, r, api.ErrorForbidden("Invalid incident webhook token")) //nolint:errcheck
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -51,7 +51,7 @@ func ContactsTemplateContext(contactsTemplate []api.WebContact) func(next http.H
 // UserContext get x-webauth-user header and sets it in request context, if header is empty sets empty string.
 func UserContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		userLogin := request.Header.Get("x-webauth-user")
+		userLogin := request.Header.Get(webAuthUserHeader)
 		ctx := context.WithValue(request.Context(), loginKey, userLogin)
 		next.ServeHTTP(writer, request.WithContext(ctx))
 	})
@@ -113,6 +113,62 @@ func SubscriptionContext(next http.Handler) http.Handler {
 	})
 }
 
+// DeadLetterContext gets deadLetterId from parsed URI corresponding to dead letter notification routes and set it to request context.
+func DeadLetterContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		deadLetterID := chi.URLParam(request, "deadLetterId")
+		if deadLetterID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("deadLetterId must be set"))) //nolint
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), deadLetterIDKey, deadLetterID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// APITokenContext gets tokenId from parsed URI corresponding to API token routes and set it to request context.
+func APITokenContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		tokenID := chi.URLParam(request, "tokenId")
+		if tokenID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("tokenId must be set"))) //nolint
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), apiTokenIDKey, tokenID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// MaintenanceWindowContext gets maintenanceWindowId from parsed URI corresponding to maintenance window routes and set it to request context.
+func MaintenanceWindowContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		windowID := chi.URLParam(request, "maintenanceWindowId")
+		if windowID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("maintenanceWindowId must be set"))) //nolint
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), maintenanceWindowKey, windowID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// SilenceContext gets silenceId from parsed URI corresponding to silence routes and set it to request context.
+func SilenceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		silenceID := chi.URLParam(request, "silenceId")
+		if silenceID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("silenceId must be set"))) //nolint
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), silenceIDKey, silenceID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
 // MetricSourceProvider adds metrics source provider to context.
 func MetricSourceProvider(sourceProvider *metricSource.SourceProvider) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
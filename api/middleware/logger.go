@@ -98,6 +98,10 @@ func NewLogEntry(logger moira.Logger, request *http.Request) *apiLoggerEntry {
 	log.String("http.remote_addr", request.RemoteAddr)
 	log.String("username", GetLogin(request))
 
+	if token := GetAPIToken(request); token != nil {
+		log.String("api_token_id", token.ID)
+	}
+
 	return entry
 }
 
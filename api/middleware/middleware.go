@@ -48,6 +48,12 @@ var (
 	searchTextContextKey ContextKey = "searchText"
 	sortOrderContextKey  ContextKey = "sort"
 	selfStateChecksKey   ContextKey = "selfstateChecks"
+	deadLetterIDKey      ContextKey = "deadLetterID"
+	apiTokenKey          ContextKey = "apiToken"
+	apiTokenIDKey        ContextKey = "apiTokenID"
+	authErrorKey         ContextKey = "authError"
+	maintenanceWindowKey ContextKey = "maintenanceWindowID"
+	silenceIDKey         ContextKey = "silenceID"
 
 	anonymousUser = "anonymous"
 )
@@ -169,6 +175,26 @@ func GetTeamUserID(request *http.Request) string {
 	return request.Context().Value(teamUserIDKey).(string)
 }
 
+// GetDeadLetterID gets dead letter notification id string from request context, which was sets in DeadLetterContext middleware.
+func GetDeadLetterID(request *http.Request) string {
+	return request.Context().Value(deadLetterIDKey).(string)
+}
+
+// GetAPITokenID gets API token id string from request context, which was sets in APITokenContext middleware.
+func GetAPITokenID(request *http.Request) string {
+	return request.Context().Value(apiTokenIDKey).(string)
+}
+
+// GetMaintenanceWindowID gets maintenance window id string from request context, which was sets in MaintenanceWindowContext middleware.
+func GetMaintenanceWindowID(request *http.Request) string {
+	return request.Context().Value(maintenanceWindowKey).(string)
+}
+
+// GetSilenceID gets silence id string from request context, which was sets in SilenceContext middleware.
+func GetSilenceID(request *http.Request) string {
+	return request.Context().Value(silenceIDKey).(string)
+}
+
 // SetContextValueForTest is a helper function that is needed for testing purposes and sets context values with local ContextKey type.
 func SetContextValueForTest(ctx context.Context, key string, value interface{}) context.Context {
 	return context.WithValue(ctx, ContextKey(key), value)
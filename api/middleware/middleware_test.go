@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -33,3 +34,30 @@ func TestGetLogin(t *testing.T) {
 		So("awesome_user", ShouldEqual, GetLogin(req))
 	})
 }
+
+func TestIncidentWebhookTokenMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	Convey("Test incident webhook token middleware", t, func() {
+		cases := []struct {
+			token          string
+			url            string
+			expectedStatus int
+		}{
+			{token: "secret", url: "https://testurl.com/api/incident/pagerduty?token=secret", expectedStatus: http.StatusOK},
+			{token: "secret", url: "https://testurl.com/api/incident/pagerduty?token=wrong", expectedStatus: http.StatusForbidden},
+			{token: "secret", url: "https://testurl.com/api/incident/pagerduty", expectedStatus: http.StatusForbidden},
+			{token: "", url: "https://testurl.com/api/incident/pagerduty?token=", expectedStatus: http.StatusForbidden},
+		}
+
+		for _, testCase := range cases {
+			req := httptest.NewRequest(http.MethodPost, testCase.url, http.NoBody)
+			recorder := httptest.NewRecorder()
+
+			IncidentWebhookTokenMiddleware(testCase.token)(next).ServeHTTP(recorder, req)
+			So(recorder.Code, ShouldEqual, testCase.expectedStatus)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+// Package oidc implements login of api users with OpenID Connect authorization code flow.
+//
+// Claims of the user are read from the userinfo endpoint of the provider with the access token received
+// from the token endpoint, so there is no need to verify signature of ID token.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moira-alert/moira/api"
+	"golang.org/x/oauth2"
+)
+
+const (
+	discoveryPath  = "/.well-known/openid-configuration"
+	requestTimeout = 10 * time.Second
+)
+
+// UserInfo contains claims of the logged in user.
+type UserInfo struct {
+	Login  string
+	Groups []string
+}
+
+// Provider is a client of OpenID Connect provider. Endpoints of the provider are discovered on first use.
+type Provider struct {
+	config     api.OIDCConfig
+	httpClient *http.Client
+
+	mutex     sync.Mutex
+	discovery *discovery
+}
+
+// discovery contains fields of the provider discovery document used by Provider.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// NewProvider returns client of the provider configured by the given config.
+func NewProvider(config api.OIDCConfig) *Provider {
+	return &Provider{
+		config:     config,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// GenerateVerifier returns new PKCE code verifier which must be passed to both AuthCodeURL and Exchange.
+func (provider *Provider) GenerateVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// AuthCodeURL returns url of the provider login page, the provider redirects user back to RedirectURL with the given state.
+func (provider *Provider) AuthCodeURL(ctx context.Context, state, verifier string) (string, error) {
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return provider.oauthConfig(discovery).AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange exchanges authorization code to access token and returns claims of the user who has logged in.
+func (provider *Provider) Exchange(ctx context.Context, code, verifier string) (UserInfo, error) {
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, provider.httpClient)
+
+	token, err := provider.oauthConfig(discovery).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	claims := make(map[string]interface{})
+	if err = provider.getJSON(ctx, discovery.UserinfoEndpoint, token, &claims); err != nil {
+		return UserInfo{}, fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	return provider.userInfo(claims)
+}
+
+func (provider *Provider) userInfo(claims map[string]interface{}) (UserInfo, error) {
+	login, _ := claims[provider.config.UsernameClaim].(string)
+	if login == "" {
+		return UserInfo{}, fmt.Errorf("user info has no claim %s", provider.config.UsernameClaim)
+	}
+
+	userInfo := UserInfo{Login: login}
+
+	switch groups := claims[provider.config.GroupsClaim].(type) {
+	case string:
+		userInfo.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if groupName, ok := group.(string); ok {
+				userInfo.Groups = append(userInfo.Groups, groupName)
+			}
+		}
+	}
+
+	return userInfo, nil
+}
+
+func (provider *Provider) oauthConfig(discovery *discovery) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     provider.config.ClientID,
+		ClientSecret: provider.config.ClientSecret,
+		RedirectURL:  provider.config.RedirectURL,
+		Scopes:       append([]string{"openid"}, provider.config.Scopes...),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+}
+
+// discover fetches discovery document of the provider, successfully fetched document is cached.
+func (provider *Provider) discover(ctx context.Context) (*discovery, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.discovery != nil {
+		return provider.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(provider.config.IssuerURL, "/")
+
+	result := &discovery{}
+	if err := provider.getJSON(ctx, issuer+discoveryPath, nil, result); err != nil {
+		return nil, fmt.Errorf("failed to discover openid provider: %w", err)
+	}
+
+	if strings.TrimSuffix(result.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer %s of openid provider does not match configured issuer %s", result.Issuer, issuer)
+	}
+
+	if result.AuthorizationEndpoint == "" || result.TokenEndpoint == "" || result.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("openid provider %s does not support authorization code flow with userinfo endpoint", issuer)
+	}
+
+	provider.discovery = result
+
+	return result, nil
+}
+
+func (provider *Provider) getJSON(ctx context.Context, url string, token *oauth2.Token, result interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	if token != nil {
+		token.SetAuthHeader(request)
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err := provider.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad response status %d from %s", response.StatusCode, url)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
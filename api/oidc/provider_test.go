@@ -0,0 +1,128 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/moira-alert/moira/api"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProvider(t *testing.T) {
+	Convey("Test OpenID Connect provider", t, func() {
+		const (
+			code        = "authorization-code"
+			accessToken = "access-token"
+		)
+
+		var (
+			issuer   string
+			userInfo map[string]interface{}
+		)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(discoveryPath, func(writer http.ResponseWriter, _ *http.Request) {
+			json.NewEncoder(writer).Encode(discovery{ //nolint
+				Issuer:                issuer,
+				AuthorizationEndpoint: issuer + "/authorize",
+				TokenEndpoint:         issuer + "/token",
+				UserinfoEndpoint:      issuer + "/userinfo",
+			})
+		})
+		mux.HandleFunc("/token", func(writer http.ResponseWriter, request *http.Request) {
+			if request.FormValue("code") != code || request.FormValue("code_verifier") == "" {
+				writer.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			writer.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(writer).Encode(map[string]string{ //nolint
+				"access_token": accessToken,
+				"token_type":   "Bearer",
+			})
+		})
+		mux.HandleFunc("/userinfo", func(writer http.ResponseWriter, request *http.Request) {
+			if request.Header.Get("Authorization") != "Bearer "+accessToken {
+				writer.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			json.NewEncoder(writer).Encode(userInfo) //nolint
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		issuer = server.URL
+
+		config := api.OIDCConfig{
+			Enabled:       true,
+			IssuerURL:     server.URL,
+			ClientID:      "moira",
+			ClientSecret:  "secret",
+			RedirectURL:   "https://moira.example.com/api/auth/callback",
+			Scopes:        []string{"groups"},
+			UsernameClaim: api.DefaultOIDCUsernameClaim,
+			GroupsClaim:   api.DefaultOIDCGroupsClaim,
+		}
+
+		Convey("AuthCodeURL points to authorization endpoint", func() {
+			provider := NewProvider(config)
+			verifier := provider.GenerateVerifier()
+
+			authCodeURL, err := provider.AuthCodeURL(context.Background(), "state", verifier)
+			So(err, ShouldBeNil)
+
+			parsed, err := url.Parse(authCodeURL)
+			So(err, ShouldBeNil)
+			So(parsed.Path, ShouldEqual, "/authorize")
+			So(parsed.Query().Get("state"), ShouldEqual, "state")
+			So(parsed.Query().Get("scope"), ShouldEqual, "openid groups")
+			So(parsed.Query().Get("code_challenge_method"), ShouldEqual, "S256")
+		})
+
+		Convey("Exchange returns login and groups", func() {
+			provider := NewProvider(config)
+
+			Convey("Groups as list", func() {
+				userInfo = map[string]interface{}{"preferred_username": "john", "groups": []string{"admins", "devs"}}
+
+				info, err := provider.Exchange(context.Background(), code, provider.GenerateVerifier())
+				So(err, ShouldBeNil)
+				So(info, ShouldResemble, UserInfo{Login: "john", Groups: []string{"admins", "devs"}})
+			})
+
+			Convey("Groups as string", func() {
+				userInfo = map[string]interface{}{"preferred_username": "john", "groups": "admins"}
+
+				info, err := provider.Exchange(context.Background(), code, provider.GenerateVerifier())
+				So(err, ShouldBeNil)
+				So(info, ShouldResemble, UserInfo{Login: "john", Groups: []string{"admins"}})
+			})
+
+			Convey("Without username claim", func() {
+				userInfo = map[string]interface{}{"email": "john@example.com"}
+
+				_, err := provider.Exchange(context.Background(), code, provider.GenerateVerifier())
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("With invalid code", func() {
+				_, err := provider.Exchange(context.Background(), "invalid", provider.GenerateVerifier())
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("Discovery fails for unknown issuer", func() {
+			config.IssuerURL = server.URL + "/realms/other"
+			provider := NewProvider(config)
+
+			_, err := provider.AuthCodeURL(context.Background(), "state", provider.GenerateVerifier())
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,246 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/clock"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metrics"
+)
+
+const (
+	// MaxBacktestChecks is the maximum number of checks of trigger with local source during one backtest,
+	// it is a week of checks every minute.
+	MaxBacktestChecks = 7 * 24 * 60
+	// MaxRemoteBacktestChecks is the maximum number of checks of trigger with remote source during one backtest,
+	// it is a day of checks every five minutes. Every check queries the remote source, so the limit is much lower.
+	MaxRemoteBacktestChecks = 24 * 12
+)
+
+// BacktestResult is a result of run of trigger against historical data, nothing is saved during it.
+type BacktestResult struct {
+	// Checks is the number of checks of trigger.
+	Checks int64 `json:"checks" example:"1440" format:"int64"`
+	// State is the state of trigger after the last check.
+	State moira.State `json:"state" example:"OK"`
+	// Transitions are changes of states of trigger and its metrics observed between checks.
+	Transitions []BacktestTransition `json:"transitions"`
+	// Events are notification events which checker would have generated.
+	Events []moira.NotificationEvent `json:"events"`
+}
+
+// BacktestTransition is a change of state of trigger or its metric.
+type BacktestTransition struct {
+	Timestamp int64 `json:"timestamp" example:"1704067200" format:"int64"`
+	// Metric is empty for transitions of trigger state.
+	Metric   string      `json:"metric,omitempty" example:"disk.web1.used"`
+	OldState moira.State `json:"old_state" example:"OK"`
+	State    moira.State `json:"state" example:"ERROR"`
+	// Suppressed is true if the transition did not generate event because of schedule of trigger.
+	Suppressed bool `json:"suppressed,omitempty" example:"false"`
+}
+
+// ValidateBacktestRange checks that the time range of backtest is not empty and does not require too many checks
+// for the source of trigger.
+func ValidateBacktestRange(triggerSource moira.TriggerSource, from, until, step int64) error {
+	if step <= 0 {
+		return fmt.Errorf("backtest step must be positive")
+	}
+
+	if from >= until {
+		return fmt.Errorf("backtest must start before it ends: %d - %d", from, until)
+	}
+
+	maxChecks := int64(MaxBacktestChecks)
+	if triggerSource != moira.GraphiteLocal {
+		maxChecks = MaxRemoteBacktestChecks
+	}
+
+	if (until-from)/step > maxChecks {
+		return fmt.Errorf("backtest can not have more than %d checks, increase step or shorten time range", maxChecks)
+	}
+
+	return nil
+}
+
+// Backtest runs the trigger against metrics of the time range through the checker logic without saving anything.
+// The trigger is checked every step seconds since from till until and starts with no metrics like a new trigger.
+// Events are generated for every point of metrics, while transitions are observed between checks,
+// so with large step some transitions of metric may be merged into one.
+// Backtest stops between checks when the context is done.
+func Backtest(
+	ctx context.Context,
+	trigger *moira.Trigger,
+	dataBase moira.Database,
+	logger moira.Logger,
+	sourceProvider *metricSource.SourceProvider,
+	from, until, step int64,
+) (*BacktestResult, error) {
+	if err := ValidateBacktestRange(trigger.TriggerSource, from, until, step); err != nil {
+		return nil, err
+	}
+
+	source, err := sourceProvider.GetTriggerMetricSource(trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := metrics.NewDummyRegistry()
+	checkMetrics := &metrics.CheckMetrics{
+		CheckError:           registry.NewMeter("backtest", "errors", "check"),
+		CheckErrorCounter:    registry.NewCounter("backtest", "errors", "check", "count"),
+		HandleError:          registry.NewMeter("backtest", "errors", "handle"),
+		HandleErrorCounter:   registry.NewCounter("backtest", "errors", "handle", "count"),
+		TriggersCheckTime:    registry.NewTimer("backtest", "triggers"),
+		TriggersToCheckCount: registry.NewHistogram("backtest", "triggersToCheck"),
+	}
+
+	backtestDataBase := &backtestDatabase{Database: dataBase}
+	lastCheck := &moira.CheckData{
+		Metrics:   make(map[string]moira.MetricState),
+		State:     moira.StateOK,
+		Timestamp: from,
+	}
+
+	result := &BacktestResult{
+		Transitions: make([]BacktestTransition, 0),
+	}
+
+	for checkTimestamp := from + step; checkTimestamp <= until; checkTimestamp += step {
+		if err = ctx.Err(); err != nil {
+			return nil, fmt.Errorf("backtest stopped at %d: %w", checkTimestamp, err)
+		}
+
+		// New metrics appear at the time of check as they do for checker
+		lastCheck.Clock = clock.NewFixedClock(time.Unix(checkTimestamp, 0))
+
+		triggerChecker := &TriggerChecker{
+			database: backtestDataBase,
+			logger:   logger,
+			config:   &Config{},
+			metrics:  checkMetrics,
+			source:   source,
+
+			from:  calculateFrom(lastCheck.Timestamp, trigger.TTL),
+			until: checkTimestamp,
+
+			triggerID: trigger.ID,
+			trigger:   trigger,
+			lastCheck: lastCheck,
+
+			ttl:      trigger.TTL,
+			ttlState: getTTLState(trigger.TTLState),
+		}
+
+		backtestDataBase.lastCheck = nil
+		if err = triggerChecker.Check(); err != nil {
+			return nil, err
+		}
+
+		if backtestDataBase.lastCheck == nil {
+			return nil, fmt.Errorf("check of trigger at %d finished without result", checkTimestamp)
+		}
+
+		result.Transitions = append(result.Transitions, getBacktestTransitions(trigger, lastCheck, backtestDataBase.lastCheck)...)
+		result.Checks++
+
+		lastCheck = backtestDataBase.lastCheck
+	}
+
+	result.State = lastCheck.State
+	result.Events = backtestDataBase.events
+
+	if result.Events == nil {
+		result.Events = make([]moira.NotificationEvent, 0)
+	}
+
+	return result, nil
+}
+
+// getBacktestTransitions returns changes of states of trigger and its metrics between two checks sorted by time.
+func getBacktestTransitions(trigger *moira.Trigger, previous, current *moira.CheckData) []BacktestTransition {
+	transitions := make([]BacktestTransition, 0)
+
+	if previous.State != current.State {
+		transitions = append(transitions, BacktestTransition{
+			Timestamp:  current.Timestamp,
+			OldState:   previous.State,
+			State:      current.State,
+			Suppressed: current.Suppressed,
+		})
+	}
+
+	for metric, state := range current.Metrics {
+		previousState, ok := previous.Metrics[metric]
+		if !ok {
+			// New metric starts with the state of empty metric
+			previousState.State = moira.StateNODATA
+			if trigger.MuteNewMetrics {
+				previousState.State = moira.StateOK
+			}
+		}
+
+		if previousState.State == state.State {
+			continue
+		}
+
+		transitions = append(transitions, BacktestTransition{
+			Timestamp:  state.GetEventTimestamp(),
+			Metric:     metric,
+			OldState:   previousState.State,
+			State:      state.State,
+			Suppressed: state.Suppressed,
+		})
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Timestamp != transitions[j].Timestamp {
+			return transitions[i].Timestamp < transitions[j].Timestamp
+		}
+
+		return transitions[i].Metric < transitions[j].Metric
+	})
+
+	return transitions
+}
+
+// backtestDatabase reads data from the database, but keeps in memory everything checker writes, so backtest saves nothing.
+type backtestDatabase struct {
+	moira.Database
+
+	lastCheck *moira.CheckData
+	events    []moira.NotificationEvent
+}
+
+// SetTriggerLastCheck keeps the result of check in memory.
+func (dataBase *backtestDatabase) SetTriggerLastCheck(_ string, checkData *moira.CheckData, _ moira.ClusterKey) error {
+	dataBase.lastCheck = checkData
+
+	return nil
+}
+
+// PushNotificationEvent keeps the event in memory instead of passing it to notifier.
+func (dataBase *backtestDatabase) PushNotificationEvent(event *moira.NotificationEvent, _ bool) error {
+	dataBase.events = append(dataBase.events, *event)
+
+	return nil
+}
+
+// RemovePatternsMetrics does nothing, metrics are not removed during backtest.
+func (*backtestDatabase) RemovePatternsMetrics([]string) error {
+	return nil
+}
+
+// RemoveMetricsValues does nothing, metrics are not removed during backtest.
+func (*backtestDatabase) RemoveMetricsValues([]string, int64) error {
+	return nil
+}
+
+// GetMetricsTTLSeconds returns zero as metrics are not removed during backtest.
+func (*backtestDatabase) GetMetricsTTLSeconds() int64 {
+	return 0
+}
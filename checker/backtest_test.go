@@ -0,0 +1,141 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateBacktestRange(t *testing.T) {
+	Convey("Test backtest range validation", t, func() {
+		Convey("With valid range", func() {
+			So(ValidateBacktestRange(moira.GraphiteLocal, 0, 3600, 60), ShouldBeNil)
+		})
+
+		Convey("With not positive step", func() {
+			So(ValidateBacktestRange(moira.GraphiteLocal, 0, 3600, 0), ShouldNotBeNil)
+		})
+
+		Convey("With empty range", func() {
+			So(ValidateBacktestRange(moira.GraphiteLocal, 3600, 3600, 60), ShouldNotBeNil)
+		})
+
+		Convey("With too many checks", func() {
+			So(ValidateBacktestRange(moira.GraphiteLocal, 0, (MaxBacktestChecks+1)*60, 60), ShouldNotBeNil)
+		})
+
+		Convey("With remote source", func() {
+			So(ValidateBacktestRange(moira.GraphiteRemote, 0, MaxRemoteBacktestChecks*60, 60), ShouldBeNil)
+			So(ValidateBacktestRange(moira.GraphiteRemote, 0, (MaxRemoteBacktestChecks+1)*60, 60), ShouldNotBeNil)
+			So(ValidateBacktestRange(moira.SQLRemote, 0, (MaxRemoteBacktestChecks+1)*60, 60), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestBacktest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger, _ := logging.GetLogger("Test")
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	source := mock_metric_source.NewMockMetricSource(mockCtrl)
+	sourceProvider := metricSource.CreateTestMetricSourceProvider(source, nil, nil)
+
+	const (
+		from      int64 = 1704067200
+		until           = from + 1800
+		step      int64 = 60
+		errorFrom       = from + 600
+		errorTill       = from + 1200
+	)
+
+	warnValue, errorValue := 50.0, 90.0
+	trigger := &moira.Trigger{
+		ID:            "trigger1",
+		Name:          "Disk usage",
+		Targets:       []string{"disk.*"},
+		Tags:          []string{"disk"},
+		WarnValue:     &warnValue,
+		ErrorValue:    &errorValue,
+		TriggerType:   moira.RisingTrigger,
+		TTL:           600,
+		TTLState:      &moira.TTLStateNODATA,
+		TriggerSource: moira.GraphiteLocal,
+		ClusterId:     moira.DefaultCluster,
+	}
+
+	source.EXPECT().Fetch("disk.*", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, fetchFrom, fetchUntil int64, _ bool) (metricSource.FetchResult, error) {
+			start := fetchFrom - fetchFrom%step
+			if start < fetchFrom {
+				start += step
+			}
+
+			values := make([]float64, 0)
+			for timestamp := start; timestamp <= fetchUntil; timestamp += step {
+				if timestamp >= errorFrom && timestamp < errorTill {
+					values = append(values, 100)
+				} else {
+					values = append(values, 0)
+				}
+			}
+
+			fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+			fetchResult.EXPECT().GetMetricsData().Return([]metricSource.MetricData{
+				*metricSource.MakeMetricData("disk.web1", values, step, start),
+			}).AnyTimes()
+			fetchResult.EXPECT().GetPatternMetrics().Return([]string{"disk.web1"}, nil).AnyTimes()
+			fetchResult.EXPECT().GetPatterns().Return([]string{"disk.*"}, nil).AnyTimes()
+
+			return fetchResult, nil
+		}).AnyTimes()
+	source.EXPECT().GetMetricsTTLSeconds().Return(int64(3600)).AnyTimes()
+
+	Convey("Test backtest of trigger", t, func() {
+		Convey("With valid range transitions and events are collected without saving", func() {
+			result, err := Backtest(context.Background(), trigger, dataBase, logger, sourceProvider, from, until, step)
+			So(err, ShouldBeNil)
+			So(result.Checks, ShouldEqual, 30)
+			So(result.State, ShouldEqual, moira.StateOK)
+
+			states := make([]moira.State, 0, len(result.Transitions))
+			for _, transition := range result.Transitions {
+				So(transition.Metric, ShouldEqual, "disk.web1")
+				states = append(states, transition.State)
+			}
+
+			So(states, ShouldResemble, []moira.State{moira.StateOK, moira.StateERROR, moira.StateOK})
+			So(result.Transitions[1].OldState, ShouldEqual, moira.StateOK)
+			So(result.Transitions[1].Timestamp, ShouldEqual, errorFrom)
+			So(result.Transitions[2].Timestamp, ShouldEqual, errorTill)
+
+			So(result.Events, ShouldHaveLength, 3)
+			So(result.Events[1].State, ShouldEqual, moira.StateERROR)
+			So(result.Events[1].Timestamp, ShouldEqual, errorFrom)
+			So(result.Events[1].TriggerID, ShouldEqual, trigger.ID)
+		})
+
+		Convey("With cancelled context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			result, err := Backtest(ctx, trigger, dataBase, logger, sourceProvider, from, until, step)
+			So(errors.Is(err, context.Canceled), ShouldBeTrue)
+			So(result, ShouldBeNil)
+		})
+
+		Convey("With invalid range", func() {
+			result, err := Backtest(context.Background(), trigger, dataBase, logger, sourceProvider, until, from, step)
+			So(err, ShouldNotBeNil)
+			So(result, ShouldBeNil)
+		})
+	})
+}
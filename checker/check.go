@@ -8,7 +8,9 @@ import (
 	"github.com/moira-alert/moira/expression"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/loki"
 	"github.com/moira-alert/moira/metric_source/remote"
+	"github.com/moira-alert/moira/metric_source/sqldb"
 )
 
 const (
@@ -137,7 +139,7 @@ func (triggerChecker *TriggerChecker) handleFetchError(checkData moira.CheckData
 				triggerChecker.trigger.ClusterKey(),
 			)
 		}
-	case remote.ErrRemoteUnavailable:
+	case remote.ErrRemoteUnavailable, loki.ErrLokiUnavailable, sqldb.ErrDatabaseUnavailable:
 		checkData.State = moira.StateEXCEPTION
 		checkData.Message = fmt.Sprintf("Remote server unavailable. Trigger is not checked since: %v", checkData.LastSuccessfulCheckTimestamp)
 
@@ -147,7 +149,7 @@ func (triggerChecker *TriggerChecker) handleFetchError(checkData moira.CheckData
 			String("trigger.source", triggerChecker.trigger.TriggerSource.String()).
 			Error(err).
 			Msg("Trigger check failed")
-	case local.ErrUnknownFunction, local.ErrEvalExpr, remote.ErrRemoteTriggerResponse:
+	case local.ErrUnknownFunction, local.ErrEvalExpr, remote.ErrRemoteTriggerResponse, loki.ErrInvalidQuery, sqldb.ErrInvalidQuery:
 		checkData.State = moira.StateEXCEPTION
 		checkData.Message = err.Error()
 		logTriggerCheckException(triggerChecker.logger, triggerChecker.triggerID, err)
@@ -487,6 +489,7 @@ func (triggerChecker *TriggerChecker) getMetricStepsStates(
 			triggerChecker.trigger.MuteNewMetrics,
 			checkPointGap,
 		)
+		lastMetricState = triggerChecker.applyMetricMaintenanceWindows(metricName, lastMetricState)
 
 		startTime = metric.StartTime
 		stepTime = metric.StepTime
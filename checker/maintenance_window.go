@@ -0,0 +1,118 @@
+package checker
+
+import (
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/filter"
+)
+
+// MaintenanceWindows are scheduled maintenance windows loaded once for many checks of triggers.
+type MaintenanceWindows struct {
+	windows []maintenanceWindow
+}
+
+type maintenanceWindow struct {
+	*moira.MaintenanceWindow
+	patternIndex *filter.PatternIndex
+}
+
+// maintenanceOccurrence is an active occurrence of maintenance window with compiled patterns of its metrics.
+type maintenanceOccurrence struct {
+	moira.MaintenanceOccurrence
+	patternIndex *filter.PatternIndex
+}
+
+// LoadMaintenanceWindows gets maintenance windows from database and compiles patterns of their metrics.
+func LoadMaintenanceWindows(dataBase moira.Database, logger moira.Logger) (*MaintenanceWindows, error) {
+	windows, err := dataBase.GetMaintenanceWindows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+
+	return NewMaintenanceWindows(logger, windows), nil
+}
+
+// NewMaintenanceWindows compiles patterns of metrics of maintenance windows.
+func NewMaintenanceWindows(logger moira.Logger, windows []*moira.MaintenanceWindow) *MaintenanceWindows {
+	maintenanceWindows := &MaintenanceWindows{
+		windows: make([]maintenanceWindow, 0, len(windows)),
+	}
+
+	for _, window := range windows {
+		compiled := maintenanceWindow{MaintenanceWindow: window}
+		if len(window.Patterns) != 0 {
+			compiled.patternIndex = filter.NewPatternIndex(logger, window.Patterns, filter.Compatibility{})
+		}
+
+		maintenanceWindows.windows = append(maintenanceWindows.windows, compiled)
+	}
+
+	return maintenanceWindows
+}
+
+// getActiveMaintenanceWindows returns occurrences of maintenance windows which are active at the given time.
+func (maintenanceWindows *MaintenanceWindows) getActiveMaintenanceWindows(timestamp int64) []maintenanceOccurrence {
+	if maintenanceWindows == nil {
+		return nil
+	}
+
+	var occurrences []maintenanceOccurrence
+
+	for _, window := range maintenanceWindows.windows {
+		if occurrence, ok := window.GetOccurrence(timestamp); ok {
+			occurrences = append(occurrences, maintenanceOccurrence{
+				MaintenanceOccurrence: occurrence,
+				patternIndex:          window.patternIndex,
+			})
+		}
+	}
+
+	return occurrences
+}
+
+// isMetricMatched returns true if the occurrence targets the metric by one of patterns of its window.
+func (occurrence *maintenanceOccurrence) isMetricMatched(metric string) bool {
+	return occurrence.patternIndex != nil && len(occurrence.patternIndex.MatchPatterns(metric)) != 0
+}
+
+// applyTriggerMaintenanceWindows extends maintenance of the trigger till the end of occurrences which target the whole trigger.
+func applyTriggerMaintenanceWindows(lastCheck *moira.CheckData, trigger *moira.Trigger, occurrences []maintenanceOccurrence) {
+	for _, occurrence := range occurrences {
+		if occurrence.Window.IsTriggerMatched(trigger) && isMaintenanceExtended(lastCheck, occurrence.MaintenanceOccurrence) {
+			lastCheck.SetMaintenance(newMaintenanceWindowInfo(occurrence.MaintenanceOccurrence), occurrence.End)
+		}
+	}
+}
+
+// applyMetricMaintenanceWindows extends maintenance of the metric till the end of occurrences which target the metric by patterns.
+func (triggerChecker *TriggerChecker) applyMetricMaintenanceWindows(metric string, metricState moira.MetricState) moira.MetricState {
+	for _, occurrence := range triggerChecker.maintenanceWindows {
+		if occurrence.isMetricMatched(metric) && isMaintenanceExtended(&metricState, occurrence.MaintenanceOccurrence) {
+			metricState.SetMaintenance(newMaintenanceWindowInfo(occurrence.MaintenanceOccurrence), occurrence.End)
+		}
+	}
+
+	return metricState
+}
+
+// isMaintenanceExtended returns true if the occurrence ends later than current maintenance.
+// Maintenance stopped by user after the occurrence has started is not extended again.
+func isMaintenanceExtended(state moira.MaintenanceCheck, occurrence moira.MaintenanceOccurrence) bool {
+	maintenanceInfo, maintenance := state.GetMaintenance()
+	if maintenanceInfo.StopTime != nil && *maintenanceInfo.StopTime >= occurrence.Start {
+		return false
+	}
+
+	return occurrence.End > maintenance
+}
+
+func newMaintenanceWindowInfo(occurrence moira.MaintenanceOccurrence) *moira.MaintenanceInfo {
+	startUser := occurrence.Window.CreatedBy
+	startTime := occurrence.Start
+
+	return &moira.MaintenanceInfo{
+		StartUser: &startUser,
+		StartTime: &startTime,
+	}
+}
@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetActiveMaintenanceWindows(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.GetLogger("Test")
+
+	Convey("Test getActiveMaintenanceWindows", t, func() {
+		Convey("Only windows active at the given time are returned", func() {
+			past := &moira.MaintenanceWindow{ID: "past", Start: 100, End: 200}
+			active := &moira.MaintenanceWindow{ID: "active", Start: 900, End: 1100}
+			future := &moira.MaintenanceWindow{ID: "future", Start: 2000, End: 3000}
+			dataBase.EXPECT().GetMaintenanceWindows().Return([]*moira.MaintenanceWindow{past, active, future}, nil)
+
+			maintenanceWindows, err := LoadMaintenanceWindows(dataBase, logger)
+			So(err, ShouldBeNil)
+
+			occurrences := maintenanceWindows.getActiveMaintenanceWindows(1000)
+			So(occurrences, ShouldHaveLength, 1)
+			So(occurrences[0].MaintenanceOccurrence, ShouldResemble, moira.MaintenanceOccurrence{Window: active, Start: 900, End: 1100})
+		})
+
+		Convey("Database error is returned", func() {
+			dataBase.EXPECT().GetMaintenanceWindows().Return(nil, errors.New("test error"))
+
+			_, err := LoadMaintenanceWindows(dataBase, logger)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Not loaded maintenance windows have no active occurrences", func() {
+			var maintenanceWindows *MaintenanceWindows
+			So(maintenanceWindows.getActiveMaintenanceWindows(1000), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestApplyMaintenanceWindows(t *testing.T) {
+	Convey("Test application of maintenance windows", t, func() {
+		window := &moira.MaintenanceWindow{
+			ID:        "backups",
+			Start:     1000,
+			End:       2000,
+			Tags:      []string{"postgres"},
+			Patterns:  []string{"db.*.lag"},
+			CreatedBy: "dba",
+		}
+		logger, _ := logging.GetLogger("Test")
+		occurrences := NewMaintenanceWindows(logger, []*moira.MaintenanceWindow{window}).getActiveMaintenanceWindows(1000)
+
+		startUser, startTime := "dba", int64(1000)
+		expectedInfo := moira.MaintenanceInfo{StartUser: &startUser, StartTime: &startTime}
+
+		Convey("Maintenance of targeted trigger is extended", func() {
+			lastCheck := &moira.CheckData{Maintenance: 1500}
+			applyTriggerMaintenanceWindows(lastCheck, &moira.Trigger{Tags: []string{"postgres"}}, occurrences)
+			So(lastCheck.Maintenance, ShouldEqual, 2000)
+			So(lastCheck.MaintenanceInfo, ShouldResemble, expectedInfo)
+		})
+
+		Convey("Maintenance of not targeted trigger is kept", func() {
+			lastCheck := &moira.CheckData{}
+			applyTriggerMaintenanceWindows(lastCheck, &moira.Trigger{Tags: []string{"mysql"}}, occurrences)
+			So(lastCheck, ShouldResemble, &moira.CheckData{})
+		})
+
+		Convey("Longer maintenance of trigger is kept", func() {
+			lastCheck := &moira.CheckData{Maintenance: 3000}
+			applyTriggerMaintenanceWindows(lastCheck, &moira.Trigger{Tags: []string{"postgres"}}, occurrences)
+			So(lastCheck, ShouldResemble, &moira.CheckData{Maintenance: 3000})
+		})
+
+		Convey("Maintenance stopped by user during occurrence is not extended again", func() {
+			stopTime := int64(1200)
+			lastCheck := &moira.CheckData{Maintenance: 1200, MaintenanceInfo: moira.MaintenanceInfo{StopTime: &stopTime}}
+			applyTriggerMaintenanceWindows(lastCheck, &moira.Trigger{Tags: []string{"postgres"}}, occurrences)
+			So(lastCheck.Maintenance, ShouldEqual, 1200)
+		})
+
+		Convey("Maintenance of metrics matching patterns is extended", func() {
+			triggerChecker := TriggerChecker{maintenanceWindows: occurrences}
+
+			metricState := triggerChecker.applyMetricMaintenanceWindows("db.pg1.lag", moira.MetricState{State: moira.StateOK})
+			So(metricState, ShouldResemble, moira.MetricState{State: moira.StateOK, Maintenance: 2000, MaintenanceInfo: expectedInfo})
+
+			metricState = triggerChecker.applyMetricMaintenanceWindows("db.pg1.cpu", moira.MetricState{State: moira.StateOK})
+			So(metricState, ShouldResemble, moira.MetricState{State: moira.StateOK})
+		})
+	})
+}
@@ -30,11 +30,14 @@ type TriggerChecker struct {
 
 	ttl      int64
 	ttlState moira.TTLState
+
+	maintenanceWindows []maintenanceOccurrence
 }
 
 // MakeTriggerChecker initialize new triggerChecker data.
 // If trigger does not exists then return ErrTriggerNotExists error.
 // If trigger metrics source does not configured then return ErrMetricSourceIsNotConfigured error.
+// Maintenance windows are loaded once for many checks, nil means that there are no maintenance windows.
 func MakeTriggerChecker(
 	triggerID string,
 	dataBase moira.Database,
@@ -42,6 +45,7 @@ func MakeTriggerChecker(
 	config *Config,
 	sourceProvider *metricSource.SourceProvider,
 	metrics *metrics.CheckerMetrics,
+	maintenanceWindows *MaintenanceWindows,
 ) (*TriggerChecker, error) {
 	until := time.Now().Unix()
 
@@ -64,6 +68,9 @@ func MakeTriggerChecker(
 		return nil, err
 	}
 
+	activeMaintenanceWindows := maintenanceWindows.getActiveMaintenanceWindows(until)
+	applyTriggerMaintenanceWindows(lastCheck, &trigger, activeMaintenanceWindows)
+
 	triggerLogger := logger.Clone().String(moira.LogFieldNameTriggerID, triggerID)
 	if logLevel, ok := config.LogTriggersToLevel[triggerID]; ok {
 		if _, err = triggerLogger.Level(logLevel); err != nil {
@@ -94,6 +101,8 @@ func MakeTriggerChecker(
 
 		ttl:      trigger.TTL,
 		ttlState: getTTLState(trigger.TTLState),
+
+		maintenanceWindows: activeMaintenanceWindows,
 	}
 
 	return triggerChecker, nil
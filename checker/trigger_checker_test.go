@@ -46,7 +46,7 @@ func TestInitTriggerChecker(t *testing.T) {
 				ClusterId:     moira.DefaultCluster,
 			}, getTriggerError)
 
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 			require.Error(t, err)
 			require.Equal(t, getTriggerError, err)
 		})
@@ -57,7 +57,7 @@ func TestInitTriggerChecker(t *testing.T) {
 				ClusterId:     moira.DefaultCluster,
 			}, database.ErrNil)
 
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 			require.Error(t, err)
 			require.Equal(t, ErrTriggerNotExists, err)
 		})
@@ -71,7 +71,7 @@ func TestInitTriggerChecker(t *testing.T) {
 				ClusterId:     moira.DefaultCluster,
 			}, nil)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, readLastCheckError)
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 			require.Error(t, err)
 			require.Equal(t, readLastCheckError, err)
 		})
@@ -134,7 +134,7 @@ func TestInitTriggerChecker(t *testing.T) {
 	t.Run("Test trigger checker with lastCheck", func(t *testing.T) {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 		require.NoError(t, err)
 
 		expectedLastCheck := lastCheck
@@ -159,7 +159,7 @@ func TestInitTriggerChecker(t *testing.T) {
 	t.Run("Test trigger checker without lastCheck", func(t *testing.T) {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 		require.NoError(t, err)
 
 		expected := TriggerChecker{
@@ -191,7 +191,7 @@ func TestInitTriggerChecker(t *testing.T) {
 	t.Run("Test trigger checker without lastCheck and ttl", func(t *testing.T) {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 		require.NoError(t, err)
 
 		expected := TriggerChecker{
@@ -219,7 +219,7 @@ func TestInitTriggerChecker(t *testing.T) {
 	t.Run("Test trigger checker with lastCheck and without ttl", func(t *testing.T) {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateTestMetricSourceProvider(localSource, nil, nil), checkerMetrics, nil)
 
 		require.NoError(t, err)
 
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira/checker"
+)
+
+const (
+	maintenanceWindowsWorkerTicker = time.Second * 10
+)
+
+func (manager *WorkerManager) startMaintenanceWindows() error {
+	manager.maintenanceWindows.Store(&checker.MaintenanceWindows{})
+
+	if err := manager.fillMaintenanceWindows(); err != nil {
+		manager.Logger.Error().
+			Error(err).
+			Msg("Failed to get maintenance windows")
+	}
+
+	manager.tomb.Go(manager.maintenanceWindowsWorker)
+
+	return nil
+}
+
+func (manager *WorkerManager) maintenanceWindowsWorker() error {
+	checkTicker := time.NewTicker(maintenanceWindowsWorkerTicker)
+	manager.Logger.Info().
+		Interface("update_maintenance_windows_every", maintenanceWindowsWorkerTicker).
+		Msg("Start maintenance windows worker")
+
+	for {
+		select {
+		case <-manager.tomb.Dying():
+			checkTicker.Stop()
+			manager.Logger.Info().Msg("Maintenance windows worker stopped")
+
+			return nil
+		case <-checkTicker.C:
+			if err := manager.fillMaintenanceWindows(); err != nil {
+				manager.Logger.Error().
+					Error(err).
+					Msg("Failed to get maintenance windows")
+			}
+		}
+	}
+}
+
+// fillMaintenanceWindows reloads maintenance windows, previously loaded windows are kept if it fails.
+func (manager *WorkerManager) fillMaintenanceWindows() error {
+	maintenanceWindows, err := checker.LoadMaintenanceWindows(manager.Database, manager.Logger)
+	if err != nil {
+		return err
+	}
+
+	manager.maintenanceWindows.Store(maintenanceWindows)
+
+	return nil
+}
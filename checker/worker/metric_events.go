@@ -66,6 +66,10 @@ func (manager *WorkerManager) newMetricsHandler(metricEventsChannel <-chan *moir
 			return nil
 		}
 
+		if manager.MetricValuesCache != nil {
+			manager.MetricValuesCache.Invalidate(metricEvent.Metric)
+		}
+
 		pattern := metricEvent.Pattern
 		if manager.needHandlePattern(pattern) {
 			if err := manager.handleMetricEvent(pattern); err != nil {
@@ -86,6 +86,7 @@ func (manager *WorkerManager) checkTrigger(triggerID string) error {
 		manager.Config,
 		manager.SourceProvider,
 		manager.Metrics,
+		manager.maintenanceWindows.Load().(*checker.MaintenanceWindows),
 	)
 
 	if errors.Is(err, checker.ErrTriggerNotExists) {
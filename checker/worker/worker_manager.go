@@ -9,6 +9,7 @@ import (
 	"github.com/moira-alert/moira/metrics"
 
 	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/local"
 	"github.com/patrickmn/go-cache"
 	"gopkg.in/tomb.v2"
 
@@ -28,9 +29,12 @@ type WorkerManager struct {
 	TriggerCache      *cache.Cache
 	LazyTriggersCache *cache.Cache
 	PatternCache      *cache.Cache
+	MetricValuesCache *local.ValuesCache
 	lazyTriggerIDs    atomic.Value
-	lastData          int64
-	tomb              tomb.Tomb
+	// maintenanceWindows are loaded once for all checks of triggers till the next reload.
+	maintenanceWindows atomic.Value
+	lastData           int64
+	tomb               tomb.Tomb
 }
 
 // StartWorkers start schedule new MetricEvents and check for NODATA triggers.
@@ -42,6 +46,11 @@ func (manager *WorkerManager) StartWorkers() error {
 		return err
 	}
 
+	err = manager.startMaintenanceWindows()
+	if err != nil {
+		return err
+	}
+
 	err = manager.startLocalMetricEvents()
 	if err != nil {
 		return err
@@ -19,3 +19,23 @@ func (t *SystemClock) NowUTC() time.Time {
 func (t *SystemClock) NowUnix() int64 {
 	return time.Now().Unix()
 }
+
+// FixedClock is clock-component which always returns the same time, e.g. time of check during backtesting of trigger.
+type FixedClock struct {
+	now time.Time
+}
+
+// NewFixedClock is construct for clock-component which always returns given time.
+func NewFixedClock(now time.Time) *FixedClock {
+	return &FixedClock{now: now}
+}
+
+// NowUTC returns the time of the clock with UTC location.
+func (t *FixedClock) NowUTC() time.Time {
+	return t.now.UTC()
+}
+
+// NowUnix returns the time of the clock in a Unix time format.
+func (t *FixedClock) NowUnix() int64 {
+	return t.now.Unix()
+}
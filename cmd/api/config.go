@@ -11,10 +11,12 @@ import (
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/cmd"
 	"github.com/moira-alert/moira/notifier/selfstate"
+	"github.com/moira-alert/moira/senders/preview"
 )
 
 type config struct {
 	Redis               cmd.RedisConfig               `yaml:"redis"`
+	Postgres            cmd.PostgresConfig            `yaml:"postgres"`
 	Logger              cmd.LoggerConfig              `yaml:"log"`
 	API                 apiConfig                     `yaml:"api"`
 	Web                 webConfig                     `yaml:"web"`
@@ -62,6 +64,16 @@ func (config *config) ClustersMetricTTL() map[moira.ClusterKey]time.Duration {
 		result[key] = to.Duration(remote.MetricsTTL)
 	}
 
+	for _, remote := range config.Remotes.Loki {
+		key := moira.MakeClusterKey(moira.LokiRemote, remote.ClusterId)
+		result[key] = to.Duration(remote.MetricsTTL)
+	}
+
+	for _, remote := range config.Remotes.SQL {
+		key := moira.MakeClusterKey(moira.SQLRemote, remote.ClusterId)
+		result[key] = to.Duration(remote.MetricsTTL)
+	}
+
 	return result
 }
 
@@ -72,8 +84,177 @@ type apiConfig struct {
 	EnableCORS bool `yaml:"enable_cors"`
 	// Authorization contains authorization configuration.
 	Authorization authorization `yaml:"authorization"`
+	// Authentication contains settings of the built-in authentication of users.
+	Authentication authenticationConfig `yaml:"authentication"`
 	// Limits contains limits applied to entities and so on.
 	Limits LimitsConfig `yaml:"limits"`
+	// IncidentSync contains settings of the incoming webhooks of PagerDuty and OpsGenie.
+	IncidentSync incidentSyncConfig `yaml:"incident_sync"`
+	// NotificationPreview contains settings of messages rendered by dry-run of subscriptions.
+	NotificationPreview notificationPreviewConfig `yaml:"notification_preview"`
+}
+
+// defaultNotificationPreviewDateTimeFormat is the default format of time of events in mails, the same as in notifier.
+const defaultNotificationPreviewDateTimeFormat = "15:04 02.01.2006"
+
+// notificationPreviewConfig represents the settings of messages rendered by dry-run of subscriptions.
+// Use the same values as front_uri, timezone, date_time_format and senders of notifier, so the messages are the same as sent ones.
+type notificationPreviewConfig struct {
+	// Web-UI uri prefix for trigger links in messages.
+	FrontURI string `yaml:"front_uri"`
+	// Timezone to use to convert time of events. Default is UTC.
+	Timezone string `yaml:"timezone"`
+	// Format of time of events in mails. Default is "15:04 02.01.2006".
+	DateTimeFormat string `yaml:"date_time_format"`
+	// Senders configuration section of notifier. Messages to contacts are rendered the way the senders of their contact types
+	// format them, credentials of senders are not used and may be omitted.
+	Senders []map[string]interface{} `yaml:"senders"`
+}
+
+func (conf notificationPreviewConfig) validate() error {
+	if _, err := time.LoadLocation(conf.Timezone); err != nil {
+		return fmt.Errorf("notification preview timezone error '%s': %w", conf.Timezone, err)
+	}
+
+	settings := conf.toNotificationPreview()
+	if _, err := preview.NewRenderer(settings.Senders, settings.FrontURI, settings.Location, settings.DateTimeFormat); err != nil {
+		return fmt.Errorf("notification preview senders error: %w", err)
+	}
+
+	return nil
+}
+
+func (conf notificationPreviewConfig) toNotificationPreview() api.NotificationPreviewConfig {
+	location, err := time.LoadLocation(conf.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	dateTimeFormat := conf.DateTimeFormat
+	if dateTimeFormat == "" {
+		dateTimeFormat = defaultNotificationPreviewDateTimeFormat
+	}
+
+	return api.NotificationPreviewConfig{
+		FrontURI:       conf.FrontURI,
+		Location:       location,
+		DateTimeFormat: dateTimeFormat,
+		Senders:        conf.Senders,
+	}
+}
+
+// incidentSyncConfig represents the settings of the incoming webhooks of incident management tools.
+type incidentSyncConfig struct {
+	// If true, acknowledgements and resolutions of incidents are mapped to the maintenance of trigger metrics.
+	Enabled bool `yaml:"enabled"`
+	// Token must be passed in the token query parameter of the webhook url, e.g. /api/incident/pagerduty?token=secret.
+	Token string `yaml:"token"`
+	// AckMaintenance is the duration of the maintenance set when the incident is acknowledged.
+	AckMaintenance time.Duration `yaml:"ack_maintenance"`
+}
+
+func (conf incidentSyncConfig) toIncidentSync() api.IncidentSyncConfig {
+	return api.IncidentSyncConfig{
+		Enabled:        conf.Enabled,
+		Token:          conf.Token,
+		AckMaintenance: conf.AckMaintenance,
+	}
+}
+
+// authenticationConfig represents the settings of the built-in authentication of users.
+type authenticationConfig struct {
+	// If true, login of the user is taken from x-webauth-user header set by authenticating proxy.
+	// Disable it if api is reachable not only through the proxy, otherwise anyone can pretend to be any user.
+	// It must be disabled if oidc is enabled, as users log in to api directly then.
+	TrustProxyHeader bool `yaml:"trust_proxy_header"`
+	// OIDC contains settings of the login with OpenID Connect provider.
+	OIDC oidcConfig `yaml:"oidc"`
+	// Session contains settings of the sessions of users logged in with OpenID Connect provider.
+	Session sessionConfig `yaml:"session"`
+	// APITokens contains settings of personal and team API tokens.
+	APITokens apiTokensConfig `yaml:"api_tokens"`
+}
+
+// oidcConfig represents the settings of the login with OpenID Connect authorization code flow.
+type oidcConfig struct {
+	// If true, users can log in with OpenID Connect provider at /api/auth/login.
+	Enabled bool `yaml:"enabled"`
+	// IssuerURL is used to discover the provider endpoints, e.g. https://keycloak.example.com/realms/company.
+	IssuerURL string `yaml:"issuer_url"`
+	// ClientID and ClientSecret are the credentials of Moira registered in the provider.
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	// RedirectURL is the public url of /api/auth/callback endpoint, e.g. https://moira.example.com/api/auth/callback.
+	RedirectURL string `yaml:"redirect_url"`
+	// Scopes are requested in addition to openid scope, e.g. profile and groups.
+	Scopes []string `yaml:"scopes"`
+	// UsernameClaim is the user info claim used as login of the user.
+	UsernameClaim string `yaml:"username_claim"`
+	// GroupsClaim is the user info claim with groups of the user, see admin_groups of authorization.
+	GroupsClaim string `yaml:"groups_claim"`
+}
+
+// sessionConfig represents the settings of the sessions of users logged in with OpenID Connect provider.
+type sessionConfig struct {
+	// TTL is the time after which user has to log in again.
+	TTL time.Duration `yaml:"ttl"`
+	// CookieName is the name of cookie with session secret.
+	CookieName string `yaml:"cookie_name"`
+	// If true, session cookie is sent only over https.
+	SecureCookie bool `yaml:"secure_cookie"`
+}
+
+// apiTokensConfig represents the settings of personal and team API tokens.
+type apiTokensConfig struct {
+	// If true, users can issue API tokens and use them in Authorization: Bearer header.
+	Enabled bool `yaml:"enabled"`
+	// MaxTTL limits lifetime of issued tokens, tokens without expiration can be issued if it is zero.
+	MaxTTL time.Duration `yaml:"max_ttl"`
+}
+
+func (conf authenticationConfig) toAuthentication() api.AuthenticationConfig {
+	return api.AuthenticationConfig{
+		TrustProxyHeader: conf.TrustProxyHeader,
+		OIDC: api.OIDCConfig{
+			Enabled:       conf.OIDC.Enabled,
+			IssuerURL:     conf.OIDC.IssuerURL,
+			ClientID:      conf.OIDC.ClientID,
+			ClientSecret:  conf.OIDC.ClientSecret,
+			RedirectURL:   conf.OIDC.RedirectURL,
+			Scopes:        conf.OIDC.Scopes,
+			UsernameClaim: conf.OIDC.UsernameClaim,
+			GroupsClaim:   conf.OIDC.GroupsClaim,
+		},
+		Session: api.SessionConfig{
+			TTL:          conf.Session.TTL,
+			CookieName:   conf.Session.CookieName,
+			SecureCookie: conf.Session.SecureCookie,
+		},
+		APITokens: api.APITokensConfig{
+			Enabled: conf.APITokens.Enabled,
+			MaxTTL:  conf.APITokens.MaxTTL,
+		},
+	}
+}
+
+func (conf *authenticationConfig) validate() error {
+	if !conf.OIDC.Enabled {
+		return nil
+	}
+
+	if conf.TrustProxyHeader {
+		return fmt.Errorf("trust_proxy_header must be disabled if oidc is enabled")
+	}
+
+	if conf.OIDC.IssuerURL == "" || conf.OIDC.ClientID == "" || conf.OIDC.RedirectURL == "" {
+		return fmt.Errorf("issuer_url, client_id and redirect_url of oidc must be set")
+	}
+
+	if conf.Session.TTL <= 0 {
+		return fmt.Errorf("session ttl must be positive")
+	}
+
+	return nil
 }
 
 // LimitsConfig contains configurable moira limits.
@@ -138,6 +319,8 @@ type authorization struct {
 	Enabled bool `yaml:"enabled"`
 	// List of logins of users who are considered to be admins.
 	AdminList []string `yaml:"admin_list"`
+	// List of groups of OpenID Connect provider, members of which are considered to be admins.
+	AdminGroups []string `yaml:"admin_groups"`
 	// List for control trigger deletion and editing, if createdBy fit in this list: only who create trigger can delete it.
 	LimitedChangeTriggerOwners []string `yaml:"limited_change_trigger_owners"`
 }
@@ -197,12 +380,15 @@ func (config *apiConfig) getSettings(
 	webConfig *webConfig,
 ) *api.Config {
 	return &api.Config{
-		EnableCORS:    config.EnableCORS,
-		Listen:        config.Listen,
-		MetricsTTL:    metricsTTL,
-		Flags:         flags,
-		Authorization: config.Authorization.toApiConfig(webConfig),
-		Limits:        config.Limits.ToLimits(),
+		EnableCORS:     config.EnableCORS,
+		Listen:         config.Listen,
+		MetricsTTL:     metricsTTL,
+		Flags:          flags,
+		Authorization:  config.Authorization.toApiConfig(webConfig),
+		Authentication: config.Authentication.toAuthentication(),
+		Limits:         config.Limits.ToLimits(),
+		IncidentSync:   config.IncidentSync.toIncidentSync(),
+		Preview:        config.NotificationPreview.toNotificationPreview(),
 	}
 }
 
@@ -218,6 +404,11 @@ func (auth *authorization) toApiConfig(webConfig *webConfig) api.Authorization {
 		allowedContactTypes[contactTemplate.ContactType] = struct{}{}
 	}
 
+	adminGroups := make(map[string]struct{}, len(auth.AdminGroups))
+	for _, group := range auth.AdminGroups {
+		adminGroups[group] = struct{}{}
+	}
+
 	canChangeTriggersList := make(map[string]struct{}, len(auth.LimitedChangeTriggerOwners))
 	for _, login := range auth.LimitedChangeTriggerOwners {
 		canChangeTriggersList[login] = struct{}{}
@@ -226,6 +417,7 @@ func (auth *authorization) toApiConfig(webConfig *webConfig) api.Authorization {
 	return api.Authorization{
 		Enabled:                    auth.Enabled,
 		AdminList:                  adminList,
+		AdminGroups:                adminGroups,
 		AllowedContactTypes:        allowedContactTypes,
 		LimitedChangeTriggerOwners: canChangeTriggersList,
 	}
@@ -322,6 +514,21 @@ func getDefault() config {
 					TestNotificationWaitTime: 10 * time.Second,
 				},
 			},
+			IncidentSync: incidentSyncConfig{
+				AckMaintenance: api.DefaultIncidentAckMaintenance,
+			},
+			Authentication: authenticationConfig{
+				TrustProxyHeader: true,
+				OIDC: oidcConfig{
+					UsernameClaim: api.DefaultOIDCUsernameClaim,
+					GroupsClaim:   api.DefaultOIDCGroupsClaim,
+				},
+				Session: sessionConfig{
+					TTL:          api.DefaultSessionTTL,
+					CookieName:   api.DefaultSessionCookieName,
+					SecureCookie: true,
+				},
+			},
 		},
 		Web: webConfig{
 			RemoteAllowed: false,
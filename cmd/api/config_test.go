@@ -38,12 +38,17 @@ func Test_apiConfig_getSettings(t *testing.T) {
 			MetricsTTL: metricTTLs,
 			Flags:      api.FeatureFlags{IsReadonlyEnabled: true},
 			Authorization: api.Authorization{
-				AdminList: make(map[string]struct{}),
+				AdminList:   make(map[string]struct{}),
+				AdminGroups: make(map[string]struct{}),
 				AllowedContactTypes: map[string]struct{}{
 					"test": {},
 				},
 				LimitedChangeTriggerOwners: make(map[string]struct{}),
 			},
+			Preview: api.NotificationPreviewConfig{
+				Location:       time.UTC,
+				DateTimeFormat: "15:04 02.01.2006",
+			},
 		}
 
 		result := apiConf.getSettings(metricTTLs, api.FeatureFlags{IsReadonlyEnabled: true}, webConfig)
@@ -110,6 +115,21 @@ func Test_webConfig_getDefault(t *testing.T) {
 						TestNotificationWaitTime: 10 * time.Second,
 					},
 				},
+				IncidentSync: incidentSyncConfig{
+					AckMaintenance: api.DefaultIncidentAckMaintenance,
+				},
+				Authentication: authenticationConfig{
+					TrustProxyHeader: true,
+					OIDC: oidcConfig{
+						UsernameClaim: api.DefaultOIDCUsernameClaim,
+						GroupsClaim:   api.DefaultOIDCGroupsClaim,
+					},
+					Session: sessionConfig{
+						TTL:          api.DefaultSessionTTL,
+						CookieName:   api.DefaultSessionCookieName,
+						SecureCookie: true,
+					},
+				},
 			},
 			Web: webConfig{
 				RemoteAllowed: false,
@@ -318,3 +338,33 @@ func Test_webConfig_validate(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func Test_authenticationConfig_validate(t *testing.T) {
+	oidc := oidcConfig{
+		Enabled:     true,
+		IssuerURL:   "https://keycloak.example.com/realms/company",
+		ClientID:    "moira",
+		RedirectURL: "https://moira.example.com/api/auth/callback",
+	}
+
+	Convey("With proxy header authentication only", t, func() {
+		config := authenticationConfig{TrustProxyHeader: true}
+
+		err := config.validate()
+		So(err, ShouldBeNil)
+	})
+
+	Convey("With oidc and proxy header authentication", t, func() {
+		config := authenticationConfig{TrustProxyHeader: true, OIDC: oidc, Session: sessionConfig{TTL: time.Hour}}
+
+		err := config.validate()
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("With oidc only", t, func() {
+		config := authenticationConfig{OIDC: oidc, Session: sessionConfig{TTL: time.Hour}}
+
+		err := config.validate()
+		So(err, ShouldBeNil)
+	})
+}
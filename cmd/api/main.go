@@ -65,6 +65,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = applicationConfig.API.Authentication.validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Can not configure authentication: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err = applicationConfig.API.NotificationPreview.validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Can not configure notification preview: %s\n", err.Error())
+		os.Exit(1)
+	}
+
 	apiConfig := applicationConfig.API.getSettings(
 		applicationConfig.ClustersMetricTTL(),
 		applicationConfig.Web.getFeatureFlags(),
@@ -93,7 +103,14 @@ func main() {
 
 	databaseSettings := applicationConfig.Redis.GetSettings()
 	notificationHistorySettings := applicationConfig.NotificationHistory.GetSettings()
-	database := redis.NewDatabase(logger, databaseSettings, notificationHistorySettings, redis.NotificationConfig{}, redis.API, clusterList)
+	redisDatabase := redis.NewDatabase(logger, databaseSettings, notificationHistorySettings, redis.NotificationConfig{}, redis.API, clusterList)
+
+	database, err := cmd.NewDatabase(logger, redisDatabase, applicationConfig.Postgres, notificationHistorySettings, redis.API)
+	if err != nil {
+		logger.Fatal().
+			Error(err).
+			Msg("Can not configure database")
+	}
 
 	// Start Index right before HTTP listener. Fail if index cannot start
 	searchIndex := index.NewSearchIndex(logger, database, telemetry.Metrics, telemetry.AttributedMetrics)
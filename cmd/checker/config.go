@@ -7,11 +7,13 @@ import (
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/checker"
 	"github.com/moira-alert/moira/cmd"
+	"github.com/moira-alert/moira/metric_source/local"
 	"github.com/xiam/to"
 )
 
 type config struct {
 	Redis     cmd.RedisConfig     `yaml:"redis"`
+	Postgres  cmd.PostgresConfig  `yaml:"postgres"`
 	Logger    cmd.LoggerConfig    `yaml:"log"`
 	Checker   checkerConfig       `yaml:"checker"`
 	Telemetry cmd.TelemetryConfig `yaml:"telemetry"`
@@ -49,6 +51,25 @@ type checkerConfig struct {
 	MetricEventPopDelay string `yaml:"metric_event_pop_delay"`
 	// Duration of check that is considered critical and must be logged
 	CriticalTimeOfCheck string `yaml:"critical_time_of_check"`
+	// Cache of metric values read from local source, disabled by default.
+	// Only for a single checker instance: metric events are popped by one instance, so only its cache is invalidated
+	// and the others return stale values until the entries expire.
+	MetricValuesCache metricValuesCacheConfig `yaml:"metric_values_cache"`
+}
+
+type metricValuesCacheConfig struct {
+	// Max number of cached (metric, from, until, retention) entries, 0 disables cache
+	Size int `yaml:"size"`
+	// Lifetime of cached entry
+	TTL string `yaml:"ttl"`
+}
+
+// getSettings returns values cache settings, enabled flag is false if cache is disabled.
+func (config *metricValuesCacheConfig) getSettings() (local.ValuesCacheConfig, bool) {
+	return local.ValuesCacheConfig{
+		Size: config.Size,
+		TTL:  to.Duration(config.TTL),
+	}, config.Size > 0
 }
 
 func handleParallelChecks(parallelChecks *int) bool {
@@ -118,6 +139,38 @@ func (config *config) getSettings(logger moira.Logger) *checker.Config {
 		sourceCheckConfigs[moira.MakeClusterKey(moira.PrometheusRemote, remote.ClusterId)] = checkConfig
 	}
 
+	for _, remote := range config.Remotes.Loki {
+		checkConfig := checker.SourceCheckConfig{
+			CheckInterval:     to.Duration(remote.CheckInterval),
+			MaxParallelChecks: remote.MaxParallelChecks,
+		}
+		if handleParallelChecks(&checkConfig.MaxParallelChecks) {
+			logger.Info().
+				Int("number_of_cpu", checkConfig.MaxParallelChecks).
+				String("trigger_source", moira.LokiRemote.String()).
+				String("cluster_id", remote.ClusterId.String()).
+				Msg("MaxParallelChecks is not configured, set it to the number of CPU")
+		}
+
+		sourceCheckConfigs[moira.MakeClusterKey(moira.LokiRemote, remote.ClusterId)] = checkConfig
+	}
+
+	for _, remote := range config.Remotes.SQL {
+		checkConfig := checker.SourceCheckConfig{
+			CheckInterval:     to.Duration(remote.CheckInterval),
+			MaxParallelChecks: remote.MaxParallelChecks,
+		}
+		if handleParallelChecks(&checkConfig.MaxParallelChecks) {
+			logger.Info().
+				Int("number_of_cpu", checkConfig.MaxParallelChecks).
+				String("trigger_source", moira.SQLRemote.String()).
+				String("cluster_id", remote.ClusterId.String()).
+				Msg("MaxParallelChecks is not configured, set it to the number of CPU")
+		}
+
+		sourceCheckConfigs[moira.MakeClusterKey(moira.SQLRemote, remote.ClusterId)] = checkConfig
+	}
+
 	return &checker.Config{
 		SourceCheckConfigs:              sourceCheckConfigs,
 		LazyTriggersCheckInterval:       to.Duration(config.Checker.LazyTriggersCheckInterval),
@@ -143,6 +196,10 @@ func getDefault() config {
 			StopCheckingInterval:            "30s",
 			CriticalTimeOfCheck:             "1h",
 			MetricEventTriggerCheckInterval: "30s",
+			MetricValuesCache: metricValuesCacheConfig{
+				Size: 0,
+				TTL:  "10s",
+			},
 		},
 		Telemetry: cmd.TelemetryConfig{
 			Listen: ":8092",
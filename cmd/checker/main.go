@@ -10,6 +10,7 @@ import (
 
 	"github.com/moira-alert/moira/checker/worker"
 	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/local"
 	"github.com/patrickmn/go-cache"
 
 	"github.com/moira-alert/moira"
@@ -83,7 +84,14 @@ func main() {
 	clusterList := cmd.MakeClusterList(config.Remotes)
 
 	databaseSettings := config.Redis.GetSettings()
-	database := redis.NewDatabase(logger, databaseSettings, redis.NotificationHistoryConfig{}, redis.NotificationConfig{}, redis.Checker, clusterList)
+	redisDatabase := redis.NewDatabase(logger, databaseSettings, redis.NotificationHistoryConfig{}, redis.NotificationConfig{}, redis.Checker, clusterList)
+
+	database, err := cmd.NewDatabase(logger, redisDatabase, config.Postgres, redis.NotificationHistoryConfig{}, redis.Checker)
+	if err != nil {
+		logger.Fatal().
+			Error(err).
+			Msg("Can not configure database")
+	}
 
 	metricSourceProvider, err := cmd.InitMetricSources(config.Remotes, database, logger)
 	if err != nil {
@@ -101,6 +109,12 @@ func main() {
 
 	checkerSettings := config.getSettings(logger)
 
+	var metricValuesCache *local.ValuesCache
+	if valuesCacheConfig, enabled := config.Checker.MetricValuesCache.getSettings(); enabled {
+		metricValuesCache = local.NewValuesCache(valuesCacheConfig, checkerMetrics)
+		metricSourceProvider.RegisterSource(moira.DefaultLocalCluster, local.CreateWithValuesCache(database, metricValuesCache))
+	}
+
 	if triggerID != nil && *triggerID != "" {
 		checkSingleTrigger(database, checkerMetrics, checkerSettings, metricSourceProvider)
 	}
@@ -115,6 +129,7 @@ func main() {
 		TriggerCache:      cache.New(cacheExpiration, time.Minute*60), //nolint
 		LazyTriggersCache: cache.New(time.Minute*10, time.Minute*60),  //nolint
 		PatternCache:      cache.New(cacheExpiration, time.Minute*60), //nolint
+		MetricValuesCache: metricValuesCache,
 	}
 
 	err = checkerWorkerManager.StartWorkers()
@@ -140,7 +155,15 @@ func main() {
 }
 
 func checkSingleTrigger(database moira.Database, metrics *metrics.CheckerMetrics, settings *checker.Config, sourceProvider *metricSource.SourceProvider) {
-	triggerChecker, err := checker.MakeTriggerChecker(*triggerID, database, logger, settings, sourceProvider, metrics)
+	maintenanceWindows, err := checker.LoadMaintenanceWindows(database, logger)
+	if err != nil {
+		logger.Error().
+			Error(err).
+			Msg("Failed to get maintenance windows")
+		os.Exit(1)
+	}
+
+	triggerChecker, err := checker.MakeTriggerChecker(*triggerID, database, logger, settings, sourceProvider, metrics, maintenanceWindows)
 	logger.String(moira.LogFieldNameTriggerID, *triggerID)
 
 	if err != nil {
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/checker"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// getBacktestTrigger returns the trigger from the file with trigger JSON from api method response if it is given,
+// otherwise the saved trigger with given ID.
+func getBacktestTrigger(database moira.Database, triggerID string, triggerFile io.Reader) (*moira.Trigger, error) {
+	if triggerFile != nil {
+		trigger := &dto.Trigger{}
+		if err := json.NewDecoder(triggerFile).Decode(trigger); err != nil {
+			return nil, fmt.Errorf("can't decode trigger: %w", err)
+		}
+
+		return trigger.ToMoiraTrigger(), nil
+	}
+
+	trigger, err := database.GetTrigger(triggerID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get trigger with id %s: %w", triggerID, err)
+	}
+
+	return &trigger, nil
+}
+
+func handleBacktestTrigger(
+	logger moira.Logger,
+	database moira.Database,
+	sourceProvider *metricSource.SourceProvider,
+	trigger *moira.Trigger,
+	from, until, step int64,
+	output io.Writer,
+) error {
+	result, err := checker.Backtest(context.Background(), trigger, database, logger, sourceProvider, from, until, step)
+	if err != nil {
+		return fmt.Errorf("can't backtest trigger: %w", err)
+	}
+
+	return writeJSON(output, result)
+}
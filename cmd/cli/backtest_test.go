@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/checker"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+	mocks "github.com/moira-alert/moira/mock/moira-alert"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_getBacktestTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := mocks.NewMockDatabase(mockCtrl)
+
+	Convey("Get saved trigger", t, func() {
+		db.EXPECT().GetTrigger("trigger1").Return(moira.Trigger{ID: "trigger1"}, nil)
+
+		trigger, err := getBacktestTrigger(db, "trigger1", nil)
+		So(err, ShouldBeNil)
+		So(trigger.ID, ShouldEqual, "trigger1")
+	})
+
+	Convey("Get missing saved trigger", t, func() {
+		dbErr := errors.New("trigger not found")
+		db.EXPECT().GetTrigger("trigger1").Return(moira.Trigger{}, dbErr)
+
+		trigger, err := getBacktestTrigger(db, "trigger1", nil)
+		So(errors.Is(err, dbErr), ShouldBeTrue)
+		So(trigger, ShouldBeNil)
+	})
+
+	Convey("Get trigger from file", t, func() {
+		triggerFile := strings.NewReader(`{"id": "trigger2", "name": "Disk usage", "targets": ["disk.*"], "error_value": 90, "trigger_type": "rising"}`)
+
+		trigger, err := getBacktestTrigger(db, "", triggerFile)
+		So(err, ShouldBeNil)
+		So(trigger.ID, ShouldEqual, "trigger2")
+		So(trigger.Targets, ShouldResemble, []string{"disk.*"})
+		So(*trigger.ErrorValue, ShouldEqual, 90)
+	})
+
+	Convey("Get trigger from invalid file", t, func() {
+		trigger, err := getBacktestTrigger(db, "", strings.NewReader("{"))
+		So(err, ShouldNotBeNil)
+		So(trigger, ShouldBeNil)
+	})
+}
+
+func Test_handleBacktestTrigger(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := mocks.NewMockDatabase(mockCtrl)
+	source := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+	sourceProvider := metricSource.CreateTestMetricSourceProvider(source, nil, nil)
+
+	until := time.Now().Unix()
+	from := until - 300
+	errorValue := 90.0
+	trigger := &moira.Trigger{
+		ID:            "trigger1",
+		Targets:       []string{"disk.*"},
+		ErrorValue:    &errorValue,
+		TriggerType:   moira.RisingTrigger,
+		TriggerSource: moira.GraphiteLocal,
+		ClusterId:     moira.DefaultCluster,
+	}
+
+	Convey("Backtest trigger prints result in JSON", t, func() {
+		source.EXPECT().Fetch("disk.*", gomock.Any(), gomock.Any(), true).Return(fetchResult, nil).Times(5)
+		fetchResult.EXPECT().GetMetricsData().Return([]metricSource.MetricData{
+			*metricSource.MakeMetricData("disk.web1", []float64{100}, 60, from),
+		}).Times(5)
+		fetchResult.EXPECT().GetPatternMetrics().Return([]string{}, nil).Times(5)
+
+		output := &bytes.Buffer{}
+		err := handleBacktestTrigger(logger, db, sourceProvider, trigger, from, until, 60, output)
+		So(err, ShouldBeNil)
+
+		result := checker.BacktestResult{}
+		So(json.Unmarshal(output.Bytes(), &result), ShouldBeNil)
+		So(result.Checks, ShouldEqual, 5)
+		So(result.State, ShouldEqual, moira.StateOK)
+		So(result.Events, ShouldHaveLength, 1)
+		So(result.Events[0].State, ShouldEqual, moira.StateERROR)
+	})
+
+	Convey("Backtest trigger with invalid range", t, func() {
+		output := &bytes.Buffer{}
+		err := handleBacktestTrigger(logger, db, sourceProvider, trigger, until, from, 60, output)
+		So(err, ShouldNotBeNil)
+		So(output.Len(), ShouldEqual, 0)
+	})
+}
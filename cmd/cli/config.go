@@ -10,6 +10,8 @@ type config struct {
 	LogPrettyFormat bool            `yaml:"log_pretty_format"`
 	Redis           cmd.RedisConfig `yaml:"redis"`
 	Cleanup         cleanupConfig   `yaml:"cleanup"`
+	// Remotes are used by backtest of triggers with remote sources.
+	Remotes cmd.RemotesConfig `yaml:",inline"`
 }
 
 type cleanupConfig struct {
@@ -33,5 +35,6 @@ func getDefault() config {
 			CleanupFutureMetricsDuration:       "60m",
 			CleanupNotificationHistoryDuration: "48h",
 		},
+		Remotes: cmd.RemotesConfig{},
 	}
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+func handleListDeadLetterNotifications(database moira.Database, output io.Writer) error {
+	notifications, _, err := database.GetDeadLetterNotifications(0, -1)
+	if err != nil {
+		return fmt.Errorf("can't get dead letter notifications: %w", err)
+	}
+
+	return writeJSON(output, notifications)
+}
+
+func handleInspectDeadLetterNotification(database moira.Database, id string, output io.Writer) error {
+	notification, err := database.GetDeadLetterNotification(id)
+	if err != nil {
+		return fmt.Errorf("can't get dead letter notification with id %s: %w", id, err)
+	}
+
+	return writeJSON(output, notification)
+}
+
+func handleReplayDeadLetterNotification(logger moira.Logger, database moira.Database, id, contactID string) error {
+	deadLetter, err := database.GetDeadLetterNotification(id)
+	if err != nil {
+		return fmt.Errorf("can't get dead letter notification with id %s: %w", id, err)
+	}
+
+	notification := deadLetter.ToScheduledNotification(time.Now().Unix())
+
+	if contactID != "" {
+		contact, err := database.GetContact(contactID)
+		if err != nil {
+			return fmt.Errorf("can't get contact with id %s: %w", contactID, err)
+		}
+
+		notification.Contact = contact
+	}
+
+	if err := database.AddNotification(notification); err != nil {
+		return fmt.Errorf("can't schedule notification: %w", err)
+	}
+
+	if _, err := database.RemoveDeadLetterNotification(id); err != nil {
+		return fmt.Errorf("can't remove dead letter notification with id %s: %w", id, err)
+	}
+
+	logger.Info().
+		String("dead_letter_id", id).
+		String(moira.LogFieldNameContactID, notification.Contact.ID).
+		Msg("Dead letter notification scheduled")
+
+	return nil
+}
+
+func handlePurgeDeadLetterNotifications(database moira.Database) error {
+	return database.RemoveAllDeadLetterNotifications()
+}
+
+func writeJSON(output io.Writer, value any) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
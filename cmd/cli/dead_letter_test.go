@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mocks "github.com/moira-alert/moira/mock/moira-alert"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_handleReplayDeadLetterNotification(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := mocks.NewMockDatabase(mockCtrl)
+
+	deadLetter := moira.DeadLetterNotification{
+		ID: "dead-letter-1",
+		Notification: moira.ScheduledNotification{
+			Contact:  moira.ContactData{ID: "contact-1"},
+			SendFail: 5,
+		},
+	}
+
+	Convey("Replay to the original contact", t, func() {
+		db.EXPECT().GetDeadLetterNotification(deadLetter.ID).Return(deadLetter, nil)
+		db.EXPECT().AddNotification(gomock.Any()).DoAndReturn(func(notification *moira.ScheduledNotification) error {
+			So(notification.Contact.ID, ShouldEqual, "contact-1")
+			So(notification.SendFail, ShouldEqual, 0)
+
+			return nil
+		})
+		db.EXPECT().RemoveDeadLetterNotification(deadLetter.ID).Return(int64(1), nil)
+
+		err := handleReplayDeadLetterNotification(logger, db, deadLetter.ID, "")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Replay to another contact", t, func() {
+		db.EXPECT().GetDeadLetterNotification(deadLetter.ID).Return(deadLetter, nil)
+		db.EXPECT().GetContact("contact-2").Return(moira.ContactData{ID: "contact-2"}, nil)
+		db.EXPECT().AddNotification(gomock.Any()).DoAndReturn(func(notification *moira.ScheduledNotification) error {
+			So(notification.Contact.ID, ShouldEqual, "contact-2")
+
+			return nil
+		})
+		db.EXPECT().RemoveDeadLetterNotification(deadLetter.ID).Return(int64(1), nil)
+
+		err := handleReplayDeadLetterNotification(logger, db, deadLetter.ID, "contact-2")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Dead letter notification is not kept on schedule error", t, func() {
+		db.EXPECT().GetDeadLetterNotification(deadLetter.ID).Return(deadLetter, nil)
+		db.EXPECT().AddNotification(gomock.Any()).Return(errors.New("oops"))
+
+		err := handleReplayDeadLetterNotification(logger, db, deadLetter.ID, "")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "can't schedule notification: oops")
+	})
+}
+
+func Test_handleListDeadLetterNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := mocks.NewMockDatabase(mockCtrl)
+
+	Convey("List dead letter notifications", t, func() {
+		notifications := []*moira.DeadLetterNotification{{ID: "dead-letter-1", SenderType: "slack"}}
+		db.EXPECT().GetDeadLetterNotifications(int64(0), int64(-1)).Return(notifications, int64(1), nil)
+
+		output := &bytes.Buffer{}
+		err := handleListDeadLetterNotifications(db, output)
+		So(err, ShouldBeNil)
+		So(output.String(), ShouldContainSubstring, `"id": "dead-letter-1"`)
+		So(output.String(), ShouldContainSubstring, `"sender_type": "slack"`)
+	})
+}
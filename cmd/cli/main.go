@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api/dto"
@@ -85,8 +86,24 @@ var (
 	removeUnusedTriggersWithTTL   = flag.String("remove-unused-triggers-with-ttl", "", "Remove unused triggers which have no subscription and no modify more that duration")
 )
 
+var (
+	listDeadLetters         = flag.Bool("dead-letter-list", false, "Print all notifications from dead letter queue in JSON")
+	inspectDeadLetter       = flag.String("dead-letter-inspect", "", "Print notification from dead letter queue with given ID in JSON")
+	replayDeadLetter        = flag.String("dead-letter-replay", "", "Schedule notification from dead letter queue with given ID to be sent again")
+	replayDeadLetterContact = flag.String("dead-letter-replay-contact", "", "Contact ID to replay dead letter notification to instead of the original one")
+	purgeDeadLetters        = flag.Bool("dead-letter-purge", false, "Remove all notifications from dead letter queue")
+)
+
+var (
+	backtestTrigger     = flag.String("backtest-trigger", "", "Run saved trigger with given ID against historical data and print result in JSON, nothing is saved and sent")
+	backtestTriggerFile = flag.String("backtest-trigger-file", "", "File that holds trigger JSON from api method response to run against historical data instead of saved trigger")
+	backtestPeriod      = flag.String("backtest-period", "24h", "Period of historical data till now to run trigger against")
+	backtestStep        = flag.String("backtest-step", "1m", "Interval between checks of trigger during backtest")
+)
+
 func main() { //nolint
-	confCleanup, logger, database := initApp()
+	conf, logger, database := initApp()
+	confCleanup := conf.Cleanup
 
 	if *update {
 		fromVersion := checkValidVersion(logger, updateFromVersion, true)
@@ -468,6 +485,88 @@ func main() { //nolint
 			Msg("Deletion of subscriptions finished")
 	}
 
+	if *listDeadLetters {
+		if err := handleListDeadLetterNotifications(database, os.Stdout); err != nil {
+			logger.Error().
+				Error(err).
+				Msg("Failed to list dead letter notifications")
+		}
+	}
+
+	if *inspectDeadLetter != "" {
+		if err := handleInspectDeadLetterNotification(database, *inspectDeadLetter, os.Stdout); err != nil {
+			logger.Error().
+				Error(err).
+				Msg("Failed to inspect dead letter notification")
+		}
+	}
+
+	if *replayDeadLetter != "" {
+		log := logger.String(moira.LogFieldNameContext, "dead-letter-replay")
+		if err := handleReplayDeadLetterNotification(log, database, *replayDeadLetter, *replayDeadLetterContact); err != nil {
+			log.Error().
+				Error(err).
+				Msg("Failed to replay dead letter notification")
+		}
+	}
+
+	if *purgeDeadLetters {
+		log := logger.String(moira.LogFieldNameContext, "dead-letter-purge")
+		log.Info().Msg("Purging dead letter queue started")
+
+		if err := handlePurgeDeadLetterNotifications(database); err != nil {
+			log.Error().
+				Error(err).
+				Msg("Failed to purge dead letter queue")
+		}
+
+		log.Info().Msg("Purging dead letter queue finished")
+	}
+
+	if *backtestTrigger != "" || *backtestTriggerFile != "" {
+		log := logger.String(moira.LogFieldNameContext, "backtest-trigger")
+
+		var triggerFile *os.File
+
+		if *backtestTriggerFile != "" {
+			f, err := openFile(*backtestTriggerFile, os.O_RDONLY)
+			if err != nil {
+				log.Fatal().
+					Error(err).
+					Msg("Failed to open backtest trigger file")
+			}
+
+			defer closeFile(f, logger)
+
+			triggerFile = f
+		}
+
+		trigger, err := getBacktestTrigger(database, *backtestTrigger, triggerFile)
+		if err != nil {
+			log.Fatal().
+				Error(err).
+				Msg("Failed to get trigger to backtest")
+		}
+
+		sourceProvider, err := cmd.InitMetricSources(conf.Remotes, database, logger)
+		if err != nil {
+			log.Fatal().
+				Error(err).
+				Msg("Failed to initialize metric sources")
+		}
+
+		until := time.Now().Unix()
+		from := until - int64(to.Duration(*backtestPeriod).Seconds())
+		from -= from % 60 //nolint
+		step := int64(to.Duration(*backtestStep).Seconds())
+
+		if err := handleBacktestTrigger(log, database, sourceProvider, trigger, from, until, step, os.Stdout); err != nil {
+			log.Error().
+				Error(err).
+				Msg("Failed to backtest trigger")
+		}
+	}
+
 	if *cleanupNotificationHistory {
 		logger.Info().
 			Msg("Start cleaning up of notification history")
@@ -497,7 +596,7 @@ func GetDumpBriefInfo(dump *dto.TriggerDump) string {
 		dump.Created, dump.Trigger.ID, len(dump.Metrics), dump.LastCheck.LastSuccessfulCheckTimestamp)
 }
 
-func initApp() (cleanupConfig, moira.Logger, moira.Database) {
+func initApp() (config, moira.Logger, moira.Database) {
 	flag.Parse()
 
 	if *printVersion {
@@ -530,7 +629,7 @@ func initApp() (cleanupConfig, moira.Logger, moira.Database) {
 	databaseSettings := config.Redis.GetSettings()
 	dataBase := redis.NewDatabase(logger, databaseSettings, redis.NotificationHistoryConfig{}, redis.NotificationConfig{}, redis.Cli, moira.ClusterList{})
 
-	return config.Cleanup, logger, dataBase
+	return config, logger, dataBase
 }
 
 func checkValidVersion(logger moira.Logger, updateFromVersion *string, isUpdate bool) string {
@@ -237,8 +237,9 @@ func removeTeamNamesHash(logger moira.Logger, database moira.Database) error {
 }
 
 type teamStorageElement struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	RestrictTriggerChanges bool   `json:"restrict_trigger_changes,omitempty"`
 }
 
 type teamWithID struct {
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira/database/redis"
+	"github.com/moira-alert/moira/metric_source/prometheus"
+	"github.com/moira-alert/moira/metric_source/retries"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -65,3 +67,44 @@ func TestRedisConfig(t *testing.T) {
 		})
 	})
 }
+
+func TestPrometheusRemoteConfig(t *testing.T) {
+	Convey("Test PrometheusRemoteConfig.GetPrometheusSourceSettings", t, func() {
+		Convey("With empty config", func() {
+			settings := (&PrometheusRemoteConfig{}).GetPrometheusSourceSettings()
+			So(settings.Retries, ShouldResemble, retries.Config{})
+			So(settings.CacheTTL, ShouldEqual, prometheus.DefaultCacheTTL)
+		})
+
+		Convey("With legacy retries", func() {
+			settings := (&PrometheusRemoteConfig{Retries: 5, RetryTimeout: "15s", CacheTTL: "0s"}).GetPrometheusSourceSettings()
+			So(settings.Retries, ShouldResemble, retries.Config{
+				InitialInterval: 15 * time.Second,
+				Multiplier:      1,
+				MaxInterval:     15 * time.Second,
+				MaxRetriesCount: 4,
+			})
+			So(settings.CacheTTL, ShouldEqual, 0)
+		})
+
+		Convey("With request retries", func() {
+			settings := (&PrometheusRemoteConfig{
+				Retries: 5,
+				RequestRetries: RetriesConfig{
+					InitialInterval: "1s",
+					Multiplier:      2,
+					MaxInterval:     "10s",
+					MaxRetriesCount: 3,
+				},
+				MaxConcurrentRequests: 10,
+			}).GetPrometheusSourceSettings()
+			So(settings.Retries, ShouldResemble, retries.Config{
+				InitialInterval: time.Second,
+				Multiplier:      2,
+				MaxInterval:     10 * time.Second,
+				MaxRetriesCount: 3,
+			})
+			So(settings.MaxConcurrentRequests, ShouldEqual, 10)
+		})
+	})
+}
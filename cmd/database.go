@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/postgres"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// NewDatabase returns database of Moira service. If PostgreSQL is enabled, configuration data is stored
+// in PostgreSQL and everything else in given Redis database, otherwise Redis database is returned as is.
+func NewDatabase(
+	logger moira.Logger,
+	redisDatabase *redis.DbConnector,
+	config PostgresConfig,
+	notificationHistory redis.NotificationHistoryConfig,
+	source redis.DBSource,
+) (moira.Database, error) {
+	if !config.Enabled {
+		return redisDatabase, nil
+	}
+
+	return postgres.NewDatabase(logger, config.GetSettings(notificationHistory), source, redisDatabase)
+}
@@ -9,6 +9,7 @@ import (
 
 type config struct {
 	Redis     cmd.RedisConfig     `yaml:"redis"`
+	Postgres  cmd.PostgresConfig  `yaml:"postgres"`
 	Logger    cmd.LoggerConfig    `yaml:"log"`
 	Filter    filterConfig        `yaml:"filter"`
 	Telemetry cmd.TelemetryConfig `yaml:"telemetry"`
@@ -94,7 +94,14 @@ func main() {
 			Msg("Failed to configure filter telemetry")
 	}
 
-	database := redis.NewDatabase(logger, config.Redis.GetSettings(), redis.NotificationHistoryConfig{}, redis.NotificationConfig{}, redis.Filter, moira.ClusterList{})
+	redisDatabase := redis.NewDatabase(logger, config.Redis.GetSettings(), redis.NotificationHistoryConfig{}, redis.NotificationConfig{}, redis.Filter, moira.ClusterList{})
+
+	database, err := cmd.NewDatabase(logger, redisDatabase, config.Postgres, redis.NotificationHistoryConfig{}, redis.Filter)
+	if err != nil {
+		logger.Fatal().
+			Error(err).
+			Msg("Can not configure database")
+	}
 
 	retentionConfigFile, err := os.Open(config.Filter.RetentionConfig)
 	if err != nil {
@@ -7,8 +7,10 @@ import (
 	"github.com/moira-alert/moira/api"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/loki"
 	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
+	"github.com/moira-alert/moira/metric_source/sqldb"
 	"github.com/xiam/to"
 )
 
@@ -44,6 +46,28 @@ func InitMetricSources(remotes RemotesConfig, database moira.Database, logger mo
 		provider.RegisterSource(moira.MakeClusterKey(moira.PrometheusRemote, prom.ClusterId), source)
 	}
 
+	for _, lokiRemote := range remotes.Loki {
+		config := lokiRemote.GetLokiSourceSettings()
+
+		source, err := loki.Create(config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.RegisterSource(moira.MakeClusterKey(moira.LokiRemote, lokiRemote.ClusterId), source)
+	}
+
+	for _, sqlRemote := range remotes.SQL {
+		config := sqlRemote.GetSQLSourceSettings()
+
+		source, err := sqldb.Create(config)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.RegisterSource(moira.MakeClusterKey(moira.SQLRemote, sqlRemote.ClusterId), source)
+	}
+
 	return provider, nil
 }
 
@@ -76,6 +100,26 @@ func MakeClustersWebConfig(redisConfig RedisConfig, remotes RemotesConfig) []api
 		clusters = append(clusters, cluster)
 	}
 
+	for _, remote := range remotes.Loki {
+		cluster := api.MetricSourceCluster{
+			TriggerSource: moira.LokiRemote,
+			ClusterId:     remote.ClusterId,
+			ClusterName:   remote.ClusterName,
+			MetricsTTL:    uint64(to.Duration(remote.MetricsTTL).Seconds()),
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	for _, remote := range remotes.SQL {
+		cluster := api.MetricSourceCluster{
+			TriggerSource: moira.SQLRemote,
+			ClusterId:     remote.ClusterId,
+			ClusterName:   remote.ClusterName,
+			MetricsTTL:    uint64(to.Duration(remote.MetricsTTL).Seconds()),
+		}
+		clusters = append(clusters, cluster)
+	}
+
 	return clusters
 }
 
@@ -93,5 +137,15 @@ func MakeClusterList(remotes RemotesConfig) moira.ClusterList {
 		clusterList = append(clusterList, cluster)
 	}
 
+	for _, remote := range remotes.Loki {
+		cluster := moira.MakeClusterKey(moira.LokiRemote, remote.ClusterId)
+		clusterList = append(clusterList, cluster)
+	}
+
+	for _, remote := range remotes.SQL {
+		cluster := moira.MakeClusterKey(moira.SQLRemote, remote.ClusterId)
+		clusterList = append(clusterList, cluster)
+	}
+
 	return clusterList
 }
@@ -14,6 +14,7 @@ import (
 
 type config struct {
 	Redis               cmd.RedisConfig               `yaml:"redis"`
+	Postgres            cmd.PostgresConfig            `yaml:"postgres"`
 	Logger              cmd.LoggerConfig              `yaml:"log"`
 	Notifier            notifierConfig                `yaml:"notifier"`
 	Telemetry           cmd.TelemetryConfig           `yaml:"telemetry"`
@@ -58,6 +59,15 @@ type notifierConfig struct {
 	SetLogLevel setLogLevelConfig `yaml:"set_log_level"`
 	// CheckNotifierStateTimeout is the timeout between marking *.alive.count metric based on notifier state.
 	CheckNotifierStateTimeout string `yaml:"check_notifier_state_timeout"`
+	// Dead letter queue configuration section, used to store notifications which notifier gave up to send
+	DeadLetterQueue deadLetterQueueConfig `yaml:"dead_letter_queue"`
+}
+
+type deadLetterQueueConfig struct {
+	// If true, notifications which can't be delivered will be saved to dead letter queue instead of being dropped
+	Enabled bool `yaml:"enabled"`
+	// Max count of notifications stored in dead letter queue, the oldest ones are removed first. Zero means no limit
+	MaxSize int `yaml:"max_size"`
 }
 
 type selfStateConfig struct {
@@ -139,6 +149,10 @@ func getDefault() config {
 			ReadBatchSize:                 int(notifier.NotificationsLimitUnlimited),
 			MaxFailAttemptToSendAvailable: 3,
 			CheckNotifierStateTimeout:     "10s",
+			DeadLetterQueue: deadLetterQueueConfig{
+				Enabled: true,
+				MaxSize: 10000,
+			},
 		},
 		Telemetry: cmd.TelemetryConfig{
 			Listen: ":8093",
@@ -240,6 +254,8 @@ func (config *notifierConfig) getSettings(logger moira.Logger) notifier.Config {
 		LogContactsToLevel:            contacts,
 		LogSubscriptionsToLevel:       subscriptions,
 		CheckNotifierStateTimeout:     to.Duration(config.CheckNotifierStateTimeout),
+		DeadLetterQueueEnabled:        config.DeadLetterQueue.Enabled,
+		DeadLetterQueueMaxSize:        int64(config.DeadLetterQueue.MaxSize),
 	}
 }
 
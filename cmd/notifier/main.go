@@ -83,7 +83,14 @@ func main() {
 	databaseSettings := config.Redis.GetSettings()
 	notificationHistorySettings := config.NotificationHistory.GetSettings()
 	notificationSettings := config.Notification.GetSettings()
-	database := redis.NewDatabase(logger, databaseSettings, notificationHistorySettings, notificationSettings, redis.Notifier, clusterList)
+	redisDatabase := redis.NewDatabase(logger, databaseSettings, notificationHistorySettings, notificationSettings, redis.Notifier, clusterList)
+
+	database, err := cmd.NewDatabase(logger, redisDatabase, config.Postgres, notificationHistorySettings, redis.Notifier)
+	if err != nil {
+		logger.Fatal().
+			Error(err).
+			Msg("Can not configure database")
+	}
 
 	metricSourceProvider, err := cmd.InitMetricSources(config.Remotes, database, logger)
 	if err != nil {
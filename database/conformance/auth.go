@@ -0,0 +1,117 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testAPITokens(t *testing.T, database moira.Database) {
+	expiresAt := int64(2000)
+
+	personal := &moira.APIToken{
+		ID:        "token1",
+		Name:      "deploy script",
+		User:      "user1",
+		Scopes:    []moira.APITokenScope{moira.APITokenScopeRead},
+		Hash:      "hash1",
+		CreatedAt: 1000,
+		ExpiresAt: &expiresAt,
+	}
+	team := &moira.APIToken{
+		ID:        "token2",
+		Name:      "team automation",
+		User:      "user1",
+		TeamID:    "team1",
+		Scopes:    []moira.APITokenScope{moira.APITokenScopeRead, moira.APITokenScopeWrite},
+		Hash:      "hash2",
+		CreatedAt: 1000,
+	}
+
+	_, err := database.GetAPIToken(personal.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetAPITokenByHash(personal.Hash)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SaveAPIToken(personal))
+	require.NoError(t, database.SaveAPIToken(team))
+
+	token, err := database.GetAPIToken(personal.ID)
+	require.NoError(t, err)
+	require.Equal(t, *personal, token)
+
+	token, err = database.GetAPITokenByHash(team.Hash)
+	require.NoError(t, err)
+	require.Equal(t, *team, token)
+
+	// Team tokens are not listed among personal tokens of the user who issued them.
+	tokens, err := database.GetUserAPITokens("user1")
+	require.NoError(t, err)
+	require.Equal(t, []*moira.APIToken{personal}, tokens)
+
+	tokens, err = database.GetTeamAPITokens("team1")
+	require.NoError(t, err)
+	require.Equal(t, []*moira.APIToken{team}, tokens)
+
+	// Rotation of the secret replaces the hash index.
+	rotated := *personal
+	rotated.Hash = "hash3"
+	require.NoError(t, database.SaveAPIToken(&rotated))
+
+	_, err = database.GetAPITokenByHash(personal.Hash)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	token, err = database.GetAPITokenByHash(rotated.Hash)
+	require.NoError(t, err)
+	require.Equal(t, rotated, token)
+
+	require.NoError(t, database.RemoveAPIToken(personal.ID))
+	require.NoError(t, database.RemoveAPIToken(personal.ID))
+
+	_, err = database.GetAPIToken(personal.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetAPITokenByHash(rotated.Hash)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	tokens, err = database.GetUserAPITokens("user1")
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	tokens, err = database.GetTeamAPITokens("team1")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+}
+
+func testAuthSessions(t *testing.T, database moira.Database) {
+	session := moira.AuthSession{
+		Login:     "user1",
+		Groups:    []string{"admins", "developers"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+
+	_, err := database.GetAuthSession("session1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SaveAuthSession("session1", session))
+
+	actual, err := database.GetAuthSession("session1")
+	require.NoError(t, err)
+	require.Equal(t, session, actual)
+
+	require.NoError(t, database.RemoveAuthSession("session1"))
+
+	_, err = database.GetAuthSession("session1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	// Expired sessions can not be fetched.
+	session.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	require.NoError(t, database.SaveAuthSession("session2", session))
+
+	_, err = database.GetAuthSession("session2")
+	require.ErrorIs(t, err, db.ErrNil)
+}
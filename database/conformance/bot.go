@@ -0,0 +1,32 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testBot(t *testing.T, database moira.Database) {
+	chat, err := database.GetChatByUsername("telegram", "#channel")
+	require.NoError(t, err)
+	require.Equal(t, "@channel", chat)
+
+	_, err = database.GetChatByUsername("telegram", "user")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SetUsernameChat("telegram", "user", "123"))
+
+	chat, err = database.GetChatByUsername("telegram", "user")
+	require.NoError(t, err)
+	require.Equal(t, "123", chat)
+
+	_, err = database.GetChatByUsername("slack", "user")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.RemoveUser("telegram", "user"))
+
+	_, err = database.GetChatByUsername("telegram", "user")
+	require.ErrorIs(t, err, db.ErrNil)
+}
@@ -0,0 +1,77 @@
+// Package conformance contains backend-agnostic tests of moira.Database implementations.
+// Tests use the database only through the interface, so every storage backend is expected
+// to pass them the same way as database/redis does.
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+)
+
+// Factory returns an empty database. It is called once per test group,
+// implementation is responsible for cleaning up stored data with t.Cleanup.
+type Factory func(t *testing.T) moira.Database
+
+// Run runs all conformance tests against databases created by newDatabase.
+func Run(t *testing.T, newDatabase Factory) {
+	t.Helper()
+
+	groups := []struct {
+		name string
+		test func(t *testing.T, database moira.Database)
+	}{
+		{"Tags", testTags},
+		{"Triggers", testTriggers},
+		{"LastChecks", testLastChecks},
+		{"Subscriptions", testSubscriptions},
+		{"Contacts", testContacts},
+		{"Teams", testTeams},
+		{"NotificationHistory", testNotificationHistory},
+		{"Throttling", testThrottling},
+		{"SearchResults", testSearchResults},
+		{"NotificationEvents", testNotificationEvents},
+		{"Metrics", testMetrics},
+		{"RemoveMetrics", testRemoveMetrics},
+		{"CleanUpOutdatedMetrics", testCleanUpOutdatedMetrics},
+		{"CleanUpFutureMetrics", testCleanUpFutureMetrics},
+		{"MetricEvents", testMetricEvents},
+		{"TriggersToCheck", testTriggersToCheck},
+		{"TriggerCheckLocks", testTriggerCheckLocks},
+		{"Lock", testLock},
+		{"Bot", testBot},
+		{"NotificationThreads", testNotificationThreads},
+		{"IncidentMaintenance", testIncidentMaintenance},
+		{"UnusedTriggers", testUnusedTriggers},
+		{"TriggersToReindex", testTriggersToReindex},
+		{"DeliveryChecks", testDeliveryChecks},
+		{"SelfState", testSelfState},
+		{"ContactScores", testContactScores},
+		{"ScheduledNotifications", testScheduledNotifications},
+		{"FetchNotificationsWithLimit", testFetchNotificationsWithLimit},
+		{"FetchDelayedNotifications", testFetchDelayedNotifications},
+		{"DeadLetterNotifications", testDeadLetterNotifications},
+		{"APITokens", testAPITokens},
+		{"AuthSessions", testAuthSessions},
+		{"MaintenanceWindows", testMaintenanceWindows},
+		{"Silences", testSilences},
+	}
+
+	for _, group := range groups {
+		t.Run(group.name, func(t *testing.T) {
+			group.test(t, newDatabase(t))
+		})
+	}
+}
+
+func newTrigger(id string, tags ...string) *moira.Trigger {
+	return &moira.Trigger{
+		ID:            id,
+		Name:          "trigger " + id,
+		Targets:       []string{"seriesByTag('name=" + id + "')"},
+		Tags:          tags,
+		Patterns:      []string{id + ".*"},
+		TriggerSource: moira.GraphiteLocal,
+		ClusterId:     moira.DefaultCluster,
+	}
+}
@@ -0,0 +1,60 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testContacts(t *testing.T, database moira.Database) {
+	_, err := database.GetContact("contact1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	contact1 := &moira.ContactData{ID: "contact1", Type: "mail", Value: "user1@example.com", User: "user1"}
+	contact2 := &moira.ContactData{ID: "contact2", Type: "slack", Value: "#channel", Team: "team1"}
+
+	require.NoError(t, database.SaveContact(contact1))
+	require.NoError(t, database.SaveContact(contact2))
+
+	actual, err := database.GetContact("contact1")
+	require.NoError(t, err)
+	require.Equal(t, *contact1, actual)
+
+	contacts, err := database.GetContacts([]string{"contact2", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, []*moira.ContactData{contact2, nil}, contacts)
+
+	contacts, err = database.GetAllContacts()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*moira.ContactData{contact1, contact2}, contacts)
+
+	ids, err := database.GetUserContactIDs("user1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"contact1"}, ids)
+
+	ids, err = database.GetTeamContactIDs("team1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"contact2"}, ids)
+
+	contact1.User = "user2"
+	require.NoError(t, database.SaveContact(contact1))
+
+	ids, err = database.GetUserContactIDs("user1")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	ids, err = database.GetUserContactIDs("user2")
+	require.NoError(t, err)
+	require.Equal(t, []string{"contact1"}, ids)
+
+	require.NoError(t, database.RemoveContact("contact1"))
+
+	_, err = database.GetContact("contact1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	ids, err = database.GetUserContactIDs("user2")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
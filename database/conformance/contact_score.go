@@ -0,0 +1,34 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testContactScores(t *testing.T, database moira.Database) {
+	score, err := database.GetContactScore("contact1")
+	require.NoError(t, err)
+	require.Nil(t, score)
+
+	increment := func(score moira.ContactScore) moira.ContactScore {
+		score.AllTXCount++
+		score.LastErrorMsg = "error"
+
+		return score
+	}
+
+	require.NoError(t, database.UpdateContactScores([]string{"contact1", "contact2"}, increment))
+	require.NoError(t, database.UpdateContactScores([]string{"contact1"}, increment))
+
+	score, err = database.GetContactScore("contact1")
+	require.NoError(t, err)
+	require.Equal(t, &moira.ContactScore{ContactID: "contact1", AllTXCount: 2, LastErrorMsg: "error"}, score)
+
+	scores, err := database.GetContactsScore([]string{"contact1", "contact2", "contact3"})
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	require.Equal(t, uint64(2), scores["contact1"].AllTXCount)
+	require.Equal(t, uint64(1), scores["contact2"].AllTXCount)
+}
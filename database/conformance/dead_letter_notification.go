@@ -0,0 +1,58 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeadLetterNotifications(t *testing.T, database moira.Database) {
+	for _, id := range []string{"dead1", "dead2", "dead3"} {
+		require.NoError(t, database.AddDeadLetterNotification(&moira.DeadLetterNotification{
+			ID:           id,
+			Notification: *newScheduledNotification("contact1", moira.DefaultLocalCluster, 100),
+			Error:        "error of " + id,
+			Attempts:     3,
+			SenderType:   "mail",
+			Timestamp:    100,
+		}, 2))
+	}
+
+	// The oldest notification is dropped because of max size.
+	notifications, total, err := database.GetDeadLetterNotifications(0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, notifications, 2)
+	require.Equal(t, "dead3", notifications[0].ID)
+	require.Equal(t, "dead2", notifications[1].ID)
+
+	notifications, total, err = database.GetDeadLetterNotifications(1, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "dead2", notifications[0].ID)
+
+	_, err = database.GetDeadLetterNotification("dead1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	notification, err := database.GetDeadLetterNotification("dead2")
+	require.NoError(t, err)
+	require.Equal(t, "error of dead2", notification.Error)
+	require.Equal(t, "contact1", notification.Notification.Contact.ID)
+
+	count, err := database.RemoveDeadLetterNotification("dead2")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	count, err = database.RemoveDeadLetterNotification("dead2")
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	require.NoError(t, database.RemoveAllDeadLetterNotifications())
+
+	_, total, err = database.GetDeadLetterNotifications(0, -1)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
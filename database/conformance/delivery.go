@@ -0,0 +1,39 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeliveryChecks(t *testing.T, database moira.Database) {
+	data, err := database.GetDeliveryChecksData("slack", "-inf", "+inf")
+	require.NoError(t, err)
+	require.Empty(t, data)
+
+	require.NoError(t, database.AddDeliveryChecksData("slack", 10, "first"))
+	require.NoError(t, database.AddDeliveryChecksData("slack", 20, "second"))
+	require.NoError(t, database.AddDeliveryChecksData("slack", 30, "third"))
+	require.NoError(t, database.AddDeliveryChecksData("mail", 10, "other"))
+
+	data, err = database.GetDeliveryChecksData("slack", "-inf", "+inf")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "third"}, data)
+
+	data, err = database.GetDeliveryChecksData("slack", "15", "+inf")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second", "third"}, data)
+
+	count, err := database.RemoveDeliveryChecksData("slack", "-inf", "20")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	data, err = database.GetDeliveryChecksData("slack", "-inf", "+inf")
+	require.NoError(t, err)
+	require.Equal(t, []string{"third"}, data)
+
+	data, err = database.GetDeliveryChecksData("mail", "-inf", "+inf")
+	require.NoError(t, err)
+	require.Equal(t, []string{"other"}, data)
+}
@@ -0,0 +1,36 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testIncidentMaintenance(t *testing.T, database moira.Database) {
+	maintenance := time.Now().Add(time.Hour).Unix()
+
+	_, err := database.GetIncidentMaintenance("incident1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SetIncidentMaintenance("incident1", maintenance))
+
+	actual, err := database.GetIncidentMaintenance("incident1")
+	require.NoError(t, err)
+	require.Equal(t, maintenance, actual)
+
+	_, err = database.GetIncidentMaintenance("incident2")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.RemoveIncidentMaintenance("incident1"))
+
+	_, err = database.GetIncidentMaintenance("incident1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SetIncidentMaintenance("incident1", time.Now().Add(-time.Hour).Unix()))
+
+	_, err = database.GetIncidentMaintenance("incident1")
+	require.ErrorIs(t, err, db.ErrNil)
+}
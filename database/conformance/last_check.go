@@ -0,0 +1,54 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testLastChecks(t *testing.T, database moira.Database) {
+	_, err := database.GetTriggerLastCheck("trigger1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	// Absent last check is not an error.
+	require.NoError(t, database.SetTriggerCheckMaintenance("trigger1", nil, nil, "user", 0))
+
+	checkData := &moira.CheckData{
+		Metrics: map[string]moira.MetricState{
+			"metric1": {State: moira.StateOK, Timestamp: 100},
+		},
+		Score:     10,
+		State:     moira.StateOK,
+		Timestamp: 100,
+	}
+	require.NoError(t, database.SetTriggerLastCheck("trigger1", checkData, moira.DefaultLocalCluster))
+
+	actual, err := database.GetTriggerLastCheck("trigger1")
+	require.NoError(t, err)
+	require.Equal(t, checkData.Score, actual.Score)
+	require.Equal(t, checkData.State, actual.State)
+	require.Equal(t, checkData.Metrics["metric1"].State, actual.Metrics["metric1"].State)
+
+	triggerMaintenance := int64(1000)
+	require.NoError(t, database.SetTriggerCheckMaintenance("trigger1", map[string]int64{"metric1": 500}, &triggerMaintenance, "user", 200))
+
+	actual, err = database.GetTriggerLastCheck("trigger1")
+	require.NoError(t, err)
+	require.Equal(t, triggerMaintenance, actual.Maintenance)
+	require.Equal(t, int64(500), actual.Metrics["metric1"].Maintenance)
+	require.Equal(t, "user", *actual.MaintenanceInfo.StartUser)
+
+	require.NoError(t, database.RemoveTriggerLastCheck("trigger1"))
+
+	_, err = database.GetTriggerLastCheck("trigger1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	// Last check without trigger is removed by cleanup.
+	require.NoError(t, database.SetTriggerLastCheck("abandoned", checkData, moira.DefaultLocalCluster))
+	require.NoError(t, database.CleanUpAbandonedTriggerLastCheck())
+
+	_, err = database.GetTriggerLastCheck("abandoned")
+	require.ErrorIs(t, err, db.ErrNil)
+}
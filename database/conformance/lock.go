@@ -0,0 +1,41 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testLock(t *testing.T, database moira.Database) {
+	lock := database.NewLock("conformance-lock", 3*time.Second)
+
+	lost, err := lock.Acquire(nil)
+	require.NoError(t, err)
+
+	_, err = lock.Acquire(nil)
+	require.ErrorIs(t, err, db.ErrLockAlreadyHeld)
+
+	stop := make(chan struct{})
+	close(stop)
+
+	other := database.NewLock("conformance-lock", 300*time.Millisecond)
+
+	_, err = other.Acquire(stop)
+	require.Error(t, err)
+
+	lock.Release()
+
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		require.Fail(t, "lost channel is not closed after release")
+	}
+
+	_, err = other.Acquire(nil)
+	require.NoError(t, err)
+
+	other.Release()
+}
@@ -0,0 +1,71 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testMaintenanceWindows(t *testing.T, database moira.Database) {
+	backups := &moira.MaintenanceWindow{
+		ID:    "window1",
+		Name:  "backups",
+		Start: 2000,
+		End:   3000,
+		Recurrence: &moira.MaintenanceRecurrence{
+			Frequency: moira.MaintenanceFrequencyWeekly,
+			Timezone:  "Europe/Berlin",
+		},
+		Tags:      []string{"postgres"},
+		Patterns:  []string{"db.*.replication_lag"},
+		CreatedBy: "user1",
+		CreatedAt: 1000,
+	}
+	release := &moira.MaintenanceWindow{
+		ID:         "window2",
+		Name:       "release",
+		Start:      1500,
+		End:        1800,
+		TriggerIDs: []string{"trigger1"},
+		CreatedBy:  "user2",
+		CreatedAt:  1000,
+	}
+
+	_, err := database.GetMaintenanceWindow(backups.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	windows, err := database.GetMaintenanceWindows()
+	require.NoError(t, err)
+	require.Empty(t, windows)
+
+	require.NoError(t, database.SaveMaintenanceWindow(backups))
+	require.NoError(t, database.SaveMaintenanceWindow(release))
+
+	window, err := database.GetMaintenanceWindow(backups.ID)
+	require.NoError(t, err)
+	require.Equal(t, *backups, window)
+
+	// Windows are ordered by their start.
+	windows, err = database.GetMaintenanceWindows()
+	require.NoError(t, err)
+	require.Equal(t, []*moira.MaintenanceWindow{release, backups}, windows)
+
+	backups.End = 4000
+	require.NoError(t, database.SaveMaintenanceWindow(backups))
+
+	window, err = database.GetMaintenanceWindow(backups.ID)
+	require.NoError(t, err)
+	require.Equal(t, *backups, window)
+
+	require.NoError(t, database.RemoveMaintenanceWindow(release.ID))
+	require.NoError(t, database.RemoveMaintenanceWindow("not-existing"))
+
+	_, err = database.GetMaintenanceWindow(release.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	windows, err = database.GetMaintenanceWindows()
+	require.NoError(t, err)
+	require.Equal(t, []*moira.MaintenanceWindow{backups}, windows)
+}
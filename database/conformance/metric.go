@@ -0,0 +1,236 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/tomb.v2"
+)
+
+// Redis implementation skips repeated removal of values of the same metric for a minute,
+// so every metric below has its values removed only once.
+func testMetrics(t *testing.T, database moira.Database) {
+	require.Positive(t, database.GetMetricsTTLSeconds())
+
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1")))
+
+	pattern := "trigger1.*"
+
+	patterns, err := database.GetPatterns()
+	require.NoError(t, err)
+	require.Equal(t, []string{pattern}, patterns)
+
+	require.NoError(t, database.SaveMetrics([]*moira.MatchedMetric{
+		newMatchedMetric("trigger1.first", pattern, 60, 1),
+		newMatchedMetric("trigger1.first", pattern, 120, 2),
+		newMatchedMetric("trigger1.second", pattern, 60, 3),
+		newMatchedMetric("trigger1.third", pattern, 60, 4),
+	}))
+
+	metrics, err := database.GetPatternMetrics(pattern)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1.first", "trigger1.second", "trigger1.third"}, metrics)
+
+	values, err := database.GetMetricsValues([]string{"trigger1.first", "trigger1.unknown"}, 0, 200)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]*moira.MetricValue{
+		"trigger1.first": {
+			{RetentionTimestamp: 60, Timestamp: 61, Value: 1},
+			{RetentionTimestamp: 120, Timestamp: 121, Value: 2},
+		},
+		"trigger1.unknown": {},
+	}, values)
+
+	values, err = database.GetMetricsValues([]string{"trigger1.first"}, 100, 200)
+	require.NoError(t, err)
+	require.Len(t, values["trigger1.first"], 1)
+
+	retention, err := database.GetMetricRetention("trigger1.first")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), retention)
+
+	retention, err = database.GetMetricRetention("trigger1.unknown")
+	require.NoError(t, err)
+	require.Equal(t, int64(60), retention)
+
+	count, err := database.RemoveMetricValues("trigger1.first", "-inf", "60")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, database.RemoveMetricsValues([]string{"trigger1.second"}, 100))
+
+	values, err = database.GetMetricsValues([]string{"trigger1.first", "trigger1.second"}, 0, 200)
+	require.NoError(t, err)
+	require.Len(t, values["trigger1.first"], 1)
+	require.Empty(t, values["trigger1.second"])
+
+	// Metric without values and metric added to pattern without values are outdated.
+	require.NoError(t, database.AddPatternMetric(pattern, "trigger1.absent"))
+
+	count, err = database.CleanupOutdatedPatternMetrics()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	metrics, err = database.GetPatternMetrics(pattern)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1.first", "trigger1.third"}, metrics)
+
+	require.NoError(t, database.CleanUpAbandonedRetentions())
+
+	retention, err = database.GetMetricRetention("trigger1.second")
+	require.NoError(t, err)
+	require.Equal(t, int64(60), retention)
+
+	require.NoError(t, database.RemoveMetricRetention("trigger1.third"))
+
+	retention, err = database.GetMetricRetention("trigger1.third")
+	require.NoError(t, err)
+	require.Equal(t, int64(60), retention)
+
+	require.NoError(t, database.RemovePatternsMetrics([]string{pattern}))
+
+	metrics, err = database.GetPatternMetrics(pattern)
+	require.NoError(t, err)
+	require.Empty(t, metrics)
+
+	require.NoError(t, database.RemovePattern(pattern))
+
+	patterns, err = database.GetPatterns()
+	require.NoError(t, err)
+	require.Empty(t, patterns)
+}
+
+func testRemoveMetrics(t *testing.T, database moira.Database) {
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1")))
+	require.NoError(t, database.SaveTrigger("trigger2", newTrigger("trigger2")))
+
+	require.NoError(t, database.SaveMetrics([]*moira.MatchedMetric{
+		newMatchedMetric("trigger1.first", "trigger1.*", 60, 1),
+		newMatchedMetric("trigger2.first", "trigger2.*", 60, 2),
+		newMatchedMetric("trigger2.second", "trigger2.*", 60, 3),
+		newMatchedMetric("other.first", "other.*", 60, 4),
+	}))
+
+	require.NoError(t, database.RemovePatternWithMetrics("trigger1.*"))
+
+	patterns, err := database.GetPatterns()
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger2.*"}, patterns)
+
+	values, err := database.GetMetricsValues([]string{"trigger1.first", "trigger2.first"}, 0, 200)
+	require.NoError(t, err)
+	require.Empty(t, values["trigger1.first"])
+	require.Len(t, values["trigger2.first"], 1)
+
+	require.NoError(t, database.RemoveMetricsByPrefix("trigger2.f"))
+
+	values, err = database.GetMetricsValues([]string{"trigger2.first", "trigger2.second"}, 0, 200)
+	require.NoError(t, err)
+	require.Empty(t, values["trigger2.first"])
+	require.Len(t, values["trigger2.second"], 1)
+
+	metrics, err := database.GetPatternMetrics("trigger2.*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger2.second"}, metrics)
+
+	require.NoError(t, database.RemoveAllMetrics())
+
+	values, err = database.GetMetricsValues([]string{"trigger2.second", "other.first"}, 0, 200)
+	require.NoError(t, err)
+	require.Empty(t, values["trigger2.second"])
+	require.Empty(t, values["other.first"])
+
+	metrics, err = database.GetPatternMetrics("other.*")
+	require.NoError(t, err)
+	require.Empty(t, metrics)
+}
+
+func testCleanUpOutdatedMetrics(t *testing.T, database moira.Database) {
+	require.Error(t, database.CleanUpOutdatedMetrics(time.Hour))
+
+	now := time.Now().Unix()
+	require.NoError(t, database.SaveMetrics([]*moira.MatchedMetric{
+		newMatchedMetric("metric", "pattern", now-7200, 1),
+		newMatchedMetric("metric", "pattern", now, 2),
+	}))
+
+	require.NoError(t, database.CleanUpOutdatedMetrics(-time.Hour))
+
+	values, err := database.GetMetricsValues([]string{"metric"}, 0, now+7200)
+	require.NoError(t, err)
+	require.Len(t, values["metric"], 1)
+	require.Equal(t, now, values["metric"][0].RetentionTimestamp)
+}
+
+func testCleanUpFutureMetrics(t *testing.T, database moira.Database) {
+	require.Error(t, database.CleanUpFutureMetrics(-time.Hour))
+
+	now := time.Now().Unix()
+	require.NoError(t, database.SaveMetrics([]*moira.MatchedMetric{
+		newMatchedMetric("metric", "pattern", now, 1),
+		newMatchedMetric("metric", "pattern", now+7200, 2),
+	}))
+
+	require.NoError(t, database.CleanUpFutureMetrics(time.Hour))
+
+	values, err := database.GetMetricsValues([]string{"metric"}, 0, now+7200)
+	require.NoError(t, err)
+	require.Len(t, values["metric"], 1)
+	require.Equal(t, now, values["metric"][0].RetentionTimestamp)
+}
+
+func testMetricEvents(t *testing.T, database moira.Database) {
+	require.NoError(t, database.SaveMetrics([]*moira.MatchedMetric{
+		{Metric: "metric1", Patterns: []string{"pattern1", "pattern2"}, Value: 1, Timestamp: 61, RetentionTimestamp: 60, Retention: 60},
+		{Metric: "metric2", Patterns: []string{"pattern1"}, Value: 2, Timestamp: 61, RetentionTimestamp: 60, Retention: 60},
+	}))
+
+	var eventsTomb tomb.Tomb
+
+	events, err := database.SubscribeMetricEvents(&eventsTomb, &moira.SubscribeMetricEventsParams{BatchSize: 100})
+	require.NoError(t, err)
+
+	expected := []moira.MetricEvent{
+		{Metric: "metric1", Pattern: "pattern1"},
+		{Metric: "metric1", Pattern: "pattern2"},
+		{Metric: "metric2", Pattern: "pattern1"},
+	}
+	received := make([]moira.MetricEvent, 0, len(expected))
+
+	for len(received) < len(expected) {
+		select {
+		case event := <-events:
+			received = append(received, *event)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "metric events are not received", "received: %v", received)
+		}
+	}
+
+	require.ElementsMatch(t, expected, received)
+
+	eventsTomb.Kill(nil)
+
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "metric events channel is not closed after tomb is killed")
+		}
+	}
+}
+
+func newMatchedMetric(metric, pattern string, retentionTimestamp int64, value float64) *moira.MatchedMetric {
+	return &moira.MatchedMetric{
+		Metric:             metric,
+		Patterns:           []string{pattern},
+		Value:              value,
+		Timestamp:          retentionTimestamp + 1,
+		RetentionTimestamp: retentionTimestamp,
+		Retention:          10,
+	}
+}
@@ -0,0 +1,141 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduledNotifications(t *testing.T, database moira.Database) {
+	first := newScheduledNotification("contact1", moira.DefaultLocalCluster, 100)
+	second := newScheduledNotification("contact2", moira.DefaultLocalCluster, 200)
+	remote := newScheduledNotification("contact3", moira.DefaultGraphiteRemoteCluster, 150, "ignored")
+
+	require.NoError(t, database.AddNotification(first))
+	require.NoError(t, database.AddNotification(second))
+	require.NoError(t, database.AddNotification(remote))
+
+	notifications, total, err := database.GetNotifications(0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Equal(t, []*moira.ScheduledNotification{first, remote, second}, notifications)
+
+	_, err = database.FetchNotifications(moira.DefaultLocalCluster, 150, 0)
+	require.Error(t, err)
+
+	fetched, err := database.FetchNotifications(moira.DefaultLocalCluster, 150, -1)
+	require.NoError(t, err)
+	require.Equal(t, []*moira.ScheduledNotification{first}, fetched)
+
+	third := newScheduledNotification("contact4", moira.DefaultLocalCluster, 300)
+	require.NoError(t, database.AddNotifications([]*moira.ScheduledNotification{third}, 300))
+
+	count, err := database.RemoveNotification("200contact2")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	count, err = database.RemoveNotification("200contact2")
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// Notification with ignored tag is kept.
+	count, err = database.RemoveFilteredNotifications(0, -1, []string{"ignored"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	notifications, total, err = database.GetNotifications(0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, []*moira.ScheduledNotification{remote}, notifications)
+
+	count, err = database.RemoveFilteredNotifications(0, -1, nil, []moira.ClusterKey{moira.DefaultLocalCluster})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	require.NoError(t, database.RemoveAllNotifications())
+
+	_, total, err = database.GetNotifications(0, -1)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func testFetchNotificationsWithLimit(t *testing.T, database moira.Database) {
+	notifications := []*moira.ScheduledNotification{
+		newScheduledNotification("contact1", moira.DefaultLocalCluster, 10),
+		newScheduledNotification("contact2", moira.DefaultLocalCluster, 20),
+		newScheduledNotification("contact3", moira.DefaultLocalCluster, 20),
+	}
+	for _, notification := range notifications {
+		require.NoError(t, database.AddNotification(notification))
+	}
+
+	// Notifications with the same timestamp are never split between fetches.
+	fetched, err := database.FetchNotifications(moira.DefaultLocalCluster, 100, 2)
+	require.NoError(t, err)
+	require.Equal(t, notifications[:1], fetched)
+
+	fetched, err = database.FetchNotifications(moira.DefaultLocalCluster, 100, 1)
+	require.NoError(t, err)
+	require.ElementsMatch(t, notifications[1:], fetched)
+
+	fetched, err = database.FetchNotifications(moira.DefaultLocalCluster, 100, 1)
+	require.NoError(t, err)
+	require.Empty(t, fetched)
+}
+
+func testFetchDelayedNotifications(t *testing.T, database moira.Database) {
+	now := time.Now().Unix()
+
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1")))
+	require.NoError(t, database.SetTriggerLastCheck("trigger1", &moira.CheckData{
+		Maintenance: now + int64(time.Hour.Seconds()),
+		State:       moira.StateOK,
+	}, moira.DefaultLocalCluster))
+
+	// Notification of removed trigger is dropped.
+	removed := newScheduledNotification("contact1", moira.DefaultLocalCluster, now-10)
+	removed.Trigger.ID = "removed"
+	removed.CreatedAt = now - 1000
+
+	// Notification of trigger on maintenance is resaved.
+	resaved := newScheduledNotification("contact2", moira.DefaultLocalCluster, now-10)
+	resaved.Trigger.ID = "trigger1"
+	resaved.CreatedAt = now - 1000
+
+	valid := newScheduledNotification("contact3", moira.DefaultLocalCluster, now-10)
+
+	require.NoError(t, database.AddNotifications([]*moira.ScheduledNotification{removed, resaved, valid}, now-10))
+
+	fetched, err := database.FetchNotifications(moira.DefaultLocalCluster, now, -1)
+	require.NoError(t, err)
+	require.Equal(t, []*moira.ScheduledNotification{valid}, fetched)
+
+	notifications, total, err := database.GetNotifications(0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "contact2", notifications[0].Contact.ID)
+	require.Greater(t, notifications[0].Timestamp, now)
+}
+
+func newScheduledNotification(contactID string, clusterKey moira.ClusterKey, timestamp int64, tags ...string) *moira.ScheduledNotification {
+	return &moira.ScheduledNotification{
+		Event: moira.NotificationEvent{
+			Metric:   "metric",
+			State:    moira.StateERROR,
+			OldState: moira.StateOK,
+			Values:   map[string]float64{"t1": 1},
+		},
+		Trigger: moira.TriggerData{
+			ID:            "trigger-" + contactID,
+			Name:          "trigger of " + contactID,
+			Tags:          tags,
+			TriggerSource: clusterKey.TriggerSource,
+			ClusterId:     clusterKey.ClusterId,
+		},
+		Contact:   moira.ContactData{ID: contactID, Type: "mail", Value: contactID + "@example.com"},
+		Timestamp: timestamp,
+		CreatedAt: timestamp,
+	}
+}
@@ -0,0 +1,81 @@
+package conformance
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotificationEvents(t *testing.T, database moira.Database) {
+	now := time.Now().Unix()
+
+	first := &moira.NotificationEvent{
+		Timestamp: now - 10,
+		Metric:    "metric1",
+		Values:    map[string]float64{"t1": 1},
+		State:     moira.StateERROR,
+		OldState:  moira.StateOK,
+		TriggerID: "trigger1",
+	}
+	second := &moira.NotificationEvent{
+		Timestamp: now - 5,
+		Metric:    "metric2",
+		Values:    map[string]float64{"t1": 2},
+		State:     moira.StateOK,
+		OldState:  moira.StateERROR,
+		TriggerID: "trigger1",
+	}
+	withoutTrigger := &moira.NotificationEvent{
+		Timestamp: now,
+		Metric:    "metric3",
+		Values:    map[string]float64{"t1": 3},
+		State:     moira.StateNODATA,
+		OldState:  moira.StateOK,
+	}
+
+	require.NoError(t, database.PushNotificationEvent(first, false))
+	require.NoError(t, database.PushNotificationEvent(second, true))
+	require.NoError(t, database.PushNotificationEvent(withoutTrigger, false))
+
+	events, err := database.GetNotificationEvents("trigger1", 0, 10, "-inf", "+inf")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	// Events are returned from the newest to the oldest.
+	require.Equal(t, "metric2", events[0].Metric)
+	require.Equal(t, "metric1", events[1].Metric)
+
+	events, err = database.GetNotificationEvents("trigger1", 1, 1, "-inf", "+inf")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "metric1", events[0].Metric)
+
+	events, err = database.GetNotificationEvents("trigger2", 0, 10, "-inf", "+inf")
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	require.Equal(t, int64(2), database.GetNotificationEventCount("trigger1", "-inf", "+inf"))
+	require.Equal(t, int64(1), database.GetNotificationEventCount("trigger1", strconv.FormatInt(now-6, 10), "+inf"))
+
+	// Events are fetched in order they were pushed.
+	for _, expected := range []*moira.NotificationEvent{first, second, withoutTrigger} {
+		event, err := database.FetchNotificationEvent()
+		require.NoError(t, err)
+		require.Equal(t, expected.Metric, event.Metric)
+		require.Equal(t, expected.TriggerID, event.TriggerID)
+		require.Equal(t, expected.State, event.State)
+		require.Equal(t, expected.Values, event.Values)
+	}
+
+	_, err = database.FetchNotificationEvent()
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.PushNotificationEvent(first, false))
+	require.NoError(t, database.RemoveAllNotificationEvents())
+
+	_, err = database.FetchNotificationEvent()
+	require.ErrorIs(t, err, db.ErrNil)
+}
@@ -0,0 +1,52 @@
+package conformance
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotificationHistory(t *testing.T, database moira.Database) {
+	now := time.Now().Unix()
+
+	for i := range 3 {
+		notification := &moira.ScheduledNotification{
+			Event: moira.NotificationEvent{
+				Metric:   "metric" + strconv.Itoa(i),
+				State:    moira.StateERROR,
+				OldState: moira.StateOK,
+			},
+			Trigger:   moira.TriggerData{ID: "trigger1"},
+			Contact:   moira.ContactData{ID: "contact1"},
+			Timestamp: now - int64(i),
+		}
+		require.NoError(t, database.PushContactNotificationToHistory(notification))
+	}
+
+	items, total, err := database.GetNotificationsHistoryByContactID("contact1", now-10, now, 0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, items, 3)
+	require.Equal(t, "metric2", items[0].Metric)
+	require.Equal(t, now-2, items[0].TimeStamp)
+	require.Equal(t, "trigger1", items[0].TriggerID)
+
+	items, total, err = database.GetNotificationsHistoryByContactID("contact1", now-10, now, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, items, 1)
+	require.Equal(t, "metric0", items[0].Metric)
+
+	counts, err := database.CountEventsInNotificationHistory([]string{"contact1", "contact2"}, strconv.FormatInt(now-1, 10), "+inf")
+	require.NoError(t, err)
+	require.Equal(t, []*moira.ContactIDWithNotificationCount{{ID: "contact1", Count: 2}, {ID: "contact2", Count: 0}}, counts)
+
+	require.NoError(t, database.CleanUpOutdatedNotificationHistory(0))
+
+	_, total, err = database.GetNotificationsHistoryByContactID("contact1", 0, now, 0, -1)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
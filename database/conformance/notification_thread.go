@@ -0,0 +1,34 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotificationThreads(t *testing.T, database moira.Database) {
+	_, err := database.GetNotificationThread("matrix", "trigger1", "room1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SetNotificationThread("matrix", "trigger1", "room1", "$root"))
+
+	threadID, err := database.GetNotificationThread("matrix", "trigger1", "room1")
+	require.NoError(t, err)
+	require.Equal(t, "$root", threadID)
+
+	_, err = database.GetNotificationThread("matrix", "trigger1", "room2")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetNotificationThread("slack", "trigger1", "room1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetChatByUsername("matrix", "trigger1:room1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.RemoveNotificationThread("matrix", "trigger1", "room1"))
+
+	_, err = database.GetNotificationThread("matrix", "trigger1", "room1")
+	require.ErrorIs(t, err, db.ErrNil)
+}
@@ -0,0 +1,47 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testSearchResults(t *testing.T, database moira.Database) {
+	exists, err := database.IsTriggersSearchResultsExist("search1")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	results, total, err := database.GetTriggersSearchResults("search1", 0, 10)
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, results)
+
+	searchResults := []*moira.SearchResult{
+		{ObjectID: "trigger1", Highlights: []moira.SearchHighlight{{Field: "name", Value: "<mark>trigger</mark>1"}}},
+		{ObjectID: "trigger2", Highlights: []moira.SearchHighlight{{Field: "name", Value: "<mark>trigger</mark>2"}}},
+		{ObjectID: "trigger3", Highlights: []moira.SearchHighlight{{Field: "name", Value: "<mark>trigger</mark>3"}}},
+	}
+	require.NoError(t, database.SaveTriggersSearchResults("search1", searchResults, time.Minute))
+
+	exists, err = database.IsTriggersSearchResultsExist("search1")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	results, total, err = database.GetTriggersSearchResults("search1", 0, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Equal(t, searchResults, results)
+
+	results, total, err = database.GetTriggersSearchResults("search1", 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Equal(t, searchResults[2:], results)
+
+	require.NoError(t, database.DeleteTriggersSearchResults("search1"))
+
+	exists, err = database.IsTriggersSearchResultsExist("search1")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
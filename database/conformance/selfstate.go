@@ -0,0 +1,59 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testSelfState(t *testing.T, database moira.Database) {
+	require.NoError(t, database.UpdateMetricsHeartbeat())
+	require.NoError(t, database.UpdateMetricsHeartbeat())
+
+	count, err := database.GetMetricsUpdatesCount()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	_, err = database.GetChecksUpdatesCount()
+	require.NoError(t, err)
+
+	_, err = database.GetRemoteChecksUpdatesCount()
+	require.NoError(t, err)
+
+	_, err = database.GetPrometheusChecksUpdatesCount()
+	require.NoError(t, err)
+
+	state, err := database.GetNotifierState()
+	require.NoError(t, err)
+	require.Equal(t, moira.SelfStateOK, state.State)
+
+	require.NoError(t, database.SetNotifierState(moira.SelfStateActorAutomatic, moira.SelfStateERROR))
+
+	state, err = database.GetNotifierState()
+	require.NoError(t, err)
+	require.Equal(t, moira.SelfStateERROR, state.State)
+	require.Equal(t, moira.SelfStateActorAutomatic, state.Actor)
+
+	state, err = database.GetNotifierStateForSource(moira.DefaultLocalCluster)
+	require.NoError(t, err)
+	require.Equal(t, moira.SelfStateOK, state.State)
+
+	require.NoError(t, database.SetNotifierStateForSource(moira.DefaultLocalCluster, moira.SelfStateActorManual, moira.SelfStateERROR))
+
+	state, err = database.GetNotifierStateForSource(moira.DefaultLocalCluster)
+	require.NoError(t, err)
+	require.Equal(t, moira.SelfStateERROR, state.State)
+	require.Equal(t, moira.SelfStateActorManual, state.Actor)
+
+	states, err := database.GetNotifierStateForSources()
+	require.NoError(t, err)
+	require.Equal(t, moira.SelfStateERROR, states[moira.DefaultLocalCluster].State)
+	require.Equal(t, moira.SelfStateOK, states[moira.DefaultGraphiteRemoteCluster].State)
+
+	unknownCluster := moira.MakeClusterKey(moira.GraphiteRemote, moira.ClusterId("unknown"))
+	require.Error(t, database.SetNotifierStateForSource(unknownCluster, moira.SelfStateActorManual, moira.SelfStateERROR))
+
+	_, err = database.GetNotifierStateForSource(unknownCluster)
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testSilences(t *testing.T, database moira.Database) {
+	hardwareSwap := &moira.Silence{
+		ID: "silence1",
+		Matchers: []moira.SilenceMatcher{
+			{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "web1"},
+		},
+		Comment:   "hardware swap",
+		CreatedBy: "user1",
+		CreatedAt: 1000,
+		StartsAt:  2000,
+		ExpiresAt: 3000,
+	}
+	release := &moira.Silence{
+		ID: "silence2",
+		Matchers: []moira.SilenceMatcher{
+			{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchRegexp, Value: "billing-.*"},
+		},
+		Comment:   "release",
+		CreatedBy: "user2",
+		CreatedAt: 1000,
+		StartsAt:  1500,
+		ExpiresAt: 1800,
+	}
+
+	_, err := database.GetSilence(hardwareSwap.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	silences, err := database.GetSilences()
+	require.NoError(t, err)
+	require.Empty(t, silences)
+
+	require.NoError(t, database.SaveSilence(hardwareSwap))
+	require.NoError(t, database.SaveSilence(release))
+
+	silence, err := database.GetSilence(hardwareSwap.ID)
+	require.NoError(t, err)
+	require.Equal(t, *hardwareSwap, silence)
+
+	// Silences are ordered by their start time.
+	silences, err = database.GetSilences()
+	require.NoError(t, err)
+	require.Equal(t, []*moira.Silence{release, hardwareSwap}, silences)
+
+	hardwareSwap.ExpiresAt = 4000
+	require.NoError(t, database.SaveSilence(hardwareSwap))
+
+	silence, err = database.GetSilence(hardwareSwap.ID)
+	require.NoError(t, err)
+	require.Equal(t, *hardwareSwap, silence)
+
+	require.NoError(t, database.RemoveSilence(release.ID))
+	require.NoError(t, database.RemoveSilence("not-existing"))
+
+	_, err = database.GetSilence(release.ID)
+	require.ErrorIs(t, err, db.ErrNil)
+
+	silences, err = database.GetSilences()
+	require.NoError(t, err)
+	require.Equal(t, []*moira.Silence{hardwareSwap}, silences)
+}
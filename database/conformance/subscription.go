@@ -0,0 +1,103 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testSubscriptions(t *testing.T, database moira.Database) {
+	_, err := database.GetSubscription("subscription1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1", "tag1", "tag2")))
+
+	unused, err := database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger1"}, unused)
+
+	subscription1 := &moira.SubscriptionData{
+		ID:       "subscription1",
+		Contacts: []string{"contact1"},
+		Tags:     []string{"tag1", "tag2"},
+		Enabled:  true,
+		User:     "user1",
+	}
+	subscription2 := &moira.SubscriptionData{
+		ID:      "subscription2",
+		Tags:    []string{"tag3"},
+		TeamID:  "team1",
+		Enabled: true,
+	}
+	anyTagsSubscription := &moira.SubscriptionData{
+		ID:      "subscription3",
+		Tags:    []string{"ignored"},
+		AnyTags: true,
+		User:    "user1",
+	}
+
+	require.NoError(t, database.SaveSubscription(subscription1))
+	require.NoError(t, database.SaveSubscriptions([]*moira.SubscriptionData{subscription2, anyTagsSubscription}))
+
+	actual, err := database.GetSubscription("subscription1")
+	require.NoError(t, err)
+	require.Equal(t, *subscription1, actual)
+
+	actual, err = database.GetSubscription("subscription3")
+	require.NoError(t, err)
+	require.Empty(t, actual.Tags)
+	require.True(t, actual.AnyTags)
+
+	subscriptions, err := database.GetSubscriptions([]string{"subscription1", "missing"})
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	require.Equal(t, "subscription1", subscriptions[0].ID)
+	require.Nil(t, subscriptions[1])
+
+	ids, err := database.GetUserSubscriptionIDs("user1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"subscription1", "subscription3"}, ids)
+
+	ids, err = database.GetTeamSubscriptionIDs("team1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"subscription2"}, ids)
+
+	subscriptions, err = database.GetTagsSubscriptions([]string{"tag1"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"subscription1", "subscription3"}, subscriptionIDs(subscriptions))
+
+	unused, err = database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.Empty(t, unused)
+
+	require.NoError(t, database.RemoveSubscription("subscription1"))
+
+	_, err = database.GetSubscription("subscription1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	ids, err = database.GetUserSubscriptionIDs("user1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"subscription3"}, ids)
+
+	// Trigger is still used by subscription on any tags.
+	unused, err = database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.Empty(t, unused)
+
+	require.NoError(t, database.SaveTrigger("trigger2", newTrigger("trigger2")))
+
+	unused, err = database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger2"}, unused)
+}
+
+func subscriptionIDs(subscriptions []*moira.SubscriptionData) []string {
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+
+	return ids
+}
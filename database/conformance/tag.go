@@ -0,0 +1,41 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testTags(t *testing.T, database moira.Database) {
+	require.NoError(t, database.CreateTags([]string{"tag1", "tag2", "abandoned"}))
+
+	names, err := database.GetTagNames()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"tag1", "tag2", "abandoned"}, names)
+
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1", "tag1")))
+	require.NoError(t, database.SaveSubscription(&moira.SubscriptionData{ID: "subscription1", Tags: []string{"tag2"}}))
+
+	triggerIDs, err := database.GetTagTriggerIDs("tag1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger1"}, triggerIDs)
+
+	count, err := database.CleanUpAbandonedTags()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	names, err = database.GetTagNames()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"tag1", "tag2"}, names)
+
+	require.NoError(t, database.RemoveTag("tag1"))
+
+	names, err = database.GetTagNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"tag2"}, names)
+
+	triggerIDs, err = database.GetTagTriggerIDs("tag1")
+	require.NoError(t, err)
+	require.Empty(t, triggerIDs)
+}
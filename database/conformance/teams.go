@@ -0,0 +1,135 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testTeams(t *testing.T, database moira.Database) {
+	_, err := database.GetTeam("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	team1 := moira.Team{ID: "team1", Name: "Team One", Description: "first team", RestrictTriggerChanges: true}
+	require.NoError(t, database.SaveTeam(team1.ID, team1))
+	require.NoError(t, database.SaveTeam("team2", moira.Team{Name: "Team Two"}))
+
+	actual, err := database.GetTeam("team1")
+	require.NoError(t, err)
+	require.Equal(t, team1, actual)
+
+	actual, err = database.GetTeamByName("team one")
+	require.NoError(t, err)
+	require.Equal(t, team1, actual)
+
+	err = database.SaveTeam("team2", moira.Team{Name: "TEAM ONE"})
+	require.ErrorIs(t, err, db.ErrTeamWithNameAlreadyExists)
+
+	team1.Name = "Renamed"
+	require.NoError(t, database.SaveTeam(team1.ID, team1))
+
+	_, err = database.GetTeamByName("Team One")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	teams, err := database.GetAllTeams()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []moira.Team{team1, {ID: "team2", Name: "Team Two"}}, teams)
+
+	err = database.SaveTeamsAndUsers("team1", []string{"user1", "user2"}, map[string][]string{
+		"user1": {"team1"},
+		"user2": {"team1", "team2"},
+	})
+	require.NoError(t, err)
+
+	users, err := database.GetTeamUsers("team1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"user1", "user2"}, users)
+
+	userTeams, err := database.GetUserTeams("user2")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"team1", "team2"}, userTeams)
+
+	ok, err := database.IsTeamContainUser("team1", "user1")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = database.IsTeamContainUser("team2", "user1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	roles, err := database.GetTeamUserRoles("team1")
+	require.NoError(t, err)
+	require.Empty(t, roles)
+
+	require.NoError(t, database.SaveTeamUserRole("team1", "user1", moira.TeamRoleOwner))
+	require.NoError(t, database.SaveTeamUserRole("team1", "user2", moira.TeamRoleViewer))
+	require.NoError(t, database.SaveTeamUserRole("team1", "user2", moira.TeamRoleEditor))
+
+	roles, err = database.GetTeamUserRoles("team1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]moira.TeamRole{"user1": moira.TeamRoleOwner, "user2": moira.TeamRoleEditor}, roles)
+
+	require.NoError(t, database.SaveTeamsAndUsers("team1", []string{"user1"}, map[string][]string{"user2": {"team2"}}))
+
+	// Role of the user who left the team is removed.
+	roles, err = database.GetTeamUserRoles("team1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]moira.TeamRole{"user1": moira.TeamRoleOwner}, roles)
+
+	_, err = database.GetTeamPreferences("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	throttling := true
+	preferences := moira.TeamPreferences{
+		SubscriptionDefaults: moira.TeamSubscriptionDefaults{PlottingTheme: "dark", ThrottlingEnabled: &throttling},
+		QuietHours:           []moira.QuietHoursPeriod{{From: 100, To: 200, Comment: "holidays"}},
+		Tags:                 []string{"team-tag"},
+		Maintenance:          300,
+	}
+	require.NoError(t, database.SaveTeamPreferences("team1", preferences))
+
+	actualPreferences, err := database.GetTeamPreferences("team1")
+	require.NoError(t, err)
+	require.Equal(t, preferences, actualPreferences)
+
+	_, err = database.GetTeamOnCallSchedule("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	schedule := moira.OnCallSchedule{
+		Rotations: []moira.OnCallRotation{{Name: "primary", Participants: []string{"user1", "user2"}, Start: 100, ShiftLength: 3600}},
+		Overrides: []moira.OnCallOverride{{User: "user2", From: 200, To: 300, Comment: "swap"}},
+	}
+	require.NoError(t, database.SaveTeamOnCallSchedule("team1", schedule))
+
+	actualSchedule, err := database.GetTeamOnCallSchedule("team1")
+	require.NoError(t, err)
+	require.Equal(t, schedule, actualSchedule)
+
+	require.NoError(t, database.DeleteTeam("team1", "user1"))
+
+	roles, err = database.GetTeamUserRoles("team1")
+	require.NoError(t, err)
+	require.Empty(t, roles)
+
+	_, err = database.GetTeamPreferences("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetTeamOnCallSchedule("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	_, err = database.GetTeam("team1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	users, err = database.GetTeamUsers("team1")
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	userTeams, err = database.GetUserTeams("user1")
+	require.NoError(t, err)
+	require.Empty(t, userTeams)
+
+	// Name of deleted team is free.
+	require.NoError(t, database.SaveTeam("team3", moira.Team{Name: "Renamed"}))
+}
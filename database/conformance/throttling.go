@@ -0,0 +1,42 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testThrottling(t *testing.T, database moira.Database) {
+	next, beginning := database.GetTriggerThrottling("trigger1")
+	require.Equal(t, time.Unix(0, 0), next)
+	require.Equal(t, time.Unix(0, 0), beginning)
+
+	throttling := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, database.SetTriggerThrottling("trigger1", throttling))
+
+	next, _ = database.GetTriggerThrottling("trigger1")
+	require.Equal(t, throttling.Unix(), next.Unix())
+
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1", "tag1")))
+
+	checks, err := database.GetTriggerChecks([]string{"trigger1", "unknown"})
+	require.NoError(t, err)
+	require.Len(t, checks, 2)
+	require.Equal(t, "trigger1", checks[0].ID)
+	require.Equal(t, []string{"tag1"}, checks[0].Tags)
+	require.Equal(t, throttling.Unix(), checks[0].Throttling)
+	require.Nil(t, checks[1])
+
+	before := time.Now().Unix()
+	require.NoError(t, database.DeleteTriggerThrottling("trigger1"))
+
+	next, beginning = database.GetTriggerThrottling("trigger1")
+	require.Equal(t, time.Unix(0, 0), next)
+	require.GreaterOrEqual(t, beginning.Unix(), before)
+
+	checks, err = database.GetTriggerChecks([]string{"trigger1"})
+	require.NoError(t, err)
+	require.Zero(t, checks[0].Throttling)
+}
@@ -0,0 +1,91 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	db "github.com/moira-alert/moira/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testTriggers(t *testing.T, database moira.Database) {
+	_, err := database.GetTrigger("trigger1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	trigger1 := newTrigger("trigger1", "tag1", "tag2")
+	require.NoError(t, database.SaveTrigger(trigger1.ID, trigger1))
+	require.NoError(t, database.SaveTrigger("trigger2", newTrigger("trigger2", "tag2")))
+
+	actual, err := database.GetTrigger("trigger1")
+	require.NoError(t, err)
+	require.Equal(t, trigger1.ID, actual.ID)
+	require.Equal(t, trigger1.Name, actual.Name)
+	require.Equal(t, trigger1.Targets, actual.Targets)
+	require.ElementsMatch(t, trigger1.Tags, actual.Tags)
+	require.Equal(t, trigger1.Patterns, actual.Patterns)
+
+	triggers, err := database.GetTriggers([]string{"trigger1", "missing", "trigger2"})
+	require.NoError(t, err)
+	require.Len(t, triggers, 3)
+	require.Equal(t, "trigger1", triggers[0].ID)
+	require.Nil(t, triggers[1])
+	require.Equal(t, "trigger2", triggers[2].ID)
+
+	ids, err := database.GetAllTriggerIDs()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, ids)
+
+	ids, err = database.GetTriggerIDs(moira.DefaultLocalCluster)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, ids)
+
+	counts, err := database.GetTriggerCount([]moira.ClusterKey{moira.DefaultLocalCluster})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), counts[moira.DefaultLocalCluster])
+
+	ids, err = database.GetTriggerIDsStartWith("trigger")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, ids)
+
+	patterns, err := database.GetPatterns()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1.*", "trigger2.*"}, patterns)
+
+	ids, err = database.GetPatternTriggerIDs("trigger1.*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger1"}, ids)
+
+	ids, err = database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, ids)
+
+	ids, err = database.FetchTriggersToReindex(0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, ids)
+
+	trigger1.Patterns = []string{"trigger1.new.*"}
+	require.NoError(t, database.SaveTrigger(trigger1.ID, trigger1))
+
+	patterns, err = database.GetPatterns()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1.new.*", "trigger2.*"}, patterns)
+
+	require.NoError(t, database.RemoveTrigger("trigger1"))
+
+	_, err = database.GetTrigger("trigger1")
+	require.ErrorIs(t, err, db.ErrNil)
+
+	ids, err = database.GetPatternTriggerIDs("trigger1.new.*")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	ids, err = database.GetTagTriggerIDs("tag1")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	require.NoError(t, database.RemovePatternTriggerIDs("trigger2.*"))
+
+	ids, err = database.GetPatternTriggerIDs("trigger2.*")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
@@ -0,0 +1,62 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testTriggersToCheck(t *testing.T, database moira.Database) {
+	require.NoError(t, database.AddTriggersToCheck(moira.DefaultLocalCluster, []string{"trigger1", "trigger2", "trigger3"}))
+	require.NoError(t, database.AddTriggersToCheck(moira.DefaultGraphiteRemoteCluster, []string{"remote1"}))
+
+	count, err := database.GetTriggersToCheckCount(moira.DefaultLocalCluster)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	first, err := database.GetTriggersToCheck(moira.DefaultLocalCluster, 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	count, err = database.GetTriggersToCheckCount(moira.DefaultLocalCluster)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	second, err := database.GetTriggersToCheck(moira.DefaultLocalCluster, 10)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2", "trigger3"}, append(first, second...))
+
+	remote, err := database.GetTriggersToCheck(moira.DefaultGraphiteRemoteCluster, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"remote1"}, remote)
+
+	unknownSource := moira.MakeClusterKey(moira.TriggerSourceNotSet, moira.DefaultCluster)
+	require.Error(t, database.AddTriggersToCheck(unknownSource, []string{"trigger1"}))
+
+	_, err = database.GetTriggersToCheckCount(unknownSource)
+	require.Error(t, err)
+}
+
+func testTriggerCheckLocks(t *testing.T, database moira.Database) {
+	acquired, err := database.SetTriggerCheckLock("trigger1")
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = database.SetTriggerCheckLock("trigger1")
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.Error(t, database.AcquireTriggerCheckLock("trigger1", 0))
+	require.NoError(t, database.AcquireTriggerCheckLock("trigger2", 0))
+
+	require.NoError(t, database.DeleteTriggerCheckLock("trigger1"))
+	require.NoError(t, database.AcquireTriggerCheckLock("trigger1", 0))
+
+	database.ReleaseTriggerCheckLock("trigger1")
+
+	acquired, err = database.SetTriggerCheckLock("trigger1")
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
@@ -0,0 +1,44 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/stretchr/testify/require"
+)
+
+func testUnusedTriggers(t *testing.T, database moira.Database) {
+	require.NoError(t, database.MarkTriggersAsUnused())
+	require.NoError(t, database.MarkTriggersAsUnused("trigger1", "trigger2"))
+
+	triggerIDs, err := database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"trigger1", "trigger2"}, triggerIDs)
+
+	require.NoError(t, database.MarkTriggersAsUsed())
+	require.NoError(t, database.MarkTriggersAsUsed("trigger1"))
+
+	triggerIDs, err = database.GetUnusedTriggerIDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger2"}, triggerIDs)
+}
+
+func testTriggersToReindex(t *testing.T, database moira.Database) {
+	before := time.Now().Unix()
+	require.NoError(t, database.SaveTrigger("trigger1", newTrigger("trigger1")))
+
+	triggerIDs, err := database.FetchTriggersToReindex(before)
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger1"}, triggerIDs)
+
+	triggerIDs, err = database.FetchTriggersToReindex(time.Now().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	require.Empty(t, triggerIDs)
+
+	require.NoError(t, database.RemoveTriggersToReindex(time.Now().Add(time.Hour).Unix()))
+
+	triggerIDs, err = database.FetchTriggersToReindex(before)
+	require.NoError(t, err)
+	require.Empty(t, triggerIDs)
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveAPIToken saves API token and indexes it by its hash and by its user or team.
+func (connector *DbConnector) SaveAPIToken(token *moira.APIToken) error {
+	bytes, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal api token: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	if existing, err := s.getAPIToken(token.ID); err == nil {
+		s.removeAPITokenIndexes(existing)
+	}
+
+	s.apiTokens[token.ID] = bytes
+	s.apiTokenHashes[token.Hash] = token.ID
+
+	if token.TeamID != "" {
+		addToSet(s.teamAPITokens, token.TeamID, token.ID)
+	} else {
+		addToSet(s.userAPITokens, token.User, token.ID)
+	}
+
+	return nil
+}
+
+// GetAPIToken returns API token by its id, if there is no such token, returns database.ErrNil error.
+func (connector *DbConnector) GetAPIToken(tokenID string) (moira.APIToken, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getAPIToken(tokenID)
+}
+
+// GetAPITokenByHash returns API token by hash of its secret, if there is no such token, returns database.ErrNil error.
+func (connector *DbConnector) GetAPITokenByHash(hash string) (moira.APIToken, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	tokenID, ok := s.apiTokenHashes[hash]
+	if !ok {
+		return moira.APIToken{}, database.ErrNil
+	}
+
+	return s.getAPIToken(tokenID)
+}
+
+// GetUserAPITokens returns personal API tokens of the user.
+func (connector *DbConnector) GetUserAPITokens(userLogin string) ([]*moira.APIToken, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getAPITokens(setMembers(s.userAPITokens, userLogin))
+}
+
+// GetTeamAPITokens returns API tokens of the team.
+func (connector *DbConnector) GetTeamAPITokens(teamID string) ([]*moira.APIToken, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getAPITokens(setMembers(s.teamAPITokens, teamID))
+}
+
+// RemoveAPIToken removes API token and all its indexes.
+func (connector *DbConnector) RemoveAPIToken(tokenID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	token, err := s.getAPIToken(tokenID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	s.removeAPITokenIndexes(token)
+	delete(s.apiTokens, tokenID)
+
+	return nil
+}
+
+// SaveAuthSession saves session by hash of its secret, the session expires at session.ExpiresAt.
+func (connector *DbConnector) SaveAuthSession(sessionHash string, session moira.AuthSession) error {
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth session: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.authSessions[sessionHash] = bytes
+
+	return nil
+}
+
+// GetAuthSession returns not expired session by hash of its secret, if there is no such session, returns database.ErrNil error.
+func (connector *DbConnector) GetAuthSession(sessionHash string) (moira.AuthSession, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.authSessions[sessionHash]
+	if !ok {
+		return moira.AuthSession{}, database.ErrNil
+	}
+
+	session := moira.AuthSession{}
+	if err := json.Unmarshal(bytes, &session); err != nil {
+		return moira.AuthSession{}, fmt.Errorf("failed to parse auth session json %s: %w", string(bytes), err)
+	}
+
+	if session.ExpiresAt <= connector.Clock.NowUnix() {
+		delete(s.authSessions, sessionHash)
+		return moira.AuthSession{}, database.ErrNil
+	}
+
+	return session, nil
+}
+
+// RemoveAuthSession removes session by hash of its secret.
+func (connector *DbConnector) RemoveAuthSession(sessionHash string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.authSessions, sessionHash)
+
+	return nil
+}
+
+func (s *state) getAPIToken(tokenID string) (moira.APIToken, error) {
+	bytes, ok := s.apiTokens[tokenID]
+	if !ok {
+		return moira.APIToken{}, database.ErrNil
+	}
+
+	token := moira.APIToken{}
+	if err := json.Unmarshal(bytes, &token); err != nil {
+		return moira.APIToken{}, fmt.Errorf("failed to parse api token json %s: %w", string(bytes), err)
+	}
+
+	return token, nil
+}
+
+func (s *state) getAPITokens(tokenIDs []string) ([]*moira.APIToken, error) {
+	tokens := make([]*moira.APIToken, 0, len(tokenIDs))
+
+	for _, tokenID := range tokenIDs {
+		token, err := s.getAPIToken(tokenID)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+func (s *state) removeAPITokenIndexes(token moira.APIToken) {
+	delete(s.apiTokenHashes, token.Hash)
+	removeFromSet(s.teamAPITokens, token.TeamID, token.ID)
+	removeFromSet(s.userAPITokens, token.User, token.ID)
+}
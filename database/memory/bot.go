@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"strings"
+
+	"github.com/moira-alert/moira/database"
+)
+
+// GetChatByUsername gets chat id by username in the messenger. Usernames starting with "#" are returned as they are, with "@" prefix.
+func (connector *DbConnector) GetChatByUsername(messenger, username string) (string, error) {
+	if strings.HasPrefix(username, "#") {
+		return "@" + username[1:], nil
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	chat, ok := s.usernameChats[usernameKey(messenger, username)]
+	if !ok {
+		return "", database.ErrNil
+	}
+
+	return chat, nil
+}
+
+// SetUsernameChat sets chat id for the username in the messenger.
+func (connector *DbConnector) SetUsernameChat(messenger, username, chatRaw string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.usernameChats[usernameKey(messenger, username)] = chatRaw
+
+	return nil
+}
+
+// RemoveUser removes username from the messenger data.
+func (connector *DbConnector) RemoveUser(messenger, username string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.usernameChats, usernameKey(messenger, username))
+
+	return nil
+}
+
+func usernameKey(messenger, username string) string {
+	return messenger + ":" + username
+}
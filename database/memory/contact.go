@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetContact returns contact data by given id, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetContact(id string) (moira.ContactData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getContact(id)
+}
+
+// GetContacts returns contacts data by given ids, len of contactIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetContacts(contactIDs []string) ([]*moira.ContactData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getContacts(contactIDs)
+}
+
+// GetAllContacts returns full contact list.
+func (connector *DbConnector) GetAllContacts() ([]*moira.ContactData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	contactIDs := make(stringSet, len(s.contacts))
+	for id := range s.contacts {
+		contactIDs.add(id)
+	}
+
+	return s.getContacts(contactIDs.members())
+}
+
+// SaveContact writes contact data and updates user contacts.
+func (connector *DbConnector) SaveContact(contact *moira.ContactData) error {
+	bytes, err := json.Marshal(contact)
+	if err != nil {
+		return err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	existing, err := s.getContact(contact.ID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return err
+	}
+
+	if err == nil {
+		if contact.User != existing.User {
+			removeFromSet(s.userContacts, existing.User, contact.ID)
+		}
+
+		if contact.Team != existing.Team {
+			removeFromSet(s.teamContacts, existing.Team, contact.ID)
+		}
+	}
+
+	s.contacts[contact.ID] = bytes
+	delete(s.contactScores, contact.ID)
+
+	if contact.User != "" {
+		addToSet(s.userContacts, contact.User, contact.ID)
+	}
+
+	if contact.Team != "" {
+		addToSet(s.teamContacts, contact.Team, contact.ID)
+	}
+
+	return nil
+}
+
+// RemoveContact deletes contact data and contactID from user contacts.
+func (connector *DbConnector) RemoveContact(contactID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	existing, err := s.getContact(contactID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return err
+	}
+
+	delete(s.contacts, contactID)
+	delete(s.contactScores, contactID)
+	removeFromSet(s.userContacts, existing.User, contactID)
+	removeFromSet(s.teamContacts, existing.Team, contactID)
+
+	return nil
+}
+
+// GetUserContactIDs returns contacts ids by given login.
+func (connector *DbConnector) GetUserContactIDs(login string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.userContacts, login), nil
+}
+
+// GetTeamContactIDs returns contacts ids by given team.
+func (connector *DbConnector) GetTeamContactIDs(login string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.teamContacts, login), nil
+}
+
+// UpdateContactScores updates the scores of contacts based on the provided IDs and updater function.
+func (connector *DbConnector) UpdateContactScores(contactIDs []string, updater func(moira.ContactScore) moira.ContactScore) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	updated := make(map[string][]byte, len(contactIDs))
+
+	for _, contactID := range contactIDs {
+		contactScore := moira.ContactScore{ContactID: contactID}
+
+		if bytes, ok := s.contactScores[contactID]; ok {
+			if err := json.Unmarshal(bytes, &contactScore); err != nil {
+				return err
+			}
+		}
+
+		bytes, err := json.Marshal(updater(contactScore))
+		if err != nil {
+			return err
+		}
+
+		updated[contactID] = bytes
+	}
+
+	for contactID, bytes := range updated {
+		s.contactScores[contactID] = bytes
+	}
+
+	return nil
+}
+
+// GetContactsScore returns contacts scores as map[contactID]ContactScore.
+func (connector *DbConnector) GetContactsScore(contactIDs []string) (map[string]*moira.ContactScore, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getContactsScore(contactIDs)
+}
+
+// GetContactScore returns contact score by given contact id.
+func (connector *DbConnector) GetContactScore(contactID string) (*moira.ContactScore, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	scores, err := s.getContactsScore([]string{contactID})
+	if err != nil {
+		return nil, err
+	}
+
+	return scores[contactID], nil
+}
+
+func (s *state) getContact(id string) (moira.ContactData, error) {
+	bytes, ok := s.contacts[id]
+	if !ok {
+		return moira.ContactData{}, database.ErrNil
+	}
+
+	contact := moira.ContactData{}
+	if err := json.Unmarshal(bytes, &contact); err != nil {
+		return contact, fmt.Errorf("failed to parse contact json %s: %w", string(bytes), err)
+	}
+
+	contact.ID = id
+
+	return contact, nil
+}
+
+func (s *state) getContacts(contactIDs []string) ([]*moira.ContactData, error) {
+	contacts := make([]*moira.ContactData, len(contactIDs))
+
+	for i, id := range contactIDs {
+		contact, err := s.getContact(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		contacts[i] = &contact
+	}
+
+	return contacts, nil
+}
+
+func (s *state) getContactsScore(contactIDs []string) (map[string]*moira.ContactScore, error) {
+	contactScores := make(map[string]*moira.ContactScore, len(contactIDs))
+
+	for _, contactID := range contactIDs {
+		bytes, ok := s.contactScores[contactID]
+		if !ok {
+			continue
+		}
+
+		contactScore := moira.ContactScore{}
+		if err := json.Unmarshal(bytes, &contactScore); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal contact score: %w", err)
+		}
+
+		contactScores[contactID] = &contactScore
+	}
+
+	return contactScores, nil
+}
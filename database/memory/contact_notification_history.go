@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// GetNotificationsHistoryByContactID returns `size` (or all if `size` is -1) notification events with timestamp between `from` and `to`.
+// The offset for fetching events may be changed by using `page` parameter, it is calculated as page * size.
+func (connector *DbConnector) GetNotificationsHistoryByContactID(contactID string, from, to, page, size int64,
+) ([]*moira.NotificationEventHistoryItem, int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	members, err := s.contactNotifications[contactID].rangeByScore(strconv.FormatInt(from, 10), strconv.FormatInt(to, 10))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(len(members))
+	members = limitMembers(members, page*size, size)
+
+	notifications := make([]*moira.NotificationEventHistoryItem, 0, len(members))
+
+	for _, member := range members {
+		notification, err := redis.GetNotificationStruct(member.member)
+		if err != nil {
+			return notifications, total, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, total, nil
+}
+
+// PushContactNotificationToHistory converts ScheduledNotification to NotificationEventHistoryItem and saves it,
+// and deletes items older than specified ttl.
+func (connector *DbConnector) PushContactNotificationToHistory(notification *moira.ScheduledNotification) error {
+	notificationItemToSave := &moira.NotificationEventHistoryItem{
+		Metric:    notification.Event.Metric,
+		State:     notification.Event.State,
+		TriggerID: notification.Trigger.ID,
+		OldState:  notification.Event.OldState,
+		ContactID: notification.Contact.ID,
+		TimeStamp: notification.Timestamp,
+	}
+
+	bytes, err := redis.GetNotificationBytes(notificationItemToSave)
+	if err != nil {
+		return fmt.Errorf("failed to serialize notification to contact event history item: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	set, ok := s.contactNotifications[notificationItemToSave.ContactID]
+	if !ok {
+		set = sortedSet{}
+		s.contactNotifications[notificationItemToSave.ContactID] = set
+	}
+
+	set[string(bytes)] = float64(notification.Timestamp)
+
+	to := connector.Clock.NowUnix() - int64(connector.notificationHistory.NotificationHistoryTTL.Seconds())
+	if _, err := set.removeRangeByScore("-inf", strconv.FormatInt(to, 10)); err != nil {
+		return fmt.Errorf("failed to push contact event history item: %w", err)
+	}
+
+	if len(set) == 0 {
+		delete(s.contactNotifications, notificationItemToSave.ContactID)
+	}
+
+	return nil
+}
+
+// CleanUpOutdatedNotificationHistory is used for deleting notification history events which have been created more than ttl ago.
+func (connector *DbConnector) CleanUpOutdatedNotificationHistory(ttl int64) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	to := strconv.FormatInt(connector.Clock.NowUnix()-ttl, 10)
+
+	var totalDelCount int64
+
+	for contactID, set := range s.contactNotifications {
+		count, err := set.removeRangeByScore("-inf", to)
+		if err != nil {
+			return err
+		}
+
+		if len(set) == 0 {
+			delete(s.contactNotifications, contactID)
+		}
+
+		totalDelCount += count
+	}
+
+	connector.logger.Info().
+		Int64("delete_count", totalDelCount).
+		Msg("Cleaned up notification history")
+
+	return nil
+}
+
+// CountEventsInNotificationHistory returns the number of events in time range (from, to) for given contact ids.
+func (connector *DbConnector) CountEventsInNotificationHistory(contactIDs []string, from, to string) ([]*moira.ContactIDWithNotificationCount, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	eventsCount := make([]*moira.ContactIDWithNotificationCount, 0, len(contactIDs))
+
+	for _, id := range contactIDs {
+		members, err := s.contactNotifications[id].rangeByScore(from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		eventsCount = append(eventsCount, &moira.ContactIDWithNotificationCount{
+			ID:    id,
+			Count: uint64(len(members)),
+		})
+	}
+
+	return eventsCount, nil
+}
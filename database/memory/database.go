@@ -0,0 +1,242 @@
+// Package memory implements moira.Database in process memory.
+//
+// The implementation follows the behaviour of database/redis and passes the same database/conformance
+// tests, so it can be used as a reference implementation when writing another storage backend
+// and as a lightweight replacement of Redis (or of generated mock) in unit tests.
+// Stored data is lost when the process stops, so it must not be used in production.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/clock"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// DbConnector keeps all the data in memory and guards it with a single mutex.
+type DbConnector struct {
+	Clock               moira.Clock
+	logger              moira.Logger
+	source              redis.DBSource
+	metricsTTLSeconds   int64
+	notificationHistory redis.NotificationHistoryConfig
+	notification        redis.NotificationConfig
+	clusterList         moira.ClusterList
+
+	mutex sync.Mutex
+	state *state
+}
+
+// NewDatabase returns configured instance of DbConnector.
+func NewDatabase(
+	logger moira.Logger,
+	metricsTTL time.Duration,
+	nh redis.NotificationHistoryConfig,
+	n redis.NotificationConfig,
+	source redis.DBSource,
+	clusterList moira.ClusterList,
+) *DbConnector {
+	return &DbConnector{
+		Clock:               clock.NewSystemClock(),
+		logger:              logger,
+		source:              source,
+		metricsTTLSeconds:   int64(metricsTTL.Seconds()),
+		notificationHistory: nh,
+		notification:        n,
+		clusterList:         clusterList,
+		state:               newState(),
+	}
+}
+
+// NewTestDatabase returns DbConnector configured the same way as redis.NewTestDatabase, use it only for tests.
+func NewTestDatabase(logger moira.Logger) *DbConnector {
+	return NewDatabase(
+		logger,
+		time.Hour,
+		redis.NotificationHistoryConfig{
+			NotificationHistoryTTL: time.Hour * 48,
+		},
+		redis.NotificationConfig{
+			DelayedTime:               time.Minute,
+			TransactionTimeout:        100 * time.Millisecond,
+			TransactionMaxRetries:     10,
+			TransactionHeuristicLimit: 10000,
+			ResaveTime:                30 * time.Second,
+		},
+		"test",
+		moira.ClusterList{moira.DefaultLocalCluster, moira.DefaultGraphiteRemoteCluster, moira.DefaultPrometheusRemoteCluster},
+	)
+}
+
+// NewTestDatabaseWithClock returns test DbConnector with the provided clock.
+func NewTestDatabaseWithClock(logger moira.Logger, clock moira.Clock) *DbConnector {
+	db := NewTestDatabase(logger)
+	db.Clock = clock
+
+	return db
+}
+
+// Flush deletes all the data, use it only for tests.
+func (connector *DbConnector) Flush() {
+	connector.mutex.Lock()
+	defer connector.mutex.Unlock()
+
+	connector.state = newState()
+}
+
+// lock locks the database and returns its state. Call unlock when work with state is done.
+func (connector *DbConnector) lock() *state {
+	connector.mutex.Lock()
+	return connector.state
+}
+
+func (connector *DbConnector) unlock() {
+	connector.mutex.Unlock()
+}
+
+// state contains all the data stored in database. Values of objects are kept serialized
+// to JSON like in Redis, so callers can not modify stored data through returned pointers.
+type state struct {
+	tags                 stringSet
+	tagTriggers          map[string]stringSet
+	tagSubscriptions     map[string]stringSet
+	anyTagsSubscriptions stringSet
+
+	triggers        map[string][]byte
+	triggerTags     map[string]stringSet
+	clusterTriggers map[moira.ClusterKey]stringSet
+	patterns        stringSet
+	patternTriggers map[string]stringSet
+	unusedTriggers  stringSet
+	toReindex       sortedSet
+	triggersToCheck map[moira.ClusterKey]stringSet
+	checkLocks      map[string]time.Time
+
+	lastChecks          map[string][]byte
+	checksCounters      map[string]int64
+	metricsHeartbeat    int64
+	notifierState       []byte
+	notifierStateByKeys map[moira.ClusterKey][]byte
+
+	throttlingNext      map[string]int64
+	throttlingBeginning map[string]int64
+
+	searchResults map[string]*searchResults
+
+	events        []string
+	uiEvents      []string
+	triggerEvents map[string]sortedSet
+
+	contacts             map[string][]byte
+	userContacts         map[string]stringSet
+	teamContacts         map[string]stringSet
+	contactScores        map[string][]byte
+	contactNotifications map[string]sortedSet
+
+	subscriptions     map[string][]byte
+	userSubscriptions map[string]stringSet
+	teamSubscriptions map[string]stringSet
+
+	teams           map[string][]byte
+	teamNames       map[string]string
+	teamUsers       map[string]stringSet
+	teamRoles       map[string]map[string]moira.TeamRole
+	teamPreferences map[string][]byte
+	userTeams       map[string]stringSet
+
+	teamOnCallSchedules map[string][]byte
+
+	maintenanceWindows map[string][]byte
+	silences           map[string][]byte
+
+	metricData       map[string]sortedSet
+	metricRetentions map[string]int64
+	patternMetrics   map[string]stringSet
+	metricEvents     map[moira.MetricEvent]struct{}
+
+	notifications  map[moira.ClusterKey]sortedSet
+	deadLetters    []string
+	deliveryChecks map[string]sortedSet
+	usernameChats  map[string]string
+	threads        map[string]string
+	incidents      map[string]int64
+	locks          stringSet
+
+	apiTokens      map[string][]byte
+	apiTokenHashes map[string]string
+	userAPITokens  map[string]stringSet
+	teamAPITokens  map[string]stringSet
+	authSessions   map[string][]byte
+}
+
+func newState() *state {
+	return &state{
+		tags:                 stringSet{},
+		tagTriggers:          map[string]stringSet{},
+		tagSubscriptions:     map[string]stringSet{},
+		anyTagsSubscriptions: stringSet{},
+
+		triggers:        map[string][]byte{},
+		triggerTags:     map[string]stringSet{},
+		clusterTriggers: map[moira.ClusterKey]stringSet{},
+		patterns:        stringSet{},
+		patternTriggers: map[string]stringSet{},
+		unusedTriggers:  stringSet{},
+		toReindex:       sortedSet{},
+		triggersToCheck: map[moira.ClusterKey]stringSet{},
+		checkLocks:      map[string]time.Time{},
+
+		lastChecks:          map[string][]byte{},
+		checksCounters:      map[string]int64{},
+		notifierStateByKeys: map[moira.ClusterKey][]byte{},
+
+		throttlingNext:      map[string]int64{},
+		throttlingBeginning: map[string]int64{},
+
+		searchResults: map[string]*searchResults{},
+
+		triggerEvents: map[string]sortedSet{},
+
+		contacts:             map[string][]byte{},
+		userContacts:         map[string]stringSet{},
+		teamContacts:         map[string]stringSet{},
+		contactScores:        map[string][]byte{},
+		contactNotifications: map[string]sortedSet{},
+
+		subscriptions:     map[string][]byte{},
+		userSubscriptions: map[string]stringSet{},
+		teamSubscriptions: map[string]stringSet{},
+
+		teams:           map[string][]byte{},
+		teamNames:       map[string]string{},
+		teamUsers:       map[string]stringSet{},
+		teamRoles:       map[string]map[string]moira.TeamRole{},
+		teamPreferences: map[string][]byte{},
+		userTeams:       map[string]stringSet{},
+
+		teamOnCallSchedules: map[string][]byte{},
+
+		maintenanceWindows: map[string][]byte{},
+		silences:           map[string][]byte{},
+
+		metricData:       map[string]sortedSet{},
+		metricRetentions: map[string]int64{},
+		patternMetrics:   map[string]stringSet{},
+		metricEvents:     map[moira.MetricEvent]struct{}{},
+
+		notifications:  map[moira.ClusterKey]sortedSet{},
+		deliveryChecks: map[string]sortedSet{},
+		usernameChats:  map[string]string{},
+		threads:        map[string]string{},
+		incidents:      map[string]int64{},
+		locks:          stringSet{},
+
+		apiTokens:      map[string][]byte{},
+		apiTokenHashes: map[string]string{},
+		userAPITokens:  map[string]stringSet{},
+		teamAPITokens:  map[string]stringSet{},
+		authSessions:   map[string][]byte{},
+	}
+}
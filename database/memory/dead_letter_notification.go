@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// AddDeadLetterNotification adds notification to the head of dead letter list and trims the list to maxSize if maxSize is positive.
+func (connector *DbConnector) AddDeadLetterNotification(notification *moira.DeadLetterNotification, maxSize int64) error {
+	bytes, err := reply.GetDeadLetterNotificationBytes(*notification)
+	if err != nil {
+		return err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.deadLetters = pushFront(s.deadLetters, string(bytes))
+
+	if maxSize > 0 {
+		s.deadLetters = listRange(s.deadLetters, 0, maxSize-1)
+	}
+
+	return nil
+}
+
+// GetDeadLetterNotifications returns dead letter notifications in range of list indices and total count of them.
+func (connector *DbConnector) GetDeadLetterNotifications(start, end int64) ([]*moira.DeadLetterNotification, int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	values := listRange(s.deadLetters, start, end)
+	notifications := make([]*moira.DeadLetterNotification, 0, len(values))
+
+	for _, value := range values {
+		notification, err := reply.DeadLetterNotification(value)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, int64(len(s.deadLetters)), nil
+}
+
+// GetDeadLetterNotification returns dead letter notification by id, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetDeadLetterNotification(id string) (moira.DeadLetterNotification, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	notification, _, err := s.findDeadLetterNotification(id)
+
+	return notification, err
+}
+
+// RemoveDeadLetterNotification removes dead letter notification by id and returns count of removed notifications.
+func (connector *DbConnector) RemoveDeadLetterNotification(id string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	_, value, err := s.findDeadLetterNotification(id)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	var count int64
+
+	remaining := make([]string, 0, len(s.deadLetters))
+
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter == value {
+			count++
+			continue
+		}
+
+		remaining = append(remaining, deadLetter)
+	}
+
+	s.deadLetters = remaining
+
+	return count, nil
+}
+
+// RemoveAllDeadLetterNotifications removes all dead letter notifications.
+func (connector *DbConnector) RemoveAllDeadLetterNotifications() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.deadLetters = nil
+
+	return nil
+}
+
+func (s *state) findDeadLetterNotification(id string) (moira.DeadLetterNotification, string, error) {
+	for _, value := range s.deadLetters {
+		notification, err := reply.DeadLetterNotification(value)
+		if err != nil {
+			return moira.DeadLetterNotification{}, "", err
+		}
+
+		if notification.ID == id {
+			return notification, value, nil
+		}
+	}
+
+	return moira.DeadLetterNotification{}, "", database.ErrNil
+}
@@ -0,0 +1,59 @@
+package memory
+
+// AddDeliveryChecksData adds data for delivery checks of the contact type.
+func (connector *DbConnector) AddDeliveryChecksData(contactType string, timestamp int64, data string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	set, ok := s.deliveryChecks[contactType]
+	if !ok {
+		set = sortedSet{}
+		s.deliveryChecks[contactType] = set
+	}
+
+	set[data] = float64(timestamp)
+
+	return nil
+}
+
+// GetDeliveryChecksData returns delivery checks data of the contact type with timestamps in given range.
+// In from and to, expect either -inf, +inf, or timestamps as strings.
+func (connector *DbConnector) GetDeliveryChecksData(contactType string, from string, to string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	members, err := s.deliveryChecks[contactType].rangeByScore(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]string, 0, len(members))
+	for _, member := range members {
+		data = append(data, member.member)
+	}
+
+	return data, nil
+}
+
+// RemoveDeliveryChecksData removes delivery checks data of the contact type with timestamps in given range
+// and returns count of removed entries.
+func (connector *DbConnector) RemoveDeliveryChecksData(contactType string, from string, to string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	set, ok := s.deliveryChecks[contactType]
+	if !ok {
+		return 0, nil
+	}
+
+	count, err := set.removeRangeByScore(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(set) == 0 {
+		delete(s.deliveryChecks, contactType)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"github.com/moira-alert/moira/database"
+)
+
+// SetIncidentMaintenance saves the maintenance which acknowledgement of the incident set, it expires with the maintenance.
+func (connector *DbConnector) SetIncidentMaintenance(incidentKey string, maintenance int64) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.incidents[incidentKey] = maintenance
+
+	return nil
+}
+
+// GetIncidentMaintenance returns the not expired maintenance which acknowledgement of the incident set,
+// if there is no such maintenance, returns database.ErrNil error.
+func (connector *DbConnector) GetIncidentMaintenance(incidentKey string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	maintenance, ok := s.incidents[incidentKey]
+	if !ok || maintenance <= connector.Clock.NowUnix() {
+		return 0, database.ErrNil
+	}
+
+	return maintenance, nil
+}
+
+// RemoveIncidentMaintenance removes the maintenance which acknowledgement of the incident set.
+func (connector *DbConnector) RemoveIncidentMaintenance(incidentKey string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.incidents, incidentKey)
+
+	return nil
+}
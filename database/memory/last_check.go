@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/clock"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// GetTriggerLastCheck gets trigger last check data by given triggerID, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetTriggerLastCheck(triggerID string) (moira.CheckData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getTriggerLastCheck(triggerID)
+}
+
+// SetTriggerLastCheck sets trigger last check data.
+func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, clusterKey moira.ClusterKey) error {
+	bytes, err := json.Marshal(checkData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal check data: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	oldCheck, err := s.getTriggerLastCheck(triggerID)
+	triggerNeedToReindex := err != nil || oldCheck.Score != checkData.Score
+
+	s.lastChecks[triggerID] = bytes
+
+	if counterName := connector.getSelfStateCheckCounterName(clusterKey); counterName != "" {
+		s.checksCounters[counterName]++
+	}
+
+	if triggerNeedToReindex {
+		s.toReindex[triggerID] = float64(connector.Clock.NowUnix())
+	}
+
+	return nil
+}
+
+// RemoveTriggerLastCheck removes trigger last check data.
+func (connector *DbConnector) RemoveTriggerLastCheck(triggerID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.lastChecks, triggerID)
+	s.toReindex[triggerID] = float64(connector.Clock.NowUnix())
+
+	return nil
+}
+
+// CleanUpAbandonedTriggerLastCheck cleans up abandoned triggers last check.
+func (connector *DbConnector) CleanUpAbandonedTriggerLastCheck() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var count int
+
+	for triggerID := range s.lastChecks {
+		if _, ok := s.triggers[triggerID]; ok {
+			continue
+		}
+
+		delete(s.lastChecks, triggerID)
+		s.toReindex[triggerID] = float64(connector.Clock.NowUnix())
+
+		count++
+	}
+
+	connector.logger.Info().
+		Int("count deleted last_check", count).
+		Msg("Cleaned up last check for trigger")
+
+	return nil
+}
+
+// SetTriggerCheckMaintenance sets maintenance for whole trigger and to given metrics,
+// If CheckData does not contain one of given metrics it will ignore this metric.
+func (connector *DbConnector) SetTriggerCheckMaintenance(triggerID string, metrics map[string]int64, triggerMaintenance *int64, userLogin string, timeCallMaintenance int64) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	lastCheck, err := s.getTriggerLastCheck(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return err
+	}
+
+	for metric, value := range metrics {
+		data, ok := lastCheck.Metrics[metric]
+		if !ok {
+			continue
+		}
+
+		moira.SetMaintenanceUserAndTime(&data, value, userLogin, timeCallMaintenance)
+		lastCheck.Metrics[metric] = data
+	}
+
+	if triggerMaintenance != nil {
+		moira.SetMaintenanceUserAndTime(&lastCheck, *triggerMaintenance, userLogin, timeCallMaintenance)
+	}
+
+	bytes, err := json.Marshal(lastCheck)
+	if err != nil {
+		return err
+	}
+
+	s.lastChecks[triggerID] = bytes
+
+	return nil
+}
+
+func (connector *DbConnector) getSelfStateCheckCounterName(clusterKey moira.ClusterKey) string {
+	if connector.source != redis.Checker {
+		return ""
+	}
+
+	var name string
+
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteLocal:
+		name = checksCounterName
+
+	case moira.GraphiteRemote:
+		name = remoteChecksCounterName
+
+	case moira.PrometheusRemote:
+		name = prometheusChecksCounterName
+
+	case moira.LokiRemote:
+		name = lokiChecksCounterName
+
+	case moira.SQLRemote:
+		name = sqlChecksCounterName
+
+	default:
+		return ""
+	}
+
+	if clusterKey.ClusterId != moira.DefaultCluster {
+		name = name + ":" + clusterKey.ClusterId.String()
+	}
+
+	return name
+}
+
+func (s *state) getTriggerLastCheck(triggerID string) (moira.CheckData, error) {
+	bytes, ok := s.lastChecks[triggerID]
+	if !ok {
+		return moira.CheckData{}, database.ErrNil
+	}
+
+	checkData := moira.CheckData{}
+	if err := json.Unmarshal(bytes, &checkData); err != nil {
+		return checkData, fmt.Errorf("failed to parse lastCheck json %s: %w", string(bytes), err)
+	}
+
+	for metricName, metricState := range checkData.Metrics {
+		if metricState.Values == nil {
+			metricState.Values = make(map[string]float64)
+			checkData.Metrics[metricName] = metricState
+		}
+	}
+
+	if checkData.MetricsToTargetRelation == nil {
+		checkData.MetricsToTargetRelation = make(map[string]string)
+	}
+
+	checkData.Clock = clock.NewSystemClock()
+
+	return checkData, nil
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// NewLock returns the implementation of moira.Lock which can be used to Acquire or Release the lock.
+func (connector *DbConnector) NewLock(name string, ttl time.Duration) moira.Lock {
+	return &Lock{name: name, ttl: ttl, connector: connector}
+}
+
+// Lock is used to hide low-level details of locking such as an in-memory name registry.
+// The lock is held until Release is called, so it never expires like Redis lock does.
+type Lock struct {
+	name      string
+	ttl       time.Duration
+	connector *DbConnector
+	lost      chan struct{}
+	m         sync.Mutex
+	isHeld    bool
+}
+
+// Acquire attempts to acquire the lock and blocks while doing so.
+// Providing a non-nil stop channel can be used to abort the acquire attempt.
+// Returns lost channel that is closed if the lock is released.
+func (lock *Lock) Acquire(stop <-chan struct{}) (<-chan struct{}, error) {
+	for {
+		lost, err := lock.tryAcquire()
+		if err == nil {
+			return lost, nil
+		}
+
+		if errors.Is(err, database.ErrLockAlreadyHeld) {
+			return nil, database.ErrLockAlreadyHeld
+		}
+
+		select {
+		case <-stop:
+			return nil, database.ErrLockAcquireInterrupted
+		case <-time.After(lock.ttl / 3): //nolint
+			continue
+		}
+	}
+}
+
+// Release releases the lock.
+func (lock *Lock) Release() {
+	lock.m.Lock()
+	defer lock.m.Unlock()
+
+	if !lock.isHeld {
+		return
+	}
+
+	lock.isHeld = false
+	close(lock.lost)
+
+	s := lock.connector.lock()
+	defer lock.connector.unlock()
+
+	s.locks.remove(lock.name)
+}
+
+func (lock *Lock) tryAcquire() (<-chan struct{}, error) {
+	lock.m.Lock()
+	defer lock.m.Unlock()
+
+	if lock.isHeld {
+		return nil, database.ErrLockAlreadyHeld
+	}
+
+	s := lock.connector.lock()
+	defer lock.connector.unlock()
+
+	if s.locks.contains(lock.name) {
+		return nil, &database.ErrLockNotAcquired{Err: errLockTaken}
+	}
+
+	s.locks.add(lock.name)
+
+	lock.lost = make(chan struct{})
+	lock.isHeld = true
+
+	return lock.lost, nil
+}
+
+var errLockTaken = errors.New("lock is already taken")
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveMaintenanceWindow saves maintenance window, existing window with the same id is replaced.
+func (connector *DbConnector) SaveMaintenanceWindow(window *moira.MaintenanceWindow) error {
+	bytes, err := json.Marshal(window)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance window: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.maintenanceWindows[window.ID] = bytes
+
+	return nil
+}
+
+// GetMaintenanceWindow returns maintenance window by its id, if there is no such window, returns database.ErrNil error.
+func (connector *DbConnector) GetMaintenanceWindow(windowID string) (moira.MaintenanceWindow, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.maintenanceWindows[windowID]
+	if !ok {
+		return moira.MaintenanceWindow{}, database.ErrNil
+	}
+
+	return unmarshalMaintenanceWindow(bytes)
+}
+
+// GetMaintenanceWindows returns all maintenance windows ordered by their start.
+func (connector *DbConnector) GetMaintenanceWindows() ([]*moira.MaintenanceWindow, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	windows := make([]*moira.MaintenanceWindow, 0, len(s.maintenanceWindows))
+
+	for _, bytes := range s.maintenanceWindows {
+		window, err := unmarshalMaintenanceWindow(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &window)
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].Start == windows[j].Start {
+			return windows[i].ID < windows[j].ID
+		}
+
+		return windows[i].Start < windows[j].Start
+	})
+
+	return windows, nil
+}
+
+// RemoveMaintenanceWindow removes maintenance window by its id.
+func (connector *DbConnector) RemoveMaintenanceWindow(windowID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.maintenanceWindows, windowID)
+
+	return nil
+}
+
+func unmarshalMaintenanceWindow(bytes []byte) (moira.MaintenanceWindow, error) {
+	var window moira.MaintenanceWindow
+	if err := json.Unmarshal(bytes, &window); err != nil {
+		return moira.MaintenanceWindow{}, fmt.Errorf("failed to parse maintenance window json %s: %w", string(bytes), err)
+	}
+
+	return window, nil
+}
@@ -0,0 +1,414 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis"
+	"gopkg.in/tomb.v2"
+)
+
+const (
+	defaultRetention          = 60
+	metricEventChannelSize    = 16384
+	receiveEmptySleepDuration = time.Second
+)
+
+// GetPatterns gets updated patterns array.
+func (connector *DbConnector) GetPatterns() ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.patterns.members(), nil
+}
+
+// GetMetricsValues gets metrics values for given interval.
+func (connector *DbConnector) GetMetricsValues(metrics []string, from int64, until int64) (map[string][]*moira.MetricValue, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	res := make(map[string][]*moira.MetricValue, len(metrics))
+
+	for _, metric := range metrics {
+		members, err := s.metricData[metric].rangeByScore(strconv.FormatInt(from, 10), strconv.FormatInt(until, 10))
+		if err != nil {
+			return nil, err
+		}
+
+		metricsValues := make([]*moira.MetricValue, 0, len(members))
+
+		for _, member := range members {
+			metricValue, err := parseMetricValue(member)
+			if err != nil {
+				return nil, err
+			}
+
+			metricsValues = append(metricsValues, metricValue)
+		}
+
+		res[metric] = metricsValues
+	}
+
+	return res, nil
+}
+
+// GetMetricRetention gets given metric retention, if retention is empty then return default retention value(60).
+func (connector *DbConnector) GetMetricRetention(metric string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	retention, ok := s.metricRetentions[metric]
+	if !ok {
+		return defaultRetention, nil
+	}
+
+	return retention, nil
+}
+
+// SaveMetrics saves new metrics.
+func (connector *DbConnector) SaveMetrics(metrics []*moira.MatchedMetric) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for _, metric := range metrics {
+		set, ok := s.metricData[metric.Metric]
+		if !ok {
+			set = sortedSet{}
+			s.metricData[metric.Metric] = set
+		}
+
+		set[fmt.Sprintf("%v %v", metric.Timestamp, metric.Value)] = float64(metric.RetentionTimestamp)
+		s.metricRetentions[metric.Metric] = int64(metric.Retention)
+
+		for _, pattern := range metric.Patterns {
+			addToSet(s.patternMetrics, pattern, metric.Metric)
+			s.metricEvents[moira.MetricEvent{Metric: metric.Metric, Pattern: pattern}] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// SubscribeMetricEvents creates subscription for new metrics and return channel for this events.
+func (connector *DbConnector) SubscribeMetricEvents(tomb *tomb.Tomb, params *moira.SubscribeMetricEventsParams) (<-chan *moira.MetricEvent, error) {
+	metricChannel := make(chan *moira.MetricEvent, metricEventChannelSize)
+
+	go func() {
+		defer close(metricChannel)
+
+		var popDelay time.Duration
+
+		for {
+			select {
+			case <-tomb.Dying():
+				return
+			case <-time.After(popDelay):
+				events := connector.popMetricEvents(params.BatchSize)
+				if len(events) == 0 {
+					popDelay = receiveEmptySleepDuration
+					continue
+				}
+
+				for _, event := range events {
+					select {
+					case metricChannel <- event:
+					case <-tomb.Dying():
+						return
+					}
+				}
+
+				popDelay = params.Delay
+			}
+		}
+	}()
+
+	return metricChannel, nil
+}
+
+// AddPatternMetric adds new metrics by given pattern.
+func (connector *DbConnector) AddPatternMetric(pattern, metric string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	addToSet(s.patternMetrics, pattern, metric)
+
+	return nil
+}
+
+// GetPatternMetrics gets all metrics by given pattern.
+func (connector *DbConnector) GetPatternMetrics(pattern string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.patternMetrics, pattern), nil
+}
+
+// RemovePattern removes pattern from patterns list.
+func (connector *DbConnector) RemovePattern(pattern string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.patterns.remove(pattern)
+
+	return nil
+}
+
+// RemovePatternsMetrics removes metrics by given patterns.
+func (connector *DbConnector) RemovePatternsMetrics(patterns []string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for _, pattern := range patterns {
+		delete(s.patternMetrics, pattern)
+	}
+
+	return nil
+}
+
+// RemovePatternWithMetrics removes pattern metrics with data and given pattern.
+func (connector *DbConnector) RemovePatternWithMetrics(pattern string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.removePatternWithMetrics(pattern)
+
+	return nil
+}
+
+// RemoveMetricRetention remove metric retention.
+func (connector *DbConnector) RemoveMetricRetention(metric string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.metricRetentions, metric)
+
+	return nil
+}
+
+// RemoveMetricValues remove values by metrics from the interval of passed parameters.
+// In from and to, expect either -inf, +inf, or timestamps as strings.
+func (connector *DbConnector) RemoveMetricValues(metric string, from, to string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	count, err := s.removeMetricValues(metric, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove metrics from %s to %s, error: %w", from, to, err)
+	}
+
+	return count, nil
+}
+
+// GetMetricsTTLSeconds returns maximum time in seconds to store metrics.
+func (connector *DbConnector) GetMetricsTTLSeconds() int64 {
+	return connector.metricsTTLSeconds
+}
+
+// RemoveMetricsValues remove metrics timestamps values from 0 to given time.
+func (connector *DbConnector) RemoveMetricsValues(metrics []string, toTime int64) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for _, metric := range metrics {
+		if _, err := s.removeMetricValues(metric, "-inf", strconv.FormatInt(toTime, 10)); err != nil {
+			return fmt.Errorf("failed to remove metrics: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CleanUpOutdatedMetrics removes metric values which are older than now plus the given negative duration.
+func (connector *DbConnector) CleanUpOutdatedMetrics(duration time.Duration) error {
+	if duration >= 0 {
+		return redis.ErrCleanUpDurationGreaterThanZero
+	}
+
+	to := strconv.FormatInt(connector.Clock.NowUTC().Add(duration).Unix(), 10)
+
+	return connector.cleanUpMetrics("-inf", to)
+}
+
+// CleanUpFutureMetrics removes metric values which are newer than now plus the given positive duration.
+func (connector *DbConnector) CleanUpFutureMetrics(duration time.Duration) error {
+	if duration <= 0 {
+		return redis.ErrCleanUpDurationLessThanZero
+	}
+
+	from := strconv.FormatInt(connector.Clock.NowUTC().Add(duration).Unix(), 10)
+
+	return connector.cleanUpMetrics(from, "+inf")
+}
+
+// CleanupOutdatedPatternMetrics removes already deleted metrics from pattern metrics.
+func (connector *DbConnector) CleanupOutdatedPatternMetrics() (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var count int64
+
+	for _, pattern := range s.patterns.members() {
+		for _, metric := range setMembers(s.patternMetrics, pattern) {
+			if _, ok := s.metricData[metric]; ok {
+				continue
+			}
+
+			removeFromSet(s.patternMetrics, pattern, metric)
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// CleanUpAbandonedRetentions removes metric retentions that have no corresponding metric data.
+func (connector *DbConnector) CleanUpAbandonedRetentions() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for metric := range s.metricRetentions {
+		if _, ok := s.metricData[metric]; !ok {
+			delete(s.metricRetentions, metric)
+		}
+	}
+
+	return nil
+}
+
+// RemoveMetricsByPrefix removes metrics by their prefix e.g. "my.super.metric.".
+func (connector *DbConnector) RemoveMetricsByPrefix(prefix string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for metric := range s.metricData {
+		if strings.HasPrefix(metric, prefix) {
+			delete(s.metricData, metric)
+		}
+	}
+
+	for metric := range s.metricRetentions {
+		if strings.HasPrefix(metric, prefix) {
+			delete(s.metricRetentions, metric)
+		}
+	}
+
+	for pattern, metrics := range s.patternMetrics {
+		for _, metric := range metrics.members() {
+			if strings.HasPrefix(metric, prefix) {
+				removeFromSet(s.patternMetrics, pattern, metric)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveAllMetrics removes all metrics.
+func (connector *DbConnector) RemoveAllMetrics() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.metricData = map[string]sortedSet{}
+	s.metricRetentions = map[string]int64{}
+	s.patternMetrics = map[string]stringSet{}
+
+	return nil
+}
+
+func (connector *DbConnector) cleanUpMetrics(from, to string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var count int64
+
+	for metric := range s.metricData {
+		deletedCount, err := s.removeMetricValues(metric, from, to)
+		if err != nil {
+			return err
+		}
+
+		count += deletedCount
+	}
+
+	connector.logger.Info().
+		Int64("count deleted metrics", count).
+		Msg("Cleaned up metrics")
+
+	return nil
+}
+
+// popMetricEvents pops up to count random metric events like SPOP command does.
+func (connector *DbConnector) popMetricEvents(count int64) []*moira.MetricEvent {
+	s := connector.lock()
+	defer connector.unlock()
+
+	events := make([]*moira.MetricEvent, 0, count)
+
+	for event := range s.metricEvents {
+		if int64(len(events)) >= count {
+			break
+		}
+
+		delete(s.metricEvents, event)
+
+		events = append(events, &event)
+	}
+
+	return events
+}
+
+func (s *state) removePatternWithMetrics(pattern string) {
+	s.patterns.remove(pattern)
+
+	for _, metric := range setMembers(s.patternMetrics, pattern) {
+		delete(s.metricData, metric)
+		delete(s.metricRetentions, metric)
+	}
+
+	delete(s.patternMetrics, pattern)
+}
+
+func (s *state) removeMetricValues(metric string, from, to string) (int64, error) {
+	set, ok := s.metricData[metric]
+	if !ok {
+		return 0, nil
+	}
+
+	count, err := set.removeRangeByScore(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(set) == 0 {
+		delete(s.metricData, metric)
+	}
+
+	return count, nil
+}
+
+func parseMetricValue(member scoredMember) (*moira.MetricValue, error) {
+	valuesArr := strings.Split(member.member, " ")
+	if len(valuesArr) != 2 {
+		return nil, fmt.Errorf("value format is not valid: %s", member.member)
+	}
+
+	timestamp, err := strconv.ParseInt(valuesArr[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("metric timestamp format is not valid: %w", err)
+	}
+
+	value, err := strconv.ParseFloat(valuesArr[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("metric value format is not valid: %w", err)
+	}
+
+	return &moira.MetricValue{
+		RetentionTimestamp: int64(member.score),
+		Timestamp:          timestamp,
+		Value:              value,
+	}, nil
+}
@@ -0,0 +1,398 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// Separate const to prevent cyclic dependencies, see the same const in database/redis.
+const notificationsLimitUnlimited = int64(-1)
+
+// GetNotifications gets ScheduledNotifications in given range and full range, where 'start' and 'end' are indices of notifications.
+func (connector *DbConnector) GetNotifications(start, end int64) ([]*moira.ScheduledNotification, int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	total := int64(0)
+	notifications := make([]*moira.ScheduledNotification, 0)
+
+	for _, clusterKey := range connector.clusterList {
+		set := s.notifications[notificationsKey(clusterKey)]
+
+		ns, err := unmarshalNotifications(set.rangeByIndex(start, end))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total += int64(len(set))
+
+		notifications = append(notifications, ns...)
+	}
+
+	sortNotifications(notifications)
+
+	return notifications, total, nil
+}
+
+// RemoveAllNotifications delete all notifications.
+func (connector *DbConnector) RemoveAllNotifications() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	for _, clusterKey := range connector.clusterList {
+		delete(s.notifications, notificationsKey(clusterKey))
+	}
+
+	return nil
+}
+
+// RemoveNotification delete notifications by key = timestamp + contactID + subID.
+func (connector *DbConnector) RemoveNotification(notificationID string) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	countTotal := int64(0)
+
+	for _, clusterKey := range connector.clusterList {
+		set := s.notifications[notificationsKey(clusterKey)]
+
+		for member := range set {
+			notification, err := unmarshalNotification(member)
+			if err != nil {
+				return countTotal, err
+			}
+
+			timestamp := strconv.FormatInt(notification.Timestamp, 10)
+			subID := moira.UseString(notification.Event.SubscriptionID)
+
+			if strings.Join([]string{timestamp, notification.Contact.ID, subID}, "") == notificationID {
+				delete(set, member)
+
+				countTotal++
+			}
+		}
+	}
+
+	return countTotal, nil
+}
+
+// RemoveFilteredNotifications deletes notifications ine time range from startTime to endTime,
+// excluding the ones that have tag from ignoredTags.
+func (connector *DbConnector) RemoveFilteredNotifications(start, end int64, ignoredTags []string, sourceList []moira.ClusterKey) (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	actualClusterList := sourceList
+	if len(sourceList) == 0 {
+		actualClusterList = connector.clusterList
+	}
+
+	stop := "inf"
+	if end >= 0 {
+		stop = strconv.FormatInt(end, 10)
+	}
+
+	ignoredTagsSet := stringSet{}
+	ignoredTagsSet.add(ignoredTags...)
+
+	countTotal := int64(0)
+
+	for _, clusterKey := range actualClusterList {
+		set := s.notifications[notificationsKey(clusterKey)]
+
+		members, err := set.rangeByScore(strconv.FormatInt(start, 10), stop)
+		if err != nil {
+			return countTotal, err
+		}
+
+	outer:
+		for _, member := range members {
+			notification, err := unmarshalNotification(member.member)
+			if err != nil {
+				return countTotal, err
+			}
+
+			for _, tag := range notification.Trigger.Tags {
+				if ignoredTagsSet.contains(tag) {
+					continue outer
+				}
+			}
+
+			delete(set, member.member)
+
+			countTotal++
+		}
+	}
+
+	return countTotal, nil
+}
+
+// FetchNotifications fetch notifications by given timestamp and delete it.
+func (connector *DbConnector) FetchNotifications(clusterKey moira.ClusterKey, to int64, limit int64) ([]*moira.ScheduledNotification, error) {
+	if limit == 0 {
+		return nil, fmt.Errorf("limit mustn't be 0")
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	key := notificationsKey(clusterKey)
+	set := s.notifications[key]
+
+	if limit != notificationsLimitUnlimited && limit > connector.notification.TransactionHeuristicLimit {
+		members, err := set.rangeByScore("-inf", strconv.FormatInt(to, 10))
+		if err != nil {
+			return nil, err
+		}
+
+		// Hope count will be not greater then limit when we fetch notifications without limit
+		if int64(len(members)) < limit/2 {
+			limit = notificationsLimitUnlimited
+		}
+	}
+
+	notifications, err := getNotificationsWithLimit(set, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notifications) == 0 {
+		return make([]*moira.ScheduledNotification, 0), nil
+	}
+
+	// Range with limit may return not all notifications with last timestamp, so drop them
+	// or, if all notifications have the same timestamp, fetch all of them.
+	if limit != notificationsLimitUnlimited {
+		limitedNotifications := limitNotifications(notifications)
+
+		if len(limitedNotifications) == len(notifications) {
+			lastTs := limitedNotifications[len(limitedNotifications)-1].Timestamp
+
+			limitedNotifications, err = getNotificationsWithLimit(set, lastTs, notificationsLimitUnlimited)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		notifications = limitedNotifications
+	}
+
+	valid, err := connector.handleNotifications(s, key, notifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to handle notifications: %w", err)
+	}
+
+	return valid, nil
+}
+
+// AddNotification store notification at given timestamp.
+func (connector *DbConnector) AddNotification(notification *moira.ScheduledNotification) error {
+	bytes, err := reply.GetNotificationBytes(*notification)
+	if err != nil {
+		return err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.addNotification(notification, bytes, notification.Timestamp)
+
+	return nil
+}
+
+// AddNotifications store notification at given timestamp.
+func (connector *DbConnector) AddNotifications(notifications []*moira.ScheduledNotification, timestamp int64) error {
+	notificationsBytes := make([][]byte, 0, len(notifications))
+
+	for _, notification := range notifications {
+		bytes, err := reply.GetNotificationBytes(*notification)
+		if err != nil {
+			return err
+		}
+
+		notificationsBytes = append(notificationsBytes, bytes)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	for i, notification := range notifications {
+		s.addNotification(notification, notificationsBytes[i], timestamp)
+	}
+
+	return nil
+}
+
+/*
+handleNotifications works like the same function of database/redis: it splits notifications into delayed
+and not delayed, filters delayed notifications by their state, resaves the ones which have to be resaved,
+removes all handled notifications from the set and returns valid notifications sorted by timestamp.
+*/
+func (connector *DbConnector) handleNotifications(s *state, key moira.ClusterKey, notifications []*moira.ScheduledNotification) ([]*moira.ScheduledNotification, error) {
+	delayedTime := int64(connector.notification.DelayedTime.Seconds())
+
+	delayed := make([]*moira.ScheduledNotification, 0, len(notifications))
+	notDelayed := make([]*moira.ScheduledNotification, 0, len(notifications))
+
+	for _, notification := range notifications {
+		if notification.IsDelayed(delayedTime) {
+			delayed = append(delayed, notification)
+		} else {
+			notDelayed = append(notDelayed, notification)
+		}
+	}
+
+	valid := notDelayed
+	toRemove := make([]*moira.ScheduledNotification, 0, len(notifications))
+	toResave := make([]*moira.ScheduledNotification, 0)
+
+	if len(delayed) > 0 {
+		validDelayed := make([]*moira.ScheduledNotification, 0, len(delayed))
+
+		for _, notification := range delayed {
+			var lastCheck *moira.CheckData
+			if checkData, err := s.getTriggerLastCheck(notification.Trigger.ID); err == nil {
+				lastCheck = &checkData
+			}
+
+			switch notification.GetState(lastCheck) {
+			case moira.ValidNotification:
+				validDelayed = append(validDelayed, notification)
+
+			case moira.RemovedNotification:
+				toRemove = append(toRemove, notification)
+
+			case moira.ResavedNotification:
+				toRemove = append(toRemove, notification)
+
+				updatedNotification := *notification
+				updatedNotification.Timestamp = connector.Clock.NowUnix() + int64(connector.notification.ResaveTime.Seconds())
+				toResave = append(toResave, &updatedNotification)
+			}
+		}
+
+		var err error
+
+		valid, err = moira.MergeToSorted[*moira.ScheduledNotification](validDelayed, notDelayed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge valid and not delayed notifications into sorted array: %w", err)
+		}
+	}
+
+	toRemove = append(toRemove, valid...)
+
+	set := s.notifications[key]
+
+	for _, notification := range toRemove {
+		bytes, err := reply.GetNotificationBytes(*notification)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(set, string(bytes))
+	}
+
+	for _, notification := range toResave {
+		bytes, err := reply.GetNotificationBytes(*notification)
+		if err != nil {
+			return nil, err
+		}
+
+		s.addNotification(notification, bytes, notification.Timestamp)
+	}
+
+	return valid, nil
+}
+
+func (s *state) addNotification(notification *moira.ScheduledNotification, bytes []byte, score int64) {
+	key := notificationsKey(moira.MakeClusterKey(notification.Trigger.TriggerSource, notification.Trigger.ClusterId))
+
+	set, ok := s.notifications[key]
+	if !ok {
+		set = sortedSet{}
+		s.notifications[key] = set
+	}
+
+	set[string(bytes)] = float64(score)
+}
+
+// getNotificationsWithLimit returns notifications with timestamp not greater than to sorted by timestamp.
+func getNotificationsWithLimit(set sortedSet, to int64, limit int64) ([]*moira.ScheduledNotification, error) {
+	members, err := set.rangeByScore("-inf", strconv.FormatInt(to, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	if limit != notificationsLimitUnlimited && int64(len(members)) > limit {
+		members = members[:limit]
+	}
+
+	return unmarshalNotifications(members)
+}
+
+// limitNotifications drops all notifications with last timestamp.
+func limitNotifications(notifications []*moira.ScheduledNotification) []*moira.ScheduledNotification {
+	i := len(notifications) - 1
+	lastTs := notifications[i].Timestamp
+
+	for ; i >= 0; i-- {
+		if notifications[i].Timestamp != lastTs {
+			break
+		}
+	}
+
+	if i == -1 {
+		return notifications
+	}
+
+	return notifications[:i+1]
+}
+
+// notificationsKey fills unset parts of cluster key with defaults, the same way database/redis builds notifications key.
+func notificationsKey(clusterKey moira.ClusterKey) moira.ClusterKey {
+	if clusterKey.ClusterId == moira.ClusterNotSet {
+		clusterKey.ClusterId = moira.DefaultCluster
+	}
+
+	if clusterKey.TriggerSource == moira.TriggerSourceNotSet {
+		clusterKey.TriggerSource = moira.GraphiteLocal
+	}
+
+	return clusterKey
+}
+
+func unmarshalNotification(member string) (moira.ScheduledNotification, error) {
+	notification := moira.ScheduledNotification{}
+	if err := json.Unmarshal([]byte(member), &notification); err != nil {
+		return notification, fmt.Errorf("failed to parse scheduledNotification json %s: %w", member, err)
+	}
+
+	return notification, nil
+}
+
+func unmarshalNotifications(members []scoredMember) ([]*moira.ScheduledNotification, error) {
+	notifications := make([]*moira.ScheduledNotification, 0, len(members))
+
+	for _, member := range members {
+		notification, err := unmarshalNotification(member.member)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, nil
+}
+
+func sortNotifications(notifications []*moira.ScheduledNotification) {
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].Timestamp < notifications[j].Timestamp
+	})
+}
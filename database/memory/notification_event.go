@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+const (
+	eventsTTL            int64 = 3600 * 24 * 30
+	uiEventsMaxLength          = 101
+	firstTarget                = "t1"
+	fetchEventsWaitTime        = time.Second
+	fetchEventsRetryTime       = 10 * time.Millisecond
+)
+
+// GetNotificationEvents gets NotificationEvents by given triggerID and interval. The events are also filtered by time range
+// with `from`, `to` params (`from` and `to` should be "+inf", "-inf" or int64 converted to string).
+func (connector *DbConnector) GetNotificationEvents(triggerID string, page, size int64, from, to string) ([]*moira.NotificationEvent, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	members, err := s.triggerEvents[triggerID].rangeByScore(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range of trigger events, triggerID: %s, error: %w", triggerID, err)
+	}
+
+	reversed := make([]scoredMember, 0, len(members))
+	for i := len(members) - 1; i >= 0; i-- {
+		reversed = append(reversed, members[i])
+	}
+
+	events := make([]*moira.NotificationEvent, 0)
+
+	for _, member := range limitMembers(reversed, page*size, size) {
+		event, err := unmarshalEvent(member.member)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+// PushNotificationEvent adds new NotificationEvent to events list and to given triggerID events list and deletes events who are older than 30 days.
+// If ui=true, then add to ui events list.
+func (connector *DbConnector) PushNotificationEvent(event *moira.NotificationEvent, ui bool) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	eventString := string(bytes)
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.events = pushFront(s.events, eventString)
+
+	if event.TriggerID != "" {
+		set, ok := s.triggerEvents[event.TriggerID]
+		if !ok {
+			set = sortedSet{}
+			s.triggerEvents[event.TriggerID] = set
+		}
+
+		set[eventString] = float64(event.Timestamp)
+
+		to := connector.Clock.NowUnix() - eventsTTL
+		if _, err := set.removeRangeByScore("-inf", strconv.FormatInt(to, 10)); err != nil {
+			return err
+		}
+
+		if len(set) == 0 {
+			delete(s.triggerEvents, event.TriggerID)
+		}
+	}
+
+	if ui {
+		s.uiEvents = listRange(pushFront(s.uiEvents, eventString), 0, uiEventsMaxLength-1)
+	}
+
+	return nil
+}
+
+// GetNotificationEventCount returns planned notifications count from given timestamp.
+func (connector *DbConnector) GetNotificationEventCount(triggerID string, from, to string) int64 {
+	s := connector.lock()
+	defer connector.unlock()
+
+	members, _ := s.triggerEvents[triggerID].rangeByScore(from, to)
+
+	return int64(len(members))
+}
+
+// FetchNotificationEvent waiting for event in events list, returns database.ErrNil if no event appeared during a second.
+func (connector *DbConnector) FetchNotificationEvent() (moira.NotificationEvent, error) {
+	deadline := time.Now().Add(fetchEventsWaitTime)
+
+	for {
+		eventString, ok := connector.popEvent()
+		if ok {
+			return unmarshalEvent(eventString)
+		}
+
+		if time.Now().After(deadline) {
+			return moira.NotificationEvent{}, database.ErrNil
+		}
+
+		time.Sleep(fetchEventsRetryTime)
+	}
+}
+
+// RemoveAllNotificationEvents removes all notification events from database.
+func (connector *DbConnector) RemoveAllNotificationEvents() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.events = nil
+
+	return nil
+}
+
+// popEvent pops event from the tail of events list like RPOP command does.
+func (connector *DbConnector) popEvent() (string, bool) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	if len(s.events) == 0 {
+		return "", false
+	}
+
+	last := len(s.events) - 1
+	eventString := s.events[last]
+	s.events = s.events[:last]
+
+	return eventString, true
+}
+
+func unmarshalEvent(eventString string) (moira.NotificationEvent, error) {
+	event := moira.NotificationEvent{}
+	if err := json.Unmarshal([]byte(eventString), &event); err != nil {
+		return event, fmt.Errorf("failed to parse event json %s: %w", eventString, err)
+	}
+
+	// Compatibility with moira < v2.6.0, the same as in database/redis.
+	if event.Values == nil {
+		event.Values = make(map[string]float64)
+	}
+
+	if event.Value != nil {
+		event.Values[firstTarget] = *event.Value
+		event.Value = nil
+	}
+
+	return event, nil
+}
+
+// limitMembers applies offset and count to the range like LIMIT argument of ZRANGEBYSCORE command does,
+// negative count means that all the members after offset are returned.
+func limitMembers(members []scoredMember, offset, count int64) []scoredMember {
+	if offset < 0 || offset >= int64(len(members)) {
+		return make([]scoredMember, 0)
+	}
+
+	members = members[offset:]
+
+	if count >= 0 && count < int64(len(members)) {
+		members = members[:count]
+	}
+
+	return members
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"github.com/moira-alert/moira/database"
+)
+
+// GetNotificationThread gets id of the thread which the sender posts notifications of the trigger to in the chat.
+func (connector *DbConnector) GetNotificationThread(senderType, triggerID, chatID string) (string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	threadID, ok := s.threads[notificationThreadKey(senderType, triggerID, chatID)]
+	if !ok {
+		return "", database.ErrNil
+	}
+
+	return threadID, nil
+}
+
+// SetNotificationThread sets id of the thread which the sender posts notifications of the trigger to in the chat.
+func (connector *DbConnector) SetNotificationThread(senderType, triggerID, chatID, threadID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.threads[notificationThreadKey(senderType, triggerID, chatID)] = threadID
+
+	return nil
+}
+
+// RemoveNotificationThread removes the thread of the trigger in the chat, so the next notification starts a new one.
+func (connector *DbConnector) RemoveNotificationThread(senderType, triggerID, chatID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.threads, notificationThreadKey(senderType, triggerID, chatID))
+
+	return nil
+}
+
+func notificationThreadKey(senderType, triggerID, chatID string) string {
+	return senderType + ":" + triggerID + ":" + chatID
+}
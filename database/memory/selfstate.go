@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/moira-alert/moira"
+)
+
+const (
+	checksCounterName           = "checks"
+	remoteChecksCounterName     = "remote_checks"
+	prometheusChecksCounterName = "prometheus_checks"
+	lokiChecksCounterName       = "loki_checks"
+	sqlChecksCounterName        = "sql_checks"
+)
+
+// UpdateMetricsHeartbeat increments metrics counter.
+func (connector *DbConnector) UpdateMetricsHeartbeat() error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.metricsHeartbeat++
+
+	return nil
+}
+
+// GetMetricsUpdatesCount return metrics count received by Moira-Filter.
+func (connector *DbConnector) GetMetricsUpdatesCount() (int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.metricsHeartbeat, nil
+}
+
+// GetChecksUpdatesCount return checks count by Moira-Checker.
+func (connector *DbConnector) GetChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(checksCounterName), nil
+}
+
+// GetRemoteChecksUpdatesCount return remote checks count by Moira-Checker.
+func (connector *DbConnector) GetRemoteChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(remoteChecksCounterName), nil
+}
+
+// GetPrometheusChecksUpdatesCount return remote checks count by Moira-Checker.
+func (connector *DbConnector) GetPrometheusChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(prometheusChecksCounterName), nil
+}
+
+func (connector *DbConnector) getChecksCounter(name string) int64 {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.checksCounters[name]
+}
+
+// GetNotifierState return current notifier state: <OK|ERROR>.
+func (connector *DbConnector) GetNotifierState() (moira.NotifierState, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	if s.notifierState == nil {
+		state := okState(connector.Clock)
+		if err := s.setNotifierState(state); err != nil {
+			return errorState(connector.Clock), err
+		}
+
+		return state, nil
+	}
+
+	return unmarshalNotifierState(s.notifierState)
+}
+
+// SetNotifierState update current notifier state: <OK|ERROR>.
+func (connector *DbConnector) SetNotifierState(actor, state string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.setNotifierState(moira.NotifierState{
+		State:     state,
+		Actor:     actor,
+		Timestamp: connector.Clock.NowUnix(),
+	})
+}
+
+// GetNotifierStateForSource returns state for a given metric source cluster.
+func (connector *DbConnector) GetNotifierStateForSource(clusterKey moira.ClusterKey) (moira.NotifierState, error) {
+	if !slices.Contains(connector.clusterList, clusterKey) {
+		return errorState(connector.Clock), fmt.Errorf("unknown cluster '%s'", clusterKey.String())
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.notifierStateByKeys[clusterKey]
+	if !ok {
+		// If state for cluster was never set, set OK by default
+		return okState(connector.Clock), nil
+	}
+
+	state, err := unmarshalNotifierState(bytes)
+	if err != nil {
+		return errorState(connector.Clock), err
+	}
+
+	return state, nil
+}
+
+// GetNotifierStateForSources returns state for all metric source clusters.
+func (connector *DbConnector) GetNotifierStateForSources() (map[moira.ClusterKey]moira.NotifierState, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	result := make(map[moira.ClusterKey]moira.NotifierState, len(connector.clusterList))
+
+	for _, cluster := range connector.clusterList {
+		bytes, ok := s.notifierStateByKeys[cluster]
+		if !ok {
+			// If state for cluster was never set, set OK by default
+			result[cluster] = okState(connector.Clock)
+			continue
+		}
+
+		state, err := unmarshalNotifierState(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result[cluster] = state
+	}
+
+	return result, nil
+}
+
+// SetNotifierStateForSource saves state for given metric source cluster.
+func (connector *DbConnector) SetNotifierStateForSource(clusterKey moira.ClusterKey, actor, state string) error {
+	if !slices.Contains(connector.clusterList, clusterKey) {
+		return fmt.Errorf("unknown cluster '%s'", clusterKey.String())
+	}
+
+	bytes, err := json.Marshal(moira.NotifierState{
+		State:     state,
+		Actor:     actor,
+		Timestamp: connector.Clock.NowUnix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.notifierStateByKeys[clusterKey] = bytes
+
+	return nil
+}
+
+func (s *state) setNotifierState(dto moira.NotifierState) error {
+	bytes, err := json.Marshal(dto)
+	if err != nil {
+		return err
+	}
+
+	s.notifierState = bytes
+
+	return nil
+}
+
+func unmarshalNotifierState(bytes []byte) (moira.NotifierState, error) {
+	state := moira.NotifierState{}
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return state, fmt.Errorf("failed to parse notifier state json %s: %w", string(bytes), err)
+	}
+
+	return state, nil
+}
+
+func errorState(clock moira.Clock) moira.NotifierState {
+	return moira.NotifierState{
+		State:     moira.SelfStateERROR,
+		Actor:     moira.SelfStateActorManual,
+		Timestamp: clock.NowUnix(),
+	}
+}
+
+func okState(clock moira.Clock) moira.NotifierState {
+	return moira.NotifierState{
+		State:     moira.SelfStateOK,
+		Actor:     moira.SelfStateActorManual,
+		Timestamp: clock.NowUnix(),
+	}
+}
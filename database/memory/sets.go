@@ -0,0 +1,232 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// stringSet is an analogue of Redis set.
+type stringSet map[string]struct{}
+
+func (set stringSet) add(members ...string) {
+	for _, member := range members {
+		set[member] = struct{}{}
+	}
+}
+
+func (set stringSet) remove(members ...string) {
+	for _, member := range members {
+		delete(set, member)
+	}
+}
+
+func (set stringSet) contains(member string) bool {
+	_, ok := set[member]
+	return ok
+}
+
+func (set stringSet) members() []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+
+	sort.Strings(members)
+
+	return members
+}
+
+// addToSet adds members to the set stored by key, creating the set if needed.
+func addToSet[K comparable](sets map[K]stringSet, key K, members ...string) {
+	set, ok := sets[key]
+	if !ok {
+		set = stringSet{}
+		sets[key] = set
+	}
+
+	set.add(members...)
+}
+
+// removeFromSet removes members from the set stored by key, empty sets are deleted like in Redis.
+func removeFromSet[K comparable](sets map[K]stringSet, key K, members ...string) {
+	set, ok := sets[key]
+	if !ok {
+		return
+	}
+
+	set.remove(members...)
+
+	if len(set) == 0 {
+		delete(sets, key)
+	}
+}
+
+// setMembers returns members of the set stored by key or empty slice if there is no such set.
+func setMembers[K comparable](sets map[K]stringSet, key K) []string {
+	return sets[key].members()
+}
+
+// sortedSet is an analogue of Redis sorted set, it maps members to their scores.
+type sortedSet map[string]float64
+
+type scoredMember struct {
+	member string
+	score  float64
+}
+
+// sorted returns members ordered by score and then lexicographically, like Redis does.
+func (set sortedSet) sorted() []scoredMember {
+	members := make([]scoredMember, 0, len(set))
+	for member, score := range set {
+		members = append(members, scoredMember{member: member, score: score})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].score != members[j].score {
+			return members[i].score < members[j].score
+		}
+
+		return members[i].member < members[j].member
+	})
+
+	return members
+}
+
+// rangeByScore returns members with score in given bounds, bounds are in format of ZRANGEBYSCORE command.
+func (set sortedSet) rangeByScore(minBound, maxBound string) ([]scoredMember, error) {
+	low, err := parseScoreBound(minBound)
+	if err != nil {
+		return nil, err
+	}
+
+	high, err := parseScoreBound(maxBound)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]scoredMember, 0)
+
+	for _, member := range set.sorted() {
+		if low.lessOrEqual(member.score) && high.greaterOrEqual(member.score) {
+			result = append(result, member)
+		}
+	}
+
+	return result, nil
+}
+
+// removeRangeByScore removes members with score in given bounds and returns count of removed members.
+func (set sortedSet) removeRangeByScore(minBound, maxBound string) (int64, error) {
+	members, err := set.rangeByScore(minBound, maxBound)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, member := range members {
+		delete(set, member.member)
+	}
+
+	return int64(len(members)), nil
+}
+
+// rangeByIndex returns members with indices from start to end, negative indices are counted from the end.
+func (set sortedSet) rangeByIndex(start, end int64) []scoredMember {
+	members := set.sorted()
+
+	from, to, ok := indexRange(int64(len(members)), start, end)
+	if !ok {
+		return make([]scoredMember, 0)
+	}
+
+	return members[from : to+1]
+}
+
+type scoreBound struct {
+	value     float64
+	exclusive bool
+}
+
+func (bound scoreBound) lessOrEqual(score float64) bool {
+	if bound.exclusive {
+		return bound.value < score
+	}
+
+	return bound.value <= score
+}
+
+func (bound scoreBound) greaterOrEqual(score float64) bool {
+	if bound.exclusive {
+		return bound.value > score
+	}
+
+	return bound.value >= score
+}
+
+// parseScoreBound parses bound of sorted set range: number, "-inf", "+inf" or number prefixed with "(" for exclusive bound.
+func parseScoreBound(bound string) (scoreBound, error) {
+	result := scoreBound{}
+
+	if strings.HasPrefix(bound, "(") {
+		result.exclusive = true
+		bound = bound[1:]
+	}
+
+	switch bound {
+	case "-inf":
+		result.value = math.Inf(-1)
+	case "+inf", "inf":
+		result.value = math.Inf(1)
+	default:
+		value, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return result, fmt.Errorf("min or max is not a float: %s", bound)
+		}
+
+		result.value = value
+	}
+
+	return result, nil
+}
+
+// indexRange converts start and end indices in format of LRANGE and ZRANGE commands to indices of slice with given length.
+func indexRange(length, start, end int64) (int64, int64, bool) {
+	if start < 0 {
+		start += length
+	}
+
+	if end < 0 {
+		end += length
+	}
+
+	if start < 0 {
+		start = 0
+	}
+
+	if end >= length {
+		end = length - 1
+	}
+
+	if start > end || start >= length {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// listRange returns elements of list with indices from start to end like LRANGE command does.
+func listRange(list []string, start, end int64) []string {
+	from, to, ok := indexRange(int64(len(list)), start, end)
+	if !ok {
+		return make([]string, 0)
+	}
+
+	return append(make([]string, 0, to-from+1), list[from:to+1]...)
+}
+
+// pushFront adds value to the head of list like LPUSH command does.
+func pushFront(list []string, value string) []string {
+	return append([]string{value}, list...)
+}
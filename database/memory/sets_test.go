@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSortedSet(t *testing.T) {
+	set := sortedSet{"a": 1, "b": 2, "c": 2, "d": 3}
+
+	Convey("Test rangeByScore", t, func() {
+		Convey("Inclusive bounds", func() {
+			members, err := set.rangeByScore("2", "+inf")
+			So(err, ShouldBeNil)
+			So(members, ShouldResemble, []scoredMember{{"b", 2}, {"c", 2}, {"d", 3}})
+		})
+
+		Convey("Exclusive bounds", func() {
+			members, err := set.rangeByScore("(1", "(3")
+			So(err, ShouldBeNil)
+			So(members, ShouldResemble, []scoredMember{{"b", 2}, {"c", 2}})
+		})
+
+		Convey("Invalid bound", func() {
+			_, err := set.rangeByScore("one", "+inf")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Test rangeByIndex", t, func() {
+		So(set.rangeByIndex(0, -1), ShouldHaveLength, 4)
+		So(set.rangeByIndex(-2, -1), ShouldResemble, []scoredMember{{"c", 2}, {"d", 3}})
+		So(set.rangeByIndex(1, 100), ShouldHaveLength, 3)
+		So(set.rangeByIndex(5, 10), ShouldBeEmpty)
+	})
+}
+
+func TestListRange(t *testing.T) {
+	list := []string{"a", "b", "c"}
+
+	Convey("Test listRange", t, func() {
+		So(listRange(list, 0, -1), ShouldResemble, list)
+		So(listRange(list, 1, 1), ShouldResemble, []string{"b"})
+		So(listRange(list, -2, 10), ShouldResemble, []string{"b", "c"})
+		So(listRange(list, 2, 1), ShouldBeEmpty)
+		So(listRange(nil, 0, -1), ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveSilence saves silence, existing silence with the same id is replaced.
+func (connector *DbConnector) SaveSilence(silence *moira.Silence) error {
+	bytes, err := json.Marshal(silence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.silences[silence.ID] = bytes
+
+	return nil
+}
+
+// GetSilence returns silence by its id, if there is no such silence, returns database.ErrNil error.
+func (connector *DbConnector) GetSilence(silenceID string) (moira.Silence, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.silences[silenceID]
+	if !ok {
+		return moira.Silence{}, database.ErrNil
+	}
+
+	return unmarshalSilence(bytes)
+}
+
+// GetSilences returns all silences ordered by their start time.
+func (connector *DbConnector) GetSilences() ([]*moira.Silence, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	silences := make([]*moira.Silence, 0, len(s.silences))
+
+	for _, bytes := range s.silences {
+		silence, err := unmarshalSilence(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		silences = append(silences, &silence)
+	}
+
+	sort.Slice(silences, func(i, j int) bool {
+		if silences[i].StartsAt == silences[j].StartsAt {
+			return silences[i].ID < silences[j].ID
+		}
+
+		return silences[i].StartsAt < silences[j].StartsAt
+	})
+
+	return silences, nil
+}
+
+// RemoveSilence removes silence by its id.
+func (connector *DbConnector) RemoveSilence(silenceID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.silences, silenceID)
+
+	return nil
+}
+
+func unmarshalSilence(bytes []byte) (moira.Silence, error) {
+	var silence moira.Silence
+	if err := json.Unmarshal(bytes, &silence); err != nil {
+		return moira.Silence{}, fmt.Errorf("failed to parse silence json %s: %w", string(bytes), err)
+	}
+
+	return silence, nil
+}
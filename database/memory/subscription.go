@@ -0,0 +1,321 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetSubscription returns subscription data by given id, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetSubscription(id string) (moira.SubscriptionData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getSubscription(id)
+}
+
+// GetSubscriptions returns subscriptions data by given ids, len of subscriptionIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetSubscriptions(subscriptionIDs []string) ([]*moira.SubscriptionData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getSubscriptions(subscriptionIDs)
+}
+
+// SaveSubscription writes subscription data, updates tags subscriptions and user subscriptions.
+func (connector *DbConnector) SaveSubscription(subscription *moira.SubscriptionData) error {
+	return connector.SaveSubscriptions([]*moira.SubscriptionData{subscription})
+}
+
+// SaveSubscriptions writes subscriptions, updates tags subscriptions and user subscriptions.
+func (connector *DbConnector) SaveSubscriptions(newSubscriptions []*moira.SubscriptionData) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	ids := make([]string, len(newSubscriptions))
+	for i, subscription := range newSubscriptions {
+		ids[i] = subscription.ID
+	}
+
+	oldSubscriptions, err := s.getSubscriptions(ids)
+	if err != nil {
+		return err
+	}
+
+	oldTriggers, err := s.getSubscriptionsTriggers(oldSubscriptions)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	for i, subscription := range newSubscriptions {
+		if err = s.updateSubscription(*subscription, oldSubscriptions[i]); err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+	}
+
+	newTriggers, err := s.getSubscriptionsTriggers(newSubscriptions)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	if err := s.refreshUnusedTriggers(newTriggers, oldTriggers); err != nil {
+		return fmt.Errorf("failed to update triggers by subscription: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSubscription deletes subscription data and removes subscriptionID from users and tags subscriptions.
+func (connector *DbConnector) RemoveSubscription(subscriptionID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	subscription, err := s.getSubscription(subscriptionID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return err
+	}
+
+	triggers, err := s.getSubscriptionTriggers(&subscription)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	removeFromSet(s.userSubscriptions, subscription.User, subscription.ID)
+	removeFromSet(s.teamSubscriptions, subscription.TeamID, subscription.ID)
+
+	for _, tag := range subscription.Tags {
+		removeFromSet(s.tagSubscriptions, tag, subscription.ID)
+	}
+
+	s.anyTagsSubscriptions.remove(subscription.ID)
+	delete(s.subscriptions, subscription.ID)
+
+	if err := s.refreshUnusedTriggers([]*moira.Trigger{}, triggers); err != nil {
+		return fmt.Errorf("failed to update triggers by subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSubscriptionIDs returns subscriptions ids by given login.
+func (connector *DbConnector) GetUserSubscriptionIDs(login string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.userSubscriptions, login), nil
+}
+
+// GetTeamSubscriptionIDs returns subscriptions ids by given team id.
+func (connector *DbConnector) GetTeamSubscriptionIDs(teamID string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.teamSubscriptions, teamID), nil
+}
+
+// GetTagsSubscriptions gets all subscriptions by given tag list and subscriptions on any tags.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTagsSubscriptions(tags []string) ([]*moira.SubscriptionData, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getTagsSubscriptions(tags)
+}
+
+func (s *state) getSubscription(id string) (moira.SubscriptionData, error) {
+	bytes, ok := s.subscriptions[id]
+	if !ok {
+		return moira.SubscriptionData{}, database.ErrNil
+	}
+
+	subscription := moira.SubscriptionData{}
+	if err := json.Unmarshal(bytes, &subscription); err != nil {
+		return subscription, fmt.Errorf("failed to parse subscription json %s: %w", string(bytes), err)
+	}
+
+	if subscription.Tags == nil {
+		subscription.Tags = []string{}
+	}
+
+	subscription.ID = id
+
+	return subscription, nil
+}
+
+func (s *state) getSubscriptions(subscriptionIDs []string) ([]*moira.SubscriptionData, error) {
+	subscriptions := make([]*moira.SubscriptionData, len(subscriptionIDs))
+
+	for i, id := range subscriptionIDs {
+		subscription, err := s.getSubscription(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		subscriptions[i] = &subscription
+	}
+
+	return subscriptions, nil
+}
+
+func (s *state) updateSubscription(subscription moira.SubscriptionData, oldSubscription *moira.SubscriptionData) error {
+	if subscription.AnyTags {
+		subscription.Tags = nil
+	}
+
+	bytes, err := json.Marshal(subscription)
+	if err != nil {
+		return err
+	}
+
+	if oldSubscription != nil {
+		for _, tag := range oldSubscription.Tags {
+			removeFromSet(s.tagSubscriptions, tag, subscription.ID)
+		}
+
+		if oldSubscription.User != subscription.User {
+			removeFromSet(s.userSubscriptions, oldSubscription.User, subscription.ID)
+		}
+
+		if oldSubscription.TeamID != subscription.TeamID {
+			removeFromSet(s.teamSubscriptions, oldSubscription.TeamID, subscription.ID)
+		}
+
+		if !subscription.AnyTags {
+			s.anyTagsSubscriptions.remove(subscription.ID)
+		}
+	}
+
+	for _, tag := range subscription.Tags {
+		addToSet(s.tagSubscriptions, tag, subscription.ID)
+	}
+
+	if subscription.AnyTags {
+		s.anyTagsSubscriptions.add(subscription.ID)
+	}
+
+	if subscription.User != "" {
+		addToSet(s.userSubscriptions, subscription.User, subscription.ID)
+	}
+
+	if subscription.TeamID != "" {
+		addToSet(s.teamSubscriptions, subscription.TeamID, subscription.ID)
+	}
+
+	s.subscriptions[subscription.ID] = bytes
+
+	return nil
+}
+
+func (s *state) getTagsSubscriptions(tags []string) ([]*moira.SubscriptionData, error) {
+	subscriptionIDs := stringSet{}
+	for _, tag := range tags {
+		subscriptionIDs.add(setMembers(s.tagSubscriptions, tag)...)
+	}
+
+	subscriptionIDs.add(s.anyTagsSubscriptions.members()...)
+
+	if len(subscriptionIDs) == 0 {
+		return make([]*moira.SubscriptionData, 0), nil
+	}
+
+	return s.getSubscriptions(subscriptionIDs.members())
+}
+
+// getTriggersIdsByTags returns ids of triggers having all of given tags.
+func (s *state) getTriggersIdsByTags(tags []string) []string {
+	triggerIDs := make([]string, 0)
+	if len(tags) == 0 {
+		return triggerIDs
+	}
+
+	for _, triggerID := range setMembers(s.tagTriggers, tags[0]) {
+		hasAllTags := true
+
+		for _, tag := range tags[1:] {
+			if !s.tagTriggers[tag].contains(triggerID) {
+				hasAllTags = false
+				break
+			}
+		}
+
+		if hasAllTags {
+			triggerIDs = append(triggerIDs, triggerID)
+		}
+	}
+
+	return triggerIDs
+}
+
+func (s *state) getSubscriptionTriggers(subscription *moira.SubscriptionData) ([]*moira.Trigger, error) {
+	if subscription == nil {
+		return make([]*moira.Trigger, 0), nil
+	}
+
+	triggerIDs := s.getTriggersIdsByTags(subscription.Tags)
+	if len(triggerIDs) == 0 {
+		return make([]*moira.Trigger, 0), nil
+	}
+
+	return s.getTriggers(triggerIDs)
+}
+
+func (s *state) getSubscriptionsTriggers(subscriptions []*moira.SubscriptionData) ([]*moira.Trigger, error) {
+	triggersMap := make(map[string]*moira.Trigger)
+	triggers := make([]*moira.Trigger, 0)
+
+	for _, subscription := range subscriptions {
+		subscriptionTriggers, err := s.getSubscriptionTriggers(subscription)
+		if err != nil {
+			return triggers, err
+		}
+
+		for _, trigger := range subscriptionTriggers {
+			if trigger == nil {
+				continue
+			}
+
+			triggersMap[trigger.ID] = trigger
+		}
+	}
+
+	for _, trigger := range triggersMap {
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+// refreshUnusedTriggers marks triggers, which are presented in oldTriggers but not in newTriggers
+// and have no subscriptions, as unused. At the end, it marks all newTriggers as used.
+func (s *state) refreshUnusedTriggers(newTriggers, oldTriggers []*moira.Trigger) error {
+	for _, trigger := range moira.GetTriggerListsDiff(oldTriggers, newTriggers) {
+		ok, err := s.triggerHasSubscriptions(trigger)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			s.unusedTriggers.add(trigger.ID)
+		}
+	}
+
+	for _, trigger := range newTriggers {
+		if trigger != nil {
+			s.unusedTriggers.remove(trigger.ID)
+		}
+	}
+
+	return nil
+}
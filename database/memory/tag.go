@@ -0,0 +1,58 @@
+package memory
+
+// GetTagNames returns all tags from set with tag data.
+func (connector *DbConnector) GetTagNames() ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.tags.members(), nil
+}
+
+// CreateTags creates an array of tags without binding to a subscription or trigger.
+func (connector *DbConnector) CreateTags(tags []string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.tags.add(tags...)
+
+	return nil
+}
+
+// RemoveTag deletes tag from tags list, deletes triggerIDs and subscriptionsIDs lists by given tag.
+func (connector *DbConnector) RemoveTag(tagName string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.tags.remove(tagName)
+	delete(s.tagSubscriptions, tagName)
+	delete(s.tagTriggers, tagName)
+
+	return nil
+}
+
+// GetTagTriggerIDs gets all triggersIDs by given tagName.
+func (connector *DbConnector) GetTagTriggerIDs(tagName string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.tagTriggers, tagName), nil
+}
+
+// CleanUpAbandonedTags deletes tags for which triggers and subscriptions don't exist.
+// Returns count of deleted tags.
+func (connector *DbConnector) CleanUpAbandonedTags() (int, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var count int
+
+	for _, tag := range s.tags.members() {
+		if len(s.tagTriggers[tag]) == 0 && len(s.tagSubscriptions[tag]) == 0 {
+			s.tags.remove(tag)
+
+			count++
+		}
+	}
+
+	return count, nil
+}
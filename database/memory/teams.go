@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveTeam saves team into database.
+func (connector *DbConnector) SaveTeam(teamID string, team moira.Team) error {
+	team.ID = ""
+
+	bytes, err := json.Marshal(team)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	newTeamLowercaseName := strings.ToLower(team.Name)
+
+	// another team with such name exists
+	if teamWithSuchNameID, ok := s.teamNames[newTeamLowercaseName]; ok && teamWithSuchNameID != teamID {
+		return database.ErrTeamWithNameAlreadyExists
+	}
+
+	if existedTeam, err := s.getTeam(teamID); err == nil {
+		delete(s.teamNames, strings.ToLower(existedTeam.Name))
+	}
+
+	s.teamNames[newTeamLowercaseName] = teamID
+	s.teams[teamID] = bytes
+
+	return nil
+}
+
+// GetAllTeams returns all teams.
+func (connector *DbConnector) GetAllTeams() ([]moira.Team, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	teams := make([]moira.Team, 0, len(s.teams))
+
+	for teamID := range s.teams {
+		team, err := s.getTeam(teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// GetTeam retrieves team from database by it's id.
+func (connector *DbConnector) GetTeam(teamID string) (moira.Team, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getTeam(teamID)
+}
+
+// GetTeamByName retrieves team from database by its name.
+func (connector *DbConnector) GetTeamByName(name string) (moira.Team, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	teamID, ok := s.teamNames[strings.ToLower(name)]
+	if !ok {
+		return moira.Team{}, database.ErrNil
+	}
+
+	return s.getTeam(teamID)
+}
+
+// SaveTeamsAndUsers is a function that saves users for one team and teams for bunch of users in one transaction.
+func (connector *DbConnector) SaveTeamsAndUsers(teamID string, users []string, teams map[string][]string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.teamUsers, teamID)
+
+	if len(users) > 0 {
+		addToSet(s.teamUsers, teamID, users...)
+	}
+
+	for userID := range s.teamRoles[teamID] {
+		if !s.teamUsers[teamID].contains(userID) {
+			delete(s.teamRoles[teamID], userID)
+		}
+	}
+
+	for userID, userTeams := range teams {
+		delete(s.userTeams, userID)
+
+		if len(userTeams) > 0 {
+			addToSet(s.userTeams, userID, userTeams...)
+		}
+	}
+
+	return nil
+}
+
+// GetUserTeams returns all teams of certain user.
+func (connector *DbConnector) GetUserTeams(userID string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.userTeams, userID), nil
+}
+
+// GetTeamUsers returns all users of certain team.
+func (connector *DbConnector) GetTeamUsers(teamID string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.teamUsers, teamID), nil
+}
+
+// IsTeamContainUser is a method to check if user is in team.
+func (connector *DbConnector) IsTeamContainUser(teamID, userID string) (bool, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.teamUsers[teamID].contains(userID), nil
+}
+
+// DeleteTeam is a method to delete all information about team and remove team from last user's teams.
+func (connector *DbConnector) DeleteTeam(teamID, userID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	team, err := s.getTeam(teamID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get team to delete: %w", err)
+	}
+
+	delete(s.teamNames, strings.ToLower(team.Name))
+	removeFromSet(s.userTeams, userID, teamID)
+	delete(s.teamUsers, teamID)
+	delete(s.teamRoles, teamID)
+	delete(s.teamPreferences, teamID)
+	delete(s.teamOnCallSchedules, teamID)
+	delete(s.teams, teamID)
+
+	return nil
+}
+
+// SaveTeamUserRole saves role of the user in the team.
+func (connector *DbConnector) SaveTeamUserRole(teamID, userID string, role moira.TeamRole) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	if _, ok := s.teamRoles[teamID]; !ok {
+		s.teamRoles[teamID] = make(map[string]moira.TeamRole)
+	}
+
+	s.teamRoles[teamID][userID] = role
+
+	return nil
+}
+
+// GetTeamUserRoles returns roles assigned to users of the team.
+func (connector *DbConnector) GetTeamUserRoles(teamID string) (map[string]moira.TeamRole, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	roles := make(map[string]moira.TeamRole, len(s.teamRoles[teamID]))
+	for userID, role := range s.teamRoles[teamID] {
+		roles[userID] = role
+	}
+
+	return roles, nil
+}
+
+// SaveTeamPreferences saves team-wide settings of the team.
+func (connector *DbConnector) SaveTeamPreferences(teamID string, preferences moira.TeamPreferences) error {
+	bytes, err := json.Marshal(preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team preferences: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.teamPreferences[teamID] = bytes
+
+	return nil
+}
+
+// GetTeamPreferences returns team-wide settings of the team or database.ErrNil if they were not saved.
+func (connector *DbConnector) GetTeamPreferences(teamID string) (moira.TeamPreferences, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.teamPreferences[teamID]
+	if !ok {
+		return moira.TeamPreferences{}, database.ErrNil
+	}
+
+	var preferences moira.TeamPreferences
+	if err := json.Unmarshal(bytes, &preferences); err != nil {
+		return moira.TeamPreferences{}, fmt.Errorf("failed to parse team preferences json %s: %w", string(bytes), err)
+	}
+
+	return preferences, nil
+}
+
+// SaveTeamOnCallSchedule saves on-call schedule of the team.
+func (connector *DbConnector) SaveTeamOnCallSchedule(teamID string, schedule moira.OnCallSchedule) error {
+	bytes, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team on-call schedule: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.teamOnCallSchedules[teamID] = bytes
+
+	return nil
+}
+
+// GetTeamOnCallSchedule returns on-call schedule of the team or database.ErrNil if it was not saved.
+func (connector *DbConnector) GetTeamOnCallSchedule(teamID string) (moira.OnCallSchedule, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	bytes, ok := s.teamOnCallSchedules[teamID]
+	if !ok {
+		return moira.OnCallSchedule{}, database.ErrNil
+	}
+
+	var schedule moira.OnCallSchedule
+	if err := json.Unmarshal(bytes, &schedule); err != nil {
+		return moira.OnCallSchedule{}, fmt.Errorf("failed to parse team on-call schedule json %s: %w", string(bytes), err)
+	}
+
+	return schedule, nil
+}
+
+func (s *state) getTeam(teamID string) (moira.Team, error) {
+	bytes, ok := s.teams[teamID]
+	if !ok {
+		return moira.Team{}, database.ErrNil
+	}
+
+	team := moira.Team{}
+	if err := json.Unmarshal(bytes, &team); err != nil {
+		return moira.Team{}, fmt.Errorf("failed to parse team json %s: %w", string(bytes), err)
+	}
+
+	team.ID = teamID
+
+	return team, nil
+}
@@ -0,0 +1,32 @@
+package memory
+
+import "time"
+
+// GetTriggerThrottling gets trigger throttling timestamp.
+func (connector *DbConnector) GetTriggerThrottling(triggerID string) (time.Time, time.Time) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return time.Unix(s.throttlingNext[triggerID], 0), time.Unix(s.throttlingBeginning[triggerID], 0)
+}
+
+// SetTriggerThrottling sets trigger throttling timestamp.
+func (connector *DbConnector) SetTriggerThrottling(triggerID string, next time.Time) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.throttlingNext[triggerID] = next.Unix()
+
+	return nil
+}
+
+// DeleteTriggerThrottling deletes throttling and sets throttling beginning to now.
+func (connector *DbConnector) DeleteTriggerThrottling(triggerID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.throttlingBeginning[triggerID] = connector.Clock.NowUnix()
+	delete(s.throttlingNext, triggerID)
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// GetAllTriggerIDs gets all moira triggerIDs.
+func (connector *DbConnector) GetAllTriggerIDs() ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.allTriggerIDs(), nil
+}
+
+// GetTriggerIDs returns list of ids of triggers with given cluster key.
+func (connector *DbConnector) GetTriggerIDs(clusterKey moira.ClusterKey) ([]string, error) {
+	if err := checkTriggerSource(clusterKey); err != nil {
+		return nil, fmt.Errorf("failed to get triggers-list: %w", err)
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.clusterTriggers, clusterKey), nil
+}
+
+// GetTriggerCount returns number of triggers for each of given cluster keys.
+func (connector *DbConnector) GetTriggerCount(clusterKeys []moira.ClusterKey) (map[moira.ClusterKey]int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	res := make(map[moira.ClusterKey]int64, len(clusterKeys))
+
+	for _, key := range clusterKeys {
+		if err := checkTriggerSource(key); err != nil {
+			return nil, err
+		}
+
+		res[key] = int64(len(s.clusterTriggers[key]))
+	}
+
+	return res, nil
+}
+
+// GetTrigger gets trigger and trigger tags by given ID and return it in merged object.
+func (connector *DbConnector) GetTrigger(triggerID string) (moira.Trigger, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getTrigger(triggerID)
+}
+
+// GetTriggers returns triggers data by given ids, len of triggerIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTriggers(triggerIDs []string) ([]*moira.Trigger, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getTriggers(triggerIDs)
+}
+
+// GetTriggerChecks gets triggers data with tags, lastCheck data and throttling by given triggersIDs.
+// Len of triggerIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTriggerChecks(triggerIDs []string) ([]*moira.TriggerCheck, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	triggers, err := s.getTriggers(triggerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := connector.Clock.NowUnix()
+	triggerChecks := make([]*moira.TriggerCheck, len(triggerIDs))
+
+	for i, trigger := range triggers {
+		if trigger == nil {
+			continue
+		}
+
+		lastCheck, err := s.getTriggerLastCheck(trigger.ID)
+		if err != nil && !errors.Is(err, database.ErrNil) {
+			return nil, err
+		}
+
+		throttling := s.throttlingNext[trigger.ID]
+		if now >= throttling {
+			throttling = 0
+		}
+
+		triggerChecks[i] = &moira.TriggerCheck{
+			Trigger:    *trigger,
+			LastCheck:  lastCheck,
+			Throttling: throttling,
+		}
+	}
+
+	return triggerChecks, nil
+}
+
+// GetPatternTriggerIDs gets trigger list by given pattern.
+func (connector *DbConnector) GetPatternTriggerIDs(pattern string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return setMembers(s.patternTriggers, pattern), nil
+}
+
+// RemovePatternTriggerIDs removes all triggerIDs list accepted to given pattern.
+func (connector *DbConnector) RemovePatternTriggerIDs(pattern string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.patternTriggers, pattern)
+
+	return nil
+}
+
+// SaveTrigger sets trigger data by given trigger and triggerID.
+// If trigger already exists, then cleanup not used tags and patterns.
+// If given trigger contains new tags then create it.
+// If given trigger has no subscription on it, mark it as unused.
+func (connector *DbConnector) SaveTrigger(triggerID string, trigger *moira.Trigger) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var oldTrigger *moira.Trigger
+	if existing, err := s.getTrigger(triggerID); err == nil {
+		oldTrigger = &existing
+	} else if !errors.Is(err, database.ErrNil) {
+		return fmt.Errorf("failed to get trigger: %w", err)
+	}
+
+	connector.preSaveTrigger(trigger, oldTrigger)
+
+	if err := connector.updateTrigger(s, triggerID, trigger, oldTrigger); err != nil {
+		return fmt.Errorf("failed to update trigger: %w", err)
+	}
+
+	hasSubscriptions, err := s.triggerHasSubscriptions(trigger)
+	if err != nil {
+		return fmt.Errorf("failed to check trigger subscriptions: %w", err)
+	}
+
+	if hasSubscriptions {
+		s.unusedTriggers.remove(triggerID)
+	} else {
+		s.unusedTriggers.add(triggerID)
+	}
+
+	if oldTrigger != nil {
+		s.cleanupPatternsOutOfUse(moira.GetStringListsDiff(oldTrigger.Patterns, trigger.Patterns))
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) preSaveTrigger(newTrigger *moira.Trigger, oldTrigger *moira.Trigger) {
+	if newTrigger.TriggerSource != moira.GraphiteLocal {
+		newTrigger.Patterns = make([]string, 0)
+	}
+
+	now := connector.Clock.NowUnix()
+
+	newTrigger.UpdatedAt = &now
+	if oldTrigger != nil {
+		newTrigger.CreatedAt = oldTrigger.CreatedAt
+		newTrigger.CreatedBy = oldTrigger.CreatedBy
+	} else {
+		newTrigger.CreatedAt = &now
+		newTrigger.CreatedBy = newTrigger.UpdatedBy
+	}
+}
+
+func (connector *DbConnector) updateTrigger(s *state, triggerID string, newTrigger *moira.Trigger, oldTrigger *moira.Trigger) error {
+	if err := checkTriggerSource(newTrigger.ClusterKey()); err != nil {
+		return fmt.Errorf("could not update trigger: %w", err)
+	}
+
+	stored := *newTrigger
+	stored.ID = triggerID
+
+	bytes, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trigger: %w", err)
+	}
+
+	if oldTrigger != nil {
+		for _, pattern := range moira.GetStringListsDiff(oldTrigger.Patterns, newTrigger.Patterns) {
+			removeFromSet(s.patternTriggers, pattern, triggerID)
+		}
+
+		for _, tag := range moira.GetStringListsDiff(oldTrigger.Tags, newTrigger.Tags) {
+			removeFromSet(s.triggerTags, triggerID, tag)
+			removeFromSet(s.tagTriggers, tag, triggerID)
+		}
+
+		if newTrigger.ClusterKey() != oldTrigger.ClusterKey() {
+			removeFromSet(s.clusterTriggers, oldTrigger.ClusterKey(), triggerID)
+		}
+	}
+
+	s.triggers[triggerID] = bytes
+	addToSet(s.clusterTriggers, newTrigger.ClusterKey(), triggerID)
+
+	if newTrigger.TriggerSource == moira.GraphiteLocal {
+		for _, pattern := range newTrigger.Patterns {
+			s.patterns.add(pattern)
+			addToSet(s.patternTriggers, pattern, triggerID)
+		}
+	}
+
+	for _, tag := range newTrigger.Tags {
+		addToSet(s.triggerTags, triggerID, tag)
+		addToSet(s.tagTriggers, tag, triggerID)
+		s.tags.add(tag)
+	}
+
+	if connector.source != redis.Cli {
+		s.toReindex[triggerID] = float64(connector.Clock.NowUnix())
+	}
+
+	return nil
+}
+
+// RemoveTrigger deletes trigger data by given triggerID, delete trigger tag list,
+// deletes triggerID from containing tags triggers list and from containing patterns triggers list.
+// If containing patterns doesn't used in another triggers, then delete this patterns with metrics data.
+func (connector *DbConnector) RemoveTrigger(triggerID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	trigger, err := s.getTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return err
+	}
+
+	delete(s.triggers, triggerID)
+	delete(s.triggerTags, triggerID)
+	delete(s.triggerEvents, triggerID)
+	removeFromSet(s.clusterTriggers, trigger.ClusterKey(), triggerID)
+	s.unusedTriggers.remove(triggerID)
+
+	for _, tag := range trigger.Tags {
+		removeFromSet(s.tagTriggers, tag, triggerID)
+	}
+
+	for _, pattern := range trigger.Patterns {
+		removeFromSet(s.patternTriggers, pattern, triggerID)
+	}
+
+	s.toReindex[triggerID] = float64(connector.Clock.NowUnix())
+	delete(s.lastChecks, triggerID)
+
+	s.cleanupPatternsOutOfUse(trigger.Patterns)
+
+	return nil
+}
+
+// GetTriggerIDsStartWith returns triggers which have ID starting with "prefix" parameter.
+func (connector *DbConnector) GetTriggerIDsStartWith(prefix string) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var matchedTriggers []string
+
+	for _, id := range s.allTriggerIDs() {
+		if strings.HasPrefix(id, prefix) {
+			matchedTriggers = append(matchedTriggers, id)
+		}
+	}
+
+	return matchedTriggers, nil
+}
+
+func (s *state) allTriggerIDs() []string {
+	triggerIDs := make(stringSet, len(s.triggers))
+	for triggerID := range s.triggers {
+		triggerIDs.add(triggerID)
+	}
+
+	return triggerIDs.members()
+}
+
+func (s *state) getTrigger(triggerID string) (moira.Trigger, error) {
+	bytes, ok := s.triggers[triggerID]
+	if !ok {
+		return moira.Trigger{}, database.ErrNil
+	}
+
+	trigger := moira.Trigger{}
+	if err := json.Unmarshal(bytes, &trigger); err != nil {
+		return moira.Trigger{}, fmt.Errorf("failed to parse trigger json %s: %w", string(bytes), err)
+	}
+
+	trigger.ID = triggerID
+	trigger.TriggerSource = trigger.TriggerSource.FillInIfNotSet(false)
+	trigger.ClusterId = trigger.ClusterId.FillInIfNotSet()
+
+	if tags := s.triggerTags[triggerID]; len(tags) > 0 {
+		trigger.Tags = tags.members()
+	}
+
+	return trigger, nil
+}
+
+func (s *state) getTriggers(triggerIDs []string) ([]*moira.Trigger, error) {
+	triggers := make([]*moira.Trigger, len(triggerIDs))
+
+	for i, triggerID := range triggerIDs {
+		trigger, err := s.getTrigger(triggerID)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		triggers[i] = &trigger
+	}
+
+	return triggers, nil
+}
+
+func (s *state) cleanupPatternsOutOfUse(patterns []string) {
+	for _, pattern := range patterns {
+		if len(s.patternTriggers[pattern]) == 0 {
+			s.removePatternWithMetrics(pattern)
+		}
+	}
+}
+
+func (s *state) triggerHasSubscriptions(trigger *moira.Trigger) (bool, error) {
+	if trigger == nil || len(trigger.Tags) == 0 {
+		return false, nil
+	}
+
+	subscriptions, err := s.getTagsSubscriptions(trigger.Tags)
+	if err != nil {
+		return false, err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription == nil {
+			continue
+		}
+
+		if subscription.AnyTags || moira.Subset(subscription.Tags, trigger.Tags) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// checkTriggerSource returns error if trigger source of cluster key is unknown, as Redis has no lists for such triggers.
+func checkTriggerSource(clusterKey moira.ClusterKey) error {
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteLocal, moira.GraphiteRemote, moira.PrometheusRemote, moira.LokiRemote, moira.SQLRemote:
+		return nil
+	default:
+		return fmt.Errorf("unknown trigger source %s", clusterKey.TriggerSource)
+	}
+}
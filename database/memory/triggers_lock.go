@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	triggerCheckLockTTL          = 30 * time.Second
+	triggerCheckLockAttemptDelay = time.Second
+)
+
+// AcquireTriggerCheckLock is used to acquire lock for trigger check, tries to set lock maxAttemptsCount times.
+func (connector *DbConnector) AcquireTriggerCheckLock(triggerID string, maxAttemptsCount int) error {
+	acquired, err := connector.SetTriggerCheckLock(triggerID)
+	if err != nil {
+		return err
+	}
+
+	attemptsCount := 0
+	for !acquired && attemptsCount < maxAttemptsCount {
+		attemptsCount++
+		<-time.After(triggerCheckLockAttemptDelay)
+
+		acquired, err = connector.SetTriggerCheckLock(triggerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !acquired {
+		return fmt.Errorf("can not acquire trigger lock in %v attempts", maxAttemptsCount)
+	}
+
+	return nil
+}
+
+// SetTriggerCheckLock sets trigger check lock if it is not set or expired, returns true if lock was set.
+func (connector *DbConnector) SetTriggerCheckLock(triggerID string) (bool, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	now := connector.Clock.NowUTC()
+
+	if expiresAt, ok := s.checkLocks[triggerID]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	s.checkLocks[triggerID] = now.Add(triggerCheckLockTTL)
+
+	return true, nil
+}
+
+// DeleteTriggerCheckLock deletes trigger check lock for given triggerID.
+func (connector *DbConnector) DeleteTriggerCheckLock(triggerID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.checkLocks, triggerID)
+
+	return nil
+}
+
+// ReleaseTriggerCheckLock deletes trigger check lock for given triggerID and logs an error if needed.
+func (connector *DbConnector) ReleaseTriggerCheckLock(triggerID string) {
+	if err := connector.DeleteTriggerCheckLock(triggerID); err != nil {
+		connector.logger.Warning().
+			Error(err).
+			Msg("Error on releasing trigger check lock")
+	}
+}
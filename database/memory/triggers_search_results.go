@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"slices"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// searchResults is a list of search results which expires like Redis key with TTL.
+type searchResults struct {
+	results   []moira.SearchResult
+	expiresAt time.Time
+}
+
+// SaveTriggersSearchResults is used to save triggers search results.
+func (connector *DbConnector) SaveTriggersSearchResults(searchResultsID string, results []*moira.SearchResult, recordTTL time.Duration) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	saved := s.getSearchResults(connector.Clock, searchResultsID)
+	if saved == nil {
+		saved = &searchResults{}
+		s.searchResults[searchResultsID] = saved
+	}
+
+	for _, result := range results {
+		saved.results = append(saved.results, moira.SearchResult{
+			ObjectID:   result.ObjectID,
+			Highlights: slices.Clone(result.Highlights),
+		})
+	}
+
+	saved.expiresAt = connector.Clock.NowUTC().Add(recordTTL)
+
+	return nil
+}
+
+// GetTriggersSearchResults is used to return results of trigger searches by ID.
+func (connector *DbConnector) GetTriggersSearchResults(searchResultsID string, page, size int64) ([]*moira.SearchResult, int64, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	var from, to int64 = 0, -1
+	if size > 0 {
+		from = page * size
+		to = from + size - 1
+	}
+
+	saved := s.getSearchResults(connector.Clock, searchResultsID)
+	if saved == nil {
+		return make([]*moira.SearchResult, 0), 0, nil
+	}
+
+	result := make([]*moira.SearchResult, 0)
+
+	if start, end, ok := indexRange(int64(len(saved.results)), from, to); ok {
+		for _, searchResult := range saved.results[start : end+1] {
+			result = append(result, &moira.SearchResult{
+				ObjectID:   searchResult.ObjectID,
+				Highlights: slices.Clone(searchResult.Highlights),
+			})
+		}
+	}
+
+	return result, int64(len(saved.results)), nil
+}
+
+// IsTriggersSearchResultsExist is used to check if triggers search results exist.
+func (connector *DbConnector) IsTriggersSearchResultsExist(pagerID string) (bool, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.getSearchResults(connector.Clock, pagerID) != nil, nil
+}
+
+// DeleteTriggersSearchResults is used to delete triggers search results by ID.
+func (connector *DbConnector) DeleteTriggersSearchResults(pagerID string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	delete(s.searchResults, pagerID)
+
+	return nil
+}
+
+// getSearchResults returns saved search results or nil if there are no such results or they are expired.
+func (s *state) getSearchResults(clock moira.Clock, searchResultsID string) *searchResults {
+	saved, ok := s.searchResults[searchResultsID]
+	if !ok {
+		return nil
+	}
+
+	if !clock.NowUTC().Before(saved.expiresAt) {
+		delete(s.searchResults, searchResultsID)
+		return nil
+	}
+
+	return saved
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"github.com/moira-alert/moira"
+)
+
+// AddTriggersToCheck gets trigger IDs and save it to set of triggers to check for the given cluster.
+func (connector *DbConnector) AddTriggersToCheck(clusterKey moira.ClusterKey, triggerIDs []string) error {
+	if err := checkTriggerSource(clusterKey); err != nil {
+		return err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	if len(triggerIDs) > 0 {
+		addToSet(s.triggersToCheck, clusterKey, triggerIDs...)
+	}
+
+	return nil
+}
+
+// GetTriggersToCheck return random trigger IDs from set of triggers to check and removes them from the set.
+func (connector *DbConnector) GetTriggersToCheck(clusterKey moira.ClusterKey, count int) ([]string, error) {
+	if err := checkTriggerSource(clusterKey); err != nil {
+		return nil, err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	triggerIDs := make([]string, 0, count)
+
+	// Map iteration order is random, so popped IDs are random like in SPOP command.
+	for triggerID := range s.triggersToCheck[clusterKey] {
+		if len(triggerIDs) >= count {
+			break
+		}
+
+		triggerIDs = append(triggerIDs, triggerID)
+	}
+
+	removeFromSet(s.triggersToCheck, clusterKey, triggerIDs...)
+
+	return triggerIDs, nil
+}
+
+// GetTriggersToCheckCount return number of triggers ID to check for the given cluster.
+func (connector *DbConnector) GetTriggersToCheckCount(clusterKey moira.ClusterKey) (int64, error) {
+	if err := checkTriggerSource(clusterKey); err != nil {
+		return 0, err
+	}
+
+	s := connector.lock()
+	defer connector.unlock()
+
+	return int64(len(s.triggersToCheck[clusterKey])), nil
+}
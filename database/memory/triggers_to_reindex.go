@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FetchTriggersToReindex returns trigger IDs updated since 'from' param.
+func (connector *DbConnector) FetchTriggersToReindex(from int64) ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	members, err := s.toReindex.rangeByScore(strconv.FormatInt(from, 10), "+inf")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch triggers to reindex: %w", err)
+	}
+
+	triggerIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		triggerIDs = append(triggerIDs, member.member)
+	}
+
+	return triggerIDs, nil
+}
+
+// RemoveTriggersToReindex removes outdated triggerIDs from the set of triggers to reindex.
+func (connector *DbConnector) RemoveTriggersToReindex(to int64) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	if _, err := s.toReindex.removeRangeByScore("-inf", strconv.FormatInt(to, 10)); err != nil {
+		return fmt.Errorf("failed to remove triggers to reindex: %w", err)
+	}
+
+	return nil
+}
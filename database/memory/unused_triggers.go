@@ -0,0 +1,29 @@
+package memory
+
+// MarkTriggersAsUnused adds unused trigger IDs to the set of unused triggers.
+func (connector *DbConnector) MarkTriggersAsUnused(triggerIDs ...string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.unusedTriggers.add(triggerIDs...)
+
+	return nil
+}
+
+// GetUnusedTriggerIDs returns all unused trigger IDs.
+func (connector *DbConnector) GetUnusedTriggerIDs() ([]string, error) {
+	s := connector.lock()
+	defer connector.unlock()
+
+	return s.unusedTriggers.members(), nil
+}
+
+// MarkTriggersAsUsed removes trigger IDs from the set of unused triggers.
+func (connector *DbConnector) MarkTriggersAsUsed(triggerIDs ...string) error {
+	s := connector.lock()
+	defer connector.unlock()
+
+	s.unusedTriggers.remove(triggerIDs...)
+
+	return nil
+}
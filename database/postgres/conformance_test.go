@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/conformance"
+	"github.com/moira-alert/moira/database/redis"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	"github.com/stretchr/testify/require"
+)
+
+// testDSNEnv is the name of environment variable with DSN of PostgreSQL database used by tests.
+// Tests are skipped if it is not set. All tables of this database are truncated by tests.
+const testDSNEnv = "MOIRA_POSTGRES_TEST_DSN"
+
+var schemaTableRegexp = regexp.MustCompile(`CREATE TABLE IF NOT EXISTS (\w+)`)
+
+// schemaTables returns names of all tables of the schema, so tests truncate tables added later too.
+func schemaTables() []string {
+	matches := schemaTableRegexp.FindAllStringSubmatch(schema, -1)
+
+	tables := make([]string, 0, len(matches))
+	for _, match := range matches {
+		tables = append(tables, match[1])
+	}
+
+	return tables
+}
+
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	logger, _ := logging.GetLogger("dataBase")
+
+	conformance.Run(t, func(t *testing.T) moira.Database {
+		metricsDatabase := redis.NewTestDatabase(logger)
+		metricsDatabase.Flush()
+		t.Cleanup(metricsDatabase.Flush)
+
+		dataBase, err := NewDatabase(logger, Config{
+			DSN:                    dsn,
+			MaxOpenConnections:     5,
+			NotificationHistoryTTL: 48 * time.Hour,
+		}, "test", metricsDatabase)
+		require.NoError(t, err)
+
+		truncate := func() {
+			_, err := dataBase.db.Exec("TRUNCATE " + strings.Join(schemaTables(), ", "))
+			require.NoError(t, err)
+		}
+
+		truncate()
+		t.Cleanup(func() {
+			truncate()
+			dataBase.Close()
+		})
+
+		return dataBase
+	})
+}
+
+func TestSchemaTables(t *testing.T) {
+	tables := schemaTables()
+
+	require.Len(t, tables, strings.Count(schema, "CREATE TABLE"))
+	require.Contains(t, tables, "moira_team_roles")
+	require.Contains(t, tables, "moira_silences")
+}
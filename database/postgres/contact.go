@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetContact returns contact data by given id, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetContact(id string) (moira.ContactData, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_contacts WHERE id = $1", id).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.ContactData{}, database.ErrNil
+		}
+
+		return moira.ContactData{}, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	return unmarshalContact(id, data)
+}
+
+// GetContacts returns contacts data by given ids, len of contactIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetContacts(contactIDs []string) ([]*moira.ContactData, error) {
+	contactsByID, err := connector.queryContacts("SELECT id, data FROM moira_contacts WHERE id = ANY($1)", pq.Array(contactIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*moira.ContactData, len(contactIDs))
+	for i, id := range contactIDs {
+		contacts[i] = contactsByID[id]
+	}
+
+	return contacts, nil
+}
+
+// GetAllContacts returns full contact list.
+func (connector *DbConnector) GetAllContacts() ([]*moira.ContactData, error) {
+	contactsByID, err := connector.queryContacts("SELECT id, data FROM moira_contacts")
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*moira.ContactData, 0, len(contactsByID))
+	for _, contact := range contactsByID {
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, nil
+}
+
+// SaveContact writes contact data and resets contact score.
+func (connector *DbConnector) SaveContact(contact *moira.ContactData) error {
+	bytes, err := json.Marshal(contact)
+	if err != nil {
+		return err
+	}
+
+	_, err = connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_contacts (id, user_login, team_id, data) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET user_login = EXCLUDED.user_login, team_id = EXCLUDED.team_id, data = EXCLUDED.data`,
+		contact.ID, contact.User, contact.Team, string(bytes))
+	if err != nil {
+		return fmt.Errorf("failed to save contact: %w", err)
+	}
+
+	// Contact scores are kept in metrics database, so the score can't be deleted in the same transaction.
+	// Reset it to the empty one, which is what the score of a new contact looks like.
+	err = connector.Database.UpdateContactScores([]string{contact.ID}, func(moira.ContactScore) moira.ContactScore {
+		return moira.ContactScore{ContactID: contact.ID}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset contact score: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveContact deletes contact data.
+func (connector *DbConnector) RemoveContact(contactID string) error {
+	if _, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_contacts WHERE id = $1", contactID); err != nil {
+		return fmt.Errorf("failed to remove contact: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserContactIDs returns contacts ids by given login.
+func (connector *DbConnector) GetUserContactIDs(login string) ([]string, error) {
+	contacts, err := connector.queryStrings("SELECT id FROM moira_contacts WHERE user_login = $1 AND user_login <> ''", login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts for user login %s: %w", login, err)
+	}
+
+	return contacts, nil
+}
+
+// GetTeamContactIDs returns contacts ids by given team.
+func (connector *DbConnector) GetTeamContactIDs(login string) ([]string, error) {
+	contacts, err := connector.queryStrings("SELECT id FROM moira_contacts WHERE team_id = $1 AND team_id <> ''", login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts for team login %s: %w", login, err)
+	}
+
+	return contacts, nil
+}
+
+func (connector *DbConnector) queryContacts(query string, args ...any) (map[string]*moira.ContactData, error) {
+	rows, err := connector.db.QueryContext(connector.context, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := make(map[string]*moira.ContactData)
+
+	for rows.Next() {
+		var id, data string
+		if err = rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+
+		contact, err := unmarshalContact(id, data)
+		if err != nil {
+			return nil, err
+		}
+
+		contacts[id] = &contact
+	}
+
+	return contacts, rows.Err()
+}
+
+func unmarshalContact(id, data string) (moira.ContactData, error) {
+	contact := moira.ContactData{}
+	if err := json.Unmarshal([]byte(data), &contact); err != nil {
+		return contact, fmt.Errorf("failed to parse contact json %s: %w", data, err)
+	}
+
+	contact.ID = id
+
+	return contact, nil
+}
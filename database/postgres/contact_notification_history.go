@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// GetNotificationsHistoryByContactID returns `size` (or all if `size` is -1) notification events with timestamp between `from` and `to`.
+// The offset for fetching events may be changed by using `page` parameter, it is calculated as page * size.
+func (connector *DbConnector) GetNotificationsHistoryByContactID(contactID string, from, to, page, size int64,
+) ([]*moira.NotificationEventHistoryItem, int64, error) {
+	var total int64
+
+	err := connector.db.QueryRowContext(connector.context,
+		"SELECT count(*) FROM moira_contact_notifications WHERE contact_id = $1 AND timestamp BETWEEN $2 AND $3",
+		contactID, from, to).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// LIMIT NULL means no limit.
+	limit := sql.NullInt64{Int64: size, Valid: size >= 0}
+
+	offset := page * size
+	if offset < 0 {
+		offset = 0
+	}
+
+	notificationStrings, err := connector.queryStrings(`
+		SELECT data FROM moira_contact_notifications WHERE contact_id = $1 AND timestamp BETWEEN $2 AND $3
+		ORDER BY timestamp, data LIMIT $4 OFFSET $5`,
+		contactID, from, to, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notifications := make([]*moira.NotificationEventHistoryItem, 0, len(notificationStrings))
+
+	for _, notification := range notificationStrings {
+		notificationObj, err := redis.GetNotificationStruct(notification)
+		if err != nil {
+			return notifications, total, err
+		}
+
+		notifications = append(notifications, &notificationObj)
+	}
+
+	return notifications, total, nil
+}
+
+// PushContactNotificationToHistory converts ScheduledNotification to NotificationEventHistoryItem and saves it,
+// and deletes items older than specified ttl.
+func (connector *DbConnector) PushContactNotificationToHistory(notification *moira.ScheduledNotification) error {
+	notificationItemToSave := &moira.NotificationEventHistoryItem{
+		Metric:    notification.Event.Metric,
+		State:     notification.Event.State,
+		TriggerID: notification.Trigger.ID,
+		OldState:  notification.Event.OldState,
+		ContactID: notification.Contact.ID,
+		TimeStamp: notification.Timestamp,
+	}
+
+	notificationBytes, serializationErr := redis.GetNotificationBytes(notificationItemToSave)
+	if serializationErr != nil {
+		return fmt.Errorf("failed to serialize notification to contact event history item: %w", serializationErr)
+	}
+
+	to := time.Now().Unix() - int64(connector.config.NotificationHistoryTTL.Seconds())
+
+	err := connector.inTx(func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(connector.context,
+			"INSERT INTO moira_contact_notifications (contact_id, timestamp, data) VALUES ($1, $2, $3)",
+			notificationItemToSave.ContactID, notification.Timestamp, string(notificationBytes))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(connector.context,
+			"DELETE FROM moira_contact_notifications WHERE contact_id = $1 AND timestamp <= $2",
+			notificationItemToSave.ContactID, to)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push contact event history item: %w", err)
+	}
+
+	return nil
+}
+
+// CleanUpOutdatedNotificationHistory is used for deleting notification history events which have been created more than ttl ago.
+func (connector *DbConnector) CleanUpOutdatedNotificationHistory(ttl int64) error {
+	result, err := connector.db.ExecContext(connector.context,
+		"DELETE FROM moira_contact_notifications WHERE timestamp <= $1", time.Now().Unix()-ttl)
+	if err != nil {
+		return fmt.Errorf("failed to delete outdated notification history: %w", err)
+	}
+
+	totalDelCount, _ := result.RowsAffected()
+
+	connector.logger.Info().
+		Int64("delete_count", totalDelCount).
+		Msg("Cleaned up notification history")
+
+	return nil
+}
+
+// CountEventsInNotificationHistory returns the number of events in time range (from, to) for given contact ids.
+func (connector *DbConnector) CountEventsInNotificationHistory(contactIDs []string, from, to string) ([]*moira.ContactIDWithNotificationCount, error) {
+	fromTimestamp, err := parseScoreBound(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTimestamp, err := parseScoreBound(to)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connector.db.QueryContext(connector.context, `
+		SELECT contact_id, count(*) FROM moira_contact_notifications
+		WHERE contact_id = ANY($1) AND timestamp BETWEEN $2 AND $3
+		GROUP BY contact_id`,
+		pq.Array(contactIDs), fromTimestamp, toTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]uint64, len(contactIDs))
+
+	for rows.Next() {
+		var (
+			id    string
+			count uint64
+		)
+
+		if err = rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+
+		counts[id] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	eventsCount := make([]*moira.ContactIDWithNotificationCount, 0, len(contactIDs))
+	for _, id := range contactIDs {
+		eventsCount = append(eventsCount, &moira.ContactIDWithNotificationCount{
+			ID:    id,
+			Count: counts[id],
+		})
+	}
+
+	return eventsCount, nil
+}
+
+// parseScoreBound parses bound of range in format of Redis sorted set score: integer, "-inf" or "+inf".
+func parseScoreBound(bound string) (int64, error) {
+	switch bound {
+	case "-inf":
+		return math.MinInt64, nil
+	case "+inf", "inf":
+		return math.MaxInt64, nil
+	}
+
+	value, err := strconv.ParseInt(bound, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse range bound %s: %w", bound, err)
+	}
+
+	return value, nil
+}
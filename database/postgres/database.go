@@ -0,0 +1,137 @@
+// Package postgres implements storage of Moira configuration data in PostgreSQL.
+//
+// Triggers, subscriptions, contacts, teams, tags, last checks and contact notification history are stored
+// in PostgreSQL, so they survive loss of Redis data. Metrics, notifications queue, locks, throttling, contact
+// scores, trigger events and other runtime data are stored in the wrapped Redis database.
+//
+// Known limitations:
+//   - events of removed trigger are not deleted from Redis, they expire with the rest of the events;
+//   - cleanup of outdated pattern metrics in Redis does not see patterns stored in PostgreSQL;
+//   - moira-cli works with Redis only.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// Driver of PostgreSQL.
+	_ "github.com/lib/pq"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/clock"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// DbConnector stores configuration data (tags, triggers, last checks, subscriptions, contacts, teams
+// and contact notification history) in PostgreSQL. All other data, such as metrics, notifications queue,
+// locks and self state, is read and written by the wrapped metrics database (Redis).
+//
+// Derived trigger indexes (patterns, triggers to reindex and unused triggers) and checks counters used
+// by self state monitor are stored in PostgreSQL too, as they are updated together with the configuration.
+type DbConnector struct {
+	moira.Database
+
+	Clock   moira.Clock
+	db      *sql.DB
+	logger  moira.Logger
+	context context.Context
+	source  redis.DBSource
+	config  Config
+}
+
+// NewDatabase opens PostgreSQL database, creates missing tables and returns configured instance of DbConnector.
+func NewDatabase(logger moira.Logger, config Config, source redis.DBSource, metricsDatabase moira.Database) (*DbConnector, error) {
+	db, err := sql.Open("postgres", config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres database: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConnections)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	connector := &DbConnector{
+		Database: metricsDatabase,
+		Clock:    clock.NewSystemClock(),
+		db:       db,
+		logger:   logger,
+		context:  context.Background(),
+		source:   source,
+		config:   config,
+	}
+
+	if err = connector.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return connector, nil
+}
+
+// Close closes PostgreSQL database.
+func (connector *DbConnector) Close() error {
+	return connector.db.Close()
+}
+
+func (connector *DbConnector) migrate() error {
+	return connector.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(connector.context, "SELECT pg_advisory_xact_lock($1)", schemaLockID); err != nil {
+			return fmt.Errorf("failed to lock postgres schema: %w", err)
+		}
+
+		if _, err := tx.ExecContext(connector.context, schema); err != nil {
+			return fmt.Errorf("failed to create postgres schema: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// inTx runs fn in transaction, which is committed if fn returns no error and rolled back otherwise.
+func (connector *DbConnector) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := connector.db.BeginTx(connector.context, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// queryStrings runs query returning single text column.
+func (connector *DbConnector) queryStrings(query string, args ...any) ([]string, error) {
+	return queryStrings(connector.context, connector.db, query, args...)
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func queryStrings(ctx context.Context, q querier, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]string, 0)
+
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/clock"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetTriggerLastCheck gets trigger last check data by given triggerID, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetTriggerLastCheck(triggerID string) (moira.CheckData, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_last_checks WHERE trigger_id = $1", triggerID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.CheckData{}, database.ErrNil
+		}
+
+		return moira.CheckData{}, fmt.Errorf("failed to get last check: %w", err)
+	}
+
+	return unmarshalCheckData(data)
+}
+
+// SetTriggerLastCheck sets trigger last check data.
+func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, clusterKey moira.ClusterKey) error {
+	bytes, err := json.Marshal(checkData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal last check: %w", err)
+	}
+
+	err = connector.inTx(func(tx *sql.Tx) error {
+		ctx := connector.context
+
+		var oldScore int64
+
+		err := tx.QueryRowContext(ctx, "SELECT score FROM moira_last_checks WHERE trigger_id = $1 FOR UPDATE", triggerID).Scan(&oldScore)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get last check score: %w", err)
+		}
+
+		triggerNeedToReindex := errors.Is(err, sql.ErrNoRows) || oldScore != checkData.Score
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO moira_last_checks (trigger_id, score, data) VALUES ($1, $2, $3)
+			ON CONFLICT (trigger_id) DO UPDATE SET score = EXCLUDED.score, data = EXCLUDED.data`,
+			triggerID, checkData.Score, string(bytes))
+		if err != nil {
+			return fmt.Errorf("failed to save last check: %w", err)
+		}
+
+		if triggerNeedToReindex {
+			return addTriggerToReindex(connector, tx, triggerID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Counter is updated out of transaction to hold the lock of its row as short as possible
+	if counterName := connector.getSelfStateCheckCounterName(clusterKey); counterName != "" {
+		return connector.incrementChecksCounter(counterName)
+	}
+
+	return nil
+}
+
+// RemoveTriggerLastCheck removes trigger last check data.
+func (connector *DbConnector) RemoveTriggerLastCheck(triggerID string) error {
+	return connector.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(connector.context, "DELETE FROM moira_last_checks WHERE trigger_id = $1", triggerID); err != nil {
+			return fmt.Errorf("failed to remove last check: %w", err)
+		}
+
+		return addTriggerToReindex(connector, tx, triggerID)
+	})
+}
+
+// CleanUpAbandonedTriggerLastCheck cleans up abandoned triggers last check.
+func (connector *DbConnector) CleanUpAbandonedTriggerLastCheck() error {
+	triggerIDs, err := connector.queryStrings(`
+		SELECT lc.trigger_id FROM moira_last_checks lc
+		WHERE NOT EXISTS (SELECT 1 FROM moira_triggers t WHERE t.id = lc.trigger_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to get abandoned last checks: %w", err)
+	}
+
+	for _, triggerID := range triggerIDs {
+		if err = connector.RemoveTriggerLastCheck(triggerID); err != nil {
+			return err
+		}
+
+		connector.logger.Debug().
+			String("trigger_id", triggerID).
+			Msg("Cleaned up last check for trigger")
+	}
+
+	connector.logger.Info().
+		Int("count deleted last_check", len(triggerIDs)).
+		Msg("Cleaned up last check for trigger")
+
+	return nil
+}
+
+// SetTriggerCheckMaintenance sets maintenance for whole trigger and to given metrics,
+// If CheckData does not contain one of given metrics it will ignore this metric.
+func (connector *DbConnector) SetTriggerCheckMaintenance(triggerID string, metrics map[string]int64, triggerMaintenance *int64, userLogin string, timeCallMaintenance int64) error {
+	return connector.inTx(func(tx *sql.Tx) error {
+		ctx := connector.context
+
+		var data string
+
+		err := tx.QueryRowContext(ctx, "SELECT data FROM moira_last_checks WHERE trigger_id = $1 FOR UPDATE", triggerID).Scan(&data)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+
+			return err
+		}
+
+		lastCheck := moira.CheckData{}
+		if err = json.Unmarshal([]byte(data), &lastCheck); err != nil {
+			return fmt.Errorf("failed to parse lastCheck json %s: %w", data, err)
+		}
+
+		metricsCheck := lastCheck.Metrics
+		if len(metricsCheck) > 0 {
+			for metric, value := range metrics {
+				data, ok := metricsCheck[metric]
+				if !ok {
+					continue
+				}
+
+				moira.SetMaintenanceUserAndTime(&data, value, userLogin, timeCallMaintenance)
+				metricsCheck[metric] = data
+			}
+		}
+
+		if triggerMaintenance != nil {
+			moira.SetMaintenanceUserAndTime(&lastCheck, *triggerMaintenance, userLogin, timeCallMaintenance)
+		}
+
+		newLastCheck, err := json.Marshal(lastCheck)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE moira_last_checks SET data = $2 WHERE trigger_id = $1", triggerID, string(newLastCheck))
+
+		return err
+	})
+}
+
+// getTriggersLastCheck returns an array of trigger checks by the passed ids, if the trigger does not exist, it is nil.
+func (connector *DbConnector) getTriggersLastCheck(triggerIDs []string) ([]*moira.CheckData, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT trigger_id, data FROM moira_last_checks WHERE trigger_id = ANY($1)", pq.Array(triggerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last checks: %w", err)
+	}
+	defer rows.Close()
+
+	checksByID := make(map[string]*moira.CheckData, len(triggerIDs))
+
+	for rows.Next() {
+		var triggerID, data string
+		if err = rows.Scan(&triggerID, &data); err != nil {
+			return nil, err
+		}
+
+		checkData, err := unmarshalCheckData(data)
+		if err != nil {
+			return nil, err
+		}
+
+		checksByID[triggerID] = &checkData
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	checks := make([]*moira.CheckData, len(triggerIDs))
+	for i, triggerID := range triggerIDs {
+		checks[i] = checksByID[triggerID]
+	}
+
+	return checks, nil
+}
+
+func unmarshalCheckData(data string) (moira.CheckData, error) {
+	checkData := moira.CheckData{}
+	if err := json.Unmarshal([]byte(data), &checkData); err != nil {
+		return checkData, fmt.Errorf("failed to parse lastCheck json %s: %w", data, err)
+	}
+
+	for metricName, metricState := range checkData.Metrics {
+		if metricState.Values == nil {
+			metricState.Values = make(map[string]float64)
+			checkData.Metrics[metricName] = metricState
+		}
+	}
+
+	if checkData.MetricsToTargetRelation == nil {
+		checkData.MetricsToTargetRelation = make(map[string]string)
+	}
+
+	checkData.Clock = clock.NewSystemClock()
+
+	return checkData, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveMaintenanceWindow saves maintenance window, existing window with the same id is replaced.
+func (connector *DbConnector) SaveMaintenanceWindow(window *moira.MaintenanceWindow) error {
+	bytes, err := json.Marshal(window)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance window: %w", err)
+	}
+
+	_, err = connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_maintenance_windows (id, start, data) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET start = EXCLUDED.start, data = EXCLUDED.data`,
+		window.ID, window.Start, string(bytes))
+	if err != nil {
+		return fmt.Errorf("failed to save maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+// GetMaintenanceWindow returns maintenance window by its id, if there is no such window, returns database.ErrNil error.
+func (connector *DbConnector) GetMaintenanceWindow(windowID string) (moira.MaintenanceWindow, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_maintenance_windows WHERE id = $1", windowID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.MaintenanceWindow{}, database.ErrNil
+		}
+
+		return moira.MaintenanceWindow{}, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+
+	return unmarshalMaintenanceWindow(data)
+}
+
+// GetMaintenanceWindows returns all maintenance windows ordered by their start.
+func (connector *DbConnector) GetMaintenanceWindows() ([]*moira.MaintenanceWindow, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT data FROM moira_maintenance_windows ORDER BY start, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+	defer rows.Close()
+
+	windows := make([]*moira.MaintenanceWindow, 0)
+
+	for rows.Next() {
+		var data string
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		window, err := unmarshalMaintenanceWindow(data)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &window)
+	}
+
+	return windows, rows.Err()
+}
+
+// RemoveMaintenanceWindow removes maintenance window by its id.
+func (connector *DbConnector) RemoveMaintenanceWindow(windowID string) error {
+	_, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_maintenance_windows WHERE id = $1", windowID)
+	if err != nil {
+		return fmt.Errorf("failed to remove maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+func unmarshalMaintenanceWindow(data string) (moira.MaintenanceWindow, error) {
+	var window moira.MaintenanceWindow
+	if err := json.Unmarshal([]byte(data), &window); err != nil {
+		return moira.MaintenanceWindow{}, fmt.Errorf("failed to parse maintenance window json %s: %w", data, err)
+	}
+
+	return window, nil
+}
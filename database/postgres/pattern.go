@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"fmt"
+)
+
+// GetPatterns gets all patterns of local triggers.
+func (connector *DbConnector) GetPatterns() ([]string, error) {
+	patterns, err := connector.queryStrings("SELECT pattern FROM moira_patterns")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moira patterns, error: %w", err)
+	}
+
+	return patterns, nil
+}
+
+// RemovePattern removes pattern from patterns list.
+func (connector *DbConnector) RemovePattern(pattern string) error {
+	if _, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_patterns WHERE pattern = $1", pattern); err != nil {
+		return fmt.Errorf("failed to remove pattern: %s, error: %w", pattern, err)
+	}
+
+	return nil
+}
+
+// RemovePatternWithMetrics removes pattern from patterns list and its metrics with data from metrics database.
+func (connector *DbConnector) RemovePatternWithMetrics(pattern string) error {
+	if err := connector.RemovePattern(pattern); err != nil {
+		return err
+	}
+
+	return connector.Database.RemovePatternWithMetrics(pattern)
+}
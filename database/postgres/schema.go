@@ -0,0 +1,146 @@
+package postgres
+
+// schemaLockID is a key of advisory lock, which prevents concurrent schema creation by several Moira services.
+const schemaLockID = 7236411
+
+const schema = `
+CREATE TABLE IF NOT EXISTS moira_tags (
+	name TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS moira_triggers (
+	id             TEXT PRIMARY KEY,
+	trigger_source TEXT NOT NULL,
+	cluster_id     TEXT NOT NULL,
+	data           TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS moira_triggers_cluster_idx ON moira_triggers (trigger_source, cluster_id);
+
+CREATE TABLE IF NOT EXISTS moira_trigger_tags (
+	trigger_id TEXT NOT NULL REFERENCES moira_triggers (id) ON DELETE CASCADE,
+	tag        TEXT NOT NULL,
+	PRIMARY KEY (trigger_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS moira_trigger_tags_tag_idx ON moira_trigger_tags (tag);
+
+CREATE TABLE IF NOT EXISTS moira_patterns (
+	pattern TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS moira_pattern_triggers (
+	pattern    TEXT NOT NULL,
+	trigger_id TEXT NOT NULL,
+	PRIMARY KEY (pattern, trigger_id)
+);
+
+CREATE TABLE IF NOT EXISTS moira_unused_triggers (
+	trigger_id TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS moira_triggers_to_reindex (
+	trigger_id TEXT PRIMARY KEY,
+	updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS moira_triggers_to_reindex_updated_at_idx ON moira_triggers_to_reindex (updated_at);
+
+CREATE TABLE IF NOT EXISTS moira_last_checks (
+	trigger_id TEXT PRIMARY KEY,
+	score      BIGINT NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moira_checks_counters (
+	name  TEXT PRIMARY KEY,
+	value BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moira_subscriptions (
+	id         TEXT PRIMARY KEY,
+	user_login TEXT NOT NULL,
+	team_id    TEXT NOT NULL,
+	any_tags   BOOLEAN NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS moira_subscriptions_user_idx ON moira_subscriptions (user_login);
+CREATE INDEX IF NOT EXISTS moira_subscriptions_team_idx ON moira_subscriptions (team_id);
+
+CREATE TABLE IF NOT EXISTS moira_subscription_tags (
+	subscription_id TEXT NOT NULL REFERENCES moira_subscriptions (id) ON DELETE CASCADE,
+	tag             TEXT NOT NULL,
+	PRIMARY KEY (subscription_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS moira_subscription_tags_tag_idx ON moira_subscription_tags (tag);
+
+CREATE TABLE IF NOT EXISTS moira_contacts (
+	id         TEXT PRIMARY KEY,
+	user_login TEXT NOT NULL,
+	team_id    TEXT NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS moira_contacts_user_idx ON moira_contacts (user_login);
+CREATE INDEX IF NOT EXISTS moira_contacts_team_idx ON moira_contacts (team_id);
+
+CREATE TABLE IF NOT EXISTS moira_teams (
+	id          TEXT PRIMARY KEY,
+	name        TEXT NOT NULL,
+	lower_name  TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL
+);
+
+ALTER TABLE moira_teams ADD COLUMN IF NOT EXISTS restrict_trigger_changes BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS moira_team_users (
+	team_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	PRIMARY KEY (team_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS moira_team_roles (
+	team_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	role    TEXT NOT NULL,
+	PRIMARY KEY (team_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS moira_team_preferences (
+	team_id TEXT PRIMARY KEY,
+	data    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moira_team_oncall_schedules (
+	team_id TEXT PRIMARY KEY,
+	data    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moira_user_teams (
+	user_id TEXT NOT NULL,
+	team_id TEXT NOT NULL,
+	PRIMARY KEY (user_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS moira_contact_notifications (
+	contact_id TEXT NOT NULL,
+	timestamp  BIGINT NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS moira_contact_notifications_idx ON moira_contact_notifications (contact_id, timestamp);
+
+CREATE TABLE IF NOT EXISTS moira_maintenance_windows (
+	id    TEXT PRIMARY KEY,
+	start BIGINT NOT NULL,
+	data  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moira_silences (
+	id        TEXT PRIMARY KEY,
+	starts_at BIGINT NOT NULL,
+	data      TEXT NOT NULL
+);
+`
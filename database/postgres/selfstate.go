@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+const (
+	checksCounterName           = "checks"
+	remoteChecksCounterName     = "remote_checks"
+	prometheusChecksCounterName = "prometheus_checks"
+	lokiChecksCounterName       = "loki_checks"
+	sqlChecksCounterName        = "sql_checks"
+)
+
+// GetChecksUpdatesCount return checks count by Moira-Checker.
+func (connector *DbConnector) GetChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(checksCounterName)
+}
+
+// GetRemoteChecksUpdatesCount return remote checks count by Moira-Checker.
+func (connector *DbConnector) GetRemoteChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(remoteChecksCounterName)
+}
+
+// GetPrometheusChecksUpdatesCount return remote checks count by Moira-Checker.
+func (connector *DbConnector) GetPrometheusChecksUpdatesCount() (int64, error) {
+	return connector.getChecksCounter(prometheusChecksCounterName)
+}
+
+func (connector *DbConnector) getChecksCounter(name string) (int64, error) {
+	var value int64
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT value FROM moira_checks_counters WHERE name = $1", name).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return value, err
+}
+
+func (connector *DbConnector) getSelfStateCheckCounterName(clusterKey moira.ClusterKey) string {
+	if connector.source != redis.Checker {
+		return ""
+	}
+
+	var name string
+
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteLocal:
+		name = checksCounterName
+
+	case moira.GraphiteRemote:
+		name = remoteChecksCounterName
+
+	case moira.PrometheusRemote:
+		name = prometheusChecksCounterName
+
+	case moira.LokiRemote:
+		name = lokiChecksCounterName
+
+	case moira.SQLRemote:
+		name = sqlChecksCounterName
+
+	default:
+		return ""
+	}
+
+	if clusterKey.ClusterId != moira.DefaultCluster {
+		name = name + ":" + clusterKey.ClusterId.String()
+	}
+
+	return name
+}
+
+func (connector *DbConnector) incrementChecksCounter(name string) error {
+	_, err := connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_checks_counters (name, value) VALUES ($1, 1)
+		ON CONFLICT (name) DO UPDATE SET value = moira_checks_counters.value + 1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to increment checks counter %s: %w", name, err)
+	}
+
+	return nil
+}
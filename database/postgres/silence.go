@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveSilence saves silence, existing silence with the same id is replaced.
+func (connector *DbConnector) SaveSilence(silence *moira.Silence) error {
+	bytes, err := json.Marshal(silence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence: %w", err)
+	}
+
+	_, err = connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_silences (id, starts_at, data) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET starts_at = EXCLUDED.starts_at, data = EXCLUDED.data`,
+		silence.ID, silence.StartsAt, string(bytes))
+	if err != nil {
+		return fmt.Errorf("failed to save silence: %w", err)
+	}
+
+	return nil
+}
+
+// GetSilence returns silence by its id, if there is no such silence, returns database.ErrNil error.
+func (connector *DbConnector) GetSilence(silenceID string) (moira.Silence, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_silences WHERE id = $1", silenceID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.Silence{}, database.ErrNil
+		}
+
+		return moira.Silence{}, fmt.Errorf("failed to get silence: %w", err)
+	}
+
+	return unmarshalSilence(data)
+}
+
+// GetSilences returns all silences ordered by their start time.
+func (connector *DbConnector) GetSilences() ([]*moira.Silence, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT data FROM moira_silences ORDER BY starts_at, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silences: %w", err)
+	}
+	defer rows.Close()
+
+	silences := make([]*moira.Silence, 0)
+
+	for rows.Next() {
+		var data string
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		silence, err := unmarshalSilence(data)
+		if err != nil {
+			return nil, err
+		}
+
+		silences = append(silences, &silence)
+	}
+
+	return silences, rows.Err()
+}
+
+// RemoveSilence removes silence by its id.
+func (connector *DbConnector) RemoveSilence(silenceID string) error {
+	_, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_silences WHERE id = $1", silenceID)
+	if err != nil {
+		return fmt.Errorf("failed to remove silence: %w", err)
+	}
+
+	return nil
+}
+
+func unmarshalSilence(data string) (moira.Silence, error) {
+	var silence moira.Silence
+	if err := json.Unmarshal([]byte(data), &silence); err != nil {
+		return moira.Silence{}, fmt.Errorf("failed to parse silence json %s: %w", data, err)
+	}
+
+	return silence, nil
+}
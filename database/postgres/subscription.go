@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetSubscription returns subscription data by given id, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetSubscription(id string) (moira.SubscriptionData, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_subscriptions WHERE id = $1", id).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.SubscriptionData{}, database.ErrNil
+		}
+
+		return moira.SubscriptionData{}, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return unmarshalSubscription(id, data)
+}
+
+// GetSubscriptions returns subscriptions data by given ids, len of subscriptionIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetSubscriptions(subscriptionIDs []string) ([]*moira.SubscriptionData, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT id, data FROM moira_subscriptions WHERE id = ANY($1)", pq.Array(subscriptionIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptionsByID := make(map[string]*moira.SubscriptionData, len(subscriptionIDs))
+
+	for rows.Next() {
+		var id, data string
+		if err = rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+
+		subscription, err := unmarshalSubscription(id, data)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptionsByID[id] = &subscription
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*moira.SubscriptionData, len(subscriptionIDs))
+	for i, id := range subscriptionIDs {
+		subscriptions[i] = subscriptionsByID[id]
+	}
+
+	return subscriptions, nil
+}
+
+// SaveSubscription writes subscription data, updates tags subscriptions and unused triggers.
+func (connector *DbConnector) SaveSubscription(subscription *moira.SubscriptionData) error {
+	return connector.SaveSubscriptions([]*moira.SubscriptionData{subscription})
+}
+
+// SaveSubscriptions writes subscriptions, updates tags subscriptions and unused triggers.
+func (connector *DbConnector) SaveSubscriptions(newSubscriptions []*moira.SubscriptionData) error {
+	ids := make([]string, len(newSubscriptions))
+	for i, subscription := range newSubscriptions {
+		ids[i] = subscription.ID
+	}
+
+	oldSubscriptions, err := connector.GetSubscriptions(ids)
+	if err != nil {
+		return err
+	}
+
+	oldTriggers, err := connector.getSubscriptionsTriggers(oldSubscriptions)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	err = connector.inTx(func(tx *sql.Tx) error {
+		for _, subscription := range newSubscriptions {
+			if err := connector.updateSubscription(tx, *subscription); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	newTriggers, err := connector.getSubscriptionsTriggers(newSubscriptions)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	if err := connector.refreshUnusedTriggers(newTriggers, oldTriggers); err != nil {
+		return fmt.Errorf("failed to update triggers by subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) updateSubscription(tx *sql.Tx, subscription moira.SubscriptionData) error {
+	if subscription.AnyTags {
+		subscription.Tags = nil
+	}
+
+	bytes, err := json.Marshal(subscription)
+	if err != nil {
+		return err
+	}
+
+	ctx := connector.context
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO moira_subscriptions (id, user_login, team_id, any_tags, data) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET user_login = EXCLUDED.user_login, team_id = EXCLUDED.team_id, any_tags = EXCLUDED.any_tags, data = EXCLUDED.data`,
+		subscription.ID, subscription.User, subscription.TeamID, subscription.AnyTags, string(bytes))
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM moira_subscription_tags WHERE subscription_id = $1", subscription.ID); err != nil {
+		return err
+	}
+
+	for _, tag := range subscription.Tags {
+		_, err = tx.ExecContext(ctx, "INSERT INTO moira_subscription_tags (subscription_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING", subscription.ID, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveSubscription deletes subscription data and updates unused triggers.
+func (connector *DbConnector) RemoveSubscription(subscriptionID string) error {
+	subscription, err := connector.GetSubscription(subscriptionID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return err
+	}
+
+	triggers, err := connector.getSubscriptionTriggers(&subscription)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers by subscription: %w", err)
+	}
+
+	if _, err = connector.db.ExecContext(connector.context, "DELETE FROM moira_subscriptions WHERE id = $1", subscriptionID); err != nil {
+		return fmt.Errorf("failed to remove subscription: %w", err)
+	}
+
+	if err := connector.refreshUnusedTriggers([]*moira.Trigger{}, triggers); err != nil {
+		return fmt.Errorf("failed to update triggers by subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSubscriptionIDs returns subscriptions ids by given login.
+func (connector *DbConnector) GetUserSubscriptionIDs(login string) ([]string, error) {
+	subscriptions, err := connector.queryStrings("SELECT id FROM moira_subscriptions WHERE user_login = $1 AND user_login <> ''", login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subscriptions for user login %s: %w", login, err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetTeamSubscriptionIDs returns subscriptions ids by given team id.
+func (connector *DbConnector) GetTeamSubscriptionIDs(teamID string) ([]string, error) {
+	subscriptions, err := connector.queryStrings("SELECT id FROM moira_subscriptions WHERE team_id = $1 AND team_id <> ''", teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subscriptions for team id %s: %w", teamID, err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetTagsSubscriptions gets all subscriptions by given tag list and subscriptions on any tags.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTagsSubscriptions(tags []string) ([]*moira.SubscriptionData, error) {
+	subscriptionsIDs, err := connector.queryStrings(`
+		SELECT subscription_id FROM moira_subscription_tags WHERE tag = ANY($1)
+		UNION
+		SELECT id FROM moira_subscriptions WHERE any_tags`,
+		pq.Array(tags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subscriptions for tags %v: %w", tags, err)
+	}
+
+	if len(subscriptionsIDs) == 0 {
+		return make([]*moira.SubscriptionData, 0), nil
+	}
+
+	return connector.GetSubscriptions(subscriptionsIDs)
+}
+
+// getTriggersIdsByTags returns ids of triggers having all of given tags.
+func (connector *DbConnector) getTriggersIdsByTags(tags []string) ([]string, error) {
+	uniqueTags := moira.GetUniqueValues(tags...)
+	if len(uniqueTags) == 0 {
+		return make([]string, 0), nil
+	}
+
+	triggerIDs, err := connector.queryStrings(`
+		SELECT trigger_id FROM moira_trigger_tags WHERE tag = ANY($1)
+		GROUP BY trigger_id HAVING count(*) = $2`,
+		pq.Array(uniqueTags), len(uniqueTags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve triggers for tags %v: %w", tags, err)
+	}
+
+	return triggerIDs, nil
+}
+
+func (connector *DbConnector) getSubscriptionTriggers(subscription *moira.SubscriptionData) ([]*moira.Trigger, error) {
+	if subscription == nil {
+		return make([]*moira.Trigger, 0), nil
+	}
+
+	triggersIDs, err := connector.getTriggersIdsByTags(subscription.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(triggersIDs) == 0 {
+		return make([]*moira.Trigger, 0), nil
+	}
+
+	return connector.GetTriggers(triggersIDs)
+}
+
+func (connector *DbConnector) getSubscriptionsTriggers(subscriptions []*moira.SubscriptionData) ([]*moira.Trigger, error) {
+	triggersMap := make(map[string]*moira.Trigger)
+	triggers := make([]*moira.Trigger, 0)
+
+	for _, subscription := range subscriptions {
+		subscriptionTriggers, err := connector.getSubscriptionTriggers(subscription)
+		if err != nil {
+			return triggers, err
+		}
+
+		for _, trigger := range subscriptionTriggers {
+			if trigger == nil {
+				continue
+			}
+
+			triggersMap[trigger.ID] = trigger
+		}
+	}
+
+	for _, trigger := range triggersMap {
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+// refreshUnusedTriggers marks triggers, which are presented in oldTriggers but not in newTriggers
+// and have no subscriptions, as unused. At the end, it marks all newTriggers as used.
+func (connector *DbConnector) refreshUnusedTriggers(newTriggers, oldTriggers []*moira.Trigger) error {
+	unusedTriggerIDs := make([]string, 0)
+	usedTriggerIDs := make([]string, 0)
+
+	for _, trigger := range moira.GetTriggerListsDiff(oldTriggers, newTriggers) {
+		ok, err := connector.triggerHasSubscriptions(trigger)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			unusedTriggerIDs = append(unusedTriggerIDs, trigger.ID)
+		}
+	}
+
+	for _, trigger := range newTriggers {
+		if trigger != nil {
+			usedTriggerIDs = append(usedTriggerIDs, trigger.ID)
+		}
+	}
+
+	if err := connector.MarkTriggersAsUnused(unusedTriggerIDs...); err != nil {
+		return err
+	}
+
+	return connector.MarkTriggersAsUsed(usedTriggerIDs...)
+}
+
+func unmarshalSubscription(id, data string) (moira.SubscriptionData, error) {
+	subscription := moira.SubscriptionData{}
+	if err := json.Unmarshal([]byte(data), &subscription); err != nil {
+		return subscription, fmt.Errorf("failed to parse subscription json %s: %w", data, err)
+	}
+
+	if subscription.Tags == nil {
+		subscription.Tags = []string{}
+	}
+
+	subscription.ID = id
+
+	return subscription, nil
+}
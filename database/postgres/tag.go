@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetTagNames returns all tags.
+func (connector *DbConnector) GetTagNames() ([]string, error) {
+	tagNames, err := connector.queryStrings("SELECT name FROM moira_tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	return tagNames, nil
+}
+
+// CreateTags creates an array of tags without binding to a subscription or trigger.
+func (connector *DbConnector) CreateTags(tags []string) error {
+	return connector.inTx(func(tx *sql.Tx) error {
+		return createTags(connector, tx, tags)
+	})
+}
+
+func createTags(connector *DbConnector, tx *sql.Tx, tags []string) error {
+	for _, tag := range tags {
+		_, err := tx.ExecContext(connector.context, "INSERT INTO moira_tags (name) VALUES ($1) ON CONFLICT DO NOTHING", tag)
+		if err != nil {
+			return fmt.Errorf("failed to create tag %s: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveTag deletes tag from tags list, unbinds it from triggers and subscriptions.
+func (connector *DbConnector) RemoveTag(tagName string) error {
+	return connector.inTx(func(tx *sql.Tx) error {
+		queries := []string{
+			"DELETE FROM moira_tags WHERE name = $1",
+			"DELETE FROM moira_subscription_tags WHERE tag = $1",
+			"DELETE FROM moira_trigger_tags WHERE tag = $1",
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(connector.context, query, tagName); err != nil {
+				return fmt.Errorf("failed to remove tag %s: %w", tagName, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetTagTriggerIDs gets all triggersIDs by given tagName.
+func (connector *DbConnector) GetTagTriggerIDs(tagName string) ([]string, error) {
+	triggerIDs, err := connector.queryStrings("SELECT trigger_id FROM moira_trigger_tags WHERE tag = $1", tagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tag triggers: %s, err: %w", tagName, err)
+	}
+
+	return triggerIDs, nil
+}
+
+// CleanUpAbandonedTags deletes tags for which triggers and subscriptions don't exist.
+// Returns count of deleted tags.
+func (connector *DbConnector) CleanUpAbandonedTags() (int, error) {
+	result, err := connector.db.ExecContext(connector.context, `
+		DELETE FROM moira_tags t
+		WHERE NOT EXISTS (SELECT 1 FROM moira_trigger_tags tt WHERE tt.tag = t.name)
+		  AND NOT EXISTS (SELECT 1 FROM moira_subscription_tags st WHERE st.tag = t.name)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up abandoned tags: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,322 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// uniqueViolation is a PostgreSQL error code of unique constraint violation.
+const uniqueViolation = "23505"
+
+// SaveTeam saves team into database.
+func (connector *DbConnector) SaveTeam(teamID string, team moira.Team) error {
+	lowerName := strings.ToLower(team.Name)
+
+	err := connector.inTx(func(tx *sql.Tx) error {
+		var teamWithSuchNameID string
+
+		err := tx.QueryRowContext(connector.context, "SELECT id FROM moira_teams WHERE lower_name = $1", lowerName).Scan(&teamWithSuchNameID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to check team name existence: %w", err)
+		}
+
+		// another team with such name exists
+		if teamWithSuchNameID != "" && teamWithSuchNameID != teamID {
+			return database.ErrTeamWithNameAlreadyExists
+		}
+
+		_, err = tx.ExecContext(connector.context, `
+			INSERT INTO moira_teams (id, name, lower_name, description, restrict_trigger_changes) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, lower_name = EXCLUDED.lower_name, description = EXCLUDED.description,
+				restrict_trigger_changes = EXCLUDED.restrict_trigger_changes`,
+			teamID, team.Name, lowerName, team.Description, team.RestrictTriggerChanges)
+
+		return err
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return database.ErrTeamWithNameAlreadyExists
+		}
+
+		if errors.Is(err, database.ErrTeamWithNameAlreadyExists) {
+			return err
+		}
+
+		return fmt.Errorf("failed to save team metadata: %w", err)
+	}
+
+	return nil
+}
+
+// GetAllTeams returns all teams.
+func (connector *DbConnector) GetAllTeams() ([]moira.Team, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT id, name, description, restrict_trigger_changes FROM moira_teams")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]moira.Team, 0)
+
+	for rows.Next() {
+		var team moira.Team
+		if err = rows.Scan(&team.ID, &team.Name, &team.Description, &team.RestrictTriggerChanges); err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+// GetTeam retrieves team from database by it's id.
+func (connector *DbConnector) GetTeam(teamID string) (moira.Team, error) {
+	return connector.getTeam("SELECT id, name, description, restrict_trigger_changes FROM moira_teams WHERE id = $1", teamID)
+}
+
+// GetTeamByName retrieves team from database by its name.
+func (connector *DbConnector) GetTeamByName(name string) (moira.Team, error) {
+	return connector.getTeam("SELECT id, name, description, restrict_trigger_changes FROM moira_teams WHERE lower_name = $1", strings.ToLower(name))
+}
+
+func (connector *DbConnector) getTeam(query string, arg string) (moira.Team, error) {
+	var team moira.Team
+
+	err := connector.db.QueryRowContext(connector.context, query, arg).Scan(&team.ID, &team.Name, &team.Description, &team.RestrictTriggerChanges)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.Team{}, database.ErrNil
+		}
+
+		return moira.Team{}, fmt.Errorf("failed to read team: %w", err)
+	}
+
+	return team, nil
+}
+
+// SaveTeamsAndUsers is a function that saves users for one team and teams for bunch of users in one transaction.
+func (connector *DbConnector) SaveTeamsAndUsers(teamID string, users []string, teams map[string][]string) error {
+	ctx := connector.context
+
+	err := connector.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_team_users WHERE team_id = $1", teamID); err != nil {
+			return fmt.Errorf("cannot clear users set for team: %s, %w", teamID, err)
+		}
+
+		_, err := tx.ExecContext(ctx, "INSERT INTO moira_team_users (team_id, user_id) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING",
+			teamID, pq.Array(users))
+		if err != nil {
+			return fmt.Errorf("cannot save users for team: %s, %w", teamID, err)
+		}
+
+		// Roles of users who left the team are removed, so they do not return with old role.
+		_, err = tx.ExecContext(ctx, "DELETE FROM moira_team_roles WHERE team_id = $1 AND NOT (user_id = ANY($2::text[]))",
+			teamID, pq.Array(users))
+		if err != nil {
+			return fmt.Errorf("cannot remove roles of team users: %s, %w", teamID, err)
+		}
+
+		for userID, userTeams := range teams {
+			if _, err = tx.ExecContext(ctx, "DELETE FROM moira_user_teams WHERE user_id = $1", userID); err != nil {
+				return fmt.Errorf("cannot clear teams set for user: %s, %w", userID, err)
+			}
+
+			_, err = tx.ExecContext(ctx, "INSERT INTO moira_user_teams (user_id, team_id) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING",
+				userID, pq.Array(userTeams))
+			if err != nil {
+				return fmt.Errorf("cannot save teams for user: %s, %w", userID, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot commit transaction and save team: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserTeams returns all teams of certain user.
+func (connector *DbConnector) GetUserTeams(userID string) ([]string, error) {
+	teams, err := connector.queryStrings("SELECT team_id FROM moira_user_teams WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// GetTeamUsers returns all users of certain team.
+func (connector *DbConnector) GetTeamUsers(teamID string) ([]string, error) {
+	users, err := connector.queryStrings("SELECT user_id FROM moira_team_users WHERE team_id = $1", teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user teams: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsTeamContainUser is a method to check if user is in team.
+func (connector *DbConnector) IsTeamContainUser(teamID, userID string) (bool, error) {
+	var result bool
+
+	err := connector.db.QueryRowContext(connector.context,
+		"SELECT EXISTS (SELECT 1 FROM moira_team_users WHERE team_id = $1 AND user_id = $2)", teamID, userID).Scan(&result)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if team contains user: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteTeam is a method to delete all information about team and remove team from last user's teams.
+func (connector *DbConnector) DeleteTeam(teamID, userID string) error {
+	ctx := connector.context
+
+	return connector.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_user_teams WHERE user_id = $1 AND team_id = $2", userID, teamID); err != nil {
+			return fmt.Errorf("failed to remove team from user's teams: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_team_users WHERE team_id = $1", teamID); err != nil {
+			return fmt.Errorf("failed to remove team users: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_team_roles WHERE team_id = $1", teamID); err != nil {
+			return fmt.Errorf("failed to remove team roles: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_team_preferences WHERE team_id = $1", teamID); err != nil {
+			return fmt.Errorf("failed to remove team preferences: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM moira_teams WHERE id = $1", teamID); err != nil {
+			return fmt.Errorf("failed to remove team metadata: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SaveTeamUserRole saves role of the user in the team.
+func (connector *DbConnector) SaveTeamUserRole(teamID, userID string, role moira.TeamRole) error {
+	_, err := connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_team_roles (team_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, user_id) DO UPDATE SET role = EXCLUDED.role`,
+		teamID, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("failed to save role of team user: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamUserRoles returns roles assigned to users of the team.
+func (connector *DbConnector) GetTeamUserRoles(teamID string) (map[string]moira.TeamRole, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT user_id, role FROM moira_team_roles WHERE team_id = $1", teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve team roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := make(map[string]moira.TeamRole)
+
+	for rows.Next() {
+		var userID, role string
+		if err = rows.Scan(&userID, &role); err != nil {
+			return nil, err
+		}
+
+		roles[userID] = moira.TeamRole(role)
+	}
+
+	return roles, rows.Err()
+}
+
+// SaveTeamPreferences saves team-wide settings of the team.
+func (connector *DbConnector) SaveTeamPreferences(teamID string, preferences moira.TeamPreferences) error {
+	bytes, err := json.Marshal(preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team preferences: %w", err)
+	}
+
+	_, err = connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_team_preferences (team_id, data) VALUES ($1, $2)
+		ON CONFLICT (team_id) DO UPDATE SET data = EXCLUDED.data`,
+		teamID, string(bytes))
+	if err != nil {
+		return fmt.Errorf("failed to save team preferences: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamPreferences returns team-wide settings of the team or database.ErrNil if they were not saved.
+func (connector *DbConnector) GetTeamPreferences(teamID string) (moira.TeamPreferences, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_team_preferences WHERE team_id = $1", teamID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.TeamPreferences{}, database.ErrNil
+		}
+
+		return moira.TeamPreferences{}, fmt.Errorf("failed to get team preferences: %w", err)
+	}
+
+	var preferences moira.TeamPreferences
+	if err = json.Unmarshal([]byte(data), &preferences); err != nil {
+		return moira.TeamPreferences{}, fmt.Errorf("failed to parse team preferences json %s: %w", data, err)
+	}
+
+	return preferences, nil
+}
+
+// SaveTeamOnCallSchedule saves on-call schedule of the team.
+func (connector *DbConnector) SaveTeamOnCallSchedule(teamID string, schedule moira.OnCallSchedule) error {
+	bytes, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team on-call schedule: %w", err)
+	}
+
+	_, err = connector.db.ExecContext(connector.context, `
+		INSERT INTO moira_team_oncall_schedules (team_id, data) VALUES ($1, $2)
+		ON CONFLICT (team_id) DO UPDATE SET data = EXCLUDED.data`,
+		teamID, string(bytes))
+	if err != nil {
+		return fmt.Errorf("failed to save team on-call schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamOnCallSchedule returns on-call schedule of the team or database.ErrNil if it was not saved.
+func (connector *DbConnector) GetTeamOnCallSchedule(teamID string) (moira.OnCallSchedule, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_team_oncall_schedules WHERE team_id = $1", teamID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.OnCallSchedule{}, database.ErrNil
+		}
+
+		return moira.OnCallSchedule{}, fmt.Errorf("failed to get team on-call schedule: %w", err)
+	}
+
+	var schedule moira.OnCallSchedule
+	if err = json.Unmarshal([]byte(data), &schedule); err != nil {
+		return moira.OnCallSchedule{}, fmt.Errorf("failed to parse team on-call schedule json %s: %w", data, err)
+	}
+
+	return schedule, nil
+}
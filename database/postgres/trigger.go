@@ -0,0 +1,387 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis"
+)
+
+// GetAllTriggerIDs gets all moira triggerIDs.
+func (connector *DbConnector) GetAllTriggerIDs() ([]string, error) {
+	triggerIDs, err := connector.queryStrings("SELECT id FROM moira_triggers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all triggers-list: %w", err)
+	}
+
+	return triggerIDs, nil
+}
+
+// GetTriggerIDs returns list of ids of triggers with given cluster key.
+func (connector *DbConnector) GetTriggerIDs(clusterKey moira.ClusterKey) ([]string, error) {
+	triggerIDs, err := connector.queryStrings(
+		"SELECT id FROM moira_triggers WHERE trigger_source = $1 AND cluster_id = $2",
+		clusterKey.TriggerSource.String(),
+		clusterKey.ClusterId.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers-list: %w", err)
+	}
+
+	return triggerIDs, nil
+}
+
+// GetTriggerCount returns number of triggers for each of given cluster keys.
+func (connector *DbConnector) GetTriggerCount(clusterKeys []moira.ClusterKey) (map[moira.ClusterKey]int64, error) {
+	res := make(map[moira.ClusterKey]int64, len(clusterKeys))
+
+	for _, key := range clusterKeys {
+		var count int64
+
+		err := connector.db.QueryRowContext(
+			connector.context,
+			"SELECT count(*) FROM moira_triggers WHERE trigger_source = $1 AND cluster_id = $2",
+			key.TriggerSource.String(),
+			key.ClusterId.String(),
+		).Scan(&count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count triggers of %s: %w", key.String(), err)
+		}
+
+		res[key] = count
+	}
+
+	return res, nil
+}
+
+// GetTrigger gets trigger by given ID, if no value, return database.ErrNil error.
+func (connector *DbConnector) GetTrigger(triggerID string) (moira.Trigger, error) {
+	var data string
+
+	err := connector.db.QueryRowContext(connector.context, "SELECT data FROM moira_triggers WHERE id = $1", triggerID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return moira.Trigger{}, database.ErrNil
+		}
+
+		return moira.Trigger{}, fmt.Errorf("failed to get trigger: %w", err)
+	}
+
+	return unmarshalTrigger(triggerID, data)
+}
+
+// GetTriggers returns triggers data by given ids, len of triggerIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTriggers(triggerIDs []string) ([]*moira.Trigger, error) {
+	rows, err := connector.db.QueryContext(connector.context, "SELECT id, data FROM moira_triggers WHERE id = ANY($1)", pq.Array(triggerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers: %w", err)
+	}
+	defer rows.Close()
+
+	triggersByID := make(map[string]*moira.Trigger, len(triggerIDs))
+
+	for rows.Next() {
+		var id, data string
+		if err = rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+
+		trigger, err := unmarshalTrigger(id, data)
+		if err != nil {
+			return nil, err
+		}
+
+		triggersByID[id] = &trigger
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	triggers := make([]*moira.Trigger, len(triggerIDs))
+	for i, triggerID := range triggerIDs {
+		triggers[i] = triggersByID[triggerID]
+	}
+
+	return triggers, nil
+}
+
+// GetTriggerChecks gets triggers data with tags, lastCheck data and throttling by given triggersIDs.
+// Len of triggerIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned.
+func (connector *DbConnector) GetTriggerChecks(triggerIDs []string) ([]*moira.TriggerCheck, error) {
+	triggers, err := connector.GetTriggers(triggerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lastChecks, err := connector.getTriggersLastCheck(triggerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	triggerChecks := make([]*moira.TriggerCheck, len(triggerIDs))
+
+	for i, trigger := range triggers {
+		if trigger == nil {
+			continue
+		}
+
+		var lastCheck moira.CheckData
+		if lastChecks[i] != nil {
+			lastCheck = *lastChecks[i]
+		}
+
+		next, _ := connector.Database.GetTriggerThrottling(trigger.ID)
+
+		throttling := next.Unix()
+		if now >= throttling {
+			throttling = 0
+		}
+
+		triggerChecks[i] = &moira.TriggerCheck{
+			Trigger:    *trigger,
+			LastCheck:  lastCheck,
+			Throttling: throttling,
+		}
+	}
+
+	return triggerChecks, nil
+}
+
+// SaveTrigger sets trigger data by given trigger and triggerID.
+// If trigger already exists, then cleanup not used patterns.
+// If given trigger contains new tags then create it.
+// If given trigger has no subscription on it, mark it as unused.
+func (connector *DbConnector) SaveTrigger(triggerID string, trigger *moira.Trigger) error {
+	var oldTrigger *moira.Trigger
+	if existing, err := connector.GetTrigger(triggerID); err == nil {
+		oldTrigger = &existing
+	} else if !errors.Is(err, database.ErrNil) {
+		return fmt.Errorf("failed to get trigger: %w", err)
+	}
+
+	connector.preSaveTrigger(trigger, oldTrigger)
+
+	err := connector.inTx(func(tx *sql.Tx) error {
+		return connector.updateTrigger(tx, triggerID, trigger)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update trigger: %w", err)
+	}
+
+	hasSubscriptions, err := connector.triggerHasSubscriptions(trigger)
+	if err != nil {
+		return fmt.Errorf("failed to check trigger subscriptions: %w", err)
+	}
+
+	if !hasSubscriptions {
+		err = connector.MarkTriggersAsUnused(triggerID)
+	} else {
+		err = connector.MarkTriggersAsUsed(triggerID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to mark trigger as (un)used: %w", err)
+	}
+
+	if oldTrigger != nil {
+		return connector.cleanupPatternsOutOfUse(moira.GetStringListsDiff(oldTrigger.Patterns, trigger.Patterns))
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) updateTrigger(tx *sql.Tx, triggerID string, newTrigger *moira.Trigger) error {
+	trigger := *newTrigger
+	trigger.ID = triggerID
+	trigger.TriggerSource = trigger.TriggerSource.FillInIfNotSet(false)
+	trigger.ClusterId = trigger.ClusterId.FillInIfNotSet()
+
+	bytes, err := json.Marshal(&trigger)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trigger: %w", err)
+	}
+
+	ctx := connector.context
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO moira_triggers (id, trigger_source, cluster_id, data) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET trigger_source = EXCLUDED.trigger_source, cluster_id = EXCLUDED.cluster_id, data = EXCLUDED.data`,
+		triggerID, trigger.TriggerSource.String(), trigger.ClusterId.String(), string(bytes))
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM moira_trigger_tags WHERE trigger_id = $1", triggerID); err != nil {
+		return err
+	}
+
+	for _, tag := range trigger.Tags {
+		if _, err = tx.ExecContext(ctx, "INSERT INTO moira_trigger_tags (trigger_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING", triggerID, tag); err != nil {
+			return err
+		}
+	}
+
+	if err = createTags(connector, tx, trigger.Tags); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM moira_pattern_triggers WHERE trigger_id = $1", triggerID); err != nil {
+		return err
+	}
+
+	if trigger.TriggerSource == moira.GraphiteLocal {
+		for _, pattern := range trigger.Patterns {
+			if _, err = tx.ExecContext(ctx, "INSERT INTO moira_patterns (pattern) VALUES ($1) ON CONFLICT DO NOTHING", pattern); err != nil {
+				return err
+			}
+
+			if _, err = tx.ExecContext(ctx, "INSERT INTO moira_pattern_triggers (pattern, trigger_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", pattern, triggerID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if connector.source != redis.Cli {
+		return addTriggerToReindex(connector, tx, triggerID)
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) preSaveTrigger(newTrigger *moira.Trigger, oldTrigger *moira.Trigger) {
+	if newTrigger.TriggerSource != moira.GraphiteLocal {
+		newTrigger.Patterns = make([]string, 0)
+	}
+
+	now := connector.Clock.NowUnix()
+
+	newTrigger.UpdatedAt = &now
+	if oldTrigger != nil {
+		newTrigger.CreatedAt = oldTrigger.CreatedAt
+		newTrigger.CreatedBy = oldTrigger.CreatedBy
+	} else {
+		newTrigger.CreatedAt = &now
+		newTrigger.CreatedBy = newTrigger.UpdatedBy
+	}
+}
+
+// RemoveTrigger deletes trigger data by given triggerID with its tags, patterns and last check.
+// If containing patterns doesn't used in another triggers, then delete this patterns with metrics data.
+func (connector *DbConnector) RemoveTrigger(triggerID string) error {
+	trigger, err := connector.GetTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return err
+	}
+
+	err = connector.inTx(func(tx *sql.Tx) error {
+		queries := []string{
+			"DELETE FROM moira_triggers WHERE id = $1",
+			"DELETE FROM moira_pattern_triggers WHERE trigger_id = $1",
+			"DELETE FROM moira_unused_triggers WHERE trigger_id = $1",
+			"DELETE FROM moira_last_checks WHERE trigger_id = $1",
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(connector.context, query, triggerID); err != nil {
+				return err
+			}
+		}
+
+		return addTriggerToReindex(connector, tx, triggerID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove trigger: %w", err)
+	}
+
+	return connector.cleanupPatternsOutOfUse(trigger.Patterns)
+}
+
+// GetPatternTriggerIDs gets trigger list by given pattern.
+func (connector *DbConnector) GetPatternTriggerIDs(pattern string) ([]string, error) {
+	triggerIDs, err := connector.queryStrings("SELECT trigger_id FROM moira_pattern_triggers WHERE pattern = $1", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pattern triggers for pattern: %s, error: %w", pattern, err)
+	}
+
+	return triggerIDs, nil
+}
+
+// RemovePatternTriggerIDs removes all triggerIDs list accepted to given pattern.
+func (connector *DbConnector) RemovePatternTriggerIDs(pattern string) error {
+	_, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_pattern_triggers WHERE pattern = $1", pattern)
+	if err != nil {
+		return fmt.Errorf("failed delete pattern-triggers: %s, error: %w", pattern, err)
+	}
+
+	return nil
+}
+
+// GetTriggerIDsStartWith returns triggers which have ID starting with "prefix" parameter.
+func (connector *DbConnector) GetTriggerIDsStartWith(prefix string) ([]string, error) {
+	return connector.queryStrings("SELECT id FROM moira_triggers WHERE left(id, length($1)) = $1", prefix)
+}
+
+func (connector *DbConnector) cleanupPatternsOutOfUse(patterns []string) error {
+	for _, pattern := range patterns {
+		triggerIDs, err := connector.GetPatternTriggerIDs(pattern)
+		if err != nil {
+			return err
+		}
+
+		if len(triggerIDs) == 0 {
+			if err := connector.RemovePatternWithMetrics(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) triggerHasSubscriptions(trigger *moira.Trigger) (bool, error) {
+	if trigger == nil || len(trigger.Tags) == 0 {
+		return false, nil
+	}
+
+	subscriptions, err := connector.GetTagsSubscriptions(trigger.Tags)
+	if err != nil {
+		return false, err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription == nil {
+			continue
+		}
+
+		if subscription.AnyTags || moira.Subset(subscription.Tags, trigger.Tags) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func unmarshalTrigger(triggerID, data string) (moira.Trigger, error) {
+	var trigger moira.Trigger
+	if err := json.Unmarshal([]byte(data), &trigger); err != nil {
+		return moira.Trigger{}, fmt.Errorf("failed to parse trigger json %s: %w", data, err)
+	}
+
+	trigger.ID = triggerID
+
+	return trigger, nil
+}
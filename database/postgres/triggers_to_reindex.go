@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// FetchTriggersToReindex returns trigger IDs updated since 'from' param.
+// The trigger could be changed by user, or it's score was changed during trigger check.
+func (connector *DbConnector) FetchTriggersToReindex(from int64) ([]string, error) {
+	triggerIDs, err := connector.queryStrings("SELECT trigger_id FROM moira_triggers_to_reindex WHERE updated_at >= $1", from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch triggers to reindex: %w", err)
+	}
+
+	return triggerIDs, nil
+}
+
+// RemoveTriggersToReindex removes outdated triggerIDs.
+func (connector *DbConnector) RemoveTriggersToReindex(to int64) error {
+	if _, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_triggers_to_reindex WHERE updated_at <= $1", to); err != nil {
+		return fmt.Errorf("failed to remove triggers to reindex: %w", err)
+	}
+
+	return nil
+}
+
+func addTriggerToReindex(connector *DbConnector, tx *sql.Tx, triggerID string) error {
+	_, err := tx.ExecContext(connector.context, `
+		INSERT INTO moira_triggers_to_reindex (trigger_id, updated_at) VALUES ($1, $2)
+		ON CONFLICT (trigger_id) DO UPDATE SET updated_at = EXCLUDED.updated_at`,
+		triggerID, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to add trigger to reindex: %w", err)
+	}
+
+	return nil
+}
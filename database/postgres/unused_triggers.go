@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// MarkTriggersAsUnused adds unused trigger IDs to unused triggers list.
+func (connector *DbConnector) MarkTriggersAsUnused(triggerIDs ...string) error {
+	if len(triggerIDs) == 0 {
+		return nil
+	}
+
+	_, err := connector.db.ExecContext(
+		connector.context,
+		"INSERT INTO moira_unused_triggers (trigger_id) SELECT unnest($1::text[]) ON CONFLICT DO NOTHING",
+		pq.Array(triggerIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark triggers as unused: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnusedTriggerIDs returns all unused trigger IDs.
+func (connector *DbConnector) GetUnusedTriggerIDs() ([]string, error) {
+	triggerIDs, err := connector.queryStrings("SELECT trigger_id FROM moira_unused_triggers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all unused triggers: %w", err)
+	}
+
+	return triggerIDs, nil
+}
+
+// MarkTriggersAsUsed removes trigger IDs from unused triggers list.
+func (connector *DbConnector) MarkTriggersAsUsed(triggerIDs ...string) error {
+	if len(triggerIDs) == 0 {
+		return nil
+	}
+
+	_, err := connector.db.ExecContext(connector.context, "DELETE FROM moira_unused_triggers WHERE trigger_id = ANY($1)", pq.Array(triggerIDs))
+	if err != nil {
+		return fmt.Errorf("failed to mark triggers as used: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// SaveAPIToken saves API token and indexes it by its hash and by its user or team.
+func (connector *DbConnector) SaveAPIToken(token *moira.APIToken) error {
+	bytes, err := reply.GetAPITokenBytes(*token)
+	if err != nil {
+		return err
+	}
+
+	existing, err := connector.GetAPIToken(token.ID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	ctx := connector.context
+	pipe := (*connector.client).TxPipeline()
+
+	if err == nil {
+		removeAPITokenIndexes(ctx, pipe, existing)
+	}
+
+	pipe.Set(ctx, apiTokenKey(token.ID), bytes, 0)
+	pipe.HSet(ctx, apiTokenHashesKey, token.Hash, token.ID)
+	pipe.SAdd(ctx, apiTokenOwnerKey(token), token.ID)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to EXEC: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPIToken returns API token by its id, if there is no such token, returns database.ErrNil error.
+func (connector *DbConnector) GetAPIToken(tokenID string) (moira.APIToken, error) {
+	return reply.APIToken((*connector.client).Get(connector.context, apiTokenKey(tokenID)))
+}
+
+// GetAPITokenByHash returns API token by hash of its secret, if there is no such token, returns database.ErrNil error.
+func (connector *DbConnector) GetAPITokenByHash(hash string) (moira.APIToken, error) {
+	tokenID, err := (*connector.client).HGet(connector.context, apiTokenHashesKey, hash).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.APIToken{}, database.ErrNil
+		}
+
+		return moira.APIToken{}, fmt.Errorf("failed to get api token id by hash: %w", err)
+	}
+
+	return connector.GetAPIToken(tokenID)
+}
+
+// GetUserAPITokens returns personal API tokens of the user.
+func (connector *DbConnector) GetUserAPITokens(userLogin string) ([]*moira.APIToken, error) {
+	return connector.getAPITokens(userAPITokensKey(userLogin))
+}
+
+// GetTeamAPITokens returns API tokens of the team.
+func (connector *DbConnector) GetTeamAPITokens(teamID string) ([]*moira.APIToken, error) {
+	return connector.getAPITokens(teamAPITokensKey(teamID))
+}
+
+// RemoveAPIToken removes API token and all its indexes.
+func (connector *DbConnector) RemoveAPIToken(tokenID string) error {
+	token, err := connector.GetAPIToken(tokenID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	ctx := connector.context
+	pipe := (*connector.client).TxPipeline()
+
+	removeAPITokenIndexes(ctx, pipe, token)
+	pipe.Del(ctx, apiTokenKey(tokenID))
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to EXEC: %w", err)
+	}
+
+	return nil
+}
+
+// SaveAuthSession saves session by hash of its secret, the session expires at session.ExpiresAt.
+func (connector *DbConnector) SaveAuthSession(sessionHash string, session moira.AuthSession) error {
+	bytes, err := reply.GetAuthSessionBytes(session)
+	if err != nil {
+		return err
+	}
+
+	ctx := connector.context
+	pipe := (*connector.client).TxPipeline()
+
+	pipe.Set(ctx, authSessionKey(sessionHash), bytes, 0)
+	pipe.ExpireAt(ctx, authSessionKey(sessionHash), time.Unix(session.ExpiresAt, 0))
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to EXEC: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuthSession returns session by hash of its secret, if there is no such session, returns database.ErrNil error.
+func (connector *DbConnector) GetAuthSession(sessionHash string) (moira.AuthSession, error) {
+	return reply.AuthSession((*connector.client).Get(connector.context, authSessionKey(sessionHash)))
+}
+
+// RemoveAuthSession removes session by hash of its secret.
+func (connector *DbConnector) RemoveAuthSession(sessionHash string) error {
+	if err := (*connector.client).Del(connector.context, authSessionKey(sessionHash)).Err(); err != nil {
+		return fmt.Errorf("failed to remove auth session: %w", err)
+	}
+
+	return nil
+}
+
+func (connector *DbConnector) getAPITokens(setKey string) ([]*moira.APIToken, error) {
+	ctx := connector.context
+	c := *connector.client
+
+	tokenIDs, err := c.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token ids: %w", err)
+	}
+
+	pipe := c.TxPipeline()
+	results := make([]*redis.StringCmd, 0, len(tokenIDs))
+
+	for _, tokenID := range tokenIDs {
+		results = append(results, pipe.Get(ctx, apiTokenKey(tokenID)))
+	}
+
+	if _, err = pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to EXEC: %w", err)
+	}
+
+	return reply.APITokens(results)
+}
+
+func removeAPITokenIndexes(ctx context.Context, pipe redis.Pipeliner, token moira.APIToken) {
+	pipe.HDel(ctx, apiTokenHashesKey, token.Hash)
+	pipe.SRem(ctx, apiTokenOwnerKey(&token), token.ID)
+}
+
+const apiTokenHashesKey = "moira-api-token-hashes"
+
+func apiTokenKey(tokenID string) string {
+	return fmt.Sprintf("moira-api-token:%s", tokenID)
+}
+
+func userAPITokensKey(userLogin string) string {
+	return fmt.Sprintf("moira-user-api-tokens:%s", userLogin)
+}
+
+func teamAPITokensKey(teamID string) string {
+	return fmt.Sprintf("moira-team-api-tokens:%s", teamID)
+}
+
+func apiTokenOwnerKey(token *moira.APIToken) string {
+	if token.TeamID != "" {
+		return teamAPITokensKey(token.TeamID)
+	}
+
+	return userAPITokensKey(token.User)
+}
+
+func authSessionKey(sessionHash string) string {
+	return fmt.Sprintf("moira-auth-session:%s", sessionHash)
+}
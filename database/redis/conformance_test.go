@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/conformance"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+)
+
+func TestConformance(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+
+	conformance.Run(t, func(t *testing.T) moira.Database {
+		dataBase := NewTestDatabase(logger)
+		dataBase.Flush()
+		t.Cleanup(dataBase.Flush)
+
+		return dataBase
+	})
+}
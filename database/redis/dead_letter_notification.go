@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+const deadLetterNotificationsKey = "moira-dead-letter-notifications"
+
+// AddDeadLetterNotification pushes notification to the head of dead letter queue and trims queue to maxSize.
+func (connector *DbConnector) AddDeadLetterNotification(notification *moira.DeadLetterNotification, maxSize int64) error {
+	bytes, err := reply.GetDeadLetterNotificationBytes(*notification)
+	if err != nil {
+		return err
+	}
+
+	ctx := connector.context
+	pipe := (*connector.client).TxPipeline()
+
+	pipe.LPush(ctx, deadLetterNotificationsKey, bytes)
+
+	if maxSize > 0 {
+		pipe.LTrim(ctx, deadLetterNotificationsKey, 0, maxSize-1)
+	}
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to add dead letter notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeadLetterNotifications gets dead letter notifications in given range, where 'start' and 'end' are indices of notifications
+// from the newest one, and total count of notifications in the queue.
+func (connector *DbConnector) GetDeadLetterNotifications(start, end int64) ([]*moira.DeadLetterNotification, int64, error) {
+	ctx := connector.context
+	pipe := (*connector.client).TxPipeline()
+
+	rangeCmd := pipe.LRange(ctx, deadLetterNotificationsKey, start, end)
+	lenCmd := pipe.LLen(ctx, deadLetterNotificationsKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, 0, fmt.Errorf("failed to EXEC: %w", err)
+	}
+
+	notifications, err := reply.DeadLetterNotifications(rangeCmd)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, lenCmd.Val(), nil
+}
+
+// GetDeadLetterNotification gets dead letter notification by its id.
+func (connector *DbConnector) GetDeadLetterNotification(id string) (moira.DeadLetterNotification, error) {
+	notification, _, err := connector.findDeadLetterNotification(id)
+
+	return notification, err
+}
+
+// RemoveDeadLetterNotification removes dead letter notification by its id.
+func (connector *DbConnector) RemoveDeadLetterNotification(id string) (int64, error) {
+	_, value, err := connector.findDeadLetterNotification(id)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	result, err := (*connector.client).LRem(connector.context, deadLetterNotificationsKey, 0, value).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove dead letter notification %s: %w", id, err)
+	}
+
+	return result, nil
+}
+
+// RemoveAllDeadLetterNotifications purges dead letter queue.
+func (connector *DbConnector) RemoveAllDeadLetterNotifications() error {
+	if err := (*connector.client).Del(connector.context, deadLetterNotificationsKey).Err(); err != nil {
+		return fmt.Errorf("failed to remove all dead letter notifications: %w", err)
+	}
+
+	return nil
+}
+
+// findDeadLetterNotification returns dead letter notification with given id and its raw value stored in redis.
+func (connector *DbConnector) findDeadLetterNotification(id string) (moira.DeadLetterNotification, string, error) {
+	values, err := (*connector.client).LRange(connector.context, deadLetterNotificationsKey, 0, -1).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.DeadLetterNotification{}, "", database.ErrNil
+		}
+
+		return moira.DeadLetterNotification{}, "", fmt.Errorf("failed to get dead letter notifications: %w", err)
+	}
+
+	for _, value := range values {
+		notification, err := reply.DeadLetterNotification(value)
+		if err != nil {
+			return moira.DeadLetterNotification{}, "", err
+		}
+
+		if notification.ID == id {
+			return notification, value, nil
+		}
+	}
+
+	return moira.DeadLetterNotification{}, "", database.ErrNil
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeadLetterNotifications(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := NewTestDatabase(logger)
+	dataBase.Flush()
+
+	defer dataBase.Flush()
+
+	first := moira.DeadLetterNotification{
+		ID: "first",
+		Notification: moira.ScheduledNotification{
+			Event:     moira.NotificationEvent{TriggerID: "trigger-1", Metric: "metric-1", State: moira.StateERROR},
+			Contact:   moira.ContactData{ID: "contact-1", Type: "slack", Value: "#alerts"},
+			SendFail:  10,
+			Timestamp: 100,
+		},
+		Error:      "failed to post message",
+		Attempts:   10,
+		SenderType: "slack",
+		Timestamp:  200,
+	}
+	second := moira.DeadLetterNotification{
+		ID: "second",
+		Notification: moira.ScheduledNotification{
+			Event:   moira.NotificationEvent{TriggerID: "trigger-2", Metric: "metric-2", State: moira.StateWARN},
+			Contact: moira.ContactData{ID: "contact-2", Type: "mail", Value: "user@example.com"},
+		},
+		Error:      "contact is broken",
+		Attempts:   1,
+		SenderType: "mail",
+		Timestamp:  300,
+	}
+
+	Convey("Dead letter notifications manipulation", t, func() {
+		Convey("Empty queue", func() {
+			notifications, total, err := dataBase.GetDeadLetterNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+			So(notifications, ShouldBeEmpty)
+
+			_, err = dataBase.GetDeadLetterNotification(first.ID)
+			So(err, ShouldEqual, database.ErrNil)
+
+			removed, err := dataBase.RemoveDeadLetterNotification(first.ID)
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 0)
+		})
+
+		Convey("Add, get and remove", func() {
+			err := dataBase.AddDeadLetterNotification(&first, 0)
+			So(err, ShouldBeNil)
+
+			err = dataBase.AddDeadLetterNotification(&second, 0)
+			So(err, ShouldBeNil)
+
+			notifications, total, err := dataBase.GetDeadLetterNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 2)
+			So(notifications, ShouldResemble, []*moira.DeadLetterNotification{&second, &first})
+
+			notifications, total, err = dataBase.GetDeadLetterNotifications(1, 1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 2)
+			So(notifications, ShouldResemble, []*moira.DeadLetterNotification{&first})
+
+			actual, err := dataBase.GetDeadLetterNotification(first.ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, first)
+
+			removed, err := dataBase.RemoveDeadLetterNotification(first.ID)
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 1)
+
+			notifications, total, err = dataBase.GetDeadLetterNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+			So(notifications, ShouldResemble, []*moira.DeadLetterNotification{&second})
+
+			err = dataBase.RemoveAllDeadLetterNotifications()
+			So(err, ShouldBeNil)
+
+			_, total, err = dataBase.GetDeadLetterNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+		})
+
+		Convey("Queue is trimmed to max size", func() {
+			err := dataBase.AddDeadLetterNotification(&first, 1)
+			So(err, ShouldBeNil)
+
+			err = dataBase.AddDeadLetterNotification(&second, 1)
+			So(err, ShouldBeNil)
+
+			notifications, total, err := dataBase.GetDeadLetterNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+			So(notifications, ShouldResemble, []*moira.DeadLetterNotification{&second})
+
+			err = dataBase.RemoveAllDeadLetterNotifications()
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("Test with incorrect config", t, func() {
+		dataBase := NewTestDatabaseWithIncorrectConfig(logger)
+
+		err := dataBase.AddDeadLetterNotification(&first, 0)
+		So(err, ShouldNotBeNil)
+
+		_, _, err = dataBase.GetDeadLetterNotifications(0, -1)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetDeadLetterNotification(first.ID)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.RemoveDeadLetterNotification(first.ID)
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.RemoveAllDeadLetterNotifications()
+		So(err, ShouldNotBeNil)
+	})
+}
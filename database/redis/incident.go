@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira/database"
+)
+
+// SetIncidentMaintenance saves the maintenance which acknowledgement of the incident set, it expires with the maintenance.
+func (connector *DbConnector) SetIncidentMaintenance(incidentKey string, maintenance int64) error {
+	c := *connector.client
+
+	ttl := time.Duration(maintenance-connector.Clock.NowUnix()) * time.Second
+	if ttl <= 0 {
+		return connector.RemoveIncidentMaintenance(incidentKey)
+	}
+
+	if err := c.Set(connector.context, incidentMaintenanceKey(incidentKey), maintenance, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set maintenance of incident %s: %w", incidentKey, err)
+	}
+
+	return nil
+}
+
+// GetIncidentMaintenance returns the not expired maintenance which acknowledgement of the incident set,
+// if there is no such maintenance, returns database.ErrNil error.
+func (connector *DbConnector) GetIncidentMaintenance(incidentKey string) (int64, error) {
+	c := *connector.client
+
+	maintenance, err := c.Get(connector.context, incidentMaintenanceKey(incidentKey)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, database.ErrNil
+		}
+
+		return 0, fmt.Errorf("failed to get maintenance of incident %s: %w", incidentKey, err)
+	}
+
+	return maintenance, nil
+}
+
+// RemoveIncidentMaintenance removes the maintenance which acknowledgement of the incident set.
+func (connector *DbConnector) RemoveIncidentMaintenance(incidentKey string) error {
+	c := *connector.client
+
+	if err := c.Del(connector.context, incidentMaintenanceKey(incidentKey)).Err(); err != nil {
+		return fmt.Errorf("failed to remove maintenance of incident %s: %w", incidentKey, err)
+	}
+
+	return nil
+}
+
+func incidentMaintenanceKey(incidentKey string) string {
+	return "moira-incident-maintenance:" + incidentKey
+}
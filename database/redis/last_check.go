@@ -83,6 +83,12 @@ func (connector *DbConnector) getSelfStateCheckCountKey(clusterKey moira.Cluster
 	case moira.PrometheusRemote:
 		key = selfStatePrometheusChecksCounterKey
 
+	case moira.LokiRemote:
+		key = selfStateLokiChecksCounterKey
+
+	case moira.SQLRemote:
+		key = selfStateSQLChecksCounterKey
+
 	default:
 		return ""
 	}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveMaintenanceWindow saves maintenance window, existing window with the same id is replaced.
+func (connector *DbConnector) SaveMaintenanceWindow(window *moira.MaintenanceWindow) error {
+	bytes, err := json.Marshal(window)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance window: %w", err)
+	}
+
+	err = (*connector.client).HSet(connector.context, maintenanceWindowsKey, window.ID, bytes).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+// GetMaintenanceWindow returns maintenance window by its id, if there is no such window, returns database.ErrNil error.
+func (connector *DbConnector) GetMaintenanceWindow(windowID string) (moira.MaintenanceWindow, error) {
+	bytes, err := (*connector.client).HGet(connector.context, maintenanceWindowsKey, windowID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.MaintenanceWindow{}, database.ErrNil
+		}
+
+		return moira.MaintenanceWindow{}, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+
+	return unmarshalMaintenanceWindow(bytes)
+}
+
+// GetMaintenanceWindows returns all maintenance windows ordered by their start.
+func (connector *DbConnector) GetMaintenanceWindows() ([]*moira.MaintenanceWindow, error) {
+	values, err := (*connector.client).HGetAll(connector.context, maintenanceWindowsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+
+	windows := make([]*moira.MaintenanceWindow, 0, len(values))
+
+	for _, value := range values {
+		window, err := unmarshalMaintenanceWindow([]byte(value))
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &window)
+	}
+
+	sortMaintenanceWindows(windows)
+
+	return windows, nil
+}
+
+// RemoveMaintenanceWindow removes maintenance window by its id.
+func (connector *DbConnector) RemoveMaintenanceWindow(windowID string) error {
+	err := (*connector.client).HDel(connector.context, maintenanceWindowsKey, windowID).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+func unmarshalMaintenanceWindow(bytes []byte) (moira.MaintenanceWindow, error) {
+	var window moira.MaintenanceWindow
+	if err := json.Unmarshal(bytes, &window); err != nil {
+		return moira.MaintenanceWindow{}, fmt.Errorf("failed to parse maintenance window json %s: %w", string(bytes), err)
+	}
+
+	return window, nil
+}
+
+func sortMaintenanceWindows(windows []*moira.MaintenanceWindow) {
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].Start == windows[j].Start {
+			return windows[i].ID < windows[j].ID
+		}
+
+		return windows[i].Start < windows[j].Start
+	})
+}
+
+const maintenanceWindowsKey = "moira-maintenance-windows"
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetNotificationThread gets id of the thread which the sender posts notifications of the trigger to in the chat.
+func (connector *DbConnector) GetNotificationThread(senderType, triggerID, chatID string) (string, error) {
+	c := *connector.client
+
+	threadID, err := c.Get(connector.context, notificationThreadKey(senderType, triggerID, chatID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", database.ErrNil
+		}
+
+		return "", fmt.Errorf("failed to get notification thread of trigger %s in chat %s: %w", triggerID, chatID, err)
+	}
+
+	return threadID, nil
+}
+
+// SetNotificationThread sets id of the thread which the sender posts notifications of the trigger to in the chat.
+func (connector *DbConnector) SetNotificationThread(senderType, triggerID, chatID, threadID string) error {
+	c := *connector.client
+
+	if err := c.Set(connector.context, notificationThreadKey(senderType, triggerID, chatID), threadID, redis.KeepTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set notification thread of trigger %s in chat %s: %w", triggerID, chatID, err)
+	}
+
+	return nil
+}
+
+// RemoveNotificationThread removes the thread of the trigger in the chat, so the next notification starts a new one.
+func (connector *DbConnector) RemoveNotificationThread(senderType, triggerID, chatID string) error {
+	c := *connector.client
+
+	if err := c.Del(connector.context, notificationThreadKey(senderType, triggerID, chatID)).Err(); err != nil {
+		return fmt.Errorf("failed to remove notification thread of trigger %s in chat %s: %w", triggerID, chatID, err)
+	}
+
+	return nil
+}
+
+func notificationThreadKey(senderType, triggerID, chatID string) string {
+	return fmt.Sprintf("moira-notification-threads:%s:%s:%s", senderType, triggerID, chatID)
+}
@@ -0,0 +1,134 @@
+package reply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// apiTokenStorageElement is a representation of API token in database.
+type apiTokenStorageElement struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	User      string                `json:"user"`
+	TeamID    string                `json:"team_id,omitempty"`
+	Scopes    []moira.APITokenScope `json:"scopes"`
+	Hash      string                `json:"hash"`
+	CreatedAt int64                 `json:"created_at"`
+	ExpiresAt *int64                `json:"expires_at,omitempty"`
+}
+
+func newAPITokenStorageElement(token moira.APIToken) apiTokenStorageElement {
+	return apiTokenStorageElement{
+		ID:        token.ID,
+		Name:      token.Name,
+		User:      token.User,
+		TeamID:    token.TeamID,
+		Scopes:    token.Scopes,
+		Hash:      token.Hash,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+func (t *apiTokenStorageElement) toAPIToken() moira.APIToken {
+	return moira.APIToken{
+		ID:        t.ID,
+		Name:      t.Name,
+		User:      t.User,
+		TeamID:    t.TeamID,
+		Scopes:    t.Scopes,
+		Hash:      t.Hash,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+// GetAPITokenBytes is a function that takes moira.APIToken and turns it to bytes that will be saved in redis.
+func GetAPITokenBytes(token moira.APIToken) ([]byte, error) {
+	bytes, err := json.Marshal(newAPITokenStorageElement(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal api token: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// APIToken converts redis DB reply to moira.APIToken object.
+func APIToken(rep *redis.StringCmd) (moira.APIToken, error) {
+	bytes, err := rep.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.APIToken{}, database.ErrNil
+		}
+
+		return moira.APIToken{}, fmt.Errorf("failed to read api token: %w", err)
+	}
+
+	tokenSE := apiTokenStorageElement{}
+	if err = json.Unmarshal(bytes, &tokenSE); err != nil {
+		return moira.APIToken{}, fmt.Errorf("failed to parse api token json %s: %w", string(bytes), err)
+	}
+
+	return tokenSE.toAPIToken(), nil
+}
+
+// APITokens converts redis DB reply to moira.APIToken objects array, missing tokens are skipped.
+func APITokens(responses []*redis.StringCmd) ([]*moira.APIToken, error) {
+	tokens := make([]*moira.APIToken, 0, len(responses))
+
+	for _, response := range responses {
+		token, err := APIToken(response)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+// authSessionStorageElement is a representation of auth session in database.
+type authSessionStorageElement struct {
+	Login     string   `json:"login"`
+	Groups    []string `json:"groups,omitempty"`
+	ExpiresAt int64    `json:"expires_at"`
+}
+
+// GetAuthSessionBytes is a function that takes moira.AuthSession and turns it to bytes that will be saved in redis.
+func GetAuthSessionBytes(session moira.AuthSession) ([]byte, error) {
+	bytes, err := json.Marshal(authSessionStorageElement(session))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal auth session: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// AuthSession converts redis DB reply to moira.AuthSession object.
+func AuthSession(rep *redis.StringCmd) (moira.AuthSession, error) {
+	bytes, err := rep.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.AuthSession{}, database.ErrNil
+		}
+
+		return moira.AuthSession{}, fmt.Errorf("failed to read auth session: %w", err)
+	}
+
+	sessionSE := authSessionStorageElement{}
+	if err = json.Unmarshal(bytes, &sessionSE); err != nil {
+		return moira.AuthSession{}, fmt.Errorf("failed to parse auth session json %s: %w", string(bytes), err)
+	}
+
+	return moira.AuthSession(sessionSE), nil
+}
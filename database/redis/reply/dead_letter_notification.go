@@ -0,0 +1,91 @@
+package reply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+)
+
+// deadLetterNotificationStorageElement represent dead letter notification object.
+type deadLetterNotificationStorageElement struct {
+	ID           string                              `json:"id"`
+	Notification scheduledNotificationStorageElement `json:"notification"`
+	Error        string                              `json:"error"`
+	Attempts     int                                 `json:"attempts"`
+	SenderType   string                              `json:"sender_type"`
+	Timestamp    int64                               `json:"timestamp"`
+}
+
+func toDeadLetterNotificationStorageElement(notification moira.DeadLetterNotification) deadLetterNotificationStorageElement {
+	return deadLetterNotificationStorageElement{
+		ID:           notification.ID,
+		Notification: toScheduledNotificationStorageElement(notification.Notification),
+		Error:        notification.Error,
+		Attempts:     notification.Attempts,
+		SenderType:   notification.SenderType,
+		Timestamp:    notification.Timestamp,
+	}
+}
+
+func (n deadLetterNotificationStorageElement) toDeadLetterNotification() moira.DeadLetterNotification {
+	return moira.DeadLetterNotification{
+		ID:           n.ID,
+		Notification: n.Notification.toScheduledNotification(),
+		Error:        n.Error,
+		Attempts:     n.Attempts,
+		SenderType:   n.SenderType,
+		Timestamp:    n.Timestamp,
+	}
+}
+
+// GetDeadLetterNotificationBytes is a function that takes moira.DeadLetterNotification and turns it to bytes that will be saved in redis.
+func GetDeadLetterNotificationBytes(notification moira.DeadLetterNotification) ([]byte, error) {
+	notificationSE := toDeadLetterNotificationStorageElement(notification)
+
+	bytes, err := json.Marshal(notificationSE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dead letter notification: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// DeadLetterNotification converts JSON stored in redis to moira.DeadLetterNotification object.
+func DeadLetterNotification(value string) (moira.DeadLetterNotification, error) {
+	notificationSE := deadLetterNotificationStorageElement{}
+
+	err := json.Unmarshal([]byte(value), &notificationSE)
+	if err != nil {
+		return moira.DeadLetterNotification{}, fmt.Errorf("failed to parse dead letter notification json %s: %w", value, err)
+	}
+
+	return notificationSE.toDeadLetterNotification(), nil
+}
+
+// DeadLetterNotifications converts redis DB reply to moira.DeadLetterNotification objects array.
+func DeadLetterNotifications(responses *redis.StringSliceCmd) ([]*moira.DeadLetterNotification, error) {
+	if responses == nil || errors.Is(responses.Err(), redis.Nil) {
+		return make([]*moira.DeadLetterNotification, 0), nil
+	}
+
+	data, err := responses.Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letter notifications: %w", err)
+	}
+
+	notifications := make([]*moira.DeadLetterNotification, 0, len(data))
+
+	for _, value := range data {
+		notification, err := DeadLetterNotification(value)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, nil
+}
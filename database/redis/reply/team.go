@@ -12,21 +12,24 @@ import (
 
 // teamStorageElement is a representation of team in database.
 type teamStorageElement struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	RestrictTriggerChanges bool   `json:"restrict_trigger_changes,omitempty"`
 }
 
 func newTeamStorageElement(team moira.Team) teamStorageElement {
 	return teamStorageElement{
-		Name:        team.Name,
-		Description: team.Description,
+		Name:                   team.Name,
+		Description:            team.Description,
+		RestrictTriggerChanges: team.RestrictTriggerChanges,
 	}
 }
 
 func (t *teamStorageElement) toTeam() moira.Team {
 	return moira.Team{
-		Name:        t.Name,
-		Description: t.Description,
+		Name:                   t.Name,
+		Description:            t.Description,
+		RestrictTriggerChanges: t.RestrictTriggerChanges,
 	}
 }
 
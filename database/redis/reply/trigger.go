@@ -31,6 +31,7 @@ type triggerStorageElement struct {
 	IsRemote         bool                `json:"is_remote"`
 	TriggerSource    moira.TriggerSource `json:"trigger_source,omitempty"`
 	ClusterId        moira.ClusterId     `json:"cluster_id,omitempty"`
+	MetricsTenant    string              `json:"metrics_tenant,omitempty"`
 	MuteNewMetrics   bool                `json:"mute_new_metrics,omitempty"`
 	AloneMetrics     map[string]bool     `json:"alone_metrics"`
 	CreatedAt        *int64              `json:"created_at"`
@@ -73,6 +74,7 @@ func (storageElement *triggerStorageElement) toTrigger() moira.Trigger {
 		TTL:              getTriggerTTL(storageElement.TTL),
 		TriggerSource:    triggerSource,
 		ClusterId:        clusterId,
+		MetricsTenant:    storageElement.MetricsTenant,
 		MuteNewMetrics:   storageElement.MuteNewMetrics,
 		AloneMetrics:     storageElement.AloneMetrics,
 		CreatedAt:        storageElement.CreatedAt,
@@ -102,6 +104,7 @@ func toTriggerStorageElement(trigger *moira.Trigger, triggerID string) *triggerS
 		IsRemote:         trigger.TriggerSource == moira.GraphiteRemote,
 		TriggerSource:    trigger.TriggerSource,
 		ClusterId:        trigger.ClusterId,
+		MetricsTenant:    trigger.MetricsTenant,
 		MuteNewMetrics:   trigger.MuteNewMetrics,
 		AloneMetrics:     trigger.AloneMetrics,
 		CreatedAt:        trigger.CreatedAt,
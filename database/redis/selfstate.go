@@ -243,6 +243,8 @@ var (
 	selfStateChecksCounterKey           = "moira-selfstate:checks-counter"
 	selfStateRemoteChecksCounterKey     = "moira-selfstate:remote-checks-counter"
 	selfStatePrometheusChecksCounterKey = "moira-selfstate:prometheus-checks-counter"
+	selfStateLokiChecksCounterKey       = "moira-selfstate:loki-checks-counter"
+	selfStateSQLChecksCounterKey        = "moira-selfstate:sql-checks-counter"
 	selfStateNotifierHealth             = "moira-selfstate:notifier-health"
 	selfStateNotifierStateForSource     = "moira-selfstate:notifier-state-for-source"
 )
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// SaveSilence saves silence, existing silence with the same id is replaced.
+func (connector *DbConnector) SaveSilence(silence *moira.Silence) error {
+	bytes, err := json.Marshal(silence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence: %w", err)
+	}
+
+	err = (*connector.client).HSet(connector.context, silencesKey, silence.ID, bytes).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save silence: %w", err)
+	}
+
+	return nil
+}
+
+// GetSilence returns silence by its id, if there is no such silence, returns database.ErrNil error.
+func (connector *DbConnector) GetSilence(silenceID string) (moira.Silence, error) {
+	bytes, err := (*connector.client).HGet(connector.context, silencesKey, silenceID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.Silence{}, database.ErrNil
+		}
+
+		return moira.Silence{}, fmt.Errorf("failed to get silence: %w", err)
+	}
+
+	return unmarshalSilence(bytes)
+}
+
+// GetSilences returns all silences ordered by their start time.
+func (connector *DbConnector) GetSilences() ([]*moira.Silence, error) {
+	values, err := (*connector.client).HGetAll(connector.context, silencesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silences: %w", err)
+	}
+
+	silences := make([]*moira.Silence, 0, len(values))
+
+	for _, value := range values {
+		silence, err := unmarshalSilence([]byte(value))
+		if err != nil {
+			return nil, err
+		}
+
+		silences = append(silences, &silence)
+	}
+
+	sortSilences(silences)
+
+	return silences, nil
+}
+
+// RemoveSilence removes silence by its id.
+func (connector *DbConnector) RemoveSilence(silenceID string) error {
+	err := (*connector.client).HDel(connector.context, silencesKey, silenceID).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove silence: %w", err)
+	}
+
+	return nil
+}
+
+func unmarshalSilence(bytes []byte) (moira.Silence, error) {
+	var silence moira.Silence
+	if err := json.Unmarshal(bytes, &silence); err != nil {
+		return moira.Silence{}, fmt.Errorf("failed to parse silence json %s: %w", string(bytes), err)
+	}
+
+	return silence, nil
+}
+
+func sortSilences(silences []*moira.Silence) {
+	sort.Slice(silences, func(i, j int) bool {
+		if silences[i].StartsAt == silences[j].StartsAt {
+			return silences[i].ID < silences[j].ID
+		}
+
+		return silences[i].StartsAt < silences[j].StartsAt
+	})
+}
+
+const silencesKey = "moira-silences"
@@ -1,8 +1,10 @@
 package redis
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/go-redis/redis/v8"
@@ -156,9 +158,14 @@ func (connector *DbConnector) GetTeamByName(name string) (moira.Team, error) {
 func (connector *DbConnector) SaveTeamsAndUsers(teamID string, users []string, teams map[string][]string) error {
 	c := *connector.client
 
+	roleUsers, err := c.HKeys(connector.context, teamRolesKey(teamID)).Result()
+	if err != nil {
+		return fmt.Errorf("cannot get roles of team users: %s, %w", teamID, err)
+	}
+
 	pipe := c.TxPipeline()
 
-	err := pipe.Del(connector.context, teamUsersKey(teamID)).Err()
+	err = pipe.Del(connector.context, teamUsersKey(teamID)).Err()
 	if err != nil {
 		return fmt.Errorf("cannot clear users set for team: %s, %w", teamID, err)
 	}
@@ -170,6 +177,16 @@ func (connector *DbConnector) SaveTeamsAndUsers(teamID string, users []string, t
 		}
 	}
 
+	// Roles of users who left the team are removed, so they do not return with old role.
+	for _, userID := range roleUsers {
+		if !slices.Contains(users, userID) {
+			err = pipe.HDel(connector.context, teamRolesKey(teamID), userID).Err()
+			if err != nil {
+				return fmt.Errorf("cannot remove role of user: %s, %w", userID, err)
+			}
+		}
+	}
+
 	for userID, userTeams := range teams {
 		err = pipe.Del(connector.context, userTeamsKey(userID)).Err()
 		if err != nil {
@@ -258,6 +275,21 @@ func (connector *DbConnector) DeleteTeam(teamID, userID string) error {
 				return fmt.Errorf("failed to remove team users: %w", err)
 			}
 
+			err = pipe.Del(connector.context, teamRolesKey(teamID)).Err()
+			if err != nil {
+				return fmt.Errorf("failed to remove team roles: %w", err)
+			}
+
+			err = pipe.Del(connector.context, teamPreferencesKey(teamID)).Err()
+			if err != nil {
+				return fmt.Errorf("failed to remove team preferences: %w", err)
+			}
+
+			err = pipe.Del(connector.context, teamOnCallScheduleKey(teamID)).Err()
+			if err != nil {
+				return fmt.Errorf("failed to remove team on-call schedule: %w", err)
+			}
+
 			err = pipe.HDel(connector.context, teamsKey, teamID).Err()
 			if err != nil {
 				return fmt.Errorf("failed to remove team metadata: %w", err)
@@ -269,6 +301,111 @@ func (connector *DbConnector) DeleteTeam(teamID, userID string) error {
 	return err
 }
 
+// SaveTeamUserRole saves role of the user in the team.
+func (connector *DbConnector) SaveTeamUserRole(teamID, userID string, role moira.TeamRole) error {
+	c := *connector.client
+
+	err := c.HSet(connector.context, teamRolesKey(teamID), userID, string(role)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save role of team user: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamUserRoles returns roles assigned to users of the team.
+func (connector *DbConnector) GetTeamUserRoles(teamID string) (map[string]moira.TeamRole, error) {
+	c := *connector.client
+
+	response, err := c.HGetAll(connector.context, teamRolesKey(teamID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve team roles: %w", err)
+	}
+
+	roles := make(map[string]moira.TeamRole, len(response))
+	for userID, role := range response {
+		roles[userID] = moira.TeamRole(role)
+	}
+
+	return roles, nil
+}
+
+// SaveTeamPreferences saves team-wide settings of the team.
+func (connector *DbConnector) SaveTeamPreferences(teamID string, preferences moira.TeamPreferences) error {
+	c := *connector.client
+
+	bytes, err := json.Marshal(preferences)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team preferences: %w", err)
+	}
+
+	err = c.Set(connector.context, teamPreferencesKey(teamID), bytes, redis.KeepTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save team preferences: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamPreferences returns team-wide settings of the team or database.ErrNil if they were not saved.
+func (connector *DbConnector) GetTeamPreferences(teamID string) (moira.TeamPreferences, error) {
+	c := *connector.client
+
+	bytes, err := c.Get(connector.context, teamPreferencesKey(teamID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.TeamPreferences{}, database.ErrNil
+		}
+
+		return moira.TeamPreferences{}, fmt.Errorf("failed to get team preferences: %w", err)
+	}
+
+	var preferences moira.TeamPreferences
+	if err = json.Unmarshal(bytes, &preferences); err != nil {
+		return moira.TeamPreferences{}, fmt.Errorf("failed to parse team preferences json %s: %w", string(bytes), err)
+	}
+
+	return preferences, nil
+}
+
+// SaveTeamOnCallSchedule saves on-call schedule of the team.
+func (connector *DbConnector) SaveTeamOnCallSchedule(teamID string, schedule moira.OnCallSchedule) error {
+	c := *connector.client
+
+	bytes, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team on-call schedule: %w", err)
+	}
+
+	err = c.Set(connector.context, teamOnCallScheduleKey(teamID), bytes, redis.KeepTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save team on-call schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeamOnCallSchedule returns on-call schedule of the team or database.ErrNil if it was not saved.
+func (connector *DbConnector) GetTeamOnCallSchedule(teamID string) (moira.OnCallSchedule, error) {
+	c := *connector.client
+
+	bytes, err := c.Get(connector.context, teamOnCallScheduleKey(teamID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return moira.OnCallSchedule{}, database.ErrNil
+		}
+
+		return moira.OnCallSchedule{}, fmt.Errorf("failed to get team on-call schedule: %w", err)
+	}
+
+	var schedule moira.OnCallSchedule
+	if err = json.Unmarshal(bytes, &schedule); err != nil {
+		return moira.OnCallSchedule{}, fmt.Errorf("failed to parse team on-call schedule json %s: %w", string(bytes), err)
+	}
+
+	return schedule, nil
+}
+
 const (
 	teamsKey        = "moira-teams"
 	teamsByNamesKey = "moira-teams-by-names"
@@ -281,3 +418,15 @@ func userTeamsKey(userID string) string {
 func teamUsersKey(teamID string) string {
 	return fmt.Sprintf("moira-teamUsers:%s", teamID)
 }
+
+func teamRolesKey(teamID string) string {
+	return fmt.Sprintf("moira-teamRoles:%s", teamID)
+}
+
+func teamPreferencesKey(teamID string) string {
+	return fmt.Sprintf("moira-teamPreferences:%s", teamID)
+}
+
+func teamOnCallScheduleKey(teamID string) string {
+	return fmt.Sprintf("moira-teamOnCallSchedule:%s", teamID)
+}
@@ -483,6 +483,8 @@ var (
 	localTriggersListKey      = "{moira-triggers-list}:moira-local-triggers-list"
 	remoteTriggersListKey     = "{moira-triggers-list}:moira-remote-triggers-list"
 	prometheusTriggersListKey = "{moira-triggers-list}:moira-prometheus-triggers-list"
+	lokiTriggersListKey       = "{moira-triggers-list}:moira-loki-triggers-list"
+	sqlTriggersListKey        = "{moira-triggers-list}:moira-sql-triggers-list"
 )
 
 func makeTriggerListKey(clusterKey moira.ClusterKey) (string, error) {
@@ -498,6 +500,12 @@ func makeTriggerListKey(clusterKey moira.ClusterKey) (string, error) {
 	case moira.PrometheusRemote:
 		key = prometheusTriggersListKey
 
+	case moira.LokiRemote:
+		key = lokiTriggersListKey
+
+	case moira.SQLRemote:
+		key = sqlTriggersListKey
+
 	default:
 		return "", fmt.Errorf("unknown trigger source %s", clusterKey.TriggerSource)
 	}
@@ -129,6 +129,8 @@ func (connector *DbConnector) getTriggersToCheckCount(key string) (int64, error)
 const (
 	remoteTriggersToCheckKey     = "moira-remote-triggers-to-check"
 	prometheusTriggersToCheckKey = "moira-prometheus-triggers-to-check"
+	lokiTriggersToCheckKey       = "moira-loki-triggers-to-check"
+	sqlTriggersToCheckKey        = "moira-sql-triggers-to-check"
 	localTriggersToCheckKey      = "moira-triggers-to-check"
 )
 
@@ -145,6 +147,12 @@ func makeTriggersToCheckKey(clusterKey moira.ClusterKey) (string, error) {
 	case moira.PrometheusRemote:
 		key = prometheusTriggersToCheckKey
 
+	case moira.LokiRemote:
+		key = lokiTriggersToCheckKey
+
+	case moira.SQLRemote:
+		key = sqlTriggersToCheckKey
+
 	default:
 		return "", fmt.Errorf("unknown trigger source `%s`", clusterKey.TriggerSource.String())
 	}
@@ -162,7 +162,11 @@ func (events NotificationEvents) ToTemplateEvents() []templating.Event {
 			MetricElements: strings.Split(event.Metric, "."),
 			Timestamp:      event.Timestamp,
 			State:          string(event.State),
+			OldState:       string(event.OldState),
 			Value:          event.Value,
+			Values:         event.Values,
+			IsTriggerEvent: event.IsTriggerEvent,
+			Message:        event.CreateMessage(nil),
 		})
 	}
 
@@ -197,6 +201,20 @@ func (trigger TriggerData) GetTriggerURI(frontURI string) string {
 	return ""
 }
 
+// ToTemplateTrigger converts a TriggerData into a template Trigger.
+func (trigger *TriggerData) ToTemplateTrigger(frontURI string) *templating.Trigger {
+	return &templating.Trigger{
+		ID:         trigger.ID,
+		Name:       trigger.Name,
+		Desc:       trigger.Desc,
+		Tags:       trigger.Tags,
+		Targets:    trigger.Targets,
+		WarnValue:  trigger.WarnValue,
+		ErrorValue: trigger.ErrorValue,
+		URL:        trigger.GetTriggerURI(frontURI),
+	}
+}
+
 // GetTags returns "[tag1][tag2]...[tagN]" string.
 func (trigger *TriggerData) GetTags() string {
 	var buffer bytes.Buffer
@@ -212,6 +230,255 @@ type Team struct {
 	ID          string
 	Name        string
 	Description string
+	// RestrictTriggerChanges allows only members of the team and administrators to modify triggers owned by the team.
+	RestrictTriggerChanges bool
+}
+
+// TeamRole is a role of the user in the team.
+type TeamRole string
+
+const (
+	// TeamRoleOwner can do everything with the team, its members and resources.
+	TeamRoleOwner TeamRole = "owner"
+	// TeamRoleEditor can change triggers, subscriptions and contacts of the team.
+	TeamRoleEditor TeamRole = "editor"
+	// TeamRoleViewer has read-only access to the team.
+	TeamRoleViewer TeamRole = "viewer"
+	// TeamRoleServiceAccount is a role of API token of the team, it can change resources of the team but can not manage the team.
+	TeamRoleServiceAccount TeamRole = "service_account"
+)
+
+// DefaultTeamRole is a role of team members without assigned role, before roles were introduced every member could do everything.
+const DefaultTeamRole = TeamRoleOwner
+
+// MemberTeamRole returns role of the team member from roles of the team, members without assigned role have default role.
+func MemberTeamRole(roles map[string]TeamRole, userID string) TeamRole {
+	if role, ok := roles[userID]; ok {
+		return role
+	}
+
+	return DefaultTeamRole
+}
+
+// TeamAccess is a level of access to the team required by some action.
+type TeamAccess int
+
+const (
+	// TeamAccessRead is required to view the team, its members and resources.
+	TeamAccessRead TeamAccess = iota
+	// TeamAccessEdit is required to change triggers, subscriptions and contacts of the team.
+	TeamAccessEdit
+	// TeamAccessManage is required to change the team itself, its members, their roles and API tokens of the team.
+	TeamAccessManage
+)
+
+// IsMemberRole returns true if the role can be assigned to member of the team.
+func (role TeamRole) IsMemberRole() bool {
+	return role == TeamRoleOwner || role == TeamRoleEditor || role == TeamRoleViewer
+}
+
+// Allows returns true if the role grants the given access to the team.
+func (role TeamRole) Allows(access TeamAccess) bool {
+	switch access {
+	case TeamAccessRead:
+		return role.IsMemberRole() || role == TeamRoleServiceAccount
+	case TeamAccessEdit:
+		return role == TeamRoleOwner || role == TeamRoleEditor || role == TeamRoleServiceAccount
+	case TeamAccessManage:
+		return role == TeamRoleOwner
+	default:
+		return false
+	}
+}
+
+// TeamPreferences are team-wide settings applied to subscriptions and triggers of the team.
+type TeamPreferences struct {
+	SubscriptionDefaults TeamSubscriptionDefaults `json:"subscription_defaults"`
+	// QuietHours are periods when notifications of team subscriptions are postponed.
+	QuietHours []QuietHoursPeriod `json:"quiet_hours,omitempty"`
+	// Tags mark triggers of the team, they are silenced by team maintenance.
+	Tags []string `json:"tags,omitempty"`
+	// Maintenance is a timestamp till which triggers of the team tagged with team tags are in maintenance.
+	Maintenance     int64           `json:"maintenance,omitempty"`
+	MaintenanceInfo MaintenanceInfo `json:"maintenance_info"`
+}
+
+// TeamSubscriptionDefaults are settings which new subscriptions of the team inherit unless they specify them.
+type TeamSubscriptionDefaults struct {
+	Schedule          *ScheduleData `json:"sched,omitempty"`
+	PlottingTheme     string        `json:"plotting_theme,omitempty"`
+	ThrottlingEnabled *bool         `json:"throttling,omitempty"`
+	IgnoreWarnings    *bool         `json:"ignore_warnings,omitempty"`
+}
+
+// QuietHoursPeriod is a period of time, from inclusive and to exclusive, given in unix seconds.
+type QuietHoursPeriod struct {
+	From    int64  `json:"from"`
+	To      int64  `json:"to"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// QuietHoursEnd returns the first moment starting from the given time that is not covered by quiet hours.
+func (preferences *TeamPreferences) QuietHoursEnd(timestamp time.Time) time.Time {
+	for moved := true; moved; {
+		moved = false
+
+		for _, period := range preferences.QuietHours {
+			if timestamp.Unix() >= period.From && timestamp.Unix() < period.To {
+				timestamp = time.Unix(period.To, 0).In(timestamp.Location())
+				moved = true
+			}
+		}
+	}
+
+	return timestamp
+}
+
+// OnCallContactType is a type of virtual contact which value is ID of the team.
+// Notifications of such contact are delivered to contacts of the user who is on-call in the team at send time.
+const OnCallContactType = "oncall"
+
+// OnCallSchedule is an on-call schedule of the team.
+type OnCallSchedule struct {
+	// Rotations are sorted by start, each rotation is in effect until the start of the next one.
+	Rotations []OnCallRotation `json:"rotations"`
+	// Overrides replace users on-call by rotations, the latest override wins if they overlap.
+	Overrides []OnCallOverride `json:"overrides,omitempty"`
+}
+
+// OnCallRotation hands off on-call duty between participants in turn, starting from the first participant.
+type OnCallRotation struct {
+	Name         string   `json:"name,omitempty" example:"Primary"`
+	Participants []string `json:"participants" example:"user1,user2"`
+	// Start is a timestamp of the first handoff, in unix seconds.
+	Start int64 `json:"start" example:"1704067200" format:"int64"`
+	// ShiftLength is a duration of the shift in seconds, duty is handed off to the next participant after it.
+	ShiftLength int64 `json:"shift_length" example:"604800" format:"int64"`
+}
+
+// OnCallOverride puts the user on-call for a period of time, from inclusive and to exclusive, given in unix seconds.
+type OnCallOverride struct {
+	User    string `json:"user" example:"user3"`
+	From    int64  `json:"from" example:"1704067200" format:"int64"`
+	To      int64  `json:"to" example:"1704153600" format:"int64"`
+	Comment string `json:"comment,omitempty" example:"user1 is on vacation"`
+}
+
+// OnCallShift is a period of time when the user is on-call, to is the time of the next handoff.
+type OnCallShift struct {
+	User string `json:"user" example:"user1"`
+	From int64  `json:"from" example:"1704067200" format:"int64"`
+	To   int64  `json:"to" example:"1704672000" format:"int64"`
+}
+
+// GetShift returns the shift of the user who is on-call at the given time, false if nobody is on-call.
+func (schedule *OnCallSchedule) GetShift(timestamp time.Time) (OnCallShift, bool) {
+	now := timestamp.Unix()
+
+	for i := len(schedule.Overrides) - 1; i >= 0; i-- {
+		override := schedule.Overrides[i]
+		if now >= override.From && now < override.To {
+			return OnCallShift{User: override.User, From: override.From, To: override.To}, true
+		}
+	}
+
+	shift, ok := schedule.getRotationShift(now)
+	if !ok {
+		return OnCallShift{}, false
+	}
+
+	// Overrides interrupt the shift of the rotation.
+	for _, override := range schedule.Overrides {
+		if override.To > shift.From && override.To <= now {
+			shift.From = override.To
+		}
+
+		if override.From > now && override.From < shift.To {
+			shift.To = override.From
+		}
+	}
+
+	return shift, true
+}
+
+func (schedule *OnCallSchedule) getRotationShift(now int64) (OnCallShift, bool) {
+	index := -1
+
+	for i, rotation := range schedule.Rotations {
+		if rotation.Start <= now {
+			index = i
+		}
+	}
+
+	if index == -1 {
+		return OnCallShift{}, false
+	}
+
+	rotation := schedule.Rotations[index]
+	if len(rotation.Participants) == 0 || rotation.ShiftLength <= 0 {
+		return OnCallShift{}, false
+	}
+
+	shiftNumber := (now - rotation.Start) / rotation.ShiftLength
+	shift := OnCallShift{
+		User: rotation.Participants[shiftNumber%int64(len(rotation.Participants))],
+		From: rotation.Start + shiftNumber*rotation.ShiftLength,
+		To:   rotation.Start + (shiftNumber+1)*rotation.ShiftLength,
+	}
+
+	if index+1 < len(schedule.Rotations) && schedule.Rotations[index+1].Start < shift.To {
+		shift.To = schedule.Rotations[index+1].Start
+	}
+
+	return shift, true
+}
+
+// APITokenScope is a permission granted to API token.
+type APITokenScope string
+
+const (
+	// APITokenScopeRead allows only requests that do not change data.
+	APITokenScopeRead APITokenScope = "read"
+	// APITokenScopeWrite allows requests that change data.
+	APITokenScopeWrite APITokenScope = "write"
+	// APITokenScopeAdmin grants administrator role to the token while its user is an administrator, only administrators can issue such tokens.
+	APITokenScopeAdmin APITokenScope = "admin"
+)
+
+// APIToken is a credential used by scripts and other automation to access API on behalf of a user or a team.
+// Only hash of the token secret is stored.
+type APIToken struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	User      string          `json:"user"`
+	TeamID    string          `json:"team_id,omitempty"`
+	Scopes    []APITokenScope `json:"scopes"`
+	Hash      string          `json:"hash"`
+	CreatedAt int64           `json:"created_at"`
+	ExpiresAt *int64          `json:"expires_at,omitempty"`
+}
+
+// HasScope returns true if the token was granted the given scope.
+func (token *APIToken) HasScope(scope APITokenScope) bool {
+	for _, tokenScope := range token.Scopes {
+		if tokenScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsExpired returns true if the token can not be used at the given timestamp.
+func (token *APIToken) IsExpired(now int64) bool {
+	return token.ExpiresAt != nil && *token.ExpiresAt <= now
+}
+
+// AuthSession is a session of the user logged in with OpenID Connect provider.
+type AuthSession struct {
+	Login     string   `json:"login"`
+	Groups    []string `json:"groups,omitempty"`
+	ExpiresAt int64    `json:"expires_at"`
 }
 
 // ContactData represents contact object.
@@ -223,6 +490,8 @@ type ContactData struct {
 	User         string `json:"user" binding:"required" example:""`
 	Team         string `json:"team" binding:"required"`
 	ExtraMessage string `json:"extra_message,omitempty"`
+	// BodyTemplate overrides the body template of the webhook sender for this contact.
+	BodyTemplate string `json:"body_template,omitempty"`
 }
 
 // ToTemplateContact converts a ContactData into a template Contact.
@@ -271,6 +540,12 @@ type ScheduleData struct {
 	TimezoneOffset int64             `json:"tzOffset" binding:"required" example:"-60" format:"int64"`
 	StartOffset    int64             `json:"startOffset" binding:"required" example:"0" format:"int64"`
 	EndOffset      int64             `json:"endOffset" binding:"required" example:"1439" format:"int64"`
+	// Timezone is an IANA timezone name, it is used instead of TimezoneOffset so daylight saving time is taken into account.
+	Timezone string `json:"timezone,omitempty" example:"Europe/Berlin"`
+	// Windows are allowed time intervals of enabled days, they are used instead of StartOffset and EndOffset.
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+	// Exceptions are periods when schedule does not allow anything, e.g. public holidays or release freeze.
+	Exceptions []ScheduleException `json:"exceptions,omitempty"`
 }
 
 // ScheduleDataDay represents week day of schedule.
@@ -386,6 +661,30 @@ func (notification *ScheduledNotification) GetState(triggerCheck *CheckData) sch
 	return ValidNotification
 }
 
+// DeadLetterNotification represents notification which notifier gave up to deliver.
+type DeadLetterNotification struct {
+	ID           string                `json:"id" binding:"required" example:"1dd38765-c5be-418d-81fa-7a5f879c2315"`
+	Notification ScheduledNotification `json:"notification" binding:"required"`
+	Error        string                `json:"error" binding:"required" example:"failed to post message: 503 Service Unavailable"`
+	Attempts     int                   `json:"attempts" binding:"required" example:"10"`
+	SenderType   string                `json:"sender_type" binding:"required" example:"slack"`
+	Timestamp    int64                 `json:"timestamp" binding:"required" example:"1594471927" format:"int64"`
+}
+
+// ToScheduledNotification returns copy of dead letter notification ready to be scheduled again at the given timestamp.
+func (notification *DeadLetterNotification) ToScheduledNotification(timestamp int64) *ScheduledNotification {
+	return &ScheduledNotification{
+		Event:     notification.Notification.Event,
+		Trigger:   notification.Notification.Trigger,
+		Contact:   notification.Notification.Contact,
+		Plotting:  notification.Notification.Plotting,
+		Throttled: notification.Notification.Throttled,
+		SendFail:  0,
+		Timestamp: timestamp,
+		CreatedAt: timestamp,
+	}
+}
+
 // MatchedMetric represents parsed and matched metric data.
 type MatchedMetric struct {
 	Metric             string
@@ -436,6 +735,7 @@ type Trigger struct {
 	Patterns         []string        `json:"patterns" binding:"required" example:""`
 	TriggerSource    TriggerSource   `json:"trigger_source,omitempty" example:"graphite_local"`
 	ClusterId        ClusterId       `json:"cluster_id,omitempty" example:"default"`
+	MetricsTenant    string          `json:"metrics_tenant,omitempty" example:"team-a"`
 	MuteNewMetrics   bool            `json:"mute_new_metrics" binding:"required" example:"false"`
 	AloneMetrics     map[string]bool `json:"alone_metrics" binding:"required" example:"t1:true"`
 	CreatedAt        *int64          `json:"created_at" binding:"required" format:"int64" extensions:"x-nullable"`
@@ -454,6 +754,22 @@ func (trigger *Trigger) ClusterKey() ClusterKey {
 	return MakeClusterKey(trigger.TriggerSource, trigger.ClusterId)
 }
 
+// ToTriggerData returns data of the trigger which is passed to senders along with notification events.
+func (trigger *Trigger) ToTriggerData() TriggerData {
+	return TriggerData{
+		ID:            trigger.ID,
+		Name:          trigger.Name,
+		Desc:          UseString(trigger.Desc),
+		Targets:       trigger.Targets,
+		WarnValue:     UseFloat64(trigger.WarnValue),
+		ErrorValue:    UseFloat64(trigger.ErrorValue),
+		IsRemote:      trigger.TriggerSource == GraphiteRemote,
+		TriggerSource: trigger.TriggerSource,
+		ClusterId:     trigger.ClusterId,
+		Tags:          trigger.Tags,
+	}
+}
+
 // TriggerSource is a enum which values correspond to types of moira's metric sources.
 type TriggerSource string
 
@@ -462,6 +778,8 @@ var (
 	GraphiteLocal       TriggerSource = "graphite_local"
 	GraphiteRemote      TriggerSource = "graphite_remote"
 	PrometheusRemote    TriggerSource = "prometheus_remote"
+	LokiRemote          TriggerSource = "loki_remote"
+	SQLRemote           TriggerSource = "sql_remote"
 )
 
 func (s *TriggerSource) UnmarshalJSON(data []byte) error {
@@ -471,7 +789,7 @@ func (s *TriggerSource) UnmarshalJSON(data []byte) error {
 	}
 
 	source := TriggerSource(v)
-	if source != GraphiteLocal && source != GraphiteRemote && source != PrometheusRemote {
+	if source != GraphiteLocal && source != GraphiteRemote && source != PrometheusRemote && source != LokiRemote && source != SQLRemote {
 		*s = TriggerSourceNotSet
 		return nil
 	}
@@ -574,11 +892,34 @@ type SearchOptions struct {
 	Tags         []string
 	CreatedBy    string
 	TeamID       string
+	Ownership    TriggerOwnership
 	CreatePager  bool
 	PagerID      string
 	PagerTTL     time.Duration
 }
 
+// TriggerOwnership is a filter of triggers by their owner.
+type TriggerOwnership string
+
+const (
+	// TriggerOwnershipAny matches all triggers.
+	TriggerOwnershipAny TriggerOwnership = ""
+	// TriggerOwnershipTeam matches triggers owned by any team.
+	TriggerOwnershipTeam TriggerOwnership = "team"
+	// TriggerOwnershipPersonal matches triggers not owned by a team.
+	TriggerOwnershipPersonal TriggerOwnership = "personal"
+)
+
+// IsValid checks that the ownership filter is known.
+func (ownership TriggerOwnership) IsValid() bool {
+	switch ownership {
+	case TriggerOwnershipAny, TriggerOwnershipTeam, TriggerOwnershipPersonal:
+		return true
+	default:
+		return false
+	}
+}
+
 // MaintenanceCheck set maintenance user, time.
 type MaintenanceCheck interface {
 	SetMaintenance(maintenanceInfo *MaintenanceInfo, maintenance int64)
@@ -772,6 +1113,15 @@ func (schedule *ScheduleData) IsScheduleAllows(ts int64) bool {
 		return true
 	}
 
+	if schedule.IsExtended() {
+		location, err := schedule.GetLocation()
+		if err != nil {
+			return true
+		}
+
+		return schedule.isAllowedAt(time.Unix(ts, 0), location)
+	}
+
 	endOffset, startOffset := schedule.EndOffset, schedule.StartOffset
 	if schedule.EndOffset < schedule.StartOffset {
 		endOffset = schedule.EndOffset + 24*60 //nolint
@@ -1106,3 +1106,97 @@ func TestParseClusterKey(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestTeamPreferences_QuietHoursEnd(t *testing.T) {
+	Convey("Test team preferences QuietHoursEnd function", t, func() {
+		preferences := TeamPreferences{
+			QuietHours: []QuietHoursPeriod{
+				{From: 200, To: 300},
+				{From: 100, To: 200},
+				{From: 500, To: 600},
+			},
+		}
+
+		Convey("Time out of quiet hours is not moved", func() {
+			So(preferences.QuietHoursEnd(time.Unix(50, 0)), ShouldEqual, time.Unix(50, 0))
+			So(preferences.QuietHoursEnd(time.Unix(300, 0)), ShouldEqual, time.Unix(300, 0))
+		})
+
+		Convey("Time in quiet hours is moved to the end of adjacent periods", func() {
+			So(preferences.QuietHoursEnd(time.Unix(150, 0)), ShouldEqual, time.Unix(300, 0))
+		})
+
+		Convey("Time in last period is moved to its end", func() {
+			So(preferences.QuietHoursEnd(time.Unix(500, 0)), ShouldEqual, time.Unix(600, 0))
+		})
+
+		Convey("Time is not moved without quiet hours", func() {
+			So((&TeamPreferences{}).QuietHoursEnd(time.Unix(150, 0)), ShouldEqual, time.Unix(150, 0))
+		})
+	})
+}
+
+func TestOnCallSchedule_GetShift(t *testing.T) {
+	Convey("Test on-call schedule GetShift function", t, func() {
+		schedule := OnCallSchedule{
+			Rotations: []OnCallRotation{
+				{Participants: []string{"user1", "user2"}, Start: 1000, ShiftLength: 100},
+				{Participants: []string{"user3"}, Start: 1350, ShiftLength: 1000},
+			},
+		}
+
+		Convey("Nobody is on-call before the first rotation", func() {
+			_, ok := schedule.GetShift(time.Unix(999, 0))
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Participants are on-call in turn", func() {
+			shift, ok := schedule.GetShift(time.Unix(1000, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user1", From: 1000, To: 1100})
+
+			shift, ok = schedule.GetShift(time.Unix(1150, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user2", From: 1100, To: 1200})
+
+			shift, ok = schedule.GetShift(time.Unix(1250, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user1", From: 1200, To: 1300})
+		})
+
+		Convey("Shift is interrupted by the next rotation", func() {
+			shift, ok := schedule.GetShift(time.Unix(1300, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user2", From: 1300, To: 1350})
+
+			shift, ok = schedule.GetShift(time.Unix(1350, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user3", From: 1350, To: 2350})
+		})
+
+		schedule.Overrides = []OnCallOverride{
+			{User: "user3", From: 1020, To: 1050},
+			{User: "user4", From: 1030, To: 1040},
+		}
+
+		Convey("The latest override wins", func() {
+			shift, ok := schedule.GetShift(time.Unix(1035, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user4", From: 1030, To: 1040})
+
+			shift, ok = schedule.GetShift(time.Unix(1045, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user3", From: 1020, To: 1050})
+		})
+
+		Convey("Shift of rotation is split by override", func() {
+			shift, ok := schedule.GetShift(time.Unix(1010, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user1", From: 1000, To: 1020})
+
+			shift, ok = schedule.GetShift(time.Unix(1060, 0))
+			So(ok, ShouldBeTrue)
+			So(shift, ShouldResemble, OnCallShift{User: "user1", From: 1050, To: 1100})
+		})
+	})
+}
@@ -0,0 +1,236 @@
+// Package ical reads periods of events from iCalendar data (RFC 5545), e.g. calendars of public holidays.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout          = "20060102"
+	localDateTimeLayout = "20060102T150405"
+	utcDateTimeLayout   = "20060102T150405Z"
+)
+
+// Event is a period of calendar event, start is inclusive and end is exclusive.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Calendar contains events of iCalendar data.
+type Calendar struct {
+	Events []Event
+	// Skipped are summaries of recurring events which are not returned, as their recurrence is not supported.
+	Skipped []string
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse returns events of iCalendar data. Dates and times without timezone are taken in the given location.
+// Recurring events with simple rules are expanded into occurrences overlapping the period since from till until,
+// other recurring events are skipped.
+func Parse(reader io.Reader, location *time.Location, from, until time.Time) (*Calendar, error) {
+	lines, err := unfoldLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &Calendar{
+		Events:  make([]Event, 0),
+		Skipped: make([]string, 0),
+	}
+
+	var (
+		inEvent     bool
+		event       Event
+		isDateOnly  bool
+		isRecurring bool
+		rule        *recurrence
+		exDates     []time.Time
+	)
+
+	for number, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			inEvent = true
+			event = Event{}
+			isDateOnly = false
+			isRecurring = false
+			rule = nil
+			exDates = nil
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: unexpected end of event", number+1)
+			}
+
+			inEvent = false
+
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no start", number+1, event.Summary)
+			}
+
+			if event.End.IsZero() && isDateOnly {
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+
+			switch {
+			case !event.End.After(event.Start):
+				// Event without duration does not make a period.
+			case isRecurring && rule == nil:
+				calendar.Skipped = append(calendar.Skipped, event.Summary)
+			case isRecurring:
+				calendar.Events = append(calendar.Events, rule.occurrences(event, exDates, from, until)...)
+			default:
+				calendar.Events = append(calendar.Events, event)
+			}
+		case !inEvent:
+			continue
+		case prop.name == "RRULE":
+			parsedRule, supported, err := parseRecurrence(prop.value, location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+
+			// Several rules of one event are not supported.
+			if supported && !isRecurring {
+				rule = &parsedRule
+			} else {
+				rule = nil
+			}
+
+			isRecurring = true
+		case prop.name == "RDATE":
+			// Occurrences of event on arbitrary dates are not supported.
+			isRecurring = true
+			rule = nil
+		case prop.name == "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				exDate, _, err := parseTime(property{name: prop.name, params: prop.params, value: value}, location)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", number+1, err)
+				}
+
+				exDates = append(exDates, exDate)
+			}
+		case prop.name == "SUMMARY":
+			event.Summary = unescapeText(prop.value)
+		case prop.name == "DTSTART":
+			event.Start, isDateOnly, err = parseTime(prop, location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+		case prop.name == "DTEND":
+			event.End, _, err = parseTime(prop, location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+		}
+	}
+
+	if inEvent {
+		return nil, fmt.Errorf("event %q is not finished", event.Summary)
+	}
+
+	return calendar, nil
+}
+
+// unfoldLines joins long content lines which are split into several lines starting with whitespace.
+func unfoldLines(reader io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+func parseProperty(line string) (property, error) {
+	nameAndParams, value, found := strings.Cut(line, ":")
+	if !found {
+		return property{}, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(nameAndParams, ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  value,
+	}
+
+	for _, param := range parts[1:] {
+		key, paramValue, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+	}
+
+	return prop, nil
+}
+
+// parseTime returns time of DTSTART or DTEND property and whether it is a date without time.
+func parseTime(prop property, location *time.Location) (time.Time, bool, error) {
+	if tzID, ok := prop.params["TZID"]; ok {
+		tzLocation, err := time.LoadLocation(tzID)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown timezone %q of %s", tzID, prop.name)
+		}
+
+		location = tzLocation
+	}
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len(dateLayout) {
+		date, err := time.ParseInLocation(dateLayout, prop.value, location)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date of %s: %w", prop.name, err)
+		}
+
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(prop.value, "Z") {
+		dateTime, err := time.Parse(utcDateTimeLayout, prop.value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid time of %s: %w", prop.name, err)
+		}
+
+		return dateTime, false, nil
+	}
+
+	dateTime, err := time.ParseInLocation(localDateTimeLayout, prop.value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time of %s: %w", prop.name, err)
+	}
+
+	return dateTime, false, nil
+}
+
+func unescapeText(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
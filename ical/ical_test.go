@@ -0,0 +1,128 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const holidays = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Holidays//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240101\r\n" +
+	"DTEND;VALUE=DATE:20240103\r\n" +
+	"SUMMARY:New Year\\, holidays\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240308\r\n" +
+	"SUMMARY:Women's\r\n" +
+	"  Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240510T220000Z\r\n" +
+	"DTEND:20240512T220000Z\r\n" +
+	"SUMMARY:Release freeze\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=America/New_York:20240601T090000\r\n" +
+	"DTEND;TZID=America/New_York:20240601T180000\r\n" +
+	"SUMMARY:Migration\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	parse := func(data string) (*Calendar, error) {
+		return Parse(strings.NewReader(data), berlin, from, until)
+	}
+
+	t.Run("Events are parsed", func(t *testing.T) {
+		calendar, err := parse(holidays)
+		require.NoError(t, err)
+		require.Empty(t, calendar.Skipped)
+
+		events := calendar.Events
+		require.Len(t, events, 4)
+
+		require.Equal(t, "New Year, holidays", events[0].Summary)
+		require.True(t, events[0].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, berlin)))
+		require.True(t, events[0].End.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, berlin)))
+
+		require.Equal(t, "Women's Day", events[1].Summary)
+		require.True(t, events[1].Start.Equal(time.Date(2024, 3, 8, 0, 0, 0, 0, berlin)))
+		require.True(t, events[1].End.Equal(time.Date(2024, 3, 9, 0, 0, 0, 0, berlin)))
+
+		require.True(t, events[2].Start.Equal(time.Date(2024, 5, 10, 22, 0, 0, 0, time.UTC)))
+		require.True(t, events[2].End.Equal(time.Date(2024, 5, 12, 22, 0, 0, 0, time.UTC)))
+
+		require.True(t, events[3].Start.Equal(time.Date(2024, 6, 1, 9, 0, 0, 0, newYork)))
+		require.True(t, events[3].End.Equal(time.Date(2024, 6, 1, 18, 0, 0, 0, newYork)))
+	})
+
+	t.Run("Event without start", func(t *testing.T) {
+		_, err := parse("BEGIN:VEVENT\nSUMMARY:Broken\nEND:VEVENT\n")
+		require.EqualError(t, err, `line 3: event "Broken" has no start`)
+	})
+
+	t.Run("Not finished event", func(t *testing.T) {
+		_, err := parse("BEGIN:VEVENT\nDTSTART:20240101\n")
+		require.EqualError(t, err, `event "" is not finished`)
+	})
+
+	t.Run("Yearly event is expanded within the period", func(t *testing.T) {
+		calendar, err := parse("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20200101\nRRULE:FREQ=YEARLY\nSUMMARY:New Year\nEND:VEVENT\n")
+		require.NoError(t, err)
+		require.Len(t, calendar.Events, 1)
+		require.Equal(t, "New Year", calendar.Events[0].Summary)
+		require.True(t, calendar.Events[0].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, berlin)))
+		require.True(t, calendar.Events[0].End.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, berlin)))
+	})
+
+	t.Run("Weekly event is expanded till count with excluded dates", func(t *testing.T) {
+		calendar, err := parse("BEGIN:VEVENT\nDTSTART:20240105T170000\nDTEND:20240105T200000\n" +
+			"RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=3\nEXDATE:20240119T170000\nSUMMARY:Release\nEND:VEVENT\n")
+		require.NoError(t, err)
+		require.Len(t, calendar.Events, 2)
+		require.True(t, calendar.Events[0].Start.Equal(time.Date(2024, 1, 5, 17, 0, 0, 0, berlin)))
+		require.True(t, calendar.Events[1].Start.Equal(time.Date(2024, 2, 2, 17, 0, 0, 0, berlin)))
+		require.True(t, calendar.Events[1].End.Equal(time.Date(2024, 2, 2, 20, 0, 0, 0, berlin)))
+	})
+
+	t.Run("Monthly event is expanded till time and skips missing days", func(t *testing.T) {
+		calendar, err := parse("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240131\nRRULE:FREQ=MONTHLY;UNTIL=20240601\nEND:VEVENT\n")
+		require.NoError(t, err)
+		require.Len(t, calendar.Events, 3)
+		require.True(t, calendar.Events[1].Start.Equal(time.Date(2024, 3, 31, 0, 0, 0, 0, berlin)))
+		require.True(t, calendar.Events[2].Start.Equal(time.Date(2024, 5, 31, 0, 0, 0, 0, berlin)))
+	})
+
+	t.Run("Recurring events with unsupported rules are skipped", func(t *testing.T) {
+		calendar, err := parse("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nRRULE:FREQ=MONTHLY;BYDAY=1MO\nSUMMARY:Planning\nEND:VEVENT\n" +
+			"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nRDATE;VALUE=DATE:20240301\nSUMMARY:Audit\nEND:VEVENT\n" +
+			"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nSUMMARY:New Year\nEND:VEVENT\n")
+		require.NoError(t, err)
+		require.Equal(t, []string{"Planning", "Audit"}, calendar.Skipped)
+		require.Len(t, calendar.Events, 1)
+	})
+
+	t.Run("Invalid recurrence rule", func(t *testing.T) {
+		_, err := parse("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nRRULE:FREQ=DAILY;INTERVAL=0\nEND:VEVENT\n")
+		require.EqualError(t, err, `line 3: invalid interval "0" of RRULE`)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		_, err := parse("BEGIN:VEVENT\nDTSTART;VALUE=DATE:2024-01-01\nEND:VEVENT\n")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package ical
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrence is a simple recurrence rule of event: occurrences repeat every interval of days, months or years
+// since the start of event till the count of occurrences or the time is reached.
+type recurrence struct {
+	years  int
+	months int
+	days   int
+	count  int
+	until  time.Time
+}
+
+// parseRecurrence returns recurrence of RRULE property and false if the rule is not supported.
+// Only rules with FREQ of DAILY, WEEKLY, MONTHLY or YEARLY and INTERVAL, COUNT and UNTIL parts are supported.
+func parseRecurrence(value string, location *time.Location) (recurrence, bool, error) {
+	var (
+		rule     recurrence
+		freq     string
+		interval = 1
+		err      error
+	)
+
+	for _, part := range strings.Split(value, ";") {
+		key, partValue, _ := strings.Cut(part, "=")
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq = strings.ToUpper(partValue)
+		case "INTERVAL":
+			interval, err = strconv.Atoi(partValue)
+			if err != nil || interval < 1 {
+				return recurrence{}, false, fmt.Errorf("invalid interval %q of RRULE", partValue)
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(partValue)
+			if err != nil || rule.count < 1 {
+				return recurrence{}, false, fmt.Errorf("invalid count %q of RRULE", partValue)
+			}
+		case "UNTIL":
+			rule.until, _, err = parseTime(property{name: "UNTIL", value: partValue}, location)
+			if err != nil {
+				return recurrence{}, false, err
+			}
+		case "WKST":
+			// Start of week matters only for rules by days of week, which are not supported.
+		default:
+			return recurrence{}, false, nil
+		}
+	}
+
+	switch freq {
+	case "DAILY":
+		rule.days = interval
+	case "WEEKLY":
+		rule.days = 7 * interval
+	case "MONTHLY":
+		rule.months = interval
+	case "YEARLY":
+		rule.years = interval
+	default:
+		return recurrence{}, false, nil
+	}
+
+	return rule, true, nil
+}
+
+// occurrences returns occurrences of the event which overlap the period since from till until.
+// Occurrences excluded by EXDATE are skipped, as well as occurrences falling on missing days, e.g. 31st of April.
+func (rule recurrence) occurrences(event Event, exDates []time.Time, from, until time.Time) []Event {
+	result := make([]Event, 0)
+	found := 0
+
+	for n := 0; ; n++ {
+		start := event.Start.AddDate(rule.years*n, rule.months*n, rule.days*n)
+		if !start.Before(until) || (!rule.until.IsZero() && start.After(rule.until)) {
+			break
+		}
+
+		if (rule.years != 0 || rule.months != 0) && start.Day() != event.Start.Day() {
+			continue
+		}
+
+		found++
+		if rule.count > 0 && found > rule.count {
+			break
+		}
+
+		end := event.End.AddDate(rule.years*n, rule.months*n, rule.days*n)
+		if end.After(from) && !slices.ContainsFunc(exDates, start.Equal) {
+			result = append(result, Event{Summary: event.Summary, Start: start, End: end})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,84 @@
+package moira
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	incidentKeyPrefix     = "moira/"
+	incidentKeySeparator  = "/"
+	incidentKeyHashPrefix = "sha256:"
+
+	// IncidentKeyMaxLength is the max length of incident key, it fits both PagerDuty dedup key and OpsGenie alias limits.
+	IncidentKeyMaxLength = 255
+)
+
+// IncidentKey returns the stable key of the incident opened in incident management tools (PagerDuty, OpsGenie)
+// for the metric of the trigger. Empty metric means the incident of the whole trigger.
+// The metric is replaced by its hash if the key does not fit IncidentKeyMaxLength.
+func IncidentKey(triggerID, metric string) string {
+	key := incidentKeyPrefix + triggerID
+	if metric == "" {
+		return key
+	}
+
+	if len(key)+len(incidentKeySeparator)+len(metric) > IncidentKeyMaxLength {
+		hash := sha256.Sum256([]byte(metric))
+		metric = incidentKeyHashPrefix + hex.EncodeToString(hash[:])
+	}
+
+	return key + incidentKeySeparator + metric
+}
+
+// ParseIncidentKey returns trigger id and metric from the incident key made by IncidentKey.
+// Metric is empty for the incident of the whole trigger and is hashed if it did not fit into the key.
+func ParseIncidentKey(key string) (triggerID, metric string, err error) {
+	if !strings.HasPrefix(key, incidentKeyPrefix) {
+		return "", "", fmt.Errorf("incident key %s is not made by moira", key)
+	}
+
+	triggerID, metric, _ = strings.Cut(strings.TrimPrefix(key, incidentKeyPrefix), incidentKeySeparator)
+	if triggerID == "" {
+		return "", "", fmt.Errorf("incident key %s has no trigger id", key)
+	}
+
+	return triggerID, metric, nil
+}
+
+// IncidentKey returns the key of the incident the event belongs to.
+func (event *NotificationEvent) IncidentKey() string {
+	if event.IsTriggerEvent {
+		return IncidentKey(event.TriggerID, "")
+	}
+
+	return IncidentKey(event.TriggerID, event.Metric)
+}
+
+// GroupByIncident splits events by incidents they belong to, keeping the order of events.
+func (events NotificationEvents) GroupByIncident() []NotificationEvents {
+	groups := make([]NotificationEvents, 0)
+	groupIndexes := make(map[string]int)
+
+	for _, event := range events {
+		key := event.IncidentKey()
+
+		index, ok := groupIndexes[key]
+		if !ok {
+			index = len(groups)
+			groupIndexes[key] = index
+			groups = append(groups, NotificationEvents{})
+		}
+
+		groups[index] = append(groups[index], event)
+	}
+
+	return groups
+}
+
+// IsIncidentResolved returns true if the last event of the incident is OK.
+func (events NotificationEvents) IsIncidentResolved() bool {
+	return len(events) != 0 && events.getLastState() == StateOK
+}
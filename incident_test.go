@@ -0,0 +1,77 @@
+package moira
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIncidentKey(t *testing.T) {
+	Convey("Test incident key", t, func() {
+		Convey("Key of the metric", func() {
+			key := IncidentKey("trigger-id", "server.web1.cpu")
+			So(key, ShouldEqual, "moira/trigger-id/server.web1.cpu")
+
+			triggerID, metric, err := ParseIncidentKey(key)
+			So(err, ShouldBeNil)
+			So(triggerID, ShouldEqual, "trigger-id")
+			So(metric, ShouldEqual, "server.web1.cpu")
+		})
+
+		Convey("Key of the whole trigger", func() {
+			key := IncidentKey("trigger-id", "")
+			So(key, ShouldEqual, "moira/trigger-id")
+
+			triggerID, metric, err := ParseIncidentKey(key)
+			So(err, ShouldBeNil)
+			So(triggerID, ShouldEqual, "trigger-id")
+			So(metric, ShouldBeEmpty)
+		})
+
+		Convey("Key of the metric with slashes", func() {
+			triggerID, metric, err := ParseIncidentKey(IncidentKey("trigger-id", "http/api/v1"))
+			So(err, ShouldBeNil)
+			So(triggerID, ShouldEqual, "trigger-id")
+			So(metric, ShouldEqual, "http/api/v1")
+		})
+
+		Convey("Too long metric is hashed", func() {
+			longMetric := strings.Repeat("metric.", 50)
+			key := IncidentKey("trigger-id", longMetric)
+			So(len(key), ShouldBeLessThanOrEqualTo, IncidentKeyMaxLength)
+			So(key, ShouldStartWith, "moira/trigger-id/sha256:")
+			So(IncidentKey("trigger-id", longMetric), ShouldEqual, key)
+		})
+
+		Convey("Foreign key can not be parsed", func() {
+			_, _, err := ParseIncidentKey("some-alias")
+			So(err, ShouldNotBeNil)
+
+			_, _, err = ParseIncidentKey("moira/")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGroupByIncident(t *testing.T) {
+	Convey("Test grouping events by incident", t, func() {
+		events := NotificationEvents{
+			{TriggerID: "trigger", Metric: "m1", State: StateERROR},
+			{TriggerID: "trigger", Metric: "m2", State: StateWARN},
+			{TriggerID: "trigger", Metric: "m1", State: StateOK},
+			{TriggerID: "trigger", Metric: "Trigger Name", State: StateNODATA, IsTriggerEvent: true},
+		}
+
+		groups := events.GroupByIncident()
+		So(groups, ShouldResemble, []NotificationEvents{
+			{events[0], events[2]},
+			{events[1]},
+			{events[3]},
+		})
+		So(groups[0].IsIncidentResolved(), ShouldBeTrue)
+		So(groups[1].IsIncidentResolved(), ShouldBeFalse)
+		So(groups[2][0].IncidentKey(), ShouldEqual, "moira/trigger")
+		So(NotificationEvents{}.IsIncidentResolved(), ShouldBeFalse)
+	})
+}
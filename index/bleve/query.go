@@ -18,6 +18,7 @@ func buildSearchQuery(options moira.SearchOptions) query.Query {
 	searchQueries = append(searchQueries, buildQueryForOnlyErrors(options.OnlyProblems)...)
 	searchQueries = append(searchQueries, buildQueryForCreatedBy(options.CreatedBy)...)
 	searchQueries = append(searchQueries, buildQueryForTeamID(options.TeamID)...)
+	searchQueries = append(searchQueries, buildQueryForOwnership(options.Ownership)...)
 
 	if len(searchQueries) == 0 {
 		return bleve.NewMatchAllQuery()
@@ -75,6 +76,24 @@ func buildQueryForTeamID(teamID string) (searchQueries []query.Query) {
 	return searchQueries
 }
 
+func buildQueryForOwnership(ownership moira.TriggerOwnership) (searchQueries []query.Query) {
+	// Triggers without team have no terms in team field, so any term there means that the trigger is owned by a team.
+	ownedByTeam := bleve.NewRegexpQuery(".+")
+	ownedByTeam.FieldVal = mapping.TriggerTeamID.GetName()
+
+	switch ownership {
+	case moira.TriggerOwnershipTeam:
+		searchQueries = append(searchQueries, ownedByTeam)
+	case moira.TriggerOwnershipPersonal:
+		qr := bleve.NewBooleanQuery()
+		qr.AddMust(bleve.NewMatchAllQuery())
+		qr.AddMustNot(ownedByTeam)
+		searchQueries = append(searchQueries, qr)
+	}
+
+	return searchQueries
+}
+
 func buildQueryForOnlyErrors(onlyErrors bool) (searchQueries []query.Query) {
 	if !onlyErrors {
 		return searchQueries
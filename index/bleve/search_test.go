@@ -203,6 +203,37 @@ func TestTriggerIndex_Search(t *testing.T) {
 			So(count, ShouldEqual, 1)
 			So(err, ShouldBeNil)
 		})
+
+		Convey("OnlyErrors = false, no tags, no text, owned by team", func() {
+			searchOptions.OnlyProblems = false
+			searchOptions.SearchString = ""
+			searchOptions.Tags = make([]string, 0)
+			searchOptions.CreatedBy = ""
+			searchOptions.TeamID = ""
+			searchOptions.Ownership = moira.TriggerOwnershipTeam
+
+			searchResults, count, err := newIndex.Search(searchOptions)
+			So(searchResults, ShouldResemble, triggerTestCases.ToSearchResults(searchOptions.SearchString)[2:5])
+			So(count, ShouldEqual, 3)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("OnlyErrors = false, no tags, no text, personal", func() {
+			searchOptions.OnlyProblems = false
+			searchOptions.SearchString = ""
+			searchOptions.Tags = make([]string, 0)
+			searchOptions.CreatedBy = ""
+			searchOptions.TeamID = ""
+			searchOptions.Ownership = moira.TriggerOwnershipPersonal
+
+			allResults := triggerTestCases.ToSearchResults(searchOptions.SearchString)
+			expected := append(allResults[:2:2], allResults[5:]...)
+
+			searchResults, count, err := newIndex.Search(searchOptions)
+			So(searchResults, ShouldResemble, expected)
+			So(count, ShouldEqual, 29)
+			So(err, ShouldBeNil)
+		})
 	})
 
 	Convey("Search for triggers with pagination", t, func() {
@@ -108,6 +108,16 @@ type Database interface {
 	SetUsernameChat(messenger, username, chatRaw string) error
 	RemoveUser(messenger, username string) error
 
+	// Notification threads storing
+	GetNotificationThread(senderType, triggerID, chatID string) (string, error)
+	SetNotificationThread(senderType, triggerID, chatID, threadID string) error
+	RemoveNotificationThread(senderType, triggerID, chatID string) error
+
+	// Incident maintenance storing
+	SetIncidentMaintenance(incidentKey string, maintenance int64) error
+	GetIncidentMaintenance(incidentKey string) (int64, error)
+	RemoveIncidentMaintenance(incidentKey string) error
+
 	// Triggers without subscription manipulation
 	MarkTriggersAsUnused(triggerIDs ...string) error
 	GetUnusedTriggerIDs() ([]string, error)
@@ -130,6 +140,24 @@ type Database interface {
 	GetTeamUsers(teamID string) ([]string, error)
 	IsTeamContainUser(teamID, userID string) (bool, error)
 	DeleteTeam(teamID, userID string) error
+	SaveTeamUserRole(teamID, userID string, role TeamRole) error
+	GetTeamUserRoles(teamID string) (map[string]TeamRole, error)
+	SaveTeamPreferences(teamID string, preferences TeamPreferences) error
+	GetTeamPreferences(teamID string) (TeamPreferences, error)
+	SaveTeamOnCallSchedule(teamID string, schedule OnCallSchedule) error
+	GetTeamOnCallSchedule(teamID string) (OnCallSchedule, error)
+
+	// MaintenanceWindow storing
+	SaveMaintenanceWindow(window *MaintenanceWindow) error
+	GetMaintenanceWindow(windowID string) (MaintenanceWindow, error)
+	GetMaintenanceWindows() ([]*MaintenanceWindow, error)
+	RemoveMaintenanceWindow(windowID string) error
+
+	// Silence storing
+	SaveSilence(silence *Silence) error
+	GetSilence(silenceID string) (Silence, error)
+	GetSilences() ([]*Silence, error)
+	RemoveSilence(silenceID string) error
 
 	// Metrics management
 	CleanUpOutdatedMetrics(duration time.Duration) error
@@ -150,6 +178,49 @@ type Database interface {
 
 	// ScheduledNotification storing
 	ScheduledNotificationsDatabase
+
+	// DeadLetterNotification storing
+	DeadLetterNotificationsDatabase
+
+	// APIToken and AuthSession storing
+	AuthDatabase
+}
+
+// AuthDatabase is used by api to store API tokens and sessions of users logged in with OpenID Connect.
+// Tokens and sessions are looked up by hashes of their secrets, the secrets themselves are never stored.
+type AuthDatabase interface {
+	// SaveAPIToken must save token and index it by its hash and by its user or team.
+	SaveAPIToken(token *APIToken) error
+	// GetAPIToken must return token by its id or database.ErrNil if there is no such token.
+	GetAPIToken(tokenID string) (APIToken, error)
+	// GetAPITokenByHash must return token by hash of its secret or database.ErrNil if there is no such token.
+	GetAPITokenByHash(hash string) (APIToken, error)
+	// GetUserAPITokens must return personal tokens of the user.
+	GetUserAPITokens(userLogin string) ([]*APIToken, error)
+	// GetTeamAPITokens must return tokens of the team.
+	GetTeamAPITokens(teamID string) ([]*APIToken, error)
+	// RemoveAPIToken must remove token and all its indexes.
+	RemoveAPIToken(tokenID string) error
+	// SaveAuthSession must save session by hash of its secret until session.ExpiresAt.
+	SaveAuthSession(sessionHash string, session AuthSession) error
+	// GetAuthSession must return not expired session by hash of its secret or database.ErrNil if there is no such session.
+	GetAuthSession(sessionHash string) (AuthSession, error)
+	// RemoveAuthSession must remove session by hash of its secret.
+	RemoveAuthSession(sessionHash string) error
+}
+
+// DeadLetterNotificationsDatabase is used to store notifications that notifier failed to deliver, to inspect, replay or purge them later.
+type DeadLetterNotificationsDatabase interface {
+	// AddDeadLetterNotification must push notification to the head of dead letter queue and trim queue to maxSize (no limit if maxSize <= 0).
+	AddDeadLetterNotification(notification *DeadLetterNotification, maxSize int64) error
+	// GetDeadLetterNotifications must return notifications from dead letter queue in given range of indices and total queue length.
+	GetDeadLetterNotifications(start, end int64) ([]*DeadLetterNotification, int64, error)
+	// GetDeadLetterNotification must return notification from dead letter queue by its id or database.ErrNil if there is no such notification.
+	GetDeadLetterNotification(id string) (DeadLetterNotification, error)
+	// RemoveDeadLetterNotification must remove notification from dead letter queue by its id and return count of removed notifications.
+	RemoveDeadLetterNotification(id string) (int64, error)
+	// RemoveAllDeadLetterNotifications must purge dead letter queue.
+	RemoveAllDeadLetterNotifications() error
 }
 
 // ScheduledNotificationsDatabase is used to schedule and fetch notifications, as well as to view list of all notifications and delete some when needed.
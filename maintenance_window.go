@@ -0,0 +1,126 @@
+package moira
+
+import (
+	"time"
+)
+
+// MaintenanceFrequency is a period with which maintenance window recurs.
+type MaintenanceFrequency string
+
+// Available frequencies of maintenance window recurrence.
+const (
+	MaintenanceFrequencyDaily  MaintenanceFrequency = "daily"
+	MaintenanceFrequencyWeekly MaintenanceFrequency = "weekly"
+)
+
+var maintenanceFrequencyDays = map[MaintenanceFrequency]int{
+	MaintenanceFrequencyDaily:  1,
+	MaintenanceFrequencyWeekly: 7, //nolint
+}
+
+// GetDays returns length of the recurrence period in days or 0 if the frequency is unknown.
+func (frequency MaintenanceFrequency) GetDays() int {
+	return maintenanceFrequencyDays[frequency]
+}
+
+// MaintenanceWindow is a scheduled maintenance of triggers and metrics which checker applies automatically.
+// The window targets triggers by ids, triggers having all the tags and metrics matching graphite-like patterns of trigger targets.
+type MaintenanceWindow struct {
+	ID         string                 `json:"id" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
+	Name       string                 `json:"name" example:"Database backups"`
+	Comment    string                 `json:"comment,omitempty" example:"Replication lag grows during backups"`
+	Start      int64                  `json:"start" example:"1704589200" format:"int64"`
+	End        int64                  `json:"end" example:"1704596400" format:"int64"`
+	Recurrence *MaintenanceRecurrence `json:"recurrence,omitempty" extensions:"x-nullable"`
+	TriggerIDs []string               `json:"trigger_ids,omitempty"`
+	Tags       []string               `json:"tags,omitempty" example:"postgres"`
+	Patterns   []string               `json:"patterns,omitempty" example:"db.*.replication_lag"`
+	CreatedBy  string                 `json:"created_by" example:"john"`
+	CreatedAt  int64                  `json:"created_at" example:"1704067200" format:"int64"`
+}
+
+// MaintenanceRecurrence describes how the first occurrence of maintenance window repeats.
+// Occurrences start at the same local time of the day in the timezone, so they follow daylight saving time.
+type MaintenanceRecurrence struct {
+	Frequency MaintenanceFrequency `json:"frequency" swaggertype:"string" example:"weekly"`
+	Timezone  string               `json:"timezone,omitempty" example:"Europe/Berlin"`
+	// Until is the time after which no occurrence starts, 0 means that the window recurs forever.
+	Until int64 `json:"until,omitempty" example:"0" format:"int64"`
+}
+
+// GetLocation returns location of the recurrence, UTC if timezone is not set.
+func (recurrence *MaintenanceRecurrence) GetLocation() (*time.Location, error) {
+	return time.LoadLocation(recurrence.Timezone)
+}
+
+// MaintenanceOccurrence is a single occurrence of maintenance window, from inclusive and to exclusive.
+type MaintenanceOccurrence struct {
+	Window *MaintenanceWindow
+	Start  int64
+	End    int64
+}
+
+// GetOccurrence returns occurrence of the window which is active at the given time.
+func (window *MaintenanceWindow) GetOccurrence(timestamp int64) (MaintenanceOccurrence, bool) {
+	if timestamp < window.Start {
+		return MaintenanceOccurrence{}, false
+	}
+
+	occurrence := MaintenanceOccurrence{
+		Window: window,
+		Start:  window.Start,
+		End:    window.End,
+	}
+
+	if window.Recurrence != nil {
+		days := window.Recurrence.Frequency.GetDays()
+		if days == 0 {
+			return MaintenanceOccurrence{}, false
+		}
+
+		location, err := window.Recurrence.GetLocation()
+		if err != nil {
+			return MaintenanceOccurrence{}, false
+		}
+
+		first := time.Unix(window.Start, 0).In(location)
+		period := int((timestamp - window.Start) / (int64(days) * int64((24 * time.Hour).Seconds())))
+
+		// Periods are counted in seconds, so the estimation is corrected for daylight saving time shifts
+		start := first.AddDate(0, 0, period*days)
+		if start.Unix() > timestamp {
+			start = first.AddDate(0, 0, (period-1)*days)
+		} else if next := first.AddDate(0, 0, (period+1)*days); next.Unix() <= timestamp {
+			start = next
+		}
+
+		if window.Recurrence.Until != 0 && start.Unix() > window.Recurrence.Until {
+			return MaintenanceOccurrence{}, false
+		}
+
+		occurrence.Start = start.Unix()
+		occurrence.End = occurrence.Start + window.End - window.Start
+	}
+
+	return occurrence, timestamp < occurrence.End
+}
+
+// IsFinished returns true if the window has no occurrences after the given time.
+func (window *MaintenanceWindow) IsFinished(timestamp int64) bool {
+	if window.Recurrence == nil {
+		return window.End <= timestamp
+	}
+
+	return window.Recurrence.Until != 0 && window.Recurrence.Until+window.End-window.Start <= timestamp
+}
+
+// IsTriggerMatched returns true if the window targets the whole trigger by its id or tags.
+func (window *MaintenanceWindow) IsTriggerMatched(trigger *Trigger) bool {
+	for _, triggerID := range window.TriggerIDs {
+		if triggerID == trigger.ID {
+			return true
+		}
+	}
+
+	return len(window.Tags) != 0 && Subset(window.Tags, trigger.Tags)
+}
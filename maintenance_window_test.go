@@ -0,0 +1,102 @@
+package moira
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMaintenanceWindow_GetOccurrence(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	Convey("Test GetOccurrence function", t, func() {
+		Convey("Single window is active from start to end", func() {
+			window := &MaintenanceWindow{Start: 1000, End: 2000}
+
+			_, ok := window.GetOccurrence(999)
+			So(ok, ShouldBeFalse)
+
+			occurrence, ok := window.GetOccurrence(1000)
+			So(ok, ShouldBeTrue)
+			So(occurrence, ShouldResemble, MaintenanceOccurrence{Window: window, Start: 1000, End: 2000})
+
+			_, ok = window.GetOccurrence(2000)
+			So(ok, ShouldBeFalse)
+		})
+
+		// Every Sunday 01:00 - 03:00, daylight saving time starts on 31 March 2024
+		window := &MaintenanceWindow{
+			Start: time.Date(2024, 3, 24, 1, 0, 0, 0, berlin).Unix(),
+			End:   time.Date(2024, 3, 24, 3, 0, 0, 0, berlin).Unix(),
+			Recurrence: &MaintenanceRecurrence{
+				Frequency: MaintenanceFrequencyWeekly,
+				Timezone:  "Europe/Berlin",
+			},
+		}
+
+		Convey("Weekly window recurs at the same local time", func() {
+			occurrence, ok := window.GetOccurrence(time.Date(2024, 4, 7, 1, 30, 0, 0, berlin).Unix())
+			So(ok, ShouldBeTrue)
+			So(occurrence.Start, ShouldEqual, time.Date(2024, 4, 7, 1, 0, 0, 0, berlin).Unix())
+			So(occurrence.End, ShouldEqual, time.Date(2024, 4, 7, 3, 0, 0, 0, berlin).Unix())
+
+			_, ok = window.GetOccurrence(time.Date(2024, 4, 7, 0, 30, 0, 0, berlin).Unix())
+			So(ok, ShouldBeFalse)
+
+			_, ok = window.GetOccurrence(time.Date(2024, 4, 8, 1, 30, 0, 0, berlin).Unix())
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Weekly window does not recur after until", func() {
+			window.Recurrence.Until = time.Date(2024, 4, 1, 0, 0, 0, 0, berlin).Unix()
+
+			_, ok := window.GetOccurrence(time.Date(2024, 3, 31, 1, 30, 0, 0, berlin).Unix())
+			So(ok, ShouldBeTrue)
+
+			_, ok = window.GetOccurrence(time.Date(2024, 4, 7, 1, 30, 0, 0, berlin).Unix())
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Daily window recurs every day", func() {
+			window.Recurrence.Frequency = MaintenanceFrequencyDaily
+
+			occurrence, ok := window.GetOccurrence(time.Date(2024, 4, 2, 2, 59, 0, 0, berlin).Unix())
+			So(ok, ShouldBeTrue)
+			So(occurrence.Start, ShouldEqual, time.Date(2024, 4, 2, 1, 0, 0, 0, berlin).Unix())
+		})
+
+		Convey("Window with unknown timezone is never active", func() {
+			window.Recurrence.Timezone = "Mars/Olympus"
+
+			_, ok := window.GetOccurrence(window.Start)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestMaintenanceWindow_IsFinished(t *testing.T) {
+	Convey("Test IsFinished function", t, func() {
+		window := &MaintenanceWindow{Start: 1000, End: 2000}
+		So(window.IsFinished(1999), ShouldBeFalse)
+		So(window.IsFinished(2000), ShouldBeTrue)
+
+		window.Recurrence = &MaintenanceRecurrence{Frequency: MaintenanceFrequencyDaily}
+		So(window.IsFinished(1000000), ShouldBeFalse)
+
+		window.Recurrence.Until = 5000
+		So(window.IsFinished(5999), ShouldBeFalse)
+		So(window.IsFinished(6000), ShouldBeTrue)
+	})
+}
+
+func TestMaintenanceWindow_IsMatched(t *testing.T) {
+	Convey("Test maintenance window targets", t, func() {
+		trigger := &Trigger{ID: "trigger1", Tags: []string{"postgres", "production"}}
+
+		So((&MaintenanceWindow{TriggerIDs: []string{"trigger2", "trigger1"}}).IsTriggerMatched(trigger), ShouldBeTrue)
+		So((&MaintenanceWindow{Tags: []string{"postgres"}}).IsTriggerMatched(trigger), ShouldBeTrue)
+		So((&MaintenanceWindow{Tags: []string{"postgres", "staging"}}).IsTriggerMatched(trigger), ShouldBeFalse)
+		So((&MaintenanceWindow{Patterns: []string{"*"}}).IsTriggerMatched(trigger), ShouldBeFalse)
+	})
+}
@@ -15,8 +15,9 @@ import (
 )
 
 type evaluator struct {
-	database moira.Database
-	metrics  []string
+	database    moira.Database
+	valuesCache *ValuesCache
+	metrics     []string
 }
 
 func (eval *evaluator) fetchAndEval(target string, from, until int64, result *FetchResult) (err error) {
@@ -59,7 +60,7 @@ func (eval *evaluator) Fetch(
 
 	for _, exp := range exprs {
 		ms := exp.Metrics(from, until)
-		if err := fetch.getMetricsData(eval.database, ms); err != nil {
+		if err := fetch.getMetricsData(fetchData{eval.database, eval.valuesCache}, ms); err != nil {
 			return nil, err
 		}
 	}
@@ -173,9 +174,7 @@ func newFetchCtx(from, until int64) *fetchCtx {
 	}
 }
 
-func (ctx *fetchCtx) getMetricsData(database moira.Database, metricRequests []parser.MetricRequest) error {
-	fetchData := fetchData{database}
-
+func (ctx *fetchCtx) getMetricsData(fetchData fetchData, metricRequests []parser.MetricRequest) error {
 	for _, mr := range metricRequests {
 		// Other fields are used in carbon for database side consolidations
 		request := parser.MetricRequest{
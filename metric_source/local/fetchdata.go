@@ -12,7 +12,8 @@ import (
 const DefaultRetention = 60
 
 type fetchData struct {
-	database moira.Database
+	database    moira.Database
+	valuesCache *ValuesCache
 }
 
 type metricsWithRetention struct {
@@ -43,7 +44,7 @@ func (fd *fetchData) fetchMetricValues(pattern string, metrics *metricsWithReten
 		return fetchDataNoMetrics(timer, pattern), nil
 	}
 
-	dataList, err := fd.database.GetMetricsValues(metrics.metrics, timer.startTime, timer.stopTime-1)
+	dataList, err := fd.getMetricsValues(metrics, timer)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +59,14 @@ func (fd *fetchData) fetchMetricValues(pattern string, metrics *metricsWithReten
 	return metricsData, nil
 }
 
+func (fd *fetchData) getMetricsValues(metrics *metricsWithRetention, timer timer) (map[string][]*moira.MetricValue, error) {
+	if fd.valuesCache == nil {
+		return fd.database.GetMetricsValues(metrics.metrics, timer.startTime, timer.stopTime-1)
+	}
+
+	return fd.valuesCache.getMetricsValues(fd.database, metrics.metrics, timer.startTime, timer.stopTime-1, metrics.retention)
+}
+
 func fetchDataNoMetrics(timer timer, pattern string) []*types.MetricData {
 	dataList := map[string][]*moira.MetricValue{pattern: make([]*moira.MetricValue, 0)}
 	valuesMap := unpackMetricsValues(dataList, timer)
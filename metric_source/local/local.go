@@ -9,17 +9,24 @@ import (
 
 // Local is implementation of MetricSource interface, which implements fetch metrics method from moira database installation.
 type Local struct {
-	database moira.Database
+	database    moira.Database
+	valuesCache *ValuesCache
 }
 
 // Create configures local metric source.
 func Create(dataBase moira.Database) metricSource.MetricSource {
+	return CreateWithValuesCache(dataBase, nil)
+}
+
+// CreateWithValuesCache configures local metric source, which reads metric values through given cache.
+func CreateWithValuesCache(dataBase moira.Database, valuesCache *ValuesCache) metricSource.MetricSource {
 	// configure carbon-api functions
 	rewrite.New(make(map[string]string))
 	functions.New(make(map[string]string))
 
 	return &Local{
-		database: dataBase,
+		database:    dataBase,
+		valuesCache: valuesCache,
 	}
 }
 
@@ -45,7 +52,7 @@ func (local *Local) Fetch(target string, from int64, until int64, allowRealTimeA
 	from = moira.MaxInt64(from, until-local.database.GetMetricsTTLSeconds())
 
 	result := CreateEmptyFetchResult()
-	eval := evaluator{local.database, make([]string, 0)}
+	eval := evaluator{local.database, local.valuesCache, make([]string, 0)}
 
 	err := eval.fetchAndEval(target, from, until, result)
 	if err != nil {
@@ -643,7 +643,7 @@ func TestLocal_evalExpr(t *testing.T) {
 func evalWithNoMetricsHelper(mockCtrl *gomock.Controller, target string, from, until int64) (*FetchResult, error) {
 	database := mock_moira_alert.NewMockDatabase(mockCtrl)
 	database.EXPECT().GetPatternMetrics(gomock.Any()).Return([]string{}, nil).AnyTimes()
-	eval := evaluator{database, make([]string, 0)}
+	eval := evaluator{database, nil, make([]string, 0)}
 
 	result := CreateEmptyFetchResult()
 	err := eval.fetchAndEval(target, from, until, result)
@@ -0,0 +1,205 @@
+package local
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/metrics"
+)
+
+// ValuesCacheConfig defines the configuration of metric values cache.
+type ValuesCacheConfig struct {
+	// Size is the max number of cached (metric, from, until, retention) entries.
+	Size int
+	// TTL is the lifetime of cached entry. It also bounds the staleness of values
+	// if invalidation by metric event was missed.
+	TTL time.Duration
+}
+
+type valuesCacheKey struct {
+	metric    string
+	from      int64
+	until     int64
+	retention int64
+}
+
+// ValuesCache is a short-lived size-bounded cache of metric values read from database.
+// Triggers sharing the same patterns read the same series within one check cycle, so cache saves duplicate reads.
+// Entries are invalidated by metric events handled by this process, so cache is correct only with a single checker.
+type ValuesCache struct {
+	cache   *expirable.LRU[valuesCacheKey, []*moira.MetricValue]
+	metrics *metrics.CheckerMetrics
+
+	// readsMutex guards reads of values from database, so values read before invalidation are not cached.
+	// It is always locked before keysMutex.
+	readsMutex sync.Mutex
+	reads      map[string]*valuesRead
+
+	keysMutex sync.Mutex
+	keys      map[string]map[valuesCacheKey]struct{}
+}
+
+// valuesRead counts reads of values of the metric from database which are in progress,
+// generation is increased by invalidation of the metric during the reads.
+type valuesRead struct {
+	readers    int
+	generation uint64
+}
+
+// NewValuesCache creates new ValuesCache.
+func NewValuesCache(config ValuesCacheConfig, metrics *metrics.CheckerMetrics) *ValuesCache {
+	valuesCache := &ValuesCache{
+		metrics: metrics,
+		reads:   make(map[string]*valuesRead),
+		keys:    make(map[string]map[valuesCacheKey]struct{}),
+	}
+	valuesCache.cache = expirable.NewLRU(config.Size, valuesCache.onEvict, config.TTL)
+
+	return valuesCache
+}
+
+// Invalidate removes all cached values of given metric, values which are being read from database are not cached.
+func (valuesCache *ValuesCache) Invalidate(metric string) {
+	valuesCache.readsMutex.Lock()
+	defer valuesCache.readsMutex.Unlock()
+
+	if read, ok := valuesCache.reads[metric]; ok {
+		read.generation++
+	}
+
+	valuesCache.keysMutex.Lock()
+	keys := valuesCache.keys[metric]
+	delete(valuesCache.keys, metric)
+	valuesCache.keysMutex.Unlock()
+
+	for key := range keys {
+		valuesCache.cache.Remove(key)
+	}
+}
+
+// getMetricsValues returns values of given metrics, reading only missed ones from database.
+func (valuesCache *ValuesCache) getMetricsValues(
+	database moira.Database,
+	metrics []string,
+	from, until, retention int64,
+) (map[string][]*moira.MetricValue, error) {
+	result := make(map[string][]*moira.MetricValue, len(metrics))
+	missed := make([]string, 0)
+
+	for _, metric := range metrics {
+		values, ok := valuesCache.cache.Get(valuesCacheKey{metric, from, until, retention})
+		if !ok {
+			valuesCache.metrics.MetricValuesCacheMisses.Inc()
+			missed = append(missed, metric)
+
+			continue
+		}
+
+		valuesCache.metrics.MetricValuesCacheHits.Inc()
+		result[metric] = values
+	}
+
+	if len(missed) == 0 {
+		return result, nil
+	}
+
+	generations := valuesCache.startReads(missed)
+
+	fetched, err := database.GetMetricsValues(missed, from, until)
+	if err != nil {
+		valuesCache.finishReads(generations, nil, from, until, retention)
+		return nil, err
+	}
+
+	valuesCache.finishReads(generations, fetched, from, until, retention)
+
+	for _, metric := range missed {
+		result[metric] = fetched[metric]
+	}
+
+	return result, nil
+}
+
+// startReads registers reads of values of the metrics and returns their current generations.
+func (valuesCache *ValuesCache) startReads(metrics []string) map[string]uint64 {
+	valuesCache.readsMutex.Lock()
+	defer valuesCache.readsMutex.Unlock()
+
+	generations := make(map[string]uint64, len(metrics))
+
+	for _, metric := range metrics {
+		if _, ok := generations[metric]; ok {
+			continue
+		}
+
+		read, ok := valuesCache.reads[metric]
+		if !ok {
+			read = &valuesRead{}
+			valuesCache.reads[metric] = read
+		}
+
+		read.readers++
+		generations[metric] = read.generation
+	}
+
+	return generations
+}
+
+// finishReads caches fetched values of the metrics which were not invalidated since the start of the read.
+func (valuesCache *ValuesCache) finishReads(
+	generations map[string]uint64,
+	fetched map[string][]*moira.MetricValue,
+	from, until, retention int64,
+) {
+	valuesCache.readsMutex.Lock()
+	defer valuesCache.readsMutex.Unlock()
+
+	for metric, generation := range generations {
+		read := valuesCache.reads[metric]
+
+		if fetched != nil && read.generation == generation {
+			valuesCache.add(valuesCacheKey{metric, from, until, retention}, fetched[metric])
+		}
+
+		read.readers--
+		if read.readers == 0 {
+			delete(valuesCache.reads, metric)
+		}
+	}
+}
+
+// add caches the values, readsMutex must be locked.
+func (valuesCache *ValuesCache) add(key valuesCacheKey, values []*moira.MetricValue) {
+	valuesCache.keysMutex.Lock()
+
+	keys, ok := valuesCache.keys[key.metric]
+	if !ok {
+		keys = make(map[valuesCacheKey]struct{})
+		valuesCache.keys[key.metric] = keys
+	}
+
+	keys[key] = struct{}{}
+
+	valuesCache.keysMutex.Unlock()
+
+	// Eviction callback locks keysMutex, so it must be unlocked here
+	valuesCache.cache.Add(key, values)
+}
+
+func (valuesCache *ValuesCache) onEvict(key valuesCacheKey, _ []*moira.MetricValue) {
+	valuesCache.keysMutex.Lock()
+	defer valuesCache.keysMutex.Unlock()
+
+	keys, ok := valuesCache.keys[key.metric]
+	if !ok {
+		return
+	}
+
+	delete(keys, key)
+
+	if len(keys) == 0 {
+		delete(valuesCache.keys, key.metric)
+	}
+}
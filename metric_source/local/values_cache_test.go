@@ -0,0 +1,175 @@
+package local
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/metrics"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+type testCounter struct {
+	count int64
+}
+
+func (counter *testCounter) Count() int64 {
+	return counter.count
+}
+
+func (counter *testCounter) Inc() {
+	counter.count++
+}
+
+func TestValuesCache(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	database := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	var (
+		from      int64 = 60
+		until     int64 = 179
+		retention int64 = 60
+	)
+
+	metric1Values := []*moira.MetricValue{{RetentionTimestamp: 60, Timestamp: 61, Value: 1}}
+	metric2Values := []*moira.MetricValue{{RetentionTimestamp: 120, Timestamp: 121, Value: 2}}
+
+	newCache := func(size int) (*ValuesCache, *testCounter, *testCounter) {
+		hits, misses := &testCounter{}, &testCounter{}
+		checkerMetrics := &metrics.CheckerMetrics{
+			MetricValuesCacheHits:   hits,
+			MetricValuesCacheMisses: misses,
+		}
+
+		return NewValuesCache(ValuesCacheConfig{Size: size, TTL: time.Minute}, checkerMetrics), hits, misses
+	}
+
+	Convey("Test values cache", t, func() {
+		Convey("Second read is served from cache", func() {
+			valuesCache, hits, misses := newCache(10)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1", "metric2"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values}, nil)
+
+			values, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values})
+
+			values, err = valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values})
+			So(hits.Count(), ShouldEqual, 2)
+			So(misses.Count(), ShouldEqual, 2)
+		})
+
+		Convey("Only missed metrics are read from database", func() {
+			valuesCache, hits, misses := newCache(10)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values}, nil)
+			database.EXPECT().GetMetricsValues([]string{"metric2"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric2": metric2Values}, nil)
+
+			_, err := valuesCache.getMetricsValues(database, []string{"metric1"}, from, until, retention)
+			So(err, ShouldBeNil)
+
+			values, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values})
+			So(hits.Count(), ShouldEqual, 1)
+			So(misses.Count(), ShouldEqual, 2)
+		})
+
+		Convey("Different ranges and retentions are cached separately", func() {
+			valuesCache, _, _ := newCache(10)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values}, nil).Times(2)
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until+60).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values}, nil)
+
+			_, err := valuesCache.getMetricsValues(database, []string{"metric1"}, from, until, retention)
+			So(err, ShouldBeNil)
+			_, err = valuesCache.getMetricsValues(database, []string{"metric1"}, from, until, 10)
+			So(err, ShouldBeNil)
+			_, err = valuesCache.getMetricsValues(database, []string{"metric1"}, from, until+60, retention)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Invalidate drops all cached values of metric", func() {
+			valuesCache, _, _ := newCache(10)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1", "metric2"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values}, nil)
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric2Values}, nil)
+
+			_, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+
+			valuesCache.Invalidate("metric1")
+			So(valuesCache.keys, ShouldNotContainKey, "metric1")
+
+			values, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": metric2Values, "metric2": metric2Values})
+		})
+
+		Convey("Evicted entries are removed from metric keys", func() {
+			valuesCache, _, _ := newCache(1)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1", "metric2"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values}, nil)
+
+			_, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(valuesCache.cache.Len(), ShouldEqual, 1)
+			So(valuesCache.keys, ShouldNotContainKey, "metric1")
+			So(valuesCache.keys, ShouldContainKey, "metric2")
+		})
+
+		Convey("Database error is returned and nothing is cached", func() {
+			valuesCache, _, _ := newCache(10)
+			dbErr := errors.New("database error")
+
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until).Return(nil, dbErr)
+
+			_, err := valuesCache.getMetricsValues(database, []string{"metric1"}, from, until, retention)
+			So(err, ShouldEqual, dbErr)
+			So(valuesCache.cache.Len(), ShouldEqual, 0)
+			So(valuesCache.reads, ShouldBeEmpty)
+		})
+
+		Convey("Values read before invalidation are returned but not cached", func() {
+			valuesCache, _, _ := newCache(10)
+			newMetric1Values := []*moira.MetricValue{{RetentionTimestamp: 60, Timestamp: 62, Value: 3}}
+
+			database.EXPECT().GetMetricsValues([]string{"metric1", "metric2"}, from, until).
+				DoAndReturn(func([]string, int64, int64) (map[string][]*moira.MetricValue, error) {
+					// Metric event comes while values are being read
+					valuesCache.Invalidate("metric1")
+
+					return map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values}, nil
+				})
+
+			values, err := valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": metric1Values, "metric2": metric2Values})
+			So(valuesCache.keys, ShouldNotContainKey, "metric1")
+			So(valuesCache.keys, ShouldContainKey, "metric2")
+			So(valuesCache.reads, ShouldBeEmpty)
+
+			database.EXPECT().GetMetricsValues([]string{"metric1"}, from, until).
+				Return(map[string][]*moira.MetricValue{"metric1": newMetric1Values}, nil)
+
+			values, err = valuesCache.getMetricsValues(database, []string{"metric1", "metric2"}, from, until, retention)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, map[string][]*moira.MetricValue{"metric1": newMetric1Values, "metric2": metric2Values})
+		})
+	})
+}
@@ -0,0 +1,22 @@
+package loki
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira/metric_source/retries"
+)
+
+// Config represents config of the remote Loki cluster.
+type Config struct {
+	URL            string `validate:"required,url"`
+	CheckInterval  time.Duration
+	MetricsTTL     time.Duration
+	RequestTimeout time.Duration `validate:"required,gt=0s"`
+	User           string
+	Password       string
+	// Tenant is the default tenant passed in X-Scope-OrgID header, triggers may override it.
+	Tenant string
+	// AllowedTenants are tenants which triggers may set as metrics tenant, the default tenant is always allowed.
+	AllowedTenants []string
+	Retries        retries.Config
+}
@@ -0,0 +1,93 @@
+package loki
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/prometheus/common/model"
+)
+
+// FetchResult is the result of LogQL metric query.
+type FetchResult struct {
+	MetricsData []metricSource.MetricData
+}
+
+// GetMetricsData return all metrics data from fetch result.
+func (fetchResult *FetchResult) GetMetricsData() []metricSource.MetricData {
+	return fetchResult.MetricsData
+}
+
+// GetPatterns always returns error, because we can't fetch target patterns from Loki.
+func (*FetchResult) GetPatterns() ([]string, error) {
+	return make([]string, 0), fmt.Errorf("loki fetch result never returns patterns")
+}
+
+// GetPatternMetrics always returns error, because Loki fetch doesn't return base pattern metrics.
+func (*FetchResult) GetPatternMetrics() ([]string, error) {
+	return make([]string, 0), fmt.Errorf("loki fetch result never returns pattern metrics")
+}
+
+func convertToFetchResult(mat model.Matrix, from, until int64, allowRealTimeAlerting bool) *FetchResult {
+	result := FetchResult{
+		MetricsData: make([]metricSource.MetricData, 0, len(mat)),
+	}
+
+	for _, series := range mat {
+		values := series.Values
+		if !allowRealTimeAlerting && len(values) != 0 {
+			values = values[:len(values)-1]
+		}
+
+		result.MetricsData = append(result.MetricsData, convertSeries(series.Metric, values, from, until))
+	}
+
+	return &result
+}
+
+// convertSeries fills the gaps of the series with NaN, because Loki omits the steps without log lines.
+func convertSeries(labels model.Metric, samples []model.SamplePair, from, until int64) metricSource.MetricData {
+	start, stop := from, until
+	if len(samples) != 0 {
+		start = samples[0].Timestamp.Unix()
+		stop = samples[len(samples)-1].Timestamp.Unix()
+	}
+
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		expected := start + int64(len(values))*StepTimeSeconds
+		for ts := expected; ts < sample.Timestamp.Unix(); ts += StepTimeSeconds {
+			values = append(values, math.NaN())
+		}
+
+		values = append(values, float64(sample.Value))
+	}
+
+	return metricSource.MetricData{
+		Name:      nameFromLabels(labels),
+		StartTime: start,
+		StopTime:  stop,
+		StepTime:  StepTimeSeconds,
+		Values:    values,
+		Wildcard:  false,
+	}
+}
+
+// nameFromLabels makes graphite-like tagged metric name, e.g. "app=api;level=error".
+func nameFromLabels(labels model.Metric) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, string(key))
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+string(labels[model.LabelName(key)]))
+	}
+
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,39 @@
+package loki
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConvertToFetchResult(t *testing.T) {
+	Convey("Test converting loki matrix", t, func() {
+		mat := model.Matrix{
+			&model.SampleStream{
+				Metric: model.Metric{},
+				Values: []model.SamplePair{
+					{Timestamp: model.TimeFromUnix(testFrom), Value: 1},
+					{Timestamp: model.TimeFromUnix(testFrom + StepTimeSeconds), Value: 2},
+				},
+			},
+			&model.SampleStream{Metric: model.Metric{"level": "error"}},
+		}
+
+		Convey("With real time alerting", func() {
+			res := convertToFetchResult(mat, testFrom, testUntil, true)
+			So(res.GetMetricsData(), ShouldHaveLength, 2)
+			So(res.GetMetricsData()[0].Name, ShouldBeEmpty)
+			So(res.GetMetricsData()[0].Values, ShouldResemble, []float64{1, 2})
+			So(res.GetMetricsData()[1].Name, ShouldEqual, "level=error")
+			So(res.GetMetricsData()[1].StartTime, ShouldEqual, testFrom)
+			So(res.GetMetricsData()[1].StopTime, ShouldEqual, testUntil)
+			So(res.GetMetricsData()[1].Values, ShouldBeEmpty)
+		})
+
+		Convey("Without real time alerting the last value is trimmed", func() {
+			res := convertToFetchResult(mat, testFrom, testUntil, false)
+			So(res.GetMetricsData()[0].Values, ShouldResemble, []float64{1})
+		})
+	})
+}
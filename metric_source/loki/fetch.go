@@ -0,0 +1,20 @@
+package loki
+
+import (
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// Fetch runs LogQL metric query and converts its series to the metrics data.
+func (loki *Loki) Fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
+	from = moira.MaxInt64(from, until-int64(loki.config.MetricsTTL.Seconds()))
+	from -= from % StepTimeSeconds
+	until -= until % StepTimeSeconds
+
+	mat, err := loki.queryRange(target, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToFetchResult(mat, from, until, allowRealTimeAlerting), nil
+}
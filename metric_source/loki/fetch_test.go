@@ -0,0 +1,137 @@
+package loki
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira/logging/zerolog_adapter"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/retries"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testFrom  int64 = 1700000040
+	testUntil int64 = testFrom + 2*StepTimeSeconds
+)
+
+var testRetries = retries.Config{
+	InitialInterval: time.Millisecond,
+	Multiplier:      1,
+	MaxInterval:     time.Millisecond,
+	MaxRetriesCount: 2,
+}
+
+func TestLokiFetch(t *testing.T) {
+	logger, _ := zerolog_adapter.GetLogger("Test")
+
+	var (
+		requests   []*http.Request
+		statusCode int
+		body       string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests = append(requests, request)
+		writer.WriteHeader(statusCode)
+		writer.Write([]byte(body)) //nolint
+	}))
+	defer server.Close()
+
+	source, err := Create(&Config{
+		URL:            server.URL,
+		MetricsTTL:     time.Hour,
+		RequestTimeout: time.Second,
+		Tenant:         "default",
+		Retries:        testRetries,
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Test loki fetch", t, func() {
+		requests = nil
+
+		Convey("Metric query", func() {
+			statusCode = http.StatusOK
+			body = `{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"app":"api","reason":"oom"},"values":[[1700000040,"2"],[1700000160,"5"]]}
+			]}}`
+
+			res, err := source.Fetch(`sum by (app, reason) (count_over_time({app="api"} |= "OOM" [5m]))`, testFrom+10, testUntil+10, true)
+			So(err, ShouldBeNil)
+			So(res.GetMetricsData(), ShouldHaveLength, 1)
+
+			data := res.GetMetricsData()[0]
+			So(data.Name, ShouldEqual, "app=api;reason=oom")
+			So(data.StartTime, ShouldEqual, testFrom)
+			So(data.StopTime, ShouldEqual, testUntil)
+			So(data.Values, ShouldHaveLength, 3)
+			So(data.Values[0], ShouldEqual, 2)
+			So(math.IsNaN(data.Values[1]), ShouldBeTrue)
+			So(data.Values[2], ShouldEqual, 5)
+
+			So(requests, ShouldHaveLength, 1)
+			So(requests[0].URL.Path, ShouldEqual, "/loki/api/v1/query_range")
+			So(requests[0].URL.Query().Get("start"), ShouldEqual, "1700000040000000000")
+			So(requests[0].URL.Query().Get("end"), ShouldEqual, "1700000160000000000")
+			So(requests[0].URL.Query().Get("step"), ShouldEqual, "60")
+			So(requests[0].Header.Get("X-Scope-OrgID"), ShouldEqual, "default")
+		})
+
+		Convey("Tenant of the trigger", func() {
+			statusCode = http.StatusOK
+			body = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+
+			tenantSource := source.(metricSource.TenantMetricSource).WithTenant("team-a")
+
+			res, err := tenantSource.Fetch(`rate({app="api"}[5m])`, testFrom, testUntil, true)
+			So(err, ShouldBeNil)
+			So(res.GetMetricsData(), ShouldBeEmpty)
+			So(requests[0].Header.Get("X-Scope-OrgID"), ShouldEqual, "team-a")
+		})
+
+		Convey("Log query is not a metric query", func() {
+			statusCode = http.StatusOK
+			body = `{"status":"success","data":{"resultType":"streams","result":[]}}`
+
+			_, err := source.Fetch(`{app="api"}`, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+			So(requests, ShouldHaveLength, 1)
+		})
+
+		Convey("Invalid query is not retried", func() {
+			statusCode = http.StatusBadRequest
+			body = "parse error at line 1, col 1: syntax error"
+
+			_, err := source.Fetch(`{app=`, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+			So(requests, ShouldHaveLength, 1)
+		})
+
+		Convey("Unavailable loki is retried", func() {
+			statusCode = http.StatusBadGateway
+			body = "bad gateway"
+
+			_, err := source.Fetch(`rate({app="api"}[5m])`, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrLokiUnavailable{})
+			So(requests, ShouldHaveLength, 3)
+
+			available, err := source.IsAvailable()
+			So(available, ShouldBeFalse)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	logger, _ := zerolog_adapter.GetLogger("Test")
+
+	Convey("Test creating loki source with invalid config", t, func() {
+		_, err := Create(&Config{URL: "http://loki:3100"}, logger)
+		So(err, ShouldNotBeNil)
+	})
+}
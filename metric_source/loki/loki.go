@@ -0,0 +1,94 @@
+package loki
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/retries"
+	"github.com/prometheus/common/model"
+)
+
+// StepTimeSeconds is the step of LogQL range queries.
+const StepTimeSeconds int64 = 60
+
+// ErrInvalidQuery is returned when Loki rejects the query, e.g. LogQL syntax error or log query instead of metric one.
+type ErrInvalidQuery struct {
+	InternalError error
+	Target        string
+}
+
+// Error is a representation of Error interface method.
+func (err ErrInvalidQuery) Error() string {
+	return err.InternalError.Error()
+}
+
+// ErrLokiUnavailable is returned when Loki cluster is not available.
+type ErrLokiUnavailable struct {
+	InternalError error
+	Target        string
+}
+
+// Error is a representation of Error interface method.
+func (err ErrLokiUnavailable) Error() string {
+	return err.InternalError.Error()
+}
+
+// Loki is implementation of MetricSource interface, which runs LogQL metric queries, e.g. count_over_time or rate.
+type Loki struct {
+	config         *Config
+	logger         moira.Logger
+	client         *http.Client
+	retrier        retries.Retrier[model.Matrix]
+	backoffFactory retries.BackoffFactory
+	tenant         string
+}
+
+// Create configures Loki metric source.
+func Create(config *Config, logger moira.Logger) (metricSource.MetricSource, error) {
+	if err := moira.ValidateStruct(config); err != nil {
+		return nil, err
+	}
+
+	return &Loki{
+		config:         config,
+		logger:         logger,
+		client:         &http.Client{},
+		retrier:        retries.NewStandardRetrier[model.Matrix](),
+		backoffFactory: retries.NewExponentialBackoffFactory(config.Retries),
+		tenant:         config.Tenant,
+	}, nil
+}
+
+// WithTenant returns metric source which fetches metrics of the given tenant.
+func (loki *Loki) WithTenant(tenant string) metricSource.MetricSource {
+	tenantLoki := *loki
+	tenantLoki.tenant = tenant
+
+	return &tenantLoki
+}
+
+// IsTenantAllowed returns true if the tenant is the default tenant or one of allowed tenants of the cluster.
+func (loki *Loki) IsTenantAllowed(tenant string) bool {
+	return tenant == loki.config.Tenant || slices.Contains(loki.config.AllowedTenants, tenant)
+}
+
+// GetMetricsTTLSeconds returns maximum time interval that we are allowed to fetch from Loki.
+func (loki *Loki) GetMetricsTTLSeconds() int64 {
+	return int64(loki.config.MetricsTTL.Seconds())
+}
+
+// IsAvailable checks if Loki query api is available.
+func (loki *Loki) IsAvailable() (bool, error) {
+	until := time.Now().Unix()
+	from := until - StepTimeSeconds
+
+	_, err := loki.queryRange(`vector(1)`, from, until)
+
+	var errUnavailable ErrLokiUnavailable
+
+	return !errors.As(err, &errUnavailable), err
+}
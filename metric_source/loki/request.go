@@ -0,0 +1,149 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	queryRangePath = "/loki/api/v1/query_range"
+	tenantHeader   = "X-Scope-OrgID"
+	matrixResult   = "matrix"
+)
+
+type queryRangeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (loki *Loki) queryRange(target string, from, until int64) (model.Matrix, error) {
+	return loki.retrier.Retry(
+		queryRangeRequest{
+			loki:   loki,
+			target: target,
+			from:   from,
+			until:  until,
+		},
+		loki.backoffFactory.NewBackOff())
+}
+
+// queryRangeRequest implements retries.RetryableOperation.
+type queryRangeRequest struct {
+	loki   *Loki
+	target string
+	from   int64
+	until  int64
+}
+
+// DoRetryableOperation is a one attempt of performing range query to Loki.
+func (r queryRangeRequest) DoRetryableOperation() (model.Matrix, error) {
+	mat, err := r.do()
+	if err != nil {
+		r.loki.logger.Warning().
+			Error(err).
+			String("target", r.target).
+			Msg("Failed to fetch loki target")
+	}
+
+	return mat, err
+}
+
+func (r queryRangeRequest) do() (model.Matrix, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.loki.config.RequestTimeout)
+	defer cancel()
+
+	req, err := r.prepareRequest(ctx)
+	if err != nil {
+		return nil, backoff.Permanent(ErrInvalidQuery{InternalError: err, Target: r.target})
+	}
+
+	resp, err := r.loki.client.Do(req)
+	if err != nil {
+		return nil, ErrLokiUnavailable{
+			InternalError: fmt.Errorf("loki is not available or the response was reset by timeout: %w", err),
+			Target:        r.target,
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ErrLokiUnavailable{InternalError: err, Target: r.target}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusBadRequest:
+		return nil, backoff.Permanent(ErrInvalidQuery{
+			InternalError: fmt.Errorf("invalid loki query: %s", strings.TrimSpace(string(body))),
+			Target:        r.target,
+		})
+	default:
+		return nil, ErrLokiUnavailable{
+			InternalError: fmt.Errorf("loki is not available. Response status %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+			Target:        r.target,
+		}
+	}
+
+	mat, err := decodeBody(body)
+	if err != nil {
+		return nil, backoff.Permanent(ErrInvalidQuery{InternalError: err, Target: r.target})
+	}
+
+	return mat, nil
+}
+
+func (r queryRangeRequest) prepareRequest(ctx context.Context) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(r.loki.config.URL, "/")+queryRangePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("query", r.target)
+	q.Add("start", strconv.FormatInt(time.Unix(r.from, 0).UnixNano(), 10))
+	q.Add("end", strconv.FormatInt(time.Unix(r.until, 0).UnixNano(), 10))
+	q.Add("step", strconv.FormatInt(StepTimeSeconds, 10))
+	req.URL.RawQuery = q.Encode()
+
+	if r.loki.config.User != "" && r.loki.config.Password != "" {
+		req.SetBasicAuth(r.loki.config.User, r.loki.config.Password)
+	}
+
+	if r.loki.tenant != "" {
+		req.Header.Set(tenantHeader, r.loki.tenant)
+	}
+
+	return req, nil
+}
+
+func decodeBody(body []byte) (model.Matrix, error) {
+	var resp queryRangeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode loki response: %w", err)
+	}
+
+	if resp.Data.ResultType != matrixResult {
+		return nil, errors.New("loki query must be a metric query, e.g. count_over_time or rate, got result of type " + strconv.Quote(resp.Data.ResultType))
+	}
+
+	var mat model.Matrix
+	if err := json.Unmarshal(resp.Data.Result, &mat); err != nil {
+		return nil, fmt.Errorf("failed to decode loki matrix: %w", err)
+	}
+
+	return mat, nil
+}
@@ -2,11 +2,13 @@ package prometheus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	metricSource "github.com/moira-alert/moira/metric_source"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/moira-alert/moira"
 	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
@@ -14,35 +16,93 @@ import (
 
 func (prometheus *Prometheus) Fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
 	from = moira.MaxInt64(from, until-int64(prometheus.config.MetricsTTL.Seconds()))
+	from, until = alignToStep(from, until)
 
-	var err error
+	mat, err := prometheus.cachedQueryRange(target, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToFetchResult(mat, from, until, allowRealTimeAlerting), nil
+}
+
+// alignToStep aligns the time range to the step, so the same queries of different triggers
+// are evaluated at the same timestamps and may share the cached result.
+func alignToStep(from, until int64) (int64, int64) {
+	return from - from%StepTimeSeconds, until - until%StepTimeSeconds
+}
+
+func cacheKey(tenant, target string, from, until int64) string {
+	return fmt.Sprintf("%q:%d:%d:%d:%s", tenant, StepTimeSeconds, from, until, target)
+}
+
+func (prometheus *Prometheus) cachedQueryRange(target string, from, until int64) (model.Matrix, error) {
+	if prometheus.cache == nil {
+		return prometheus.queryRange(target, from, until)
+	}
+
+	key := cacheKey(prometheus.tenant, target, from, until)
+	if cached, ok := prometheus.cache.Get(key); ok {
+		return cached.(model.Matrix), nil
+	}
 
-	for i := 1; ; i++ {
-		var res metricSource.FetchResult
+	mat, err := prometheus.queryRange(target, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	prometheus.cache.SetDefault(key, mat)
+
+	return mat, nil
+}
+
+func (prometheus *Prometheus) queryRange(target string, from, until int64) (model.Matrix, error) {
+	return prometheus.retrier.Retry(
+		queryRangeOperation{
+			prometheus: prometheus,
+			target:     target,
+			from:       from,
+			until:      until,
+		},
+		prometheus.backoffFactory.NewBackOff())
+}
 
-		res, err = prometheus.fetch(target, from, until, allowRealTimeAlerting)
-		if err == nil {
-			return res, nil
-		}
+// queryRangeOperation implements retries.RetryableOperation.
+type queryRangeOperation struct {
+	prometheus *Prometheus
+	target     string
+	from       int64
+	until      int64
+}
 
-		prometheus.logger.Warning().
-			Error(err).
-			Int("retries left", prometheus.config.Retries-i).
-			String("target", target).
-			Msg("Failed to fetch prometheus target")
+// DoRetryableOperation is a one attempt of performing range query to prometheus.
+func (op queryRangeOperation) DoRetryableOperation() (model.Matrix, error) {
+	mat, err := op.prometheus.fetch(op.target, op.from, op.until)
+	if err == nil {
+		return mat, nil
+	}
 
-		if i >= prometheus.config.Retries {
-			break
-		}
+	op.prometheus.logger.Warning().
+		Error(err).
+		String("target", op.target).
+		Msg("Failed to fetch prometheus target")
 
-		time.Sleep(prometheus.config.RetryTimeout)
+	// Broken query will not be fixed by retries.
+	var apiErr *promApi.Error
+	if errors.As(err, &apiErr) && apiErr.Type == promApi.ErrBadData {
+		return nil, backoff.Permanent(err)
 	}
 
 	return nil, err
 }
 
-func (prometheus *Prometheus) fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), prometheus.config.RequestTimeout)
+func (prometheus *Prometheus) fetch(target string, from, until int64) (model.Matrix, error) {
+	if prometheus.requestsLimiter != nil {
+		prometheus.requestsLimiter <- struct{}{}
+		defer func() { <-prometheus.requestsLimiter }()
+	}
+
+	ctx, cancel := context.WithTimeout(withTenant(context.Background(), prometheus.tenant), prometheus.config.RequestTimeout)
 	defer cancel()
 
 	val, warns, err := prometheus.api.QueryRange(ctx, target, promApi.Range{
@@ -62,9 +122,7 @@ func (prometheus *Prometheus) fetch(target string, from, until int64, allowRealT
 		return nil, err
 	}
 
-	mat := val.(model.Matrix)
-
-	return convertToFetchResult(mat, from, until, allowRealTimeAlerting), nil
+	return val.(model.Matrix), nil
 }
 
 type FetchResult struct {
@@ -1,19 +1,54 @@
 package prometheus
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/moira-alert/moira/logging/zerolog_adapter"
 	metricsource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/retries"
 	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
 	"go.uber.org/mock/gomock"
 
 	"github.com/prometheus/common/model"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+const (
+	testFrom  int64 = 1700000040
+	testUntil int64 = testFrom + StepTimeSeconds
+)
+
+func testMatrix() model.Matrix {
+	return model.Matrix{
+		&model.SampleStream{
+			Metric: model.Metric{"__name__": "name1"},
+			Values: []model.SamplePair{
+				{Timestamp: model.TimeFromUnix(testFrom), Value: 3.14},
+				{Timestamp: model.TimeFromUnix(testUntil), Value: 2.71},
+			},
+		},
+	}
+}
+
+func testFetchResult() *FetchResult {
+	return &FetchResult{
+		MetricsData: []metricsource.MetricData{
+			{
+				Name:      "name1",
+				StartTime: testFrom,
+				StopTime:  testUntil,
+				StepTime:  60,
+				Values:    []float64{3.14, 2.71},
+				Wildcard:  false,
+			},
+		},
+	}
+}
+
 func TestPrometheusFetch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,55 +57,36 @@ func TestPrometheusFetch(t *testing.T) {
 
 	logger, _ := zerolog_adapter.GetLogger("Test")
 
-	now := time.Now()
-
-	prometheus := Prometheus{
-		config: &Config{},
-		api:    api,
-		logger: logger,
-	}
+	prometheus := newPrometheus(&Config{MetricsTTL: time.Hour}, api, logger)
 
 	Convey("Given two metric points", t, func() {
-		fromMilli := now.UnixMilli()
-		untilMilli := now.Add(time.Second * 60).UnixMilli()
+		api.EXPECT().QueryRange(gomock.Any(), "target", promApi.Range{
+			Start: time.Unix(testFrom, 0),
+			End:   time.Unix(testUntil, 0),
+			Step:  time.Minute,
+		}).Return(testMatrix(), nil, nil)
 
-		from := now.Unix()
-		until := now.Add(time.Second * 60).Unix()
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
 
-		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
-			Return(
-				model.Matrix{
-					&model.SampleStream{
-						Metric: model.Metric{"__name__": "name1"},
-						Values: []model.SamplePair{
-							{Timestamp: model.Time(fromMilli), Value: 3.14},
-							{Timestamp: model.Time(untilMilli), Value: 2.71},
-						},
-					},
-				},
-				nil,
-				nil,
-			)
-
-		res, err := prometheus.fetch("target", fromMilli, untilMilli, true)
+		So(err, ShouldBeNil)
+		So(res, ShouldResemble, testFetchResult())
+	})
+
+	Convey("Given time range not aligned to the step", t, func() {
+		api.EXPECT().QueryRange(gomock.Any(), "target", promApi.Range{
+			Start: time.Unix(testFrom, 0),
+			End:   time.Unix(testUntil, 0),
+			Step:  time.Minute,
+		}).Return(testMatrix(), nil, nil)
+
+		res, err := prometheus.Fetch("target", testFrom+10, testUntil+59, true)
 
 		So(err, ShouldBeNil)
-		So(res, ShouldResemble, &FetchResult{
-			MetricsData: []metricsource.MetricData{
-				{
-					Name:      "name1",
-					StartTime: from,
-					StopTime:  until,
-					StepTime:  60,
-					Values:    []float64{3.14, 2.71},
-					Wildcard:  false,
-				},
-			},
-		})
+		So(res, ShouldResemble, testFetchResult())
 	})
 }
 
-func TestPrometheusFetchRetries(t *testing.T) {
+func TestPrometheusFetchCache(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -78,67 +94,160 @@ func TestPrometheusFetchRetries(t *testing.T) {
 
 	logger, _ := zerolog_adapter.GetLogger("Test")
 
-	now := time.Now()
+	prometheus := newPrometheus(&Config{MetricsTTL: time.Hour, CacheTTL: time.Minute}, api, logger)
 
-	prometheus := Prometheus{
-		config: &Config{Retries: 3, RetryTimeout: time.Second * 0},
-		api:    api,
-		logger: logger,
-	}
+	Convey("Same target in the same step is fetched once", t, func() {
+		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).Return(testMatrix(), nil, nil).Times(1)
 
-	Convey("Given two metric points and two fails", t, func() {
-		fromMilli := now.UnixMilli()
-		untilMilli := now.Add(time.Second * 60).UnixMilli()
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
+		So(err, ShouldBeNil)
+		So(res, ShouldResemble, testFetchResult())
+
+		res, err = prometheus.Fetch("target", testFrom+5, testUntil+5, true)
+		So(err, ShouldBeNil)
+		So(res, ShouldResemble, testFetchResult())
+
+		Convey("Cached result is not changed by conversion", func() {
+			res, err = prometheus.Fetch("target", testFrom, testUntil, false)
+			So(err, ShouldBeNil)
+			So(res.GetMetricsData()[0].Values, ShouldResemble, []float64{3.14})
+
+			res, err = prometheus.Fetch("target", testFrom, testUntil, true)
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, testFetchResult())
+		})
+	})
+
+	Convey("Other target or time range is fetched again", t, func() {
+		api.EXPECT().QueryRange(gomock.Any(), "other", gomock.Any()).Return(testMatrix(), nil, nil).Times(1)
+		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).Return(testMatrix(), nil, nil).Times(1)
+
+		_, err := prometheus.Fetch("other", testFrom, testUntil, true)
+		So(err, ShouldBeNil)
+
+		_, err = prometheus.Fetch("target", testFrom, testUntil+StepTimeSeconds, true)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Failed request is not cached", t, func() {
+		api.EXPECT().QueryRange(gomock.Any(), "failed", gomock.Any()).Return(nil, nil, fmt.Errorf("Error")).Times(2)
+
+		_, err := prometheus.Fetch("failed", testFrom, testUntil, true)
+		So(err, ShouldNotBeNil)
+
+		_, err = prometheus.Fetch("failed", testFrom, testUntil, true)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestPrometheusFetchRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock_moira_alert.NewMockPrometheusApi(ctrl)
+
+	logger, _ := zerolog_adapter.GetLogger("Test")
 
-		from := now.Unix()
-		until := now.Add(time.Second * 60).Unix()
+	prometheus := newPrometheus(&Config{
+		MetricsTTL: time.Hour,
+		Retries: retries.Config{
+			InitialInterval: time.Millisecond,
+			Multiplier:      1,
+			MaxInterval:     time.Millisecond,
+			MaxRetriesCount: 2,
+		},
+	}, api, logger)
 
+	Convey("Given two metric points and two fails", t, func() {
 		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
 			Return(nil, nil, fmt.Errorf("Error")).Times(2)
 		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
-			Return(
-				model.Matrix{
-					&model.SampleStream{
-						Metric: model.Metric{"__name__": "name1"},
-						Values: []model.SamplePair{
-							{Timestamp: model.Time(fromMilli), Value: 3.14},
-							{Timestamp: model.Time(untilMilli), Value: 2.71},
-						},
-					},
-				},
-				nil,
-				nil,
-			)
-
-		res, err := prometheus.Fetch("target", fromMilli, untilMilli, true)
+			Return(testMatrix(), nil, nil)
+
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
 
 		So(err, ShouldBeNil)
-		So(res, ShouldResemble, &FetchResult{
-			MetricsData: []metricsource.MetricData{
-				{
-					Name:      "name1",
-					StartTime: from,
-					StopTime:  until,
-					StepTime:  60,
-					Values:    []float64{3.14, 2.71},
-					Wildcard:  false,
-				},
-			},
-		})
+		So(res, ShouldResemble, testFetchResult())
 	})
 
 	Convey("Given all requests failed", t, func() {
-		fromMilli := now.UnixMilli()
-		untilMilli := now.Add(time.Second * 60).UnixMilli()
-
 		expectedErr := fmt.Errorf("Error")
 
 		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
 			Return(nil, nil, expectedErr).Times(3)
 
-		res, err := prometheus.Fetch("target", fromMilli, untilMilli, true)
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
+
+		So(res, ShouldBeNil)
+		So(err, ShouldEqual, expectedErr)
+	})
+
+	Convey("Given bad query", t, func() {
+		expectedErr := &promApi.Error{Type: promApi.ErrBadData, Msg: "parse error"}
+
+		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
+			Return(nil, nil, expectedErr).Times(1)
+
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
+
+		So(res, ShouldBeNil)
+		So(err, ShouldEqual, expectedErr)
+	})
+
+	Convey("Given retries are not configured", t, func() {
+		prometheus := newPrometheus(&Config{MetricsTTL: time.Hour}, api, logger)
+		expectedErr := fmt.Errorf("Error")
+
+		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
+			Return(nil, nil, expectedErr).Times(1)
+
+		res, err := prometheus.Fetch("target", testFrom, testUntil, true)
 
 		So(res, ShouldBeNil)
 		So(err, ShouldEqual, expectedErr)
 	})
 }
+
+func TestPrometheusFetchWithTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock_moira_alert.NewMockPrometheusApi(ctrl)
+
+	logger, _ := zerolog_adapter.GetLogger("Test")
+
+	prometheus := newPrometheus(&Config{MetricsTTL: time.Hour, CacheTTL: time.Minute}, api, logger)
+
+	Convey("Tenants of the cluster do not share cached results", t, func() {
+		var tenants []string
+
+		api.EXPECT().QueryRange(gomock.Any(), "target", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string, _ promApi.Range, _ ...promApi.Option) (model.Value, promApi.Warnings, error) {
+				tenants = append(tenants, tenantFromContext(ctx))
+				return testMatrix(), nil, nil
+			}).Times(2)
+
+		_, err := prometheus.Fetch("target", testFrom, testUntil, true)
+		So(err, ShouldBeNil)
+
+		_, err = prometheus.WithTenant("team-a").Fetch("target", testFrom, testUntil, true)
+		So(err, ShouldBeNil)
+
+		_, err = prometheus.WithTenant("team-a").Fetch("target", testFrom, testUntil, true)
+		So(err, ShouldBeNil)
+
+		So(tenants, ShouldResemble, []string{"", "team-a"})
+	})
+}
+
+func TestPrometheusIsTenantAllowed(t *testing.T) {
+	logger, _ := zerolog_adapter.GetLogger("Test")
+
+	prometheus := newPrometheus(&Config{Tenant: "default", AllowedTenants: []string{"team-a"}}, nil, logger)
+
+	Convey("Only default and allowed tenants are allowed", t, func() {
+		So(prometheus.IsTenantAllowed("default"), ShouldBeTrue)
+		So(prometheus.IsTenantAllowed("team-a"), ShouldBeTrue)
+		So(prometheus.IsTenantAllowed("team-b"), ShouldBeFalse)
+	})
+}
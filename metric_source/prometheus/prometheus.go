@@ -2,40 +2,120 @@ package prometheus
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/moira-alert/moira"
 	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/retries"
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/common/model"
 )
 
 const StepTimeSeconds int64 = 60
 
+// DefaultCacheTTL is the default time the result of the query is shared between triggers.
+const DefaultCacheTTL = time.Duration(StepTimeSeconds) * time.Second
+
 var ErrPrometheusStorageDisabled = fmt.Errorf("remote prometheus storage is not enabled")
 
 type Config struct {
 	CheckInterval  time.Duration
 	MetricsTTL     time.Duration
 	RequestTimeout time.Duration
-	Retries        int
-	RetryTimeout   time.Duration
-	URL            string
-	User           string
-	Password       string
+	// Retries of the failed requests, requests are not retried if InitialInterval is not set.
+	Retries  retries.Config
+	URL      string
+	User     string
+	Password string
+	// MaxConcurrentRequests limits the amount of parallel requests to the cluster, zero means no limit.
+	MaxConcurrentRequests int
+	// CacheTTL is the time the result of the query is shared between triggers with the same expression, zero disables the cache.
+	CacheTTL time.Duration
+	// BearerToken is used for authorization instead of basic auth.
+	BearerToken string
+	// TLS configures mTLS and the custom CA of the cluster.
+	TLS TLSConfig
+	// Tenant is the default tenant of the cluster, it is used by the triggers without metrics tenant.
+	Tenant string
+	// AllowedTenants are tenants which triggers may set as metrics tenant, the default tenant is always allowed.
+	AllowedTenants []string
+	// TenantHeader is the header to pass the tenant in, the tenant is not passed in the header if empty.
+	TenantHeader string
+	// TenantPathPrefix is added to the path of api requests, TenantPlaceholder in it is replaced by the tenant.
+	TenantPathPrefix string
+	// QueryParams are added to every api request, e.g. dedup or partial_response of Thanos.
+	QueryParams map[string]string
+}
+
+// TLSConfig is the tls settings of the requests to the cluster.
+type TLSConfig struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
 }
 
 func Create(config *Config, logger moira.Logger) (metricSource.MetricSource, error) {
+	if config.Retries.InitialInterval != 0 {
+		if err := moira.ValidateStruct(config.Retries); err != nil {
+			return nil, fmt.Errorf("prometheus retries config validation error: %w", err)
+		}
+	}
+
 	promApi, err := createPrometheusApi(config)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Prometheus{config: config, api: promApi, logger: logger}, nil
+	return newPrometheus(config, promApi, logger), nil
+}
+
+func newPrometheus(config *Config, api PrometheusApi, logger moira.Logger) *Prometheus {
+	prometheus := &Prometheus{
+		config:         config,
+		api:            api,
+		logger:         logger,
+		retrier:        retries.NewStandardRetrier[model.Matrix](),
+		backoffFactory: newBackoffFactory(config.Retries),
+	}
+
+	if config.CacheTTL > 0 {
+		prometheus.cache = cache.New(config.CacheTTL, config.CacheTTL)
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		prometheus.requestsLimiter = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	return prometheus
 }
 
 type Prometheus struct {
-	config *Config
-	logger moira.Logger
-	api    PrometheusApi
+	config          *Config
+	logger          moira.Logger
+	api             PrometheusApi
+	retrier         retries.Retrier[model.Matrix]
+	backoffFactory  retries.BackoffFactory
+	cache           *cache.Cache
+	requestsLimiter chan struct{}
+	tenant          string
+}
+
+// WithTenant returns metric source which fetches metrics of the given tenant,
+// the cache and the limit of concurrent requests are shared between tenants of the cluster.
+func (prometheus *Prometheus) WithTenant(tenant string) metricSource.MetricSource {
+	tenantPrometheus := *prometheus
+	tenantPrometheus.tenant = tenant
+
+	return &tenantPrometheus
+}
+
+// IsTenantAllowed returns true if the tenant is the default tenant or one of allowed tenants of the cluster.
+func (prometheus *Prometheus) IsTenantAllowed(tenant string) bool {
+	return tenant == prometheus.config.Tenant || slices.Contains(prometheus.config.AllowedTenants, tenant)
 }
 
 func (prometheus *Prometheus) GetMetricsTTLSeconds() int64 {
@@ -48,7 +128,23 @@ func (prometheus *Prometheus) IsConfigured() (bool, error) {
 
 func (prometheus *Prometheus) IsAvailable() (bool, error) {
 	now := time.Now().Unix()
-	_, err := prometheus.Fetch("1", now, now, true)
+	_, err := prometheus.queryRange("1", now, now)
 
 	return err == nil, err
 }
+
+func newBackoffFactory(config retries.Config) retries.BackoffFactory {
+	if config.InitialInterval == 0 {
+		return noRetriesBackoffFactory{}
+	}
+
+	return retries.NewExponentialBackoffFactory(config)
+}
+
+// noRetriesBackoffFactory creates backoffs which never retry.
+type noRetriesBackoffFactory struct{}
+
+// NewBackOff creates new backoff.
+func (noRetriesBackoffFactory) NewBackOff() backoff.BackOff {
+	return &backoff.StopBackOff{}
+}
@@ -3,7 +3,11 @@ package prometheus
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/prometheus/client_golang/api"
 	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
@@ -11,13 +15,77 @@ import (
 	"github.com/prometheus/common/model"
 )
 
+// DefaultTenantHeader is the header used by Mimir, Cortex, Loki and Thanos receivers to pass the tenant.
+const DefaultTenantHeader = "X-Scope-OrgID"
+
+// TenantPlaceholder is replaced by the tenant in Config.TenantPathPrefix.
+const TenantPlaceholder = "{tenant}"
+
+var errTenantRequired = errors.New("tenant is required by the tenant path prefix")
+
 type PrometheusApi interface {
 	QueryRange(ctx context.Context, query string, r promApi.Range, opts ...promApi.Option) (model.Value, promApi.Warnings, error)
 }
 
+type tenantContextKey struct{}
+
+// withTenant returns context of the request made on behalf of the tenant.
+func withTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
 func createPrometheusApi(config *Config) (promApi.API, error) {
+	roundTripper, err := createRoundTripper(config)
+	if err != nil {
+		return nil, err
+	}
+
+	promClientConfig := api.Config{
+		Address:      config.URL,
+		RoundTripper: roundTripper,
+	}
+
+	promCl, err := api.NewClient(promClientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return promApi.NewAPI(promCl), nil
+}
+
+func createRoundTripper(config *Config) (http.RoundTripper, error) {
 	roundTripper := api.DefaultRoundTripper
 
+	if config.TLS != (TLSConfig{}) {
+		tlsConfig, err := promConfig.NewTLSConfig(&promConfig.TLSConfig{
+			CAFile:             config.TLS.CAFile,
+			CertFile:           config.TLS.CertFile,
+			KeyFile:            config.TLS.KeyFile,
+			ServerName:         config.TLS.ServerName,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prometheus tls config: %w", err)
+		}
+
+		transport := api.DefaultRoundTripper.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		roundTripper = transport
+	}
+
+	if config.BearerToken != "" && config.User != "" {
+		return nil, fmt.Errorf("prometheus basic auth and bearer token can not be used together")
+	}
+
 	if config.User != "" && config.Password != "" {
 		rawToken := fmt.Sprintf("%s:%s", config.User, config.Password)
 		token := base64.StdEncoding.EncodeToString([]byte(rawToken))
@@ -29,15 +97,71 @@ func createPrometheusApi(config *Config) (promApi.API, error) {
 		)
 	}
 
-	promClientConfig := api.Config{
-		Address:      config.URL,
-		RoundTripper: roundTripper,
+	if config.BearerToken != "" {
+		roundTripper = promConfig.NewAuthorizationCredentialsRoundTripper(
+			"Bearer",
+			promConfig.NewInlineSecret(config.BearerToken),
+			roundTripper,
+		)
 	}
 
-	promCl, err := api.NewClient(promClientConfig)
+	baseURL, err := url.Parse(config.URL)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse prometheus url: %w", err)
 	}
 
-	return promApi.NewAPI(promCl), nil
+	return &tenantRoundTripper{
+		basePath:      strings.TrimRight(baseURL.Path, "/"),
+		defaultTenant: config.Tenant,
+		tenantHeader:  config.TenantHeader,
+		pathPrefix:    config.TenantPathPrefix,
+		queryParams:   config.QueryParams,
+		next:          roundTripper,
+	}, nil
+}
+
+// tenantRoundTripper adds the tenant and the custom query params to the requests of multi-tenant
+// Prometheus compatible storages, e.g. Mimir, Cortex, Thanos or VictoriaMetrics cluster.
+type tenantRoundTripper struct {
+	basePath      string
+	defaultTenant string
+	tenantHeader  string
+	pathPrefix    string
+	queryParams   map[string]string
+	next          http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (roundTripper *tenantRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	tenant := tenantFromContext(request.Context())
+	if tenant == "" {
+		tenant = roundTripper.defaultTenant
+	}
+
+	request = request.Clone(request.Context())
+
+	if tenant != "" && roundTripper.tenantHeader != "" {
+		request.Header.Set(roundTripper.tenantHeader, tenant)
+	}
+
+	if roundTripper.pathPrefix != "" {
+		if tenant == "" && strings.Contains(roundTripper.pathPrefix, TenantPlaceholder) {
+			return nil, errTenantRequired
+		}
+
+		prefix := strings.ReplaceAll(roundTripper.pathPrefix, TenantPlaceholder, url.PathEscape(tenant))
+		request.URL.Path = roundTripper.basePath + prefix + strings.TrimPrefix(request.URL.Path, roundTripper.basePath)
+		request.URL.RawPath = ""
+	}
+
+	if len(roundTripper.queryParams) != 0 {
+		query := request.URL.Query()
+		for key, value := range roundTripper.queryParams {
+			query.Set(key, value)
+		}
+
+		request.URL.RawQuery = query.Encode()
+	}
+
+	return roundTripper.next.RoundTrip(request)
 }
@@ -0,0 +1,86 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrometheusApiTenant(t *testing.T) {
+	var lastRequest *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lastRequest = request
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`)) //nolint
+	}))
+	defer server.Close()
+
+	queryRange := func(config *Config, tenant string) error {
+		api, err := createPrometheusApi(config)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = api.QueryRange(withTenant(context.Background(), tenant), "up", promApi.Range{
+			Start: time.Unix(testFrom, 0),
+			End:   time.Unix(testUntil, 0),
+			Step:  time.Minute,
+		})
+
+		return err
+	}
+
+	Convey("Test prometheus api requests", t, func() {
+		Convey("Plain prometheus", func() {
+			err := queryRange(&Config{URL: server.URL, TenantHeader: DefaultTenantHeader}, "")
+			So(err, ShouldBeNil)
+			So(lastRequest.URL.Path, ShouldEqual, "/api/v1/query_range")
+			So(lastRequest.Header.Get(DefaultTenantHeader), ShouldBeEmpty)
+		})
+
+		Convey("Default tenant of the cluster is passed in header", func() {
+			err := queryRange(&Config{URL: server.URL, Tenant: "default", TenantHeader: DefaultTenantHeader}, "")
+			So(err, ShouldBeNil)
+			So(lastRequest.Header.Get(DefaultTenantHeader), ShouldEqual, "default")
+		})
+
+		Convey("Tenant of the trigger overrides default tenant", func() {
+			err := queryRange(&Config{URL: server.URL, Tenant: "default", TenantHeader: DefaultTenantHeader}, "team-a")
+			So(err, ShouldBeNil)
+			So(lastRequest.Header.Get(DefaultTenantHeader), ShouldEqual, "team-a")
+		})
+
+		Convey("Tenant path prefix and query params", func() {
+			config := &Config{
+				URL:              server.URL + "/vm",
+				TenantPathPrefix: "/select/" + TenantPlaceholder + "/prometheus",
+				QueryParams:      map[string]string{"dedup": "true"},
+			}
+
+			err := queryRange(config, "42")
+			So(err, ShouldBeNil)
+			So(lastRequest.URL.Path, ShouldEqual, "/vm/select/42/prometheus/api/v1/query_range")
+			So(lastRequest.URL.Query().Get("dedup"), ShouldEqual, "true")
+
+			err = queryRange(config, "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Bearer token", func() {
+			err := queryRange(&Config{URL: server.URL, BearerToken: "token"}, "")
+			So(err, ShouldBeNil)
+			So(lastRequest.Header.Get("Authorization"), ShouldEqual, "Bearer token")
+		})
+
+		Convey("Basic auth and bearer token together", func() {
+			err := queryRange(&Config{URL: server.URL, User: "user", Password: "pass", BearerToken: "token"}, "")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -1,11 +1,15 @@
 package metricsource
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/moira-alert/moira"
 )
 
+// ErrTenantNotAllowed is returned when the tenant is not in allowed tenants of the cluster.
+var ErrTenantNotAllowed = errors.New("metrics tenant is not allowed")
+
 // SourceProvider is a provider for all known metrics sources.
 type SourceProvider struct {
 	sources map[moira.ClusterKey]MetricSource
@@ -60,7 +64,28 @@ func (provider *SourceProvider) GetClusterList() []moira.ClusterKey {
 
 // GetTriggerMetricSource get metrics source by given trigger. If it not configured returns not empty error.
 func (provider *SourceProvider) GetTriggerMetricSource(trigger *moira.Trigger) (MetricSource, error) {
-	return provider.GetMetricSource(trigger.ClusterKey())
+	return provider.GetTenantMetricSource(trigger.ClusterKey(), trigger.MetricsTenant)
+}
+
+// GetTenantMetricSource returns metric source with given cluster key which fetches metrics of the given tenant.
+// Empty tenant means the default tenant of the cluster. Tenants not allowed by the cluster config are rejected,
+// so both api and checker refuse to query them with credentials of the cluster.
+func (provider *SourceProvider) GetTenantMetricSource(clusterKey moira.ClusterKey, tenant string) (MetricSource, error) {
+	source, err := provider.GetMetricSource(clusterKey)
+	if err != nil || tenant == "" {
+		return source, err
+	}
+
+	tenantSource, ok := source.(TenantMetricSource)
+	if !ok {
+		return nil, fmt.Errorf("metric source with cluster key `%s` does not support tenants", clusterKey.String())
+	}
+
+	if !tenantSource.IsTenantAllowed(tenant) {
+		return nil, fmt.Errorf("%w: tenant `%s` of cluster `%s`", ErrTenantNotAllowed, tenant, clusterKey.String())
+	}
+
+	return tenantSource.WithTenant(tenant), nil
 }
 
 // GetMetricSource return metric source depending on trigger flag: is remote trigger or not. GetLocal if not.
@@ -0,0 +1,61 @@
+package metricsource
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testTenantSource struct {
+	MetricSource
+	tenant         string
+	allowedTenants []string
+}
+
+func (source *testTenantSource) WithTenant(tenant string) MetricSource {
+	tenantSource := *source
+	tenantSource.tenant = tenant
+
+	return &tenantSource
+}
+
+func (source *testTenantSource) IsTenantAllowed(tenant string) bool {
+	return slices.Contains(source.allowedTenants, tenant)
+}
+
+func TestSourceProvider_GetTenantMetricSource(t *testing.T) {
+	Convey("Test getting metric source of tenant", t, func() {
+		clusterKey := moira.MakeClusterKey(moira.PrometheusRemote, moira.DefaultCluster)
+		source := &testTenantSource{allowedTenants: []string{"team-a"}}
+
+		provider := CreateMetricSourceProvider()
+		provider.RegisterSource(clusterKey, source)
+
+		Convey("Default tenant", func() {
+			actual, err := provider.GetTenantMetricSource(clusterKey, "")
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, source)
+		})
+
+		Convey("Allowed tenant", func() {
+			actual, err := provider.GetTenantMetricSource(clusterKey, "team-a")
+			So(err, ShouldBeNil)
+			So(actual.(*testTenantSource).tenant, ShouldEqual, "team-a")
+		})
+
+		Convey("Not allowed tenant", func() {
+			actual, err := provider.GetTenantMetricSource(clusterKey, "team-b")
+			So(errors.Is(err, ErrTenantNotAllowed), ShouldBeTrue)
+			So(actual, ShouldBeNil)
+		})
+
+		Convey("Trigger with not allowed tenant", func() {
+			trigger := &moira.Trigger{TriggerSource: moira.PrometheusRemote, ClusterId: moira.DefaultCluster, MetricsTenant: "team-b"}
+			_, err := provider.GetTriggerMetricSource(trigger)
+			So(errors.Is(err, ErrTenantNotAllowed), ShouldBeTrue)
+		})
+	})
+}
@@ -17,4 +17,14 @@ type Config struct {
 	HealthcheckTimeout time.Duration `validate:"required,gt=0s"`
 	Retries            retries.Config
 	HealthcheckRetries retries.Config
+	// Format of render responses, json is used if empty.
+	Format Format `validate:"omitempty,oneof=json protobuf carbonapi_v3_pb pickle"`
+	// MaxDataPoints is passed as maxDataPoints render parameter if set.
+	MaxDataPoints int `validate:"gte=0"`
+	// NoNullPoints is passed as noNullPoints render parameter.
+	NoNullPoints bool
+	// DisableCompression disables gzip compression of render responses.
+	DisableCompression bool
+	// MaxConcurrentRequests limits the amount of parallel requests to the cluster, zero means no limit.
+	MaxConcurrentRequests int `validate:"gte=0"`
 }
@@ -39,6 +39,7 @@ type Remote struct {
 	retrier                   retries.Retrier[[]byte]
 	requestBackoffFactory     retries.BackoffFactory
 	healthcheckBackoffFactory retries.BackoffFactory
+	requestsLimiter           chan struct{}
 }
 
 // Create configures remote metric source.
@@ -47,13 +48,22 @@ func Create(config *Config) (metricSource.MetricSource, error) {
 		return nil, err
 	}
 
-	return &Remote{
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = config.DisableCompression
+
+	remote := &Remote{
 		config:                    config,
-		client:                    &http.Client{},
+		client:                    &http.Client{Transport: transport},
 		retrier:                   retries.NewStandardRetrier[[]byte](),
 		requestBackoffFactory:     retries.NewExponentialBackoffFactory(config.Retries),
 		healthcheckBackoffFactory: retries.NewExponentialBackoffFactory(config.HealthcheckRetries),
-	}, nil
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		remote.requestsLimiter = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	return remote, nil
 }
 
 // Fetch fetches remote metrics and converts them to expected format.
@@ -75,7 +85,7 @@ func (remote *Remote) Fetch(target string, from, until int64, allowRealTimeAlert
 		return nil, internalErrToPublicErr(err, target)
 	}
 
-	resp, err := decodeBody(body)
+	resp, err := decodeBody(body, remote.config.Format)
 	if err != nil {
 		return nil, ErrRemoteTriggerResponse{
 			InternalError: err,
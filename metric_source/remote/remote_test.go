@@ -1,8 +1,13 @@
 package remote
 
 import (
+	"compress/gzip"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -284,3 +289,87 @@ func TestFetch(t *testing.T) {
 		}
 	})
 }
+
+func TestFetchWithCreatedRemote(t *testing.T) {
+	validBody := []byte(`[{"target":"t1","datapoints":[[1,240],[3,300]]}]`)
+
+	var (
+		inFlight      int32
+		maxInFlight   int32
+		acceptedGzip  atomic.Bool
+		requestsCount int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requestsCount, 1)
+
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		if !strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
+			rw.Write(validBody) //nolint
+			return
+		}
+
+		acceptedGzip.Store(true)
+		rw.Header().Set("Content-Encoding", "gzip")
+
+		writer := gzip.NewWriter(rw)
+		writer.Write(validBody) //nolint
+		writer.Close()
+	}))
+	defer server.Close()
+
+	config := &Config{
+		URL:                   server.URL,
+		Timeout:               time.Second,
+		HealthcheckTimeout:    time.Second,
+		Retries:               testConfigs[0].Retries,
+		HealthcheckRetries:    testConfigs[0].Retries,
+		MaxConcurrentRequests: 1,
+	}
+
+	Convey("Given remote with concurrency limit", t, func() {
+		source, err := Create(config)
+		So(err, ShouldBeNil)
+
+		wg := sync.WaitGroup{}
+		for range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				result, err := source.Fetch("t1", 240, 300, true)
+				if err == nil && len(result.GetMetricsData()) == 1 {
+					return
+				}
+
+				t.Errorf("unexpected fetch result: %v, %v", result, err)
+			}()
+		}
+
+		wg.Wait()
+
+		So(atomic.LoadInt32(&requestsCount), ShouldEqual, 4)
+		So(atomic.LoadInt32(&maxInFlight), ShouldEqual, 1)
+		So(acceptedGzip.Load(), ShouldBeTrue)
+	})
+
+	Convey("Given invalid format", t, func() {
+		invalidConfig := *config
+		invalidConfig.Format = "xml"
+
+		_, err := Create(&invalidConfig)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -18,11 +18,25 @@ func (remote *Remote) prepareRequest(from, until int64, target string) (*http.Re
 		return nil, err
 	}
 
+	format := remote.config.Format
+	if format == "" {
+		format = FormatJSON
+	}
+
 	q := req.URL.Query()
-	q.Add("format", "json")
+	q.Add("format", string(format))
 	q.Add("from", strconv.FormatInt(from, 10))
 	q.Add("target", target)
 	q.Add("until", strconv.FormatInt(until, 10))
+
+	if remote.config.MaxDataPoints > 0 {
+		q.Add("maxDataPoints", strconv.Itoa(remote.config.MaxDataPoints))
+	}
+
+	if remote.config.NoNullPoints {
+		q.Add("noNullPoints", "true")
+	}
+
 	req.URL.RawQuery = q.Encode()
 
 	if remote.config.User != "" && remote.config.Password != "" {
@@ -35,22 +49,29 @@ func (remote *Remote) prepareRequest(from, until int64, target string) (*http.Re
 func (remote *Remote) makeRequest(req *http.Request, timeout time.Duration, backoffPolicy backoff.BackOff) ([]byte, error) {
 	return remote.retrier.Retry(
 		requestToRemoteGraphite{
-			client:         remote.client,
-			request:        req,
-			requestTimeout: timeout,
+			client:          remote.client,
+			request:         req,
+			requestTimeout:  timeout,
+			requestsLimiter: remote.requestsLimiter,
 		},
 		backoffPolicy)
 }
 
 // requestToRemoteGraphite implements retries.RetryableOperation.
 type requestToRemoteGraphite struct {
-	client         *http.Client
-	request        *http.Request
-	requestTimeout time.Duration
+	client          *http.Client
+	request         *http.Request
+	requestTimeout  time.Duration
+	requestsLimiter chan struct{}
 }
 
 // DoRetryableOperation is a one attempt of performing request to remote graphite.
 func (r requestToRemoteGraphite) DoRetryableOperation() ([]byte, error) {
+	if r.requestsLimiter != nil {
+		r.requestsLimiter <- struct{}{}
+		defer func() { <-r.requestsLimiter }()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), r.requestTimeout)
 	defer cancel()
 
@@ -34,6 +34,18 @@ func TestPrepareRequest(t *testing.T) {
 			So(req.Header.Get("Authorization"), ShouldEqual, "")
 		})
 	})
+	Convey("Given render params", t, func() {
+		remote := Remote{config: &Config{
+			URL:           "http://test/",
+			Format:        FormatCarbonAPIV3,
+			MaxDataPoints: 100,
+			NoNullPoints:  true,
+		}}
+		req, err := remote.prepareRequest(from, until, target)
+
+		So(err, ShouldBeNil)
+		So(req.URL.String(), ShouldEqual, "http://test/?format=carbonapi_v3_pb&from=300&maxDataPoints=100&noNullPoints=true&target=foo.bar&until=500")
+	})
 	Convey("Given valid params with user and password", t, func() {
 		remote := Remote{config: &Config{
 			URL:      "http://test/",
@@ -1,12 +1,33 @@
 package remote
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"math"
 
+	pbv2 "github.com/go-graphite/protocol/carbonapi_v2_pb"
+	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	ogórek "github.com/lomik/og-rek"
 	metricSource "github.com/moira-alert/moira/metric_source"
 )
 
+// Format is the format of graphite render response.
+type Format string
+
+const (
+	// FormatJSON is the default json format of graphite-web and carbonapi.
+	FormatJSON Format = "json"
+	// FormatProtobuf is carbonapi_v2_pb format of carbonapi.
+	FormatProtobuf Format = "protobuf"
+	// FormatCarbonAPIV3 is carbonapi_v3_pb format of carbonapi.
+	FormatCarbonAPIV3 Format = "carbonapi_v3_pb"
+	// FormatPickle is pickle format of graphite-web and carbonapi.
+	FormatPickle Format = "pickle"
+)
+
+const defaultStepTime int64 = 60
+
 type graphiteMetric struct {
 	Target     string
 	DataPoints [][2]*float64
@@ -21,14 +42,32 @@ func convertResponse(metricsData []metricSource.MetricData, allowRealTimeAlertin
 
 	for _, metricData := range metricsData {
 		// remove last value
-		metricData.Values = metricData.Values[:len(metricData.Values)-1]
+		if len(metricData.Values) != 0 {
+			metricData.Values = metricData.Values[:len(metricData.Values)-1]
+		}
+
 		result = append(result, metricData)
 	}
 
 	return FetchResult{MetricsData: result}
 }
 
-func decodeBody(body []byte) ([]metricSource.MetricData, error) {
+func decodeBody(body []byte, format Format) ([]metricSource.MetricData, error) {
+	switch format {
+	case FormatJSON, "":
+		return decodeJSON(body)
+	case FormatProtobuf:
+		return decodeProtobuf(body)
+	case FormatCarbonAPIV3:
+		return decodeCarbonAPIV3(body)
+	case FormatPickle:
+		return decodePickle(body)
+	default:
+		return nil, fmt.Errorf("unknown graphite response format %s", format)
+	}
+}
+
+func decodeJSON(body []byte) ([]metricSource.MetricData, error) {
 	var tmp []graphiteMetric
 
 	err := json.Unmarshal(body, &tmp)
@@ -39,25 +78,151 @@ func decodeBody(body []byte) ([]metricSource.MetricData, error) {
 	res := make([]metricSource.MetricData, 0, len(tmp))
 
 	for _, m := range tmp {
-		var stepTime int64 = 60
-		if len(m.DataPoints) > 1 {
-			stepTime = int64(*m.DataPoints[1][1] - *m.DataPoints[0][1])
+		timestamps := make([]int64, 0, len(m.DataPoints))
+		values := make([]float64, 0, len(m.DataPoints))
+
+		for _, point := range m.DataPoints {
+			if point[1] == nil {
+				return nil, fmt.Errorf("datapoint of %s has no timestamp", m.Target)
+			}
+
+			value := math.NaN()
+			if point[0] != nil {
+				value = *point[0]
+			}
+
+			timestamps = append(timestamps, int64(*point[1]))
+			values = append(values, value)
+		}
+
+		if len(timestamps) == 0 {
+			continue
+		}
+
+		res = append(res, makeMetricData(m.Target, timestamps, values))
+	}
+
+	return res, nil
+}
+
+// makeMetricData makes metric data from the points with timestamps, the gaps left by noNullPoints are filled with NaN.
+func makeMetricData(name string, timestamps []int64, values []float64) metricSource.MetricData {
+	stepTime := defaultStepTime
+
+	for i := 1; i < len(timestamps); i++ {
+		delta := timestamps[i] - timestamps[i-1]
+		if delta > 0 && (i == 1 || delta < stepTime) {
+			stepTime = delta
 		}
+	}
+
+	startTime := timestamps[0]
+	stopTime := timestamps[len(timestamps)-1]
+
+	metricData := metricSource.MetricData{
+		Name:      name,
+		StartTime: startTime,
+		StopTime:  stopTime,
+		StepTime:  stepTime,
+		Values:    make([]float64, max(stopTime-startTime, 0)/stepTime+1),
+	}
+
+	for i := range metricData.Values {
+		metricData.Values[i] = math.NaN()
+	}
 
-		metricData := metricSource.MetricData{
-			Name:      m.Target,
-			StartTime: int64(*m.DataPoints[0][1]),
-			StopTime:  int64(*m.DataPoints[len(m.DataPoints)-1][1]),
-			StepTime:  stepTime,
-			Values:    make([]float64, len(m.DataPoints)),
+	for i, timestamp := range timestamps {
+		index := (timestamp - startTime) / stepTime
+		if index >= 0 && index < int64(len(metricData.Values)) {
+			metricData.Values[index] = values[i]
 		}
+	}
+
+	return metricData
+}
+
+func decodeProtobuf(body []byte) ([]metricSource.MetricData, error) {
+	var response pbv2.MultiFetchResponse
+	if err := response.Unmarshal(body); err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf response: %w", err)
+	}
 
-		for i, v := range m.DataPoints {
-			if v[0] == nil {
-				metricData.Values[i] = math.NaN()
-			} else {
-				metricData.Values[i] = *v[0]
+	res := make([]metricSource.MetricData, 0, len(response.Metrics))
+
+	for _, m := range response.Metrics {
+		values := make([]float64, len(m.Values))
+		for i, value := range m.Values {
+			if i < len(m.IsAbsent) && m.IsAbsent[i] {
+				value = math.NaN()
 			}
+
+			values[i] = value
+		}
+
+		res = append(res, makeMetricDataWithStep(m.Name, int64(m.StartTime), int64(m.StepTime), values))
+	}
+
+	return res, nil
+}
+
+func decodeCarbonAPIV3(body []byte) ([]metricSource.MetricData, error) {
+	var response pbv3.MultiFetchResponse
+	if err := response.Unmarshal(body); err != nil {
+		return nil, fmt.Errorf("failed to decode carbonapi_v3_pb response: %w", err)
+	}
+
+	res := make([]metricSource.MetricData, 0, len(response.Metrics))
+
+	for _, m := range response.Metrics {
+		res = append(res, makeMetricDataWithStep(m.Name, m.StartTime, m.StepTime, m.Values))
+	}
+
+	return res, nil
+}
+
+// makeMetricDataWithStep makes metric data from the values starting at startTime with the step,
+// the stop time is the timestamp of the last value as in json response.
+func makeMetricDataWithStep(name string, startTime, stepTime int64, values []float64) metricSource.MetricData {
+	if stepTime <= 0 {
+		stepTime = defaultStepTime
+	}
+
+	stopTime := startTime
+	if len(values) != 0 {
+		stopTime = startTime + int64(len(values)-1)*stepTime
+	}
+
+	return metricSource.MetricData{
+		Name:      name,
+		StartTime: startTime,
+		StopTime:  stopTime,
+		StepTime:  stepTime,
+		Values:    values,
+	}
+}
+
+func decodePickle(body []byte) ([]metricSource.MetricData, error) {
+	decoded, err := ogórek.NewDecoder(bytes.NewReader(body)).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pickle response: %w", err)
+	}
+
+	series, ok := decoded.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("pickle response must be a list, got %T", decoded)
+	}
+
+	res := make([]metricSource.MetricData, 0, len(series))
+
+	for _, s := range series {
+		m, ok := s.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("pickle series must be a dict, got %T", s)
+		}
+
+		metricData, err := pickleSeriesToMetricData(m)
+		if err != nil {
+			return nil, err
 		}
 
 		res = append(res, metricData)
@@ -65,3 +230,60 @@ func decodeBody(body []byte) ([]metricSource.MetricData, error) {
 
 	return res, nil
 }
+
+func pickleSeriesToMetricData(series map[interface{}]interface{}) (metricSource.MetricData, error) {
+	name, ok := series["name"].(string)
+	if !ok {
+		return metricSource.MetricData{}, fmt.Errorf("pickle series has no name")
+	}
+
+	startTime, err := pickleNumber(series["start"])
+	if err != nil {
+		return metricSource.MetricData{}, fmt.Errorf("pickle series %s has invalid start: %w", name, err)
+	}
+
+	stepTime, err := pickleNumber(series["step"])
+	if err != nil {
+		return metricSource.MetricData{}, fmt.Errorf("pickle series %s has invalid step: %w", name, err)
+	}
+
+	rawValues, ok := series["values"].([]interface{})
+	if !ok {
+		return metricSource.MetricData{}, fmt.Errorf("pickle series %s has no values", name)
+	}
+
+	values := make([]float64, len(rawValues))
+
+	for i, rawValue := range rawValues {
+		if _, isNone := rawValue.(ogórek.None); isNone {
+			values[i] = math.NaN()
+			continue
+		}
+
+		value, err := pickleNumber(rawValue)
+		if err != nil {
+			return metricSource.MetricData{}, fmt.Errorf("pickle series %s has invalid value: %w", name, err)
+		}
+
+		values[i] = value
+	}
+
+	return makeMetricDataWithStep(name, int64(startTime), int64(stepTime), values), nil
+}
+
+func pickleNumber(value interface{}) (float64, error) {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed), nil
+	case float64:
+		return typed, nil
+	case bool:
+		if typed {
+			return 1, nil
+		}
+
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("number expected, got %T", value)
+	}
+}
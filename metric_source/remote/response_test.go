@@ -1,9 +1,14 @@
 package remote
 
 import (
+	"bytes"
 	"encoding/json"
+	"math"
 	"testing"
 
+	pbv2 "github.com/go-graphite/protocol/carbonapi_v2_pb"
+	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	ogórek "github.com/lomik/og-rek"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -11,7 +16,7 @@ import (
 func TestDecodeBody(t *testing.T) {
 	Convey("Given empty json response", t, func() {
 		body := []byte("[]")
-		resp, err := decodeBody(body)
+		resp, err := decodeBody(body, FormatJSON)
 
 		Convey("response should be empty and without error", func() {
 			So(err, ShouldBeNil)
@@ -28,7 +33,7 @@ func TestDecodeBody(t *testing.T) {
 		}}}
 		body, _ := json.Marshal(r)
 
-		resp, err := decodeBody(body)
+		resp, err := decodeBody(body, FormatJSON)
 
 		Convey("length should be one", func() {
 			So(resp, ShouldHaveLength, 1)
@@ -51,7 +56,7 @@ func TestDecodeBody(t *testing.T) {
 		}}}
 		body, _ := json.Marshal(r)
 
-		resp, err := decodeBody(body)
+		resp, err := decodeBody(body, FormatJSON)
 
 		Convey("second response value should be set", func() {
 			So(err, ShouldBeNil)
@@ -81,3 +86,94 @@ func TestConvertResponse(t *testing.T) {
 		})
 	})
 }
+
+func TestDecodeBodyFormats(t *testing.T) {
+	Convey("Given json response with no null points", t, func() {
+		body := []byte(`[{"target":"t","datapoints":[[1,1522076520],[3,1522076640],[4,1522076700]]},{"target":"empty","datapoints":[]}]`)
+
+		resp, err := decodeBody(body, FormatJSON)
+		So(err, ShouldBeNil)
+		So(resp, ShouldHaveLength, 1)
+		So(resp[0].StartTime, ShouldEqual, 1522076520)
+		So(resp[0].StopTime, ShouldEqual, 1522076700)
+		So(resp[0].StepTime, ShouldEqual, 60)
+		So(resp[0].Values, ShouldHaveLength, 4)
+		So(resp[0].Values[0], ShouldEqual, 1)
+		So(math.IsNaN(resp[0].Values[1]), ShouldBeTrue)
+		So(resp[0].Values[2:], ShouldResemble, []float64{3, 4})
+	})
+
+	Convey("Given protobuf response", t, func() {
+		response := pbv2.MultiFetchResponse{Metrics: []pbv2.FetchResponse{{
+			Name:      "t",
+			StartTime: 1522076520,
+			StopTime:  1522076700,
+			StepTime:  60,
+			Values:    []float64{1, 0, 3},
+			IsAbsent:  []bool{false, true, false},
+		}}}
+		body, _ := response.Marshal()
+
+		resp, err := decodeBody(body, FormatProtobuf)
+		So(err, ShouldBeNil)
+		So(resp, ShouldHaveLength, 1)
+		So(resp[0].Name, ShouldEqual, "t")
+		So(resp[0].StartTime, ShouldEqual, 1522076520)
+		So(resp[0].StopTime, ShouldEqual, 1522076640)
+		So(resp[0].StepTime, ShouldEqual, 60)
+		So(resp[0].Values[0], ShouldEqual, 1)
+		So(math.IsNaN(resp[0].Values[1]), ShouldBeTrue)
+		So(resp[0].Values[2], ShouldEqual, 3)
+	})
+
+	Convey("Given carbonapi_v3_pb response", t, func() {
+		response := pbv3.MultiFetchResponse{Metrics: []pbv3.FetchResponse{{
+			Name:      "t",
+			StartTime: 1522076520,
+			StopTime:  1522076700,
+			StepTime:  60,
+			Values:    []float64{1, math.NaN(), 3},
+		}}}
+		body, _ := response.Marshal()
+
+		resp, err := decodeBody(body, FormatCarbonAPIV3)
+		So(err, ShouldBeNil)
+		So(resp, ShouldHaveLength, 1)
+		So(resp[0].StopTime, ShouldEqual, 1522076640)
+		So(resp[0].Values[0], ShouldEqual, 1)
+		So(math.IsNaN(resp[0].Values[1]), ShouldBeTrue)
+	})
+
+	Convey("Given pickle response", t, func() {
+		buffer := bytes.Buffer{}
+		err := ogórek.NewEncoder(&buffer).Encode([]interface{}{
+			map[interface{}]interface{}{
+				"name":           "t",
+				"pathExpression": "t",
+				"start":          int64(1522076520),
+				"end":            int64(1522076700),
+				"step":           int64(60),
+				"values":         []interface{}{1.5, ogórek.None{}, int64(3)},
+			},
+		})
+		So(err, ShouldBeNil)
+
+		resp, err := decodeBody(buffer.Bytes(), FormatPickle)
+		So(err, ShouldBeNil)
+		So(resp, ShouldHaveLength, 1)
+		So(resp[0].Name, ShouldEqual, "t")
+		So(resp[0].StartTime, ShouldEqual, 1522076520)
+		So(resp[0].StepTime, ShouldEqual, 60)
+		So(resp[0].Values[0], ShouldEqual, 1.5)
+		So(math.IsNaN(resp[0].Values[1]), ShouldBeTrue)
+		So(resp[0].Values[2], ShouldEqual, 3)
+	})
+
+	Convey("Given invalid responses", t, func() {
+		_, err := decodeBody([]byte("invalid"), FormatPickle)
+		So(err, ShouldNotBeNil)
+
+		_, err = decodeBody([]byte("invalid"), FormatCarbonAPIV3)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -7,6 +7,15 @@ type MetricSource interface {
 	IsAvailable() (bool, error)
 }
 
+// TenantMetricSource is implemented by metric sources which serve metrics of multiple tenants.
+type TenantMetricSource interface {
+	MetricSource
+	// WithTenant returns metric source which fetches metrics of the given tenant.
+	WithTenant(tenant string) MetricSource
+	// IsTenantAllowed returns true if triggers may fetch metrics of the given tenant with credentials of the cluster.
+	IsTenantAllowed(tenant string) bool
+}
+
 // FetchResult implements moira metric sources fetching result format.
 type FetchResult interface {
 	GetMetricsData() []MetricData
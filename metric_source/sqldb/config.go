@@ -0,0 +1,18 @@
+package sqldb
+
+import "time"
+
+// Config represents config of the SQL database metric source.
+type Config struct {
+	// Driver is the name of database/sql driver, "postgres" for PostgreSQL or "clickhouse" for ClickHouse.
+	Driver string `validate:"required"`
+	// DSN is the data source name passed to the driver, it must use a read-only user.
+	DSN            string `validate:"required"`
+	CheckInterval  time.Duration
+	MetricsTTL     time.Duration
+	RequestTimeout time.Duration `validate:"required,gt=0s"`
+	// Step is the width of the time buckets rows are grouped into.
+	Step time.Duration `validate:"required,gte=1s"`
+	// MaxOpenConnections limits the amount of parallel queries to the database, zero means no limit.
+	MaxOpenConnections int `validate:"gte=0"`
+}
@@ -0,0 +1,187 @@
+package sqldb
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// FetchResult is the result of SQL query.
+type FetchResult struct {
+	MetricsData []metricSource.MetricData
+}
+
+// GetMetricsData return all metrics data from fetch result.
+func (fetchResult *FetchResult) GetMetricsData() []metricSource.MetricData {
+	return fetchResult.MetricsData
+}
+
+// GetPatterns always returns error, because we can't fetch target patterns from SQL database.
+func (*FetchResult) GetPatterns() ([]string, error) {
+	return make([]string, 0), fmt.Errorf("sql fetch result never returns patterns")
+}
+
+// GetPatternMetrics always returns error, because SQL fetch doesn't return base pattern metrics.
+func (*FetchResult) GetPatternMetrics() ([]string, error) {
+	return make([]string, 0), fmt.Errorf("sql fetch result never returns pattern metrics")
+}
+
+// convertRows groups rows by series into the buckets of the step from the aligned from until the aligned until inclusive.
+// Values of the rows in the same bucket are summed, buckets without rows are NaN, rows outside the interval are dropped.
+func convertRows(rows []row, from, until, step int64) *FetchResult {
+	bucketsCount := int((until-from)/step) + 1
+	if bucketsCount < 0 {
+		bucketsCount = 0
+	}
+
+	seriesValues := make(map[string][]float64)
+
+	for _, r := range rows {
+		values, ok := seriesValues[r.series]
+		if !ok {
+			values = make([]float64, bucketsCount)
+			for i := range values {
+				values[i] = math.NaN()
+			}
+
+			seriesValues[r.series] = values
+		}
+
+		if r.timestamp < from || math.IsNaN(r.value) {
+			continue
+		}
+
+		bucket := int((r.timestamp - from) / step)
+		if bucket >= bucketsCount {
+			continue
+		}
+
+		if math.IsNaN(values[bucket]) {
+			values[bucket] = r.value
+		} else {
+			values[bucket] += r.value
+		}
+	}
+
+	names := make([]string, 0, len(seriesValues))
+	for name := range seriesValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	result := FetchResult{
+		MetricsData: make([]metricSource.MetricData, 0, len(names)),
+	}
+
+	for _, name := range names {
+		result.MetricsData = append(result.MetricsData, metricSource.MetricData{
+			Name:      name,
+			StartTime: from,
+			StopTime:  until,
+			StepTime:  step,
+			Values:    seriesValues[name],
+			Wildcard:  false,
+		})
+	}
+
+	return &result
+}
+
+func parseRow(series, timestamp, value any) (row, error) {
+	var (
+		r   row
+		err error
+	)
+
+	r.series, err = parseSeries(series)
+	if err != nil {
+		return r, errInvalidRow{internalErr: err}
+	}
+
+	r.timestamp, err = parseTimestamp(timestamp)
+	if err != nil {
+		return r, errInvalidRow{internalErr: err}
+	}
+
+	r.value, err = parseValue(value)
+	if err != nil {
+		return r, errInvalidRow{internalErr: err}
+	}
+
+	return r, nil
+}
+
+func parseSeries(series any) (string, error) {
+	switch typed := series.(type) {
+	case string:
+		return typed, nil
+	case []byte:
+		return string(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	default:
+		return "", fmt.Errorf("series column has unsupported type %T", series)
+	}
+}
+
+func parseTimestamp(timestamp any) (int64, error) {
+	switch typed := timestamp.(type) {
+	case time.Time:
+		return typed.Unix(), nil
+	case int64:
+		return typed, nil
+	case float64:
+		return int64(typed), nil
+	case []byte:
+		return parseTimestampString(string(typed))
+	case string:
+		return parseTimestampString(typed)
+	default:
+		return 0, fmt.Errorf("timestamp column has unsupported type %T", timestamp)
+	}
+}
+
+func parseTimestampString(timestamp string) (int64, error) {
+	if unix, err := strconv.ParseFloat(timestamp, 64); err == nil {
+		return int64(unix), nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("timestamp column must be unix timestamp or RFC3339 time: %w", err)
+	}
+
+	return parsed.Unix(), nil
+}
+
+// parseValue returns NaN for NULL value.
+func parseValue(value any) (float64, error) {
+	switch typed := value.(type) {
+	case nil:
+		return math.NaN(), nil
+	case float64:
+		return typed, nil
+	case int64:
+		return float64(typed), nil
+	case []byte:
+		return parseValueString(string(typed))
+	case string:
+		return parseValueString(typed)
+	default:
+		return 0, fmt.Errorf("value column has unsupported type %T", value)
+	}
+}
+
+func parseValueString(value string) (float64, error) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value column must be a number: %w", err)
+	}
+
+	return parsed, nil
+}
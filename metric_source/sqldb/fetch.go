@@ -0,0 +1,220 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+const (
+	// FromPlaceholder is replaced by the start of the requested interval as unix timestamp in seconds.
+	FromPlaceholder = "{{from}}"
+	// UntilPlaceholder is replaced by the end of the requested interval as unix timestamp in seconds.
+	UntilPlaceholder = "{{until}}"
+	// StepPlaceholder is replaced by the step in seconds.
+	StepPlaceholder = "{{step}}"
+)
+
+// columnsCount is the amount of columns in the query result: series name, timestamp and value.
+const columnsCount = 3
+
+// Fetch evaluates the query and groups its rows into series bucketed by the step.
+// The query must return series name, timestamp and value columns in this order.
+func (source *SQLDB) Fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
+	if err := validateQuery(target); err != nil {
+		return nil, ErrInvalidQuery{InternalError: err, Target: target}
+	}
+
+	step := source.stepSeconds()
+
+	from = moira.MaxInt64(from, until-int64(source.config.MetricsTTL.Seconds()))
+	from -= from % step
+	until -= until % step
+
+	rows, err := source.query(renderQuery(target, from, until, step))
+	if err != nil {
+		return nil, queryErrToPublicErr(err, target)
+	}
+
+	if !allowRealTimeAlerting {
+		until -= step
+	}
+
+	return convertRows(rows, from, until, step), nil
+}
+
+func (source *SQLDB) stepSeconds() int64 {
+	return int64(source.config.Step.Seconds())
+}
+
+func renderQuery(target string, from, until, step int64) string {
+	return strings.NewReplacer(
+		FromPlaceholder, strconv.FormatInt(from, 10),
+		UntilPlaceholder, strconv.FormatInt(until, 10),
+		StepPlaceholder, strconv.FormatInt(step, 10),
+	).Replace(target)
+}
+
+type row struct {
+	series    string
+	timestamp int64
+	value     float64
+}
+
+// validateQuery checks that the query is a single statement, a trailing semicolon is allowed.
+// Semicolons inside string literals, quoted identifiers and comments are ignored. Databases differ in whether
+// backslash escapes a quote, so the query is rejected if it has several statements in either case.
+func validateQuery(query string) error {
+	if hasMultipleStatements(query, false) || hasMultipleStatements(query, true) {
+		return errMultipleStatements
+	}
+
+	return nil
+}
+
+func hasMultipleStatements(query string, backslashEscapes bool) bool {
+	statementEnd := false
+
+	for i := 0; i < len(query); i++ {
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return false
+			}
+
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return false
+			}
+
+			i += end + 3
+		case statementEnd && !isSpace(query[i]):
+			return true
+		case query[i] == '\'' || query[i] == '"':
+			end := quotedEnd(query, i, backslashEscapes)
+			if end < 0 {
+				return false
+			}
+
+			i = end
+		case query[i] == ';':
+			statementEnd = true
+		}
+	}
+
+	return false
+}
+
+// quotedEnd returns index of the quote closing the quoted string which starts at given index, or -1 if it is not closed.
+func quotedEnd(query string, start int, backslashEscapes bool) int {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslashEscapes {
+				i++
+			}
+		case query[start]:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+var errMultipleStatements = errors.New("query must be a single statement")
+
+func (source *SQLDB) query(query string) ([]row, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), source.config.RequestTimeout)
+	defer cancel()
+
+	var result []row
+
+	// Query is written by user, so it is run read-only
+	err := source.querier(ctx, source.db, query, func(sqlRows *sql.Rows) error {
+		var err error
+
+		result, err = readRows(sqlRows)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func readRows(sqlRows *sql.Rows) ([]row, error) {
+	columns, err := sqlRows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) != columnsCount {
+		return nil, errInvalidColumns{columnsCount: len(columns)}
+	}
+
+	result := make([]row, 0)
+
+	for sqlRows.Next() {
+		var series, timestamp, value any
+		if err = sqlRows.Scan(&series, &timestamp, &value); err != nil {
+			return nil, err
+		}
+
+		r, err := parseRow(series, timestamp, value)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, r)
+	}
+
+	return result, sqlRows.Err()
+}
+
+type errInvalidColumns struct {
+	columnsCount int
+}
+
+func (err errInvalidColumns) Error() string {
+	return fmt.Sprintf("query must return %d columns: series, timestamp and value, got %d columns", columnsCount, err.columnsCount)
+}
+
+type errInvalidRow struct {
+	internalErr error
+}
+
+func (err errInvalidRow) Error() string {
+	return err.internalErr.Error()
+}
+
+func queryErrToPublicErr(err error, target string) error {
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return ErrDatabaseUnavailable{
+			InternalError: fmt.Errorf("the database is not available or the query was cancelled by timeout: %w", err),
+			Target:        target,
+		}
+	}
+
+	return ErrInvalidQuery{
+		InternalError: err,
+		Target:        target,
+	}
+}
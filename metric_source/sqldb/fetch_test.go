@@ -0,0 +1,284 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testFrom  int64 = 1700000040
+	testUntil int64 = testFrom + 2*60
+)
+
+// testDriver returns the prepared rows for any query and remembers the last query and transaction.
+type testDriver struct {
+	columns   []string
+	rows      [][]driver.Value
+	err       error
+	lastQuery string
+	lastTx    *testTx
+}
+
+func (d *testDriver) Open(string) (driver.Conn, error) {
+	return testConn{driver: d}, nil
+}
+
+type testConn struct {
+	driver *testDriver
+}
+
+func (c testConn) Prepare(query string) (driver.Stmt, error) {
+	return testStmt{driver: c.driver, query: query}, nil
+}
+
+func (testConn) Close() error {
+	return nil
+}
+
+func (testConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("only read-only transactions are supported")
+}
+
+func (c testConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if !opts.ReadOnly {
+		return nil, errors.New("only read-only transactions are supported")
+	}
+
+	c.driver.lastTx = &testTx{}
+
+	return c.driver.lastTx, nil
+}
+
+type testTx struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *testTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *testTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+type testStmt struct {
+	driver *testDriver
+	query  string
+}
+
+func (testStmt) Close() error {
+	return nil
+}
+
+func (testStmt) NumInput() int {
+	return 0
+}
+
+func (testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.driver.lastQuery = s.query
+	if s.driver.err != nil {
+		return nil, s.driver.err
+	}
+
+	return &testRows{columns: s.driver.columns, rows: s.driver.rows}, nil
+}
+
+type testRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *testRows) Columns() []string {
+	return r.columns
+}
+
+func (*testRows) Close() error {
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
+
+var testSQLDriver = &testDriver{}
+
+func init() {
+	sql.Register("moira-test", testSQLDriver)
+	readOnlyQueriers["moira-test"] = queryInReadOnlyTransaction
+
+	sql.Register("moira-test-writable", &testDriver{})
+}
+
+func TestSQLFetch(t *testing.T) {
+	source, err := Create(&Config{
+		Driver:         "moira-test",
+		DSN:            "test",
+		MetricsTTL:     time.Hour,
+		RequestTimeout: time.Second,
+		Step:           time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const query = "SELECT 'orders', toStartOfMinute(ts), count() FROM orders WHERE ts BETWEEN {{from}} AND {{until}} GROUP BY 2"
+
+	Convey("Test sql fetch", t, func() {
+		testSQLDriver.columns = []string{"series", "ts", "value"}
+		testSQLDriver.err = nil
+
+		Convey("Rows are bucketed by the step", func() {
+			testSQLDriver.rows = [][]driver.Value{
+				{"orders", time.Unix(testFrom, 0), int64(10)},
+				{"orders", time.Unix(testFrom+120, 0), int64(12)},
+				{[]byte("payments.failed"), int64(testFrom + 5), []byte("1.5")},
+				{"payments.failed", "1700000050", 2.5},
+				{"payments.failed", time.Unix(testFrom+60, 0), nil},
+			}
+
+			res, err := source.Fetch(query, testFrom+10, testUntil+10, true)
+			So(err, ShouldBeNil)
+			So(testSQLDriver.lastQuery, ShouldEqual,
+				"SELECT 'orders', toStartOfMinute(ts), count() FROM orders WHERE ts BETWEEN 1700000040 AND 1700000160 GROUP BY 2")
+			So(testSQLDriver.lastTx.rolledBack, ShouldBeTrue)
+			So(testSQLDriver.lastTx.committed, ShouldBeFalse)
+
+			data := res.GetMetricsData()
+			So(data, ShouldHaveLength, 2)
+
+			So(data[0].Name, ShouldEqual, "orders")
+			So(data[0].StartTime, ShouldEqual, testFrom)
+			So(data[0].StopTime, ShouldEqual, testUntil)
+			So(data[0].StepTime, ShouldEqual, 60)
+			So(data[0].Values[0], ShouldEqual, 10)
+			So(math.IsNaN(data[0].Values[1]), ShouldBeTrue)
+			So(data[0].Values[2], ShouldEqual, 12)
+
+			So(data[1].Name, ShouldEqual, "payments.failed")
+			So(data[1].Values[0], ShouldEqual, 4)
+			So(math.IsNaN(data[1].Values[1]), ShouldBeTrue)
+		})
+
+		Convey("Current step is trimmed without real time alerting", func() {
+			testSQLDriver.rows = [][]driver.Value{
+				{"orders", time.Unix(testFrom, 0), int64(10)},
+				{"orders", time.Unix(testUntil, 0), int64(12)},
+			}
+
+			res, err := source.Fetch(query, testFrom, testUntil, false)
+			So(err, ShouldBeNil)
+			So(res.GetMetricsData()[0].StopTime, ShouldEqual, testUntil-60)
+			So(res.GetMetricsData()[0].Values, ShouldHaveLength, 2)
+		})
+
+		Convey("Query with wrong columns", func() {
+			testSQLDriver.columns = []string{"series", "value"}
+			testSQLDriver.rows = nil
+
+			_, err := source.Fetch(query, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+		})
+
+		Convey("Row with wrong value", func() {
+			testSQLDriver.rows = [][]driver.Value{{"orders", time.Unix(testFrom, 0), "many"}}
+
+			_, err := source.Fetch(query, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+		})
+
+		Convey("Query with several statements is not run", func() {
+			testSQLDriver.lastQuery = ""
+
+			_, err := source.Fetch("SELECT 'orders', now(), 1; DROP TABLE orders", testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+			So(testSQLDriver.lastQuery, ShouldBeEmpty)
+		})
+
+		Convey("Broken connection", func() {
+			testSQLDriver.err = driver.ErrBadConn
+
+			_, err := source.Fetch(query, testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrDatabaseUnavailable{})
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	Convey("Test creating sql source", t, func() {
+		Convey("With invalid config", func() {
+			_, err := Create(&Config{Driver: "moira-test"})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("With registered driver which can not run queries read-only", func() {
+			_, err := Create(&Config{Driver: "moira-test-writable", DSN: "test", RequestTimeout: time.Second, Step: time.Minute})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("With supported drivers", func() {
+			for _, driver := range []string{"postgres", "clickhouse"} {
+				So(ValidateDriver(driver), ShouldBeNil)
+			}
+		})
+
+		Convey("With unknown driver", func() {
+			_, err := Create(&Config{Driver: "unknown", DSN: "test", RequestTimeout: time.Second, Step: time.Minute})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestValidateQuery(t *testing.T) {
+	Convey("Test validating sql query", t, func() {
+		Convey("Single statements are valid", func() {
+			for _, query := range []string{
+				"SELECT 'a', ts, v FROM t",
+				"SELECT 'a', ts, v FROM t;",
+				"SELECT 'a', ts, v FROM t; \n",
+				"SELECT 'a;b', ts, v FROM t",
+				`SELECT "a;b", ts, v FROM t`,
+				"SELECT 'it''s', ts, v FROM t",
+				"SELECT 'a', ts, v FROM t -- comment; DROP TABLE t",
+				"SELECT 'a', ts, v /* ; DROP TABLE t */ FROM t",
+				"SELECT 'a', ts, v FROM t; -- comment",
+			} {
+				So(validateQuery(query), ShouldBeNil)
+			}
+		})
+
+		Convey("Several statements are invalid", func() {
+			for _, query := range []string{
+				"SELECT 'a', ts, v FROM t; DROP TABLE t",
+				"SELECT 'a', ts, v FROM t;DROP TABLE t;",
+				"COMMIT; DELETE FROM t",
+				"SELECT 'a', ts, v FROM t; /* comment */ DROP TABLE t",
+				"SELECT '\\'';DROP TABLE t;--'",
+				"SELECT '\\';DROP TABLE t;--'",
+			} {
+				So(validateQuery(query), ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// readOnlyQuerier runs the query so that it can not change data in the database and passes its rows to read.
+type readOnlyQuerier func(ctx context.Context, db *sql.DB, query string, read func(*sql.Rows) error) error
+
+// readOnlyQueriers holds supported drivers, each database has its own way to run queries read-only.
+var readOnlyQueriers = map[string]readOnlyQuerier{
+	"postgres":   queryInReadOnlyTransaction,
+	"clickhouse": queryWithReadOnlySetting,
+}
+
+// queryInReadOnlyTransaction runs the query in read-only transaction which is never committed.
+func queryInReadOnlyTransaction(ctx context.Context, db *sql.DB, query string, read func(*sql.Rows) error) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return read(rows)
+}
+
+// queryWithReadOnlySetting runs the query with ClickHouse readonly=1 setting, which allows only reading queries
+// and forbids changing settings. ClickHouse has no read-only transactions.
+func queryWithReadOnlySetting(ctx context.Context, db *sql.DB, query string, read func(*sql.Rows) error) error {
+	ctx = clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"readonly": 1}))
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return read(rows)
+}
@@ -0,0 +1,101 @@
+package sqldb
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	chProto "github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testClickHouse is ClickHouse HTTP interface which answers queries with prepared blocks in Native format
+// and remembers the settings of the last query of a trigger.
+type testClickHouse struct {
+	mutex        sync.Mutex
+	lastSettings url.Values
+}
+
+func (server *testClickHouse) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	query := string(body)
+
+	block := &chProto.Block{}
+
+	switch query {
+	case "SELECT timezone()":
+		block.AddColumn("timezone()", "String") //nolint:errcheck
+		block.Append("UTC")                     //nolint:errcheck
+	case "SELECT version()":
+		block.AddColumn("version()", "String") //nolint:errcheck
+		block.Append("24.8.1.1")               //nolint:errcheck
+	default:
+		server.mutex.Lock()
+		server.lastSettings = r.URL.Query()
+		server.mutex.Unlock()
+
+		if r.URL.Query().Get("readonly") == "1" && !strings.HasPrefix(query, "SELECT") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("Code: 164. DB::Exception: Cannot execute query in readonly mode. (READONLY)")) //nolint:errcheck
+
+			return
+		}
+
+		block.AddColumn("series", "String")                     //nolint:errcheck
+		block.AddColumn("ts", "DateTime")                       //nolint:errcheck
+		block.AddColumn("value", "Float64")                     //nolint:errcheck
+		block.Append("orders", time.Unix(testFrom, 0), 10.0)    //nolint:errcheck
+		block.Append("orders", time.Unix(testFrom+60, 0), 12.0) //nolint:errcheck
+	}
+
+	buffer := &proto.Buffer{}
+	if err := block.Encode(buffer, 0); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(buffer.Buf) //nolint:errcheck
+}
+
+func TestClickHouseFetch(t *testing.T) {
+	clickHouse := &testClickHouse{}
+	server := httptest.NewServer(clickHouse)
+	defer server.Close()
+
+	source, err := Create(&Config{
+		Driver:         "clickhouse",
+		DSN:            server.URL + "?readonly=0",
+		MetricsTTL:     time.Hour,
+		RequestTimeout: time.Second,
+		Step:           time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Test clickhouse fetch", t, func() {
+		Convey("Query is run with readonly setting", func() {
+			res, err := source.Fetch("SELECT 'orders', ts, v FROM orders WHERE ts >= {{from}}", testFrom, testUntil, true)
+			So(err, ShouldBeNil)
+			So(clickHouse.lastSettings.Get("readonly"), ShouldEqual, "1")
+
+			data := res.GetMetricsData()
+			So(data, ShouldHaveLength, 1)
+			So(data[0].Name, ShouldEqual, "orders")
+			So(data[0].Values[0], ShouldEqual, 10)
+			So(data[0].Values[1], ShouldEqual, 12)
+		})
+
+		Convey("Query which changes data is rejected", func() {
+			_, err := source.Fetch("INSERT INTO orders SELECT 'orders', now(), 1", testFrom, testUntil, true)
+			So(err, ShouldHaveSameTypeAs, ErrInvalidQuery{})
+			So(clickHouse.lastSettings.Get("readonly"), ShouldEqual, "1")
+		})
+	})
+}
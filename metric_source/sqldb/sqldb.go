@@ -0,0 +1,112 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	// Driver of PostgreSQL.
+	_ "github.com/lib/pq"
+
+	// Driver of ClickHouse.
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// ErrInvalidQuery is returned when the database rejects the query or its result has unexpected format.
+type ErrInvalidQuery struct {
+	InternalError error
+	Target        string
+}
+
+// Error is a representation of Error interface method.
+func (err ErrInvalidQuery) Error() string {
+	return err.InternalError.Error()
+}
+
+// ErrDatabaseUnavailable is returned when the database is not available.
+type ErrDatabaseUnavailable struct {
+	InternalError error
+	Target        string
+}
+
+// Error is a representation of Error interface method.
+func (err ErrDatabaseUnavailable) Error() string {
+	return err.InternalError.Error()
+}
+
+// SQLDB is implementation of MetricSource interface, which evaluates SQL queries returning
+// (series, timestamp, value) rows against PostgreSQL ("postgres" driver) or ClickHouse ("clickhouse" driver).
+// Queries are run read-only in the way of the database: in read-only transactions which are always rolled back
+// in PostgreSQL and with readonly=1 setting in ClickHouse. Still the DSN must use a read-only user.
+type SQLDB struct {
+	config  *Config
+	db      *sql.DB
+	querier readOnlyQuerier
+}
+
+// Create configures SQL database metric source.
+func Create(config *Config) (metricSource.MetricSource, error) {
+	if err := moira.ValidateStruct(config); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateDriver(config.Driver); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sql database: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConnections)
+
+	return &SQLDB{
+		config:  config,
+		db:      db,
+		querier: readOnlyQueriers[config.Driver],
+	}, nil
+}
+
+// ValidateDriver checks that the database/sql driver with given name is registered
+// and Moira knows how to run queries read-only with it.
+func ValidateDriver(driver string) error {
+	if _, ok := readOnlyQueriers[driver]; !ok || !slices.Contains(sql.Drivers(), driver) {
+		return fmt.Errorf("unsupported sql driver %q, supported drivers: %s", driver, strings.Join(supportedDrivers(), ", "))
+	}
+
+	return nil
+}
+
+func supportedDrivers() []string {
+	drivers := make([]string, 0, len(readOnlyQueriers))
+	for driver := range readOnlyQueriers {
+		drivers = append(drivers, driver)
+	}
+
+	slices.Sort(drivers)
+
+	return drivers
+}
+
+// GetMetricsTTLSeconds returns maximum time interval that we are allowed to fetch from the database.
+func (source *SQLDB) GetMetricsTTLSeconds() int64 {
+	return int64(source.config.MetricsTTL.Seconds())
+}
+
+// IsAvailable checks if the database is available.
+func (source *SQLDB) IsAvailable() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), source.config.RequestTimeout)
+	defer cancel()
+
+	if err := source.db.PingContext(ctx); err != nil {
+		return false, ErrDatabaseUnavailable{InternalError: err}
+	}
+
+	return true, nil
+}
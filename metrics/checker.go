@@ -8,10 +8,12 @@ import (
 
 // CheckerMetrics is a collection of metrics used in checker.
 type CheckerMetrics struct {
-	MetricsBySource        map[moira.ClusterKey]*CheckMetrics
-	MetricEventsChannelLen Histogram
-	UnusedTriggersCount    Histogram
-	MetricEventsHandleTime Timer
+	MetricsBySource         map[moira.ClusterKey]*CheckMetrics
+	MetricEventsChannelLen  Histogram
+	UnusedTriggersCount     Histogram
+	MetricEventsHandleTime  Timer
+	MetricValuesCacheHits   Counter
+	MetricValuesCacheMisses Counter
 }
 
 // GetCheckMetrics return check metrics dependent on given trigger type.
@@ -59,11 +61,23 @@ func ConfigureCheckerMetrics(registry Registry, attributedRegistry MetricRegistr
 		return nil, err
 	}
 
+	metricValuesCacheHits, err := attributedRegistry.NewCounter("metric_values_cache.hits")
+	if err != nil {
+		return nil, err
+	}
+
+	metricValuesCacheMisses, err := attributedRegistry.NewCounter("metric_values_cache.misses")
+	if err != nil {
+		return nil, err
+	}
+
 	metrics := &CheckerMetrics{
-		MetricsBySource:        make(map[moira.ClusterKey]*CheckMetrics),
-		MetricEventsChannelLen: NewCompositeHistogram(registry.NewHistogram("metricEvents"), metricEventsChannelLen),
-		MetricEventsHandleTime: NewCompositeTimer(registry.NewTimer("metricEventsHandle"), metricEventsHandleTime),
-		UnusedTriggersCount:    NewCompositeHistogram(registry.NewHistogram("triggers", "unused"), unusedTriggersCount),
+		MetricsBySource:         make(map[moira.ClusterKey]*CheckMetrics),
+		MetricEventsChannelLen:  NewCompositeHistogram(registry.NewHistogram("metricEvents"), metricEventsChannelLen),
+		MetricEventsHandleTime:  NewCompositeTimer(registry.NewTimer("metricEventsHandle"), metricEventsHandleTime),
+		UnusedTriggersCount:     NewCompositeHistogram(registry.NewHistogram("triggers", "unused"), unusedTriggersCount),
+		MetricValuesCacheHits:   NewCompositeCounter(registry.NewCounter("metricValuesCache", "hits"), metricValuesCacheHits),
+		MetricValuesCacheMisses: NewCompositeCounter(registry.NewCounter("metricValuesCache", "misses"), metricValuesCacheMisses),
 	}
 
 	for _, clusterKey := range sources {
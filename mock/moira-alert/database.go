@@ -56,6 +56,20 @@ func (mr *MockDatabaseMockRecorder) AcquireTriggerCheckLock(triggerID, maxAttemp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireTriggerCheckLock", reflect.TypeOf((*MockDatabase)(nil).AcquireTriggerCheckLock), triggerID, maxAttemptsCount)
 }
 
+// AddDeadLetterNotification mocks base method.
+func (m *MockDatabase) AddDeadLetterNotification(notification *moira.DeadLetterNotification, maxSize int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetterNotification", notification, maxSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeadLetterNotification indicates an expected call of AddDeadLetterNotification.
+func (mr *MockDatabaseMockRecorder) AddDeadLetterNotification(notification, maxSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetterNotification", reflect.TypeOf((*MockDatabase)(nil).AddDeadLetterNotification), notification, maxSize)
+}
+
 // AddDeliveryChecksData mocks base method.
 func (m *MockDatabase) AddDeliveryChecksData(contactType string, timestamp int64, data string) error {
 	m.ctrl.T.Helper()
@@ -356,6 +370,36 @@ func (mr *MockDatabaseMockRecorder) FetchTriggersToReindex(from any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTriggersToReindex", reflect.TypeOf((*MockDatabase)(nil).FetchTriggersToReindex), from)
 }
 
+// GetAPIToken mocks base method.
+func (m *MockDatabase) GetAPIToken(tokenID string) (moira.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIToken", tokenID)
+	ret0, _ := ret[0].(moira.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIToken indicates an expected call of GetAPIToken.
+func (mr *MockDatabaseMockRecorder) GetAPIToken(tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIToken", reflect.TypeOf((*MockDatabase)(nil).GetAPIToken), tokenID)
+}
+
+// GetAPITokenByHash mocks base method.
+func (m *MockDatabase) GetAPITokenByHash(hash string) (moira.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenByHash", hash)
+	ret0, _ := ret[0].(moira.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenByHash indicates an expected call of GetAPITokenByHash.
+func (mr *MockDatabaseMockRecorder) GetAPITokenByHash(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenByHash", reflect.TypeOf((*MockDatabase)(nil).GetAPITokenByHash), hash)
+}
+
 // GetAllContacts mocks base method.
 func (m *MockDatabase) GetAllContacts() ([]*moira.ContactData, error) {
 	m.ctrl.T.Helper()
@@ -401,6 +445,21 @@ func (mr *MockDatabaseMockRecorder) GetAllTriggerIDs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetAllTriggerIDs))
 }
 
+// GetAuthSession mocks base method.
+func (m *MockDatabase) GetAuthSession(sessionHash string) (moira.AuthSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthSession", sessionHash)
+	ret0, _ := ret[0].(moira.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthSession indicates an expected call of GetAuthSession.
+func (mr *MockDatabaseMockRecorder) GetAuthSession(sessionHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthSession", reflect.TypeOf((*MockDatabase)(nil).GetAuthSession), sessionHash)
+}
+
 // GetChatByUsername mocks base method.
 func (m *MockDatabase) GetChatByUsername(messenger, username string) (string, error) {
 	m.ctrl.T.Helper()
@@ -491,6 +550,37 @@ func (mr *MockDatabaseMockRecorder) GetContactsScore(contactIDs any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsScore", reflect.TypeOf((*MockDatabase)(nil).GetContactsScore), contactIDs)
 }
 
+// GetDeadLetterNotification mocks base method.
+func (m *MockDatabase) GetDeadLetterNotification(id string) (moira.DeadLetterNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterNotification", id)
+	ret0, _ := ret[0].(moira.DeadLetterNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterNotification indicates an expected call of GetDeadLetterNotification.
+func (mr *MockDatabaseMockRecorder) GetDeadLetterNotification(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterNotification", reflect.TypeOf((*MockDatabase)(nil).GetDeadLetterNotification), id)
+}
+
+// GetDeadLetterNotifications mocks base method.
+func (m *MockDatabase) GetDeadLetterNotifications(start, end int64) ([]*moira.DeadLetterNotification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterNotifications", start, end)
+	ret0, _ := ret[0].([]*moira.DeadLetterNotification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeadLetterNotifications indicates an expected call of GetDeadLetterNotifications.
+func (mr *MockDatabaseMockRecorder) GetDeadLetterNotifications(start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterNotifications", reflect.TypeOf((*MockDatabase)(nil).GetDeadLetterNotifications), start, end)
+}
+
 // GetDeliveryChecksData mocks base method.
 func (m *MockDatabase) GetDeliveryChecksData(contactType, from, to string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -506,6 +596,51 @@ func (mr *MockDatabaseMockRecorder) GetDeliveryChecksData(contactType, from, to
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryChecksData", reflect.TypeOf((*MockDatabase)(nil).GetDeliveryChecksData), contactType, from, to)
 }
 
+// GetIncidentMaintenance mocks base method.
+func (m *MockDatabase) GetIncidentMaintenance(incidentKey string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentMaintenance", incidentKey)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentMaintenance indicates an expected call of GetIncidentMaintenance.
+func (mr *MockDatabaseMockRecorder) GetIncidentMaintenance(incidentKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentMaintenance", reflect.TypeOf((*MockDatabase)(nil).GetIncidentMaintenance), incidentKey)
+}
+
+// GetMaintenanceWindow mocks base method.
+func (m *MockDatabase) GetMaintenanceWindow(windowID string) (moira.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaintenanceWindow", windowID)
+	ret0, _ := ret[0].(moira.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaintenanceWindow indicates an expected call of GetMaintenanceWindow.
+func (mr *MockDatabaseMockRecorder) GetMaintenanceWindow(windowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaintenanceWindow", reflect.TypeOf((*MockDatabase)(nil).GetMaintenanceWindow), windowID)
+}
+
+// GetMaintenanceWindows mocks base method.
+func (m *MockDatabase) GetMaintenanceWindows() ([]*moira.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaintenanceWindows")
+	ret0, _ := ret[0].([]*moira.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaintenanceWindows indicates an expected call of GetMaintenanceWindows.
+func (mr *MockDatabaseMockRecorder) GetMaintenanceWindows() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaintenanceWindows", reflect.TypeOf((*MockDatabase)(nil).GetMaintenanceWindows))
+}
+
 // GetMetricRetention mocks base method.
 func (m *MockDatabase) GetMetricRetention(metric string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -594,6 +729,21 @@ func (mr *MockDatabaseMockRecorder) GetNotificationEvents(triggerID, page, size,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationEvents", reflect.TypeOf((*MockDatabase)(nil).GetNotificationEvents), triggerID, page, size, from, to)
 }
 
+// GetNotificationThread mocks base method.
+func (m *MockDatabase) GetNotificationThread(senderType, triggerID, chatID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationThread", senderType, triggerID, chatID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationThread indicates an expected call of GetNotificationThread.
+func (mr *MockDatabaseMockRecorder) GetNotificationThread(senderType, triggerID, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationThread", reflect.TypeOf((*MockDatabase)(nil).GetNotificationThread), senderType, triggerID, chatID)
+}
+
 // GetNotifications mocks base method.
 func (m *MockDatabase) GetNotifications(start, end int64) ([]*moira.ScheduledNotification, int64, error) {
 	m.ctrl.T.Helper()
@@ -746,6 +896,36 @@ func (mr *MockDatabaseMockRecorder) GetRemoteChecksUpdatesCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteChecksUpdatesCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteChecksUpdatesCount))
 }
 
+// GetSilence mocks base method.
+func (m *MockDatabase) GetSilence(silenceID string) (moira.Silence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSilence", silenceID)
+	ret0, _ := ret[0].(moira.Silence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSilence indicates an expected call of GetSilence.
+func (mr *MockDatabaseMockRecorder) GetSilence(silenceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSilence", reflect.TypeOf((*MockDatabase)(nil).GetSilence), silenceID)
+}
+
+// GetSilences mocks base method.
+func (m *MockDatabase) GetSilences() ([]*moira.Silence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSilences")
+	ret0, _ := ret[0].([]*moira.Silence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSilences indicates an expected call of GetSilences.
+func (mr *MockDatabaseMockRecorder) GetSilences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSilences", reflect.TypeOf((*MockDatabase)(nil).GetSilences))
+}
+
 // GetSubscription mocks base method.
 func (m *MockDatabase) GetSubscription(id string) (moira.SubscriptionData, error) {
 	m.ctrl.T.Helper()
@@ -836,6 +1016,21 @@ func (mr *MockDatabaseMockRecorder) GetTeam(teamID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockDatabase)(nil).GetTeam), teamID)
 }
 
+// GetTeamAPITokens mocks base method.
+func (m *MockDatabase) GetTeamAPITokens(teamID string) ([]*moira.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamAPITokens", teamID)
+	ret0, _ := ret[0].([]*moira.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamAPITokens indicates an expected call of GetTeamAPITokens.
+func (mr *MockDatabaseMockRecorder) GetTeamAPITokens(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamAPITokens", reflect.TypeOf((*MockDatabase)(nil).GetTeamAPITokens), teamID)
+}
+
 // GetTeamByName mocks base method.
 func (m *MockDatabase) GetTeamByName(name string) (moira.Team, error) {
 	m.ctrl.T.Helper()
@@ -866,6 +1061,36 @@ func (mr *MockDatabaseMockRecorder) GetTeamContactIDs(teamID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamContactIDs", reflect.TypeOf((*MockDatabase)(nil).GetTeamContactIDs), teamID)
 }
 
+// GetTeamOnCallSchedule mocks base method.
+func (m *MockDatabase) GetTeamOnCallSchedule(teamID string) (moira.OnCallSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamOnCallSchedule", teamID)
+	ret0, _ := ret[0].(moira.OnCallSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamOnCallSchedule indicates an expected call of GetTeamOnCallSchedule.
+func (mr *MockDatabaseMockRecorder) GetTeamOnCallSchedule(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamOnCallSchedule", reflect.TypeOf((*MockDatabase)(nil).GetTeamOnCallSchedule), teamID)
+}
+
+// GetTeamPreferences mocks base method.
+func (m *MockDatabase) GetTeamPreferences(teamID string) (moira.TeamPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamPreferences", teamID)
+	ret0, _ := ret[0].(moira.TeamPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamPreferences indicates an expected call of GetTeamPreferences.
+func (mr *MockDatabaseMockRecorder) GetTeamPreferences(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamPreferences", reflect.TypeOf((*MockDatabase)(nil).GetTeamPreferences), teamID)
+}
+
 // GetTeamSubscriptionIDs mocks base method.
 func (m *MockDatabase) GetTeamSubscriptionIDs(teamID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -881,6 +1106,21 @@ func (mr *MockDatabaseMockRecorder) GetTeamSubscriptionIDs(teamID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSubscriptionIDs", reflect.TypeOf((*MockDatabase)(nil).GetTeamSubscriptionIDs), teamID)
 }
 
+// GetTeamUserRoles mocks base method.
+func (m *MockDatabase) GetTeamUserRoles(teamID string) (map[string]moira.TeamRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamUserRoles", teamID)
+	ret0, _ := ret[0].(map[string]moira.TeamRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamUserRoles indicates an expected call of GetTeamUserRoles.
+func (mr *MockDatabaseMockRecorder) GetTeamUserRoles(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamUserRoles", reflect.TypeOf((*MockDatabase)(nil).GetTeamUserRoles), teamID)
+}
+
 // GetTeamUsers mocks base method.
 func (m *MockDatabase) GetTeamUsers(teamID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -1077,6 +1317,21 @@ func (mr *MockDatabaseMockRecorder) GetUnusedTriggerIDs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnusedTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetUnusedTriggerIDs))
 }
 
+// GetUserAPITokens mocks base method.
+func (m *MockDatabase) GetUserAPITokens(userLogin string) ([]*moira.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAPITokens", userLogin)
+	ret0, _ := ret[0].([]*moira.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAPITokens indicates an expected call of GetUserAPITokens.
+func (mr *MockDatabaseMockRecorder) GetUserAPITokens(userLogin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAPITokens", reflect.TypeOf((*MockDatabase)(nil).GetUserAPITokens), userLogin)
+}
+
 // GetUserContactIDs mocks base method.
 func (m *MockDatabase) GetUserContactIDs(userLogin string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -1242,6 +1497,34 @@ func (mr *MockDatabaseMockRecorder) ReleaseTriggerCheckLock(triggerID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTriggerCheckLock", reflect.TypeOf((*MockDatabase)(nil).ReleaseTriggerCheckLock), triggerID)
 }
 
+// RemoveAPIToken mocks base method.
+func (m *MockDatabase) RemoveAPIToken(tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAPIToken", tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAPIToken indicates an expected call of RemoveAPIToken.
+func (mr *MockDatabaseMockRecorder) RemoveAPIToken(tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAPIToken", reflect.TypeOf((*MockDatabase)(nil).RemoveAPIToken), tokenID)
+}
+
+// RemoveAllDeadLetterNotifications mocks base method.
+func (m *MockDatabase) RemoveAllDeadLetterNotifications() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAllDeadLetterNotifications")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAllDeadLetterNotifications indicates an expected call of RemoveAllDeadLetterNotifications.
+func (mr *MockDatabaseMockRecorder) RemoveAllDeadLetterNotifications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllDeadLetterNotifications", reflect.TypeOf((*MockDatabase)(nil).RemoveAllDeadLetterNotifications))
+}
+
 // RemoveAllMetrics mocks base method.
 func (m *MockDatabase) RemoveAllMetrics() error {
 	m.ctrl.T.Helper()
@@ -1284,6 +1567,20 @@ func (mr *MockDatabaseMockRecorder) RemoveAllNotifications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllNotifications", reflect.TypeOf((*MockDatabase)(nil).RemoveAllNotifications))
 }
 
+// RemoveAuthSession mocks base method.
+func (m *MockDatabase) RemoveAuthSession(sessionHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAuthSession", sessionHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAuthSession indicates an expected call of RemoveAuthSession.
+func (mr *MockDatabaseMockRecorder) RemoveAuthSession(sessionHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAuthSession", reflect.TypeOf((*MockDatabase)(nil).RemoveAuthSession), sessionHash)
+}
+
 // RemoveContact mocks base method.
 func (m *MockDatabase) RemoveContact(contactID string) error {
 	m.ctrl.T.Helper()
@@ -1298,6 +1595,21 @@ func (mr *MockDatabaseMockRecorder) RemoveContact(contactID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContact", reflect.TypeOf((*MockDatabase)(nil).RemoveContact), contactID)
 }
 
+// RemoveDeadLetterNotification mocks base method.
+func (m *MockDatabase) RemoveDeadLetterNotification(id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDeadLetterNotification", id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDeadLetterNotification indicates an expected call of RemoveDeadLetterNotification.
+func (mr *MockDatabaseMockRecorder) RemoveDeadLetterNotification(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeadLetterNotification", reflect.TypeOf((*MockDatabase)(nil).RemoveDeadLetterNotification), id)
+}
+
 // RemoveDeliveryChecksData mocks base method.
 func (m *MockDatabase) RemoveDeliveryChecksData(contactType, from, to string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1328,6 +1640,34 @@ func (mr *MockDatabaseMockRecorder) RemoveFilteredNotifications(start, end, igno
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilteredNotifications", reflect.TypeOf((*MockDatabase)(nil).RemoveFilteredNotifications), start, end, ignoredTags, sourceList)
 }
 
+// RemoveIncidentMaintenance mocks base method.
+func (m *MockDatabase) RemoveIncidentMaintenance(incidentKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveIncidentMaintenance", incidentKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveIncidentMaintenance indicates an expected call of RemoveIncidentMaintenance.
+func (mr *MockDatabaseMockRecorder) RemoveIncidentMaintenance(incidentKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIncidentMaintenance", reflect.TypeOf((*MockDatabase)(nil).RemoveIncidentMaintenance), incidentKey)
+}
+
+// RemoveMaintenanceWindow mocks base method.
+func (m *MockDatabase) RemoveMaintenanceWindow(windowID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMaintenanceWindow", windowID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMaintenanceWindow indicates an expected call of RemoveMaintenanceWindow.
+func (mr *MockDatabaseMockRecorder) RemoveMaintenanceWindow(windowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMaintenanceWindow", reflect.TypeOf((*MockDatabase)(nil).RemoveMaintenanceWindow), windowID)
+}
+
 // RemoveMetricRetention mocks base method.
 func (m *MockDatabase) RemoveMetricRetention(metric string) error {
 	m.ctrl.T.Helper()
@@ -1400,6 +1740,20 @@ func (mr *MockDatabaseMockRecorder) RemoveNotification(notificationKey any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNotification", reflect.TypeOf((*MockDatabase)(nil).RemoveNotification), notificationKey)
 }
 
+// RemoveNotificationThread mocks base method.
+func (m *MockDatabase) RemoveNotificationThread(senderType, triggerID, chatID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNotificationThread", senderType, triggerID, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNotificationThread indicates an expected call of RemoveNotificationThread.
+func (mr *MockDatabaseMockRecorder) RemoveNotificationThread(senderType, triggerID, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNotificationThread", reflect.TypeOf((*MockDatabase)(nil).RemoveNotificationThread), senderType, triggerID, chatID)
+}
+
 // RemovePattern mocks base method.
 func (m *MockDatabase) RemovePattern(pattern string) error {
 	m.ctrl.T.Helper()
@@ -1456,6 +1810,20 @@ func (mr *MockDatabaseMockRecorder) RemovePatternsMetrics(pattern any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePatternsMetrics", reflect.TypeOf((*MockDatabase)(nil).RemovePatternsMetrics), pattern)
 }
 
+// RemoveSilence mocks base method.
+func (m *MockDatabase) RemoveSilence(silenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSilence", silenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSilence indicates an expected call of RemoveSilence.
+func (mr *MockDatabaseMockRecorder) RemoveSilence(silenceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSilence", reflect.TypeOf((*MockDatabase)(nil).RemoveSilence), silenceID)
+}
+
 // RemoveSubscription mocks base method.
 func (m *MockDatabase) RemoveSubscription(subscriptionID string) error {
 	m.ctrl.T.Helper()
@@ -1540,6 +1908,34 @@ func (mr *MockDatabaseMockRecorder) RemoveUser(messenger, username any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockDatabase)(nil).RemoveUser), messenger, username)
 }
 
+// SaveAPIToken mocks base method.
+func (m *MockDatabase) SaveAPIToken(token *moira.APIToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAPIToken indicates an expected call of SaveAPIToken.
+func (mr *MockDatabaseMockRecorder) SaveAPIToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIToken", reflect.TypeOf((*MockDatabase)(nil).SaveAPIToken), token)
+}
+
+// SaveAuthSession mocks base method.
+func (m *MockDatabase) SaveAuthSession(sessionHash string, session moira.AuthSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuthSession", sessionHash, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuthSession indicates an expected call of SaveAuthSession.
+func (mr *MockDatabaseMockRecorder) SaveAuthSession(sessionHash, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuthSession", reflect.TypeOf((*MockDatabase)(nil).SaveAuthSession), sessionHash, session)
+}
+
 // SaveContact mocks base method.
 func (m *MockDatabase) SaveContact(contact *moira.ContactData) error {
 	m.ctrl.T.Helper()
@@ -1554,6 +1950,20 @@ func (mr *MockDatabaseMockRecorder) SaveContact(contact any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveContact", reflect.TypeOf((*MockDatabase)(nil).SaveContact), contact)
 }
 
+// SaveMaintenanceWindow mocks base method.
+func (m *MockDatabase) SaveMaintenanceWindow(window *moira.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMaintenanceWindow", window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMaintenanceWindow indicates an expected call of SaveMaintenanceWindow.
+func (mr *MockDatabaseMockRecorder) SaveMaintenanceWindow(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMaintenanceWindow", reflect.TypeOf((*MockDatabase)(nil).SaveMaintenanceWindow), window)
+}
+
 // SaveMetrics mocks base method.
 func (m *MockDatabase) SaveMetrics(buffer []*moira.MatchedMetric) error {
 	m.ctrl.T.Helper()
@@ -1568,6 +1978,20 @@ func (mr *MockDatabaseMockRecorder) SaveMetrics(buffer any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMetrics", reflect.TypeOf((*MockDatabase)(nil).SaveMetrics), buffer)
 }
 
+// SaveSilence mocks base method.
+func (m *MockDatabase) SaveSilence(silence *moira.Silence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSilence", silence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSilence indicates an expected call of SaveSilence.
+func (mr *MockDatabaseMockRecorder) SaveSilence(silence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSilence", reflect.TypeOf((*MockDatabase)(nil).SaveSilence), silence)
+}
+
 // SaveSubscription mocks base method.
 func (m *MockDatabase) SaveSubscription(subscription *moira.SubscriptionData) error {
 	m.ctrl.T.Helper()
@@ -1610,6 +2034,48 @@ func (mr *MockDatabaseMockRecorder) SaveTeam(teamID, team any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeam", reflect.TypeOf((*MockDatabase)(nil).SaveTeam), teamID, team)
 }
 
+// SaveTeamOnCallSchedule mocks base method.
+func (m *MockDatabase) SaveTeamOnCallSchedule(teamID string, schedule moira.OnCallSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamOnCallSchedule", teamID, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTeamOnCallSchedule indicates an expected call of SaveTeamOnCallSchedule.
+func (mr *MockDatabaseMockRecorder) SaveTeamOnCallSchedule(teamID, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamOnCallSchedule", reflect.TypeOf((*MockDatabase)(nil).SaveTeamOnCallSchedule), teamID, schedule)
+}
+
+// SaveTeamPreferences mocks base method.
+func (m *MockDatabase) SaveTeamPreferences(teamID string, preferences moira.TeamPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamPreferences", teamID, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTeamPreferences indicates an expected call of SaveTeamPreferences.
+func (mr *MockDatabaseMockRecorder) SaveTeamPreferences(teamID, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamPreferences", reflect.TypeOf((*MockDatabase)(nil).SaveTeamPreferences), teamID, preferences)
+}
+
+// SaveTeamUserRole mocks base method.
+func (m *MockDatabase) SaveTeamUserRole(teamID, userID string, role moira.TeamRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamUserRole", teamID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTeamUserRole indicates an expected call of SaveTeamUserRole.
+func (mr *MockDatabaseMockRecorder) SaveTeamUserRole(teamID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamUserRole", reflect.TypeOf((*MockDatabase)(nil).SaveTeamUserRole), teamID, userID, role)
+}
+
 // SaveTeamsAndUsers mocks base method.
 func (m *MockDatabase) SaveTeamsAndUsers(teamID string, users []string, usersTeams map[string][]string) error {
 	m.ctrl.T.Helper()
@@ -1652,6 +2118,34 @@ func (mr *MockDatabaseMockRecorder) SaveTriggersSearchResults(searchResultsID, s
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTriggersSearchResults", reflect.TypeOf((*MockDatabase)(nil).SaveTriggersSearchResults), searchResultsID, searchResults, recordTTL)
 }
 
+// SetIncidentMaintenance mocks base method.
+func (m *MockDatabase) SetIncidentMaintenance(incidentKey string, maintenance int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIncidentMaintenance", incidentKey, maintenance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIncidentMaintenance indicates an expected call of SetIncidentMaintenance.
+func (mr *MockDatabaseMockRecorder) SetIncidentMaintenance(incidentKey, maintenance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIncidentMaintenance", reflect.TypeOf((*MockDatabase)(nil).SetIncidentMaintenance), incidentKey, maintenance)
+}
+
+// SetNotificationThread mocks base method.
+func (m *MockDatabase) SetNotificationThread(senderType, triggerID, chatID, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationThread", senderType, triggerID, chatID, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationThread indicates an expected call of SetNotificationThread.
+func (mr *MockDatabaseMockRecorder) SetNotificationThread(senderType, triggerID, chatID, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationThread", reflect.TypeOf((*MockDatabase)(nil).SetNotificationThread), senderType, triggerID, chatID, threadID)
+}
+
 // SetNotifierState mocks base method.
 func (m *MockDatabase) SetNotifierState(actor, state string) error {
 	m.ctrl.T.Helper()
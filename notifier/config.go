@@ -26,4 +26,6 @@ type Config struct {
 	LogContactsToLevel            map[string]string
 	LogSubscriptionsToLevel       map[string]string
 	CheckNotifierStateTimeout     time.Duration
+	DeadLetterQueueEnabled        bool
+	DeadLetterQueueMaxSize        int64
 }
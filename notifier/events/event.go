@@ -12,6 +12,14 @@ import (
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/database"
 	"github.com/moira-alert/moira/notifier"
+	"github.com/moira-alert/moira/silences"
+)
+
+const (
+	// silencesReloadInterval is how often silences are reloaded from database while events are fetched.
+	silencesReloadInterval = 10 * time.Second
+	// expiredSilencesRetention is how long expired silences are kept before being removed.
+	expiredSilencesRetention = 7 * 24 * time.Hour
 )
 
 // FetchEventsWorker checks for new events and new notifications based on it.
@@ -22,6 +30,10 @@ type FetchEventsWorker struct {
 	Metrics   *metrics.NotifierMetrics
 	Config    notifier.Config
 	tomb      tomb.Tomb
+
+	// silences are compiled not expired silences, they are used only by the fetching goroutine.
+	silences         []*silences.Silence
+	silencesLoadedAt time.Time
 }
 
 // Start is a cycle that fetches events from database.
@@ -125,17 +137,15 @@ func (worker *FetchEventsWorker) processEvent(event moira.NotificationEvent) err
 			return fmt.Errorf("no tags found for trigger id %s", event.TriggerID)
 		}
 
-		triggerData = moira.TriggerData{
-			ID:            trigger.ID,
-			Name:          trigger.Name,
-			Desc:          moira.UseString(trigger.Desc),
-			Targets:       trigger.Targets,
-			WarnValue:     moira.UseFloat64(trigger.WarnValue),
-			ErrorValue:    moira.UseFloat64(trigger.ErrorValue),
-			IsRemote:      trigger.TriggerSource == moira.GraphiteRemote,
-			TriggerSource: trigger.TriggerSource,
-			ClusterId:     trigger.ClusterId,
-			Tags:          trigger.Tags,
+		triggerData = trigger.ToTriggerData()
+
+		if silence := worker.getEventSilence(&trigger, event, log); silence != nil {
+			log.Info().
+				String("silence_id", silence.ID).
+				String("metric", event.Metric).
+				Msg("Event is silenced, skip sending notifications")
+
+			return nil
 		}
 
 		log.Debug().
@@ -258,6 +268,73 @@ func (worker *FetchEventsWorker) getNotificationSubscriptions(event moira.Notifi
 	return nil, nil
 }
 
+// getEventSilence returns active silence which matches the event or nil if the event is not silenced.
+func (worker *FetchEventsWorker) getEventSilence(trigger *moira.Trigger, event moira.NotificationEvent, logger moira.Logger) *moira.Silence {
+	now := time.Now()
+	worker.reloadSilences(now, logger)
+
+	metric := event.Metric
+	if event.IsTriggerEvent {
+		metric = ""
+	}
+
+	for _, silence := range worker.silences {
+		if silence.IsActive(now.Unix()) && silence.IsMatched(trigger, metric) {
+			return silence.Silence
+		}
+	}
+
+	return nil
+}
+
+// reloadSilences loads and compiles not expired silences at most once per silencesReloadInterval
+// and removes silences expired longer than expiredSilencesRetention ago.
+// Failure to get silences must not stop notifications, so it is only logged and previously loaded silences are kept.
+func (worker *FetchEventsWorker) reloadSilences(now time.Time, logger moira.Logger) {
+	if !worker.silencesLoadedAt.IsZero() && now.Sub(worker.silencesLoadedAt) < silencesReloadInterval {
+		return
+	}
+
+	allSilences, err := worker.Database.GetSilences()
+	if err != nil {
+		logger.Warning().
+			Error(err).
+			Msg("Failed to get silences, previously loaded silences are used")
+
+		return
+	}
+
+	worker.silencesLoadedAt = now
+	worker.silences = make([]*silences.Silence, 0, len(allSilences))
+
+	for _, silence := range allSilences {
+		if silence.ExpiresAt <= now.Unix() {
+			if silence.ExpiresAt < now.Add(-expiredSilencesRetention).Unix() {
+				if err = worker.Database.RemoveSilence(silence.ID); err != nil {
+					logger.Warning().
+						String("silence_id", silence.ID).
+						Error(err).
+						Msg("Failed to remove expired silence")
+				}
+			}
+
+			continue
+		}
+
+		compiled, err := silences.Compile(logger, silence)
+		if err != nil {
+			logger.Warning().
+				String("silence_id", silence.ID).
+				Error(err).
+				Msg("Failed to compile silence, it is skipped")
+
+			continue
+		}
+
+		worker.silences = append(worker.silences, compiled)
+	}
+}
+
 func (worker *FetchEventsWorker) isNotificationRequired(subscription *moira.SubscriptionData, trigger moira.TriggerData,
 	event moira.NotificationEvent, logger moira.Logger,
 ) bool {
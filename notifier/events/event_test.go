@@ -161,6 +161,7 @@ func TestNoSubscription(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return(make([]*moira.SubscriptionData, 0), nil)
 
 		err := worker.processEvent(event)
@@ -168,6 +169,161 @@ func TestNoSubscription(t *testing.T) {
 	})
 }
 
+func TestSilencedEvent(t *testing.T) {
+	Convey("When event is silenced, should not get subscriptions", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+		logger, _ := logging.GetLogger("Events")
+
+		worker := FetchEventsWorker{
+			Database: dataBase,
+			Logger:   logger,
+			Metrics:  notifierMetrics,
+			Config:   emptyNotifierConfig,
+		}
+
+		now := time.Now().Unix()
+		silence := &moira.Silence{
+			ID: "silence1",
+			Matchers: []moira.SilenceMatcher{
+				{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "test-tag"},
+				{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "web1"},
+			},
+			StartsAt:  now - 60,
+			ExpiresAt: now + 60,
+		}
+
+		Convey("Matching metric event is silenced", func() {
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web1",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{silence}, nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Not matching metric event is not silenced", func() {
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web2",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{silence}, nil)
+			dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return(make([]*moira.SubscriptionData, 0), nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Expired silence does not silence event", func() {
+			silence.ExpiresAt = now - 1
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web1",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{silence}, nil)
+			dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return(make([]*moira.SubscriptionData, 0), nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Silences are not reloaded within reload interval", func() {
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web1",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil).Times(2)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{silence}, nil).Times(1)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+			err = worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Silences expired longer than retention are removed", func() {
+			oldSilence := &moira.Silence{
+				ID:        "silence2",
+				Matchers:  silence.Matchers,
+				StartsAt:  now - int64(expiredSilencesRetention.Seconds()) - 120,
+				ExpiresAt: now - int64(expiredSilencesRetention.Seconds()) - 60,
+			}
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web2",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{oldSilence, silence}, nil)
+			dataBase.EXPECT().RemoveSilence(oldSilence.ID).Return(nil)
+			dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return(make([]*moira.SubscriptionData, 0), nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Failure to reload silences keeps previously loaded silences", func() {
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web1",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return([]*moira.Silence{silence}, nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+
+			worker.silencesLoadedAt = worker.silencesLoadedAt.Add(-silencesReloadInterval)
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return(nil, fmt.Errorf("test error"))
+
+			err = worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+
+		Convey("Failure to get silences does not stop notifications", func() {
+			event := moira.NotificationEvent{
+				Metric:    "cpu.user;dc=west;host=web1",
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				TriggerID: triggerData.ID,
+			}
+
+			dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+			dataBase.EXPECT().GetSilences().Return(nil, fmt.Errorf("test error"))
+			dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return(make([]*moira.SubscriptionData, 0), nil)
+
+			err := worker.processEvent(event)
+			So(err, ShouldBeEmpty)
+		})
+	})
+}
+
 func TestDisabledNotification(t *testing.T) {
 	Convey("When subscription event tags is disabled, should not call AddNotification", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -201,6 +357,7 @@ func TestDisabledNotification(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{&disabledSubscription}, nil)
 
 		logger.EXPECT().Clone().Return(logger).AnyTimes()
@@ -259,6 +416,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).
 			Return([]*moira.SubscriptionData{&subscriptionToIgnoreWarnings}, nil)
 
@@ -302,6 +460,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).
 			Return([]*moira.SubscriptionData{&subscriptionToIgnoreWarnings}, nil)
 
@@ -345,6 +504,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 
 		subscriptionToIgnoreWarningsAndRecoverings := moira.SubscriptionData{
 			ID:                "subscriptionID-00000000000003",
@@ -410,6 +570,7 @@ func TestAddNotification(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{&subscription}, nil)
 		dataBase.EXPECT().GetContact(contact.ID).Times(1).Return(contact, nil)
 		scheduler.EXPECT().ScheduleNotification(params, gomock.Any()).Times(1).Return(&emptyNotification)
@@ -461,6 +622,7 @@ func TestAddOneNotificationByTwoSubscriptionsWithSame(t *testing.T) {
 		params2.Event = event2
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{&subscription, &subscription4}, nil)
 		dataBase.EXPECT().GetContact(contact.ID).Times(2).Return(contact, nil)
 
@@ -507,6 +669,7 @@ func TestFailReadContact(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{&subscription}, nil)
 
 		getContactError := fmt.Errorf("Can not get contact")
@@ -573,6 +736,7 @@ func TestEmptySubscriptions(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{{ThrottlingEnabled: true}}, nil)
 
 		metricString := fmt.Sprintf("%s == %s", event.Metric, event.GetMetricsValues(moira.DefaultNotificationSettings))
@@ -615,6 +779,7 @@ func TestEmptySubscriptions(t *testing.T) {
 		}
 
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{nil}, nil)
 
 		metricString := fmt.Sprintf("%s == %s", event.Metric, event.GetMetricsValues(moira.DefaultNotificationSettings))
@@ -721,6 +886,7 @@ func TestGoRoutine(t *testing.T) {
 			})
 		})
 		dataBase.EXPECT().GetTrigger(event.TriggerID).Times(1).Return(trigger, nil)
+		dataBase.EXPECT().GetSilences().Return(nil, nil)
 		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Times(1).Return([]*moira.SubscriptionData{&subscription}, nil)
 		dataBase.EXPECT().GetContact(contact.ID).Times(1).Return(contact, nil)
 		scheduler.EXPECT().ScheduleNotification(params, gomock.Any()).Times(1).Return(&emptyNotification)
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/moira-alert/go-chart"
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/logging"
@@ -91,6 +92,8 @@ type StandardNotifier struct {
 	metrics              *metrics.NotifierMetrics
 	metricSourceProvider *metricSource.SourceProvider
 	imageStores          map[string]moira.ImageStore
+	senderTypes          map[string]string
+	clock                moira.Clock
 }
 
 // NewNotifier is initializer for StandardNotifier.
@@ -107,11 +110,18 @@ func NewNotifier(database moira.Database, logger moira.Logger, config Config, me
 		metrics:              metrics,
 		metricSourceProvider: metricSourceProvider,
 		imageStores:          imageStoreMap,
+		senderTypes:          make(map[string]string),
+		clock:                clock,
 	}
 }
 
 // Send is realization of StandardNotifier Send functionality.
 func (notifier *StandardNotifier) Send(pkg *NotificationPackage, waitGroup *sync.WaitGroup) {
+	if pkg.Contact.Type == moira.OnCallContactType {
+		notifier.sendToOnCall(pkg, waitGroup)
+		return
+	}
+
 	ch, found := notifier.senders[pkg.Contact.Type]
 	if !found {
 		notifier.reschedule(pkg, fmt.Sprintf("Unknown sender contact type '%s' [%s]", pkg.Contact.Type, pkg))
@@ -169,6 +179,8 @@ func (notifier *StandardNotifier) reschedule(pkg *NotificationPackage, reason st
 		logger.Error().
 			Msg("Stop resending. Notification interval is timed out")
 
+		notifier.pushToDeadLetterQueue(pkg, reason, logger)
+
 		return
 	}
 
@@ -200,6 +212,51 @@ func (notifier *StandardNotifier) reschedule(pkg *NotificationPackage, reason st
 	}
 }
 
+// pushToDeadLetterQueue saves notifications of the package which will never be sent to dead letter queue,
+// so they can be inspected and replayed later.
+func (notifier *StandardNotifier) pushToDeadLetterQueue(pkg *NotificationPackage, reason string, logger moira.Logger) {
+	if !notifier.config.DeadLetterQueueEnabled {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	for _, event := range pkg.Events {
+		id, err := uuid.NewV4()
+		if err != nil {
+			logger.Error().
+				Error(err).
+				Msg("Failed to generate dead letter notification id")
+
+			continue
+		}
+
+		deadLetter := &moira.DeadLetterNotification{
+			ID: id.String(),
+			Notification: moira.ScheduledNotification{
+				Event:     event,
+				Trigger:   pkg.Trigger,
+				Contact:   pkg.Contact,
+				Plotting:  pkg.Plotting,
+				Throttled: pkg.Throttled,
+				SendFail:  pkg.FailCount,
+				Timestamp: now,
+			},
+			Error:      reason,
+			Attempts:   pkg.FailCount + 1,
+			SenderType: notifier.senderTypes[pkg.Contact.Type],
+			Timestamp:  now,
+		}
+
+		if err := notifier.database.AddDeadLetterNotification(deadLetter, notifier.config.DeadLetterQueueMaxSize); err != nil {
+			logger.Error().
+				Error(err).
+				String(moira.LogFieldNameSubscriptionID, moira.UseString(event.SubscriptionID)).
+				Msg("Failed to save notification to dead letter queue")
+		}
+	}
+}
+
 func (notifier *StandardNotifier) runSender(sender moira.Sender, ch chan NotificationPackage) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -266,6 +323,7 @@ func (notifier *StandardNotifier) runSender(sender moira.Sender, ch chan Notific
 				Error(e).
 				Msg("Cannot send to broken contact")
 			notifier.metrics.MarkContactDroppedNotifications(pkg.Contact.Type)
+			notifier.pushToDeadLetterQueue(&pkg, e.Error(), log)
 		default:
 			if pkg.FailCount > notifier.config.MaxFailAttemptToSendAvailable {
 				log.Error().
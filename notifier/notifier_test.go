@@ -223,6 +223,56 @@ func TestNoResendForSendToBrokenContact(t *testing.T) {
 	time.Sleep(time.Second * 2)
 }
 
+func TestDeadLetterQueueForBrokenContact(t *testing.T) {
+	config := defaultConfig
+	config.DeadLetterQueueEnabled = true
+	config.DeadLetterQueueMaxSize = 100
+
+	configureNotifier(t, config)
+
+	defer afterTest()
+
+	var eventsData moira.NotificationEvents = []moira.NotificationEvent{event}
+
+	pkg := NotificationPackage{
+		Events: eventsData,
+		Contact: moira.ContactData{
+			ID:   "contactID",
+			Type: "test_contact_type",
+		},
+		FailCount: 2,
+	}
+
+	done := make(chan struct{})
+
+	sender.EXPECT().SendEvents(eventsData, pkg.Contact, pkg.Trigger, plots, pkg.Throttled).
+		Return(moira.NewSenderBrokenContactError(fmt.Errorf("some sender reason")))
+	dataBase.EXPECT().UpdateContactScores([]string{pkg.Contact.ID}, gomock.Any()).Return(nil)
+	dataBase.EXPECT().AddDeadLetterNotification(gomock.Any(), int64(100)).DoAndReturn(func(notification *moira.DeadLetterNotification, maxSize int64) error {
+		defer close(done)
+
+		require.NotEmpty(t, notification.ID)
+		require.Equal(t, "some sender reason", notification.Error)
+		require.Equal(t, 3, notification.Attempts)
+		require.Equal(t, "test_type", notification.SenderType)
+		require.Equal(t, event, notification.Notification.Event)
+		require.Equal(t, pkg.Contact, notification.Notification.Contact)
+
+		return nil
+	})
+
+	var wg sync.WaitGroup
+
+	standardNotifier.Send(&pkg, &wg)
+	wg.Wait()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("notification was not saved to dead letter queue")
+	}
+}
+
 func TestSetContactScoreIfSuccessSending(t *testing.T) {
 	configureNotifier(t, defaultConfig)
 
@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// sendToOnCall sends the package of on-call contact to contacts of the user who is on-call in the team now.
+// The package is rescheduled if nobody can receive it, so on-call user is resolved again on the next attempt.
+func (notifier *StandardNotifier) sendToOnCall(pkg *NotificationPackage, waitGroup *sync.WaitGroup) {
+	teamID := pkg.Contact.Value
+
+	contacts, err := notifier.getOnCallContacts(teamID)
+	if err != nil {
+		notifier.reschedule(pkg, fmt.Sprintf("Failed to resolve on-call of team '%s': %s [%s]", teamID, err.Error(), pkg))
+		return
+	}
+
+	for _, contact := range contacts {
+		onCallPkg := *pkg
+		onCallPkg.Contact = *contact
+
+		notifier.Send(&onCallPkg, waitGroup)
+	}
+}
+
+// getOnCallContacts returns contacts of the user who is on-call in the team now.
+func (notifier *StandardNotifier) getOnCallContacts(teamID string) ([]*moira.ContactData, error) {
+	schedule, err := notifier.database.GetTeamOnCallSchedule(teamID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, errors.New("team has no on-call schedule")
+		}
+
+		return nil, err
+	}
+
+	shift, ok := schedule.GetShift(notifier.clock.NowUTC())
+	if !ok {
+		return nil, errors.New("nobody is on-call")
+	}
+
+	contactIDs, err := notifier.database.GetUserContactIDs(shift.User)
+	if err != nil {
+		return nil, err
+	}
+
+	userContacts, err := notifier.database.GetContacts(contactIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*moira.ContactData, 0, len(userContacts))
+
+	for _, contact := range userContacts {
+		// On-call contacts of the user are skipped, so notification can not circulate between teams.
+		if contact == nil || contact.Type == moira.OnCallContactType {
+			continue
+		}
+
+		contacts = append(contacts, contact)
+	}
+
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("on-call user %s has no contacts", shift.User)
+	}
+
+	return contacts, nil
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	mock_clock "github.com/moira-alert/moira/mock/clock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSendToOnCall(t *testing.T) {
+	const teamID = "teamID"
+
+	now := time.Unix(1000, 0)
+	onCallSchedule := moira.OnCallSchedule{
+		Rotations: []moira.OnCallRotation{{Participants: []string{"user1", "user2"}, Start: 500, ShiftLength: 1000}},
+	}
+	eventsData := []moira.NotificationEvent{event}
+
+	configure := func(t *testing.T) {
+		t.Helper()
+
+		configureNotifier(t, defaultConfig)
+
+		systemClock := mock_clock.NewMockClock(mockCtrl)
+		systemClock.EXPECT().NowUTC().Return(now).AnyTimes()
+		standardNotifier.clock = systemClock
+	}
+
+	t.Run("Package is sent to contacts of on-call user", func(t *testing.T) {
+		configure(t)
+		defer afterTest()
+
+		pkg := NotificationPackage{
+			Events:  eventsData,
+			Contact: moira.ContactData{ID: "onCallContact", Type: moira.OnCallContactType, Value: teamID},
+		}
+		userContact := moira.ContactData{ID: "userContact", Type: "test_contact_type", Value: "user1@example.com", User: "user1"}
+		anotherOnCallContact := moira.ContactData{ID: "anotherOnCallContact", Type: moira.OnCallContactType, Value: "anotherTeam", User: "user1"}
+
+		dataBase.EXPECT().GetTeamOnCallSchedule(teamID).Return(onCallSchedule, nil)
+		dataBase.EXPECT().GetUserContactIDs("user1").Return([]string{userContact.ID, anotherOnCallContact.ID, "deleted"}, nil)
+		dataBase.EXPECT().GetContacts([]string{userContact.ID, anotherOnCallContact.ID, "deleted"}).
+			Return([]*moira.ContactData{&userContact, &anotherOnCallContact, nil}, nil)
+		sender.EXPECT().SendEvents(eventsData, userContact, pkg.Trigger, plots, pkg.Throttled).Return(nil)
+		dataBase.EXPECT().UpdateContactScores([]string{userContact.ID}, gomock.Any()).Return(nil)
+
+		var wg sync.WaitGroup
+
+		standardNotifier.Send(&pkg, &wg)
+		wg.Wait()
+		time.Sleep(time.Second)
+	})
+
+	t.Run("Package is rescheduled with on-call contact if team has no on-call schedule", func(t *testing.T) {
+		configure(t)
+		defer afterTest()
+
+		pkg := NotificationPackage{
+			Events:  eventsData,
+			Contact: moira.ContactData{ID: "onCallContact", Type: moira.OnCallContactType, Value: teamID},
+		}
+		params := moira.SchedulerParams{
+			Event:    event,
+			Trigger:  pkg.Trigger,
+			Contact:  pkg.Contact,
+			Plotting: pkg.Plotting,
+			SendFail: 1,
+		}
+		notification := moira.ScheduledNotification{}
+
+		dataBase.EXPECT().GetTeamOnCallSchedule(teamID).Return(moira.OnCallSchedule{}, database.ErrNil)
+		scheduler.EXPECT().ScheduleNotification(params, gomock.Any()).Return(&notification)
+		dataBase.EXPECT().AddNotification(&notification).Return(nil)
+
+		var wg sync.WaitGroup
+
+		standardNotifier.Send(&pkg, &wg)
+		wg.Wait()
+	})
+
+	t.Run("Package is rescheduled if on-call user has no contacts", func(t *testing.T) {
+		configure(t)
+		defer afterTest()
+
+		pkg := NotificationPackage{
+			Events:  eventsData,
+			Contact: moira.ContactData{ID: "onCallContact", Type: moira.OnCallContactType, Value: teamID},
+		}
+		notification := moira.ScheduledNotification{}
+
+		dataBase.EXPECT().GetTeamOnCallSchedule(teamID).Return(onCallSchedule, nil)
+		dataBase.EXPECT().GetUserContactIDs("user1").Return([]string{}, nil)
+		dataBase.EXPECT().GetContacts([]string{}).Return([]*moira.ContactData{}, nil)
+		scheduler.EXPECT().ScheduleNotification(gomock.Any(), gomock.Any()).Return(&notification)
+		dataBase.EXPECT().AddNotification(&notification).Return(nil)
+
+		var wg sync.WaitGroup
+
+		standardNotifier.Send(&pkg, &wg)
+		wg.Wait()
+	})
+}
@@ -10,38 +10,49 @@ import (
 	"github.com/moira-alert/moira/metrics"
 	"github.com/moira-alert/moira/senders/delivery"
 	"github.com/moira-alert/moira/senders/discord"
+	"github.com/moira-alert/moira/senders/googlechat"
 	"github.com/moira-alert/moira/senders/mail"
+	"github.com/moira-alert/moira/senders/matrix"
 	"github.com/moira-alert/moira/senders/mattermost"
 	"github.com/moira-alert/moira/senders/msteams"
 	"github.com/moira-alert/moira/senders/opsgenie"
 	"github.com/moira-alert/moira/senders/pagerduty"
 	"github.com/moira-alert/moira/senders/pushover"
+	"github.com/moira-alert/moira/senders/rocketchat"
 	"github.com/moira-alert/moira/senders/script"
 	"github.com/moira-alert/moira/senders/selfstate"
 	"github.com/moira-alert/moira/senders/slack"
+	"github.com/moira-alert/moira/senders/teamsworkflows"
 	"github.com/moira-alert/moira/senders/telegram"
 	"github.com/moira-alert/moira/senders/twilio"
 	"github.com/moira-alert/moira/senders/victorops"
 	"github.com/moira-alert/moira/senders/webhook"
+	"github.com/moira-alert/moira/senders/zulip"
 	// "github.com/moira-alert/moira/senders/kontur"
 )
 
 const (
-	mailSender        = "mail"
-	pushoverSender    = "pushover"
-	discordSender     = "discord"
-	scriptSender      = "script"
-	selfStateSender   = "selfstate"
-	slackSender       = "slack"
-	telegramSender    = "telegram"
-	twilioSmsSender   = "twilio sms"
-	twilioVoiceSender = "twilio voice"
-	webhookSender     = "webhook"
-	opsgenieSender    = "opsgenie"
-	victoropsSender   = "victorops"
-	pagerdutySender   = "pagerduty"
-	msTeamsSender     = "msteams"
-	mattermostSender  = "mattermost"
+	mailSender           = "mail"
+	pushoverSender       = "pushover"
+	discordSender        = "discord"
+	scriptSender         = "script"
+	selfStateSender      = "selfstate"
+	slackSender          = "slack"
+	telegramSender       = "telegram"
+	twilioSmsSender      = "twilio sms"
+	twilioVoiceSender    = "twilio voice"
+	webhookSender        = "webhook"
+	opsgenieSender       = "opsgenie"
+	victoropsSender      = "victorops"
+	pagerdutySender      = "pagerduty"
+	msTeamsSender        = "msteams"
+	mattermostSender     = "mattermost"
+	teamsWorkflowsSender = "msteams workflows"
+	googleChatSender     = "google chat"
+	matrixSender         = "matrix"
+	zulipSender          = "zulip"
+	rocketChatSender     = "rocketchat"
+	mailAPISender        = "mail api"
 )
 
 var (
@@ -79,6 +90,8 @@ func (notifier *StandardNotifier) RegisterSenders(connector moira.Database) erro
 		switch senderType {
 		case mailSender:
 			err = notifier.RegisterSender(senderSettings, &mail.Sender{})
+		case mailAPISender:
+			err = notifier.RegisterSender(senderSettings, &mail.HTTPAPISender{})
 		case pushoverSender:
 			err = notifier.RegisterSender(senderSettings, &pushover.Sender{})
 		case scriptSender:
@@ -99,13 +112,23 @@ func (notifier *StandardNotifier) RegisterSenders(connector moira.Database) erro
 			workerLock := connector.NewLock(workerDeliveryCheckLockKey(senderContactType), deliveryCheckLockTTL)
 			controller := delivery.NewChecksController(connector, workerLock, senderContactType)
 
-			err = notifier.RegisterSender(senderSettings, &webhook.Sender{Controller: controller})
+			err = notifier.RegisterSender(senderSettings, &webhook.Sender{Controller: controller, ImageStores: notifier.imageStores})
 		case opsgenieSender:
 			err = notifier.RegisterSender(senderSettings, &opsgenie.Sender{ImageStores: notifier.imageStores})
 		case victoropsSender:
 			err = notifier.RegisterSender(senderSettings, &victorops.Sender{ImageStores: notifier.imageStores})
 		case mattermostSender:
 			err = notifier.RegisterSender(senderSettings, &mattermost.Sender{})
+		case teamsWorkflowsSender:
+			err = notifier.RegisterSender(senderSettings, &teamsworkflows.Sender{ImageStores: notifier.imageStores})
+		case googleChatSender:
+			err = notifier.RegisterSender(senderSettings, &googlechat.Sender{ImageStores: notifier.imageStores})
+		case matrixSender:
+			err = notifier.RegisterSender(senderSettings, &matrix.Sender{DataBase: connector})
+		case zulipSender:
+			err = notifier.RegisterSender(senderSettings, &zulip.Sender{})
+		case rocketChatSender:
+			err = notifier.RegisterSender(senderSettings, &rocketchat.Sender{ImageStores: notifier.imageStores})
 		// case "email":
 		// 	err = notifier.RegisterSender(senderSettings, &kontur.MailSender{})
 		// case "phone":
@@ -212,6 +235,7 @@ func (notifier *StandardNotifier) RegisterSender(senderSettings map[string]inter
 
 	eventsChannel := make(chan NotificationPackage)
 	notifier.senders[senderContactType] = eventsChannel
+	notifier.senderTypes[senderContactType] = senderType
 
 	err = notifier.registerMetrics(senderContactType)
 	if err != nil {
@@ -1,11 +1,13 @@
 package notifier
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
 	"github.com/moira-alert/moira/metrics"
 )
 
@@ -164,10 +166,55 @@ func (scheduler *StandardScheduler) calculateNextDelivery(now time.Time, event *
 			Msg("Failed to apply schedule")
 	}
 
+	if subscription.TeamID != "" {
+		next = scheduler.applyTeamQuietHours(subscription, next, logger)
+	}
+
 	return next, alarmFatigue
 }
 
+// maxQuietHoursShifts limits alternation of team quiet hours and subscription schedule
+// when end of quiet hours is out of schedule and next allowed schedule day is in quiet hours.
+const maxQuietHoursShifts = 10
+
+// applyTeamQuietHours postpones delivery of team subscription notification until the end of team quiet hours.
+func (scheduler *StandardScheduler) applyTeamQuietHours(subscription moira.SubscriptionData, next time.Time, logger moira.Logger) time.Time {
+	preferences, err := scheduler.database.GetTeamPreferences(subscription.TeamID)
+	if err != nil {
+		if !errors.Is(err, database.ErrNil) {
+			logger.Error().
+				Error(err).
+				String(moira.LogFieldNameSubscriptionID, subscription.ID).
+				Msg("Failed to get team preferences")
+		}
+
+		return next
+	}
+
+	for i := 0; i < maxQuietHoursShifts; i++ {
+		quietHoursEnd := preferences.QuietHoursEnd(next)
+		if quietHoursEnd.Equal(next) {
+			return next
+		}
+
+		logger.Debug().
+			String("quiet_hours_end", quietHoursEnd.String()).
+			Msg("Team quiet hours, delaying next notification")
+
+		next, err = calculateNextDelivery(&subscription.Schedule, quietHoursEnd)
+		if err != nil {
+			return quietHoursEnd
+		}
+	}
+
+	return next
+}
+
 func calculateNextDelivery(schedule *moira.ScheduleData, nextTime time.Time) (time.Time, error) {
+	if schedule.IsExtended() {
+		return schedule.NextAllowedTime(nextTime)
+	}
+
 	if len(schedule.Days) != 0 && len(schedule.Days) != 7 {
 		return nextTime, fmt.Errorf("invalid scheduled settings: %d days defined", len(schedule.Days))
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
 	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
 	"github.com/moira-alert/moira/metrics"
 	mock_clock "github.com/moira-alert/moira/mock/clock"
@@ -519,6 +520,142 @@ func TestSubscriptionSchedule(t *testing.T) {
 			require.False(t, throttled)
 		})
 	})
+
+	t.Run("Extended schedule", func(t *testing.T) {
+		// 2015-09-02 09:45:15 UTC is 11:45:15 in Berlin
+		now := time.Unix(1441187115, 0)
+		subscription.ThrottlingEnabled = false
+
+		t.Run("When current time is in holiday exception, should send notification after the holiday", func(t *testing.T) {
+			subscription.Schedule = moira.ScheduleData{
+				Days:       moira.GetFilledScheduleDataDays(true),
+				Timezone:   "Europe/Berlin",
+				Windows:    []moira.ScheduleWindow{{StartOffset: 540, EndOffset: 1080}},
+				Exceptions: []moira.ScheduleException{{Name: "holiday", From: 1441144800, To: 1441231200}}, // 2015-09-02 in Berlin
+			}
+
+			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+
+			next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+			require.Equal(t, time.Unix(1441263600, 0), next) // 2015-09-03 09:00 in Berlin
+			require.False(t, throttled)
+		})
+
+		t.Run("When current time is between windows, should send notification at the beginning of the next window", func(t *testing.T) {
+			subscription.Schedule = moira.ScheduleData{
+				Days:     moira.GetFilledScheduleDataDays(true),
+				Timezone: "Europe/Berlin",
+				Windows:  []moira.ScheduleWindow{{StartOffset: 540, EndOffset: 660}, {StartOffset: 780, EndOffset: 1080}},
+			}
+
+			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+
+			next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+			require.Equal(t, time.Unix(1441191600, 0), next) // 2015-09-02 13:00 in Berlin
+			require.False(t, throttled)
+		})
+	})
+}
+
+func TestTeamQuietHours(t *testing.T) {
+	subID := "SubscriptionID-000000000000001"
+	subscription := moira.SubscriptionData{
+		ID:       subID,
+		Enabled:  true,
+		Tags:     []string{"test-tag"},
+		Contacts: []string{"ContactID-000000000000001"},
+		TeamID:   "teamID",
+	}
+
+	event := moira.NotificationEvent{
+		Metric:         "generate.event.1",
+		State:          moira.StateOK,
+		OldState:       moira.StateWARN,
+		TriggerID:      "triggerID-0000000000001",
+		SubscriptionID: &subID,
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.GetLogger("Scheduler")
+	metricRegistry, err := metrics.NewMetricContext(context.Background()).CreateRegistry()
+	require.NoError(t, err)
+
+	notifierMetrics, _ := metrics.ConfigureNotifierMetrics(metrics.NewDummyRegistry(), metricRegistry, "notifier")
+	systemClock := mock_clock.NewMockClock(mockCtrl)
+	scheduler := NewScheduler(dataBase, logger, notifierMetrics, SchedulerConfig{ReschedulingDelay: time.Minute}, systemClock)
+
+	now := time.Unix(1441187115, 0)
+
+	t.Run("Team has no preferences, should send notification now", func(t *testing.T) {
+		dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+		dataBase.EXPECT().GetTeamPreferences(subscription.TeamID).Return(moira.TeamPreferences{}, database.ErrNil)
+
+		next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+		require.Equal(t, now, next)
+		require.False(t, throttled)
+	})
+
+	t.Run("Current time is out of quiet hours, should send notification now", func(t *testing.T) {
+		preferences := moira.TeamPreferences{
+			QuietHours: []moira.QuietHoursPeriod{{From: now.Unix() + 60, To: now.Unix() + 3600}},
+		}
+
+		dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+		dataBase.EXPECT().GetTeamPreferences(subscription.TeamID).Return(preferences, nil)
+
+		next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+		require.Equal(t, now, next)
+		require.False(t, throttled)
+	})
+
+	t.Run("Current time is in quiet hours, should send notification at the end of quiet hours", func(t *testing.T) {
+		preferences := moira.TeamPreferences{
+			QuietHours: []moira.QuietHoursPeriod{{From: now.Unix() - 60, To: now.Unix() + 3600}},
+		}
+
+		dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+		dataBase.EXPECT().GetTeamPreferences(subscription.TeamID).Return(preferences, nil)
+
+		next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+		require.Equal(t, time.Unix(now.Unix()+3600, 0), next)
+		require.False(t, throttled)
+	})
+
+	t.Run("End of quiet hours is out of schedule, should send notification at the beginning of allowed interval", func(t *testing.T) {
+		subscription := subscription
+		subscription.Schedule = schedule2
+
+		// 09:44 - 10:00 (UTC) quiet hours and 11:00 - 15:00 (UTC) schedule
+		preferences := moira.TeamPreferences{
+			QuietHours: []moira.QuietHoursPeriod{{From: now.Unix() - 60, To: 1441188000}},
+		}
+
+		dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+		dataBase.EXPECT().GetTeamPreferences(subscription.TeamID).Return(preferences, nil)
+
+		next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+		require.Equal(t, time.Unix(1441191600, 0), next)
+		require.False(t, throttled)
+	})
+
+	t.Run("Failed to get team preferences, should send notification now", func(t *testing.T) {
+		dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+		dataBase.EXPECT().GetTeamPreferences(subscription.TeamID).Return(moira.TeamPreferences{}, errors.New("test error"))
+
+		next, throttled := scheduler.calculateNextDelivery(now, &event, logger)
+		require.Equal(t, now, next)
+		require.False(t, throttled)
+	})
 }
 
 var schedule1 = moira.ScheduleData{
@@ -0,0 +1,126 @@
+package moira
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxScheduleLookupDays limits search of the next allowed time, so long exceptions are still skipped.
+const maxScheduleLookupDays = 400
+
+// ScheduleWindow is an interval of the day in minutes from the local midnight, both ends are inclusive.
+// Window with end before start spans midnight, it allows the beginning and the end of the day.
+type ScheduleWindow struct {
+	StartOffset int64 `json:"startOffset" example:"540" format:"int64"`
+	EndOffset   int64 `json:"endOffset" example:"1080" format:"int64"`
+}
+
+// ScheduleException is a period of time, from inclusive and to exclusive, given in unix seconds.
+type ScheduleException struct {
+	Name string `json:"name,omitempty" example:"New Year"`
+	From int64  `json:"from" example:"1704067200" format:"int64"`
+	To   int64  `json:"to" example:"1704153600" format:"int64"`
+}
+
+// IsExtended returns true if the schedule uses timezone name, time windows or exceptions.
+func (schedule *ScheduleData) IsExtended() bool {
+	return schedule.Timezone != "" || len(schedule.Windows) != 0 || len(schedule.Exceptions) != 0
+}
+
+// GetLocation returns location of the schedule: IANA timezone if it is set, otherwise fixed TimezoneOffset.
+func (schedule *ScheduleData) GetLocation() (*time.Location, error) {
+	if schedule.Timezone != "" {
+		return time.LoadLocation(schedule.Timezone)
+	}
+
+	return time.FixedZone("", int(-schedule.TimezoneOffset*60)), nil //nolint
+}
+
+// NextAllowedTime returns the first moment starting from the given time which the extended schedule allows.
+func (schedule *ScheduleData) NextAllowedTime(timestamp time.Time) (time.Time, error) {
+	if len(schedule.Days) != 0 && len(schedule.Days) != len(DaysOrder) {
+		return timestamp, fmt.Errorf("invalid scheduled settings: %d days defined", len(schedule.Days))
+	}
+
+	location, err := schedule.GetLocation()
+	if err != nil {
+		return timestamp, fmt.Errorf("invalid schedule timezone: %w", err)
+	}
+
+	if schedule.isAllowedAt(timestamp, location) {
+		return timestamp, nil
+	}
+
+	local := timestamp.In(location)
+
+	for day := 0; day <= maxScheduleLookupDays; day++ {
+		dayStart := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, location)
+		nextDayStart := time.Date(local.Year(), local.Month(), local.Day()+day+1, 0, 0, 0, 0, location)
+
+		candidates := []time.Time{dayStart}
+		for _, window := range schedule.getWindows() {
+			candidates = append(candidates, time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, int(window.StartOffset), 0, 0, location))
+		}
+
+		for _, exception := range schedule.Exceptions {
+			exceptionEnd := time.Unix(exception.To, 0)
+			if !exceptionEnd.Before(dayStart) && exceptionEnd.Before(nextDayStart) {
+				candidates = append(candidates, exceptionEnd)
+			}
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Before(candidates[j])
+		})
+
+		for _, candidate := range candidates {
+			if candidate.After(timestamp) && schedule.isAllowedAt(candidate, location) {
+				return candidate.In(timestamp.Location()), nil
+			}
+		}
+	}
+
+	return timestamp, fmt.Errorf("can not find allowed schedule time")
+}
+
+func (schedule *ScheduleData) isAllowedAt(timestamp time.Time, location *time.Location) bool {
+	for _, exception := range schedule.Exceptions {
+		if timestamp.Unix() >= exception.From && timestamp.Unix() < exception.To {
+			return false
+		}
+	}
+
+	local := timestamp.In(location)
+
+	if len(schedule.Days) == len(DaysOrder) && !schedule.Days[int(local.Weekday()+6)%7].Enabled { //nolint
+		return false
+	}
+
+	minute := int64(local.Hour()*60 + local.Minute()) //nolint
+
+	for _, window := range schedule.getWindows() {
+		if window.StartOffset <= window.EndOffset {
+			if minute >= window.StartOffset && minute <= window.EndOffset {
+				return true
+			}
+		} else if minute >= window.StartOffset || minute <= window.EndOffset {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (schedule *ScheduleData) getWindows() []ScheduleWindow {
+	if len(schedule.Windows) != 0 {
+		return schedule.Windows
+	}
+
+	// Schedule without days allows the whole day, as it does without extensions.
+	if len(schedule.Days) == 0 {
+		return []ScheduleWindow{{StartOffset: 0, EndOffset: DefaultEndOffset}}
+	}
+
+	return []ScheduleWindow{{StartOffset: schedule.StartOffset, EndOffset: schedule.EndOffset}}
+}
@@ -0,0 +1,132 @@
+package moira
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScheduleData_NextAllowedTime(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	Convey("Test extended schedule NextAllowedTime function", t, func() {
+		schedule := ScheduleData{
+			Days:     GetFilledScheduleDataDays(true),
+			Timezone: "Europe/Berlin",
+			Windows: []ScheduleWindow{
+				{StartOffset: 540, EndOffset: 720},  // 09:00 - 12:00
+				{StartOffset: 840, EndOffset: 1080}, // 14:00 - 18:00
+			},
+		}
+
+		Convey("Time in window is allowed", func() {
+			now := time.Date(2024, 3, 5, 10, 0, 0, 0, berlin)
+			next, err := schedule.NextAllowedTime(now)
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, now)
+		})
+
+		Convey("Time between windows is moved to the next window", func() {
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 5, 12, 30, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 5, 14, 0, 0, 0, berlin)), ShouldBeTrue)
+		})
+
+		Convey("Time after windows is moved to the next day", func() {
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 5, 20, 0, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 6, 9, 0, 0, 0, berlin)), ShouldBeTrue)
+		})
+
+		Convey("Daylight saving time is taken into account", func() {
+			// Clocks are moved forward on 31 March 2024 in Berlin, 09:00 is 07:00 UTC instead of 08:00 UTC.
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 30, 19, 0, 0, 0, time.UTC))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		})
+
+		Convey("Disabled days are skipped", func() {
+			schedule.Days[5].Enabled = false
+			schedule.Days[6].Enabled = false
+
+			// Friday evening
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 8, 20, 0, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 11, 9, 0, 0, 0, berlin)), ShouldBeTrue)
+		})
+
+		Convey("Exceptions are skipped", func() {
+			schedule.Exceptions = []ScheduleException{
+				{Name: "holiday", From: time.Date(2024, 3, 6, 0, 0, 0, 0, berlin).Unix(), To: time.Date(2024, 3, 7, 0, 0, 0, 0, berlin).Unix()},
+				{Name: "freeze", From: time.Date(2024, 3, 7, 0, 0, 0, 0, berlin).Unix(), To: time.Date(2024, 3, 7, 15, 0, 0, 0, berlin).Unix()},
+			}
+
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 5, 20, 0, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 7, 15, 0, 0, 0, berlin)), ShouldBeTrue)
+		})
+
+		Convey("Window spanning midnight", func() {
+			schedule.Windows = []ScheduleWindow{{StartOffset: 1320, EndOffset: 360}} // 22:00 - 06:00
+
+			next, err := schedule.NextAllowedTime(time.Date(2024, 3, 5, 3, 0, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 5, 3, 0, 0, 0, berlin)), ShouldBeTrue)
+
+			next, err = schedule.NextAllowedTime(time.Date(2024, 3, 5, 12, 0, 0, 0, berlin))
+			So(err, ShouldBeNil)
+			So(next.Equal(time.Date(2024, 3, 5, 22, 0, 0, 0, berlin)), ShouldBeTrue)
+		})
+
+		Convey("Unknown timezone", func() {
+			schedule.Timezone = "Mars/Olympus"
+
+			_, err := schedule.NextAllowedTime(time.Date(2024, 3, 5, 12, 0, 0, 0, berlin))
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Schedule with exceptions only keeps offsets", t, func() {
+		schedule := ScheduleData{
+			Days:           GetFilledScheduleDataDays(true),
+			TimezoneOffset: -180,
+			StartOffset:    0,
+			EndOffset:      1439,
+			Exceptions:     []ScheduleException{{From: 1000, To: 2000}},
+		}
+
+		So(schedule.IsExtended(), ShouldBeTrue)
+
+		next, err := schedule.NextAllowedTime(time.Unix(1500, 0))
+		So(err, ShouldBeNil)
+		So(next.Unix(), ShouldEqual, 2000)
+	})
+
+	Convey("Schedule without days allows the whole day", t, func() {
+		schedule := ScheduleData{Exceptions: []ScheduleException{{From: 1000, To: 2000}}}
+
+		next, err := schedule.NextAllowedTime(time.Unix(5000, 0))
+		So(err, ShouldBeNil)
+		So(next.Unix(), ShouldEqual, 5000)
+	})
+}
+
+func TestScheduleData_IsScheduleAllows_Extended(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	Convey("Test IsScheduleAllows function of extended schedule", t, func() {
+		schedule := &ScheduleData{
+			Days:       GetFilledScheduleDataDays(true),
+			Timezone:   "Europe/Berlin",
+			Windows:    []ScheduleWindow{{StartOffset: 540, EndOffset: 1080}},
+			Exceptions: []ScheduleException{{From: time.Date(2024, 12, 25, 0, 0, 0, 0, berlin).Unix(), To: time.Date(2024, 12, 26, 0, 0, 0, 0, berlin).Unix()}},
+		}
+
+		So(schedule.IsScheduleAllows(time.Date(2024, 7, 1, 9, 30, 0, 0, berlin).Unix()), ShouldBeTrue)
+		So(schedule.IsScheduleAllows(time.Date(2024, 7, 1, 18, 0, 59, 0, berlin).Unix()), ShouldBeTrue)
+		So(schedule.IsScheduleAllows(time.Date(2024, 7, 1, 18, 1, 0, 0, berlin).Unix()), ShouldBeFalse)
+		So(schedule.IsScheduleAllows(time.Date(2024, 12, 25, 12, 0, 0, 0, berlin).Unix()), ShouldBeFalse)
+		So(schedule.IsScheduleAllows(time.Date(2024, 12, 26, 12, 0, 0, 0, berlin).Unix()), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,30 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of Discord messages which the sender with given settings sends.
+// The bot token is not used, so it may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to discord config: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI: cfg.FrontURI,
+		location: location,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, trigger, contact, false), nil
+	}, nil
+}
@@ -0,0 +1,67 @@
+package googlechat
+
+// Message represents a Google Chat message with cardsV2.
+type Message struct {
+	Thread  *Thread  `json:"thread,omitempty"`
+	CardsV2 []CardV2 `json:"cardsV2"`
+}
+
+// Thread identifies the thread the message is posted to.
+type Thread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+// CardV2 wraps a card with its identifier.
+type CardV2 struct {
+	CardID string `json:"cardId"`
+	Card   Card   `json:"card"`
+}
+
+// Card represents a Google Chat card.
+type Card struct {
+	Sections []Section `json:"sections"`
+}
+
+// Section represents a section of the card.
+type Section struct {
+	Widgets []Widget `json:"widgets"`
+}
+
+// Widget represents a card widget, only one of the fields must be set.
+type Widget struct {
+	TextParagraph *TextParagraph `json:"textParagraph,omitempty"`
+	Image         *Image         `json:"image,omitempty"`
+	ButtonList    *ButtonList    `json:"buttonList,omitempty"`
+}
+
+// TextParagraph is a widget with formatted text.
+type TextParagraph struct {
+	Text string `json:"text"`
+}
+
+// Image is a widget with an image.
+type Image struct {
+	ImageURL string `json:"imageUrl"`
+	AltText  string `json:"altText,omitempty"`
+}
+
+// ButtonList is a widget with buttons.
+type ButtonList struct {
+	Buttons []Button `json:"buttons"`
+}
+
+// Button represents a button that opens a link.
+type Button struct {
+	Text    string  `json:"text"`
+	OnClick OnClick `json:"onClick"`
+}
+
+// OnClick represents the button action.
+type OnClick struct {
+	OpenLink OpenLink `json:"openLink"`
+}
+
+// OpenLink represents a link to open.
+type OpenLink struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,2 @@
+// Package googlechat is Moira sender for Google Chat incoming webhooks, which accept cardsV2 messages.
+package googlechat
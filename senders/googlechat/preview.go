@@ -0,0 +1,43 @@
+package googlechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+)
+
+// NewPreview returns preview of Google Chat messages which the sender with given settings sends.
+// Previewed messages are the bodies of requests to webhooks without plots.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to google chat config: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize google chat preview, err: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI:   cfg.FrontURI,
+		useThreads: cfg.UseThreads,
+		formatter:  newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location),
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		requestBody, err := json.Marshal(sender.buildMessage(events, contact, trigger, nil, false))
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal message: %w", err)
+		}
+
+		return string(requestBody), nil
+	}, nil
+}
@@ -0,0 +1,290 @@
+package googlechat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+	"github.com/moira-alert/moira/senders/msgformat"
+)
+
+const (
+	googleChatHost  = "chat.googleapis.com"
+	cardID          = "moira-alert"
+	openLinkTitle   = "View in Moira"
+	plotAltText     = "Trigger plot"
+	replyOptionKey  = "messageReplyOption"
+	replyOptionNew  = "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD"
+	lineBreak       = "<br>"
+	threadKeyPrefix = "moira-"
+
+	messageMaxCharacters = 4_000
+)
+
+var (
+	codeBlockStart = ""
+	codeBlockEnd   = ""
+
+	headers = map[string]string{
+		"User-Agent":   "Moira",
+		"Content-Type": "application/json; charset=UTF-8",
+	}
+
+	defaultClientTimeout = 30 * time.Second
+)
+
+// Structure that represents the Google Chat configuration in the YAML file.
+type config struct {
+	FrontURI     string            `mapstructure:"front_uri"`
+	UseEmoji     bool              `mapstructure:"use_emoji"`
+	DefaultEmoji string            `mapstructure:"default_emoji"`
+	EmojiMap     map[string]string `mapstructure:"emoji_map"`
+	ImageStore   string            `mapstructure:"image_store"`
+	UseThreads   bool              `mapstructure:"use_threads"`
+}
+
+// Sender implements moira sender interface via Google Chat incoming webhooks.
+type Sender struct {
+	ImageStores          map[string]moira.ImageStore
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	frontURI             string
+	useThreads           bool
+	logger               moira.Logger
+	client               *http.Client
+	formatter            msgformat.MessageFormatter
+}
+
+// Init initialises settings required for full functionality.
+func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, _ string) error {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to google chat config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("google chat config validation error: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return fmt.Errorf("cannot initialize google chat sender, err: %w", err)
+	}
+
+	if cfg.ImageStore != "" {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+
+	sender.logger = logger
+	sender.frontURI = cfg.FrontURI
+	sender.useThreads = cfg.UseThreads
+	sender.client = &http.Client{
+		Timeout: defaultClientTimeout,
+	}
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		uriFormatter,
+		descriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		boldFormatter,
+		eventStringFormatter,
+		codeBlockStart,
+		codeBlockEnd)
+}
+
+func uriFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", triggerURI, html.EscapeString(triggerName))
+}
+
+func descriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := html.EscapeString(trigger.Desc)
+	if trigger.Desc != "" {
+		desc += "\n"
+	}
+
+	if contact.ExtraMessage != "" {
+		desc = html.EscapeString(contact.ExtraMessage) + "\n" + desc
+	}
+
+	return desc
+}
+
+func boldFormatter(str string) string {
+	return fmt.Sprintf("<b>%s</b>", str)
+}
+
+func eventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return fmt.Sprintf(
+		"%s: <font color=\"#5f6368\">%s</font> = %s (%s to %s)",
+		event.FormatTimestamp(loc, moira.DefaultTimeFormat),
+		html.EscapeString(event.Metric),
+		event.GetMetricsValues(moira.DefaultNotificationSettings),
+		event.OldState,
+		event.State)
+}
+
+// SendEvents implements Sender interface Send.
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	requestURL, err := sender.buildRequestURL(contact.Value)
+	if err != nil {
+		return moira.NewSenderBrokenContactError(err)
+	}
+
+	message := sender.buildMessage(events, contact, trigger, sender.storePlots(plots, trigger), throttled)
+
+	requestBody, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, requestURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer response.Body.Close()
+
+	// read the entire response as required by https://golang.org/pkg/net/http/#Client.Do
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("server responded with a non 2xx code: %d, body: %s", response.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plotURLs []string, throttled bool) Message {
+	text := sender.formatter.Format(msgformat.MessageFormatterParams{
+		Events:          events,
+		Trigger:         trigger,
+		Contact:         contact,
+		MessageMaxChars: messageMaxCharacters,
+		Throttled:       throttled,
+	})
+
+	widgets := []Widget{
+		{
+			TextParagraph: &TextParagraph{
+				Text: strings.ReplaceAll(strings.TrimSpace(text), "\n", lineBreak),
+			},
+		},
+	}
+
+	for _, plotURL := range plotURLs {
+		widgets = append(widgets, Widget{
+			Image: &Image{
+				ImageURL: plotURL,
+				AltText:  plotAltText,
+			},
+		})
+	}
+
+	if triggerURI := trigger.GetTriggerURI(sender.frontURI); triggerURI != "" {
+		widgets = append(widgets, Widget{
+			ButtonList: &ButtonList{
+				Buttons: []Button{
+					{
+						Text:    openLinkTitle,
+						OnClick: OnClick{OpenLink: OpenLink{URL: triggerURI}},
+					},
+				},
+			},
+		})
+	}
+
+	message := Message{
+		CardsV2: []CardV2{
+			{
+				CardID: cardID,
+				Card: Card{
+					Sections: []Section{{Widgets: widgets}},
+				},
+			},
+		},
+	}
+
+	if sender.useThreads && trigger.ID != "" {
+		message.Thread = &Thread{ThreadKey: threadKeyPrefix + trigger.ID}
+	}
+
+	return message
+}
+
+// buildRequestURL validates the webhook url from the contact and adds thread reply options to it if needed.
+func (sender *Sender) buildRequestURL(webhookURL string) (string, error) {
+	parsedURL, err := url.ParseRequestURI(webhookURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid google chat webhook url %s: %w", webhookURL, err)
+	}
+
+	if parsedURL.Scheme != "https" || parsedURL.Host != googleChatHost {
+		return "", fmt.Errorf("invalid google chat webhook url %s: must start with https://%s/", webhookURL, googleChatHost)
+	}
+
+	if sender.useThreads {
+		query := parsedURL.Query()
+		query.Set(replyOptionKey, replyOptionNew)
+		parsedURL.RawQuery = query.Encode()
+	}
+
+	return parsedURL.String(), nil
+}
+
+// storePlots uploads plots to the configured image store and returns their public URLs.
+func (sender *Sender) storePlots(plots [][]byte, trigger moira.TriggerData) []string {
+	if !sender.imageStoreConfigured {
+		return nil
+	}
+
+	plotURLs := make([]string, 0, len(plots))
+
+	for _, plot := range plots {
+		plotURL, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.logger.Warning().
+				String(moira.LogFieldNameTriggerID, trigger.ID).
+				Error(err).
+				Msg("Could not store the plot image in the image store")
+
+			continue
+		}
+
+		plotURLs = append(plotURLs, plotURL)
+	}
+
+	return plotURLs
+}
@@ -0,0 +1,149 @@
+package googlechat
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+const testWebhookURL = "https://chat.googleapis.com/v1/spaces/space/messages?key=key&token=token"
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+
+	Convey("Init tests", t, func() {
+		Convey("Without image store", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"front_uri": "http://moira.url", "use_threads": true}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.useThreads, ShouldBeTrue)
+			So(sender.imageStoreConfigured, ShouldBeFalse)
+		})
+
+		Convey("With disabled image store", func() {
+			imageStore.EXPECT().IsEnabled().Return(false)
+
+			sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+			err := sender.Init(map[string]interface{}{"image_store": "s3"}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.imageStoreConfigured, ShouldBeFalse)
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+	imageStore.EXPECT().IsEnabled().Return(true)
+
+	sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+	err := sender.Init(map[string]interface{}{"front_uri": "http://moira.url", "image_store": "s3", "use_threads": true}, logger, location, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := moira.NotificationEvents{{
+		TriggerID: "TriggerID",
+		Values:    map[string]float64{"t1": 123},
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateERROR,
+	}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	contact := moira.ContactData{Value: testWebhookURL}
+
+	Convey("Send events", t, func() {
+		defer gock.Off()
+
+		Convey("With plot and thread", func() {
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("https://images.url/plot.png", nil)
+
+			gock.New("https://chat.googleapis.com").
+				Post("/v1/spaces/space/messages").
+				MatchParam("key", "key").
+				MatchParam("token", "token").
+				MatchParam(replyOptionKey, replyOptionNew).
+				AddMatcher(func(request *http.Request, _ *gock.Request) (bool, error) {
+					var message Message
+					if err := json.NewDecoder(request.Body).Decode(&message); err != nil {
+						return false, err
+					}
+
+					widgets := message.CardsV2[0].Card.Sections[0].Widgets
+
+					return message.Thread.ThreadKey == "moira-TriggerID" &&
+						widgets[1].Image.ImageURL == "https://images.url/plot.png" &&
+						widgets[2].ButtonList.Buttons[0].OnClick.OpenLink.URL == "http://moira.url/trigger/TriggerID", nil
+				}).
+				Reply(http.StatusOK).
+				JSON(map[string]string{"name": "spaces/space/messages/message"})
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Non 2xx response", func() {
+			gock.New("https://chat.googleapis.com").
+				Post("/v1/spaces/space/messages").
+				Reply(http.StatusForbidden).
+				BodyString("forbidden")
+
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldResemble, errors.New("server responded with a non 2xx code: 403, body: forbidden"))
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Invalid webhook url", func() {
+			err := sender.SendEvents(events, moira.ContactData{Value: "https://example.com/webhook"}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	sender := Sender{}
+	_ = sender.Init(map[string]interface{}{"front_uri": "http://moira.url"}, logger, location, "")
+
+	Convey("Build message escapes html", t, func() {
+		events := moira.NotificationEvents{{
+			Values:    map[string]float64{"t1": 97},
+			Timestamp: 150000000,
+			Metric:    "<Metric>",
+			OldState:  moira.StateOK,
+			State:     moira.StateWARN,
+		}}
+		trigger := moira.TriggerData{ID: "TriggerID", Name: "Name & Co", Desc: "a < b"}
+
+		message := sender.buildMessage(events, moira.ContactData{}, trigger, nil, false)
+
+		So(message.Thread, ShouldBeNil)
+		So(message.CardsV2[0].Card.Sections[0].Widgets[0].TextParagraph.Text, ShouldEqual,
+			`<b>WARN</b> <a href="http://moira.url/trigger/TriggerID">Name &amp; Co</a><br>a &lt; b<br><br>`+
+				`02:40 (GMT+00:00): <font color="#5f6368">&lt;Metric&gt;</font> = 97 (OK to WARN)`)
+	})
+}
@@ -0,0 +1,160 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+)
+
+const (
+	sendgridProvider = "sendgrid"
+	mailgunProvider  = "mailgun"
+	sesProvider      = "ses"
+)
+
+var defaultHTTPAPIClientTimeout = 30 * time.Second
+
+// Structure that represents the HTTP mail API configuration in the YAML file.
+type httpAPIConfig struct {
+	Provider     string `mapstructure:"provider" validate:"required,oneof=sendgrid mailgun ses"`
+	APIURL       string `mapstructure:"api_url" validate:"omitempty,url"`
+	APIKey       string `mapstructure:"api_key" validate:"required_unless=Provider ses"`
+	Domain       string `mapstructure:"domain" validate:"required_if=Provider mailgun"`
+	Region       string `mapstructure:"region" validate:"required_if=Provider ses"`
+	AccessKeyID  string `mapstructure:"access_key_id" validate:"required_if=Provider ses"`
+	AccessKey    string `mapstructure:"access_key" validate:"required_if=Provider ses"`
+	MailFrom     string `mapstructure:"mail_from" validate:"required"`
+	FrontURI     string `mapstructure:"front_uri"`
+	TemplateFile string `mapstructure:"template_file"`
+}
+
+// HTTPAPISender implements moira sender interface via HTTP mail APIs (SendGrid, Mailgun or Amazon SES v2).
+// It uses the same template as Sender, but does not require SMTP access.
+type HTTPAPISender struct {
+	From           string
+	FrontURI       string
+	TemplateName   string
+	Template       *template.Template
+	provider       mailProvider
+	client         *http.Client
+	logger         moira.Logger
+	location       *time.Location
+	dateTimeFormat string
+}
+
+// apiMail is the provider agnostic representation of the mail.
+type apiMail struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Plots   []inlinePlot
+}
+
+// inlinePlot is the plot attached to the mail and referenced from the html by its content id.
+type inlinePlot struct {
+	ContentID string
+	Data      []byte
+}
+
+// mailProvider builds HTTP requests for the particular mail API.
+type mailProvider interface {
+	buildRequest(ctx context.Context, mail apiMail) (*http.Request, error)
+}
+
+// Init read yaml config.
+func (sender *HTTPAPISender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, dateTimeFormat string) error {
+	var cfg httpAPIConfig
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to mail api config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("mail api config validation error: %w", err)
+	}
+
+	sender.TemplateName, sender.Template, err = parseTemplate(cfg.TemplateFile)
+	if err != nil {
+		return err
+	}
+
+	sender.provider = newMailProvider(cfg)
+	sender.From = cfg.MailFrom
+	sender.FrontURI = cfg.FrontURI
+	sender.logger = logger
+	sender.location = location
+	sender.dateTimeFormat = dateTimeFormat
+	sender.client = &http.Client{
+		Timeout: defaultHTTPAPIClientTimeout,
+	}
+
+	return nil
+}
+
+// SendEvents implements Sender interface Send.
+func (sender *HTTPAPISender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	mail, err := sender.makeMail(events, contact, trigger, plots, throttled)
+	if err != nil {
+		return fmt.Errorf("failed to make mail: %w", err)
+	}
+
+	request, err := sender.provider.buildRequest(context.Background(), mail)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	request.Header.Set("User-Agent", "Moira")
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer response.Body.Close()
+
+	// read the entire response as required by https://golang.org/pkg/net/http/#Client.Do
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("mail api responded with a non 2xx code: %d, body: %s", response.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (sender *HTTPAPISender) makeMail(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) (apiMail, error) {
+	subject, templateData := buildTemplateData(events, contact, trigger, throttled, sender.FrontURI, sender.location, sender.dateTimeFormat)
+
+	mail := apiMail{
+		From:    sender.From,
+		To:      contact.Value,
+		Subject: subject,
+		Plots:   make([]inlinePlot, 0, len(plots)),
+	}
+
+	for i, plot := range plots {
+		plotCID := plotContentID(i)
+		templateData.PlotCID = plotCID
+		mail.Plots = append(mail.Plots, inlinePlot{ContentID: plotCID, Data: plot})
+	}
+
+	var html bytes.Buffer
+	if err := sender.Template.ExecuteTemplate(&html, sender.TemplateName, templateData); err != nil {
+		return mail, err
+	}
+
+	mail.HTML = html.String()
+
+	return mail, nil
+}
@@ -0,0 +1,254 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	sendgridDefaultURL = "https://api.sendgrid.com"
+	sendgridSendPath   = "/v3/mail/send"
+	mailgunDefaultURL  = "https://api.mailgun.net"
+	mailgunSendPath    = "/v3/%s/messages"
+	mailgunAPIUser     = "api"
+	sesDefaultURL      = "https://email.%s.amazonaws.com"
+	sesSendPath        = "/v2/email/outbound-emails"
+	sesSigningName     = "ses"
+
+	plotMimeType    = "image/png"
+	htmlContentType = "text/html"
+)
+
+func newMailProvider(cfg httpAPIConfig) mailProvider {
+	switch cfg.Provider {
+	case mailgunProvider:
+		return &mailgun{
+			url:    baseURL(cfg.APIURL, mailgunDefaultURL) + fmt.Sprintf(mailgunSendPath, url.PathEscape(cfg.Domain)),
+			apiKey: cfg.APIKey,
+		}
+	case sesProvider:
+		return &ses{
+			url:    baseURL(cfg.APIURL, fmt.Sprintf(sesDefaultURL, cfg.Region)) + sesSendPath,
+			region: cfg.Region,
+			signer: v4.NewSigner(credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.AccessKey, "")),
+		}
+	default:
+		return &sendgrid{
+			url:    baseURL(cfg.APIURL, sendgridDefaultURL) + sendgridSendPath,
+			apiKey: cfg.APIKey,
+		}
+	}
+}
+
+func baseURL(configured, defaultURL string) string {
+	if configured == "" {
+		return defaultURL
+	}
+
+	return strings.TrimSuffix(configured, "/")
+}
+
+// sendgrid builds requests for SendGrid v3 Mail Send API.
+type sendgrid struct {
+	url    string
+	apiKey string
+}
+
+type sendgridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendgridPersonalization struct {
+	To []sendgridAddress `json:"to"`
+}
+
+type sendgridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendgridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+	ContentID   string `json:"content_id"`
+}
+
+type sendgridMail struct {
+	Personalizations []sendgridPersonalization `json:"personalizations"`
+	From             sendgridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendgridContent         `json:"content"`
+	Attachments      []sendgridAttachment      `json:"attachments,omitempty"`
+}
+
+func (provider *sendgrid) buildRequest(ctx context.Context, mail apiMail) (*http.Request, error) {
+	payload := sendgridMail{
+		Personalizations: []sendgridPersonalization{{To: []sendgridAddress{{Email: mail.To}}}},
+		From:             sendgridAddress{Email: mail.From},
+		Subject:          mail.Subject,
+		Content:          []sendgridContent{{Type: htmlContentType, Value: mail.HTML}},
+	}
+
+	for _, plot := range mail.Plots {
+		payload.Attachments = append(payload.Attachments, sendgridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(plot.Data),
+			Type:        plotMimeType,
+			Filename:    plot.ContentID,
+			Disposition: "inline",
+			ContentID:   plot.ContentID,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+provider.apiKey)
+	request.Header.Set("Content-Type", "application/json")
+
+	return request, nil
+}
+
+// mailgun builds requests for Mailgun Messages API.
+type mailgun struct {
+	url    string
+	apiKey string
+}
+
+func (provider *mailgun) buildRequest(ctx context.Context, mail apiMail) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	fields := [][2]string{
+		{"from", mail.From},
+		{"to", mail.To},
+		{"subject", mail.Subject},
+		{"html", mail.HTML},
+	}
+	for _, field := range fields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Mailgun uses the file name of the inline attachment as its content id.
+	for _, plot := range mail.Plots {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="inline"; filename="%s"`, plot.ContentID))
+		header.Set("Content-Type", plotMimeType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = part.Write(plot.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.SetBasicAuth(mailgunAPIUser, provider.apiKey)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return request, nil
+}
+
+// ses builds requests for Amazon SES v2 SendEmail API, the mail is sent as a raw MIME message.
+type ses struct {
+	url    string
+	region string
+	signer *v4.Signer
+}
+
+type sesDestination struct {
+	ToAddresses []string `json:"ToAddresses"`
+}
+
+type sesRawMessage struct {
+	Data []byte `json:"Data"`
+}
+
+type sesContent struct {
+	Raw sesRawMessage `json:"Raw"`
+}
+
+type sesMail struct {
+	FromEmailAddress string         `json:"FromEmailAddress"`
+	Destination      sesDestination `json:"Destination"`
+	Content          sesContent     `json:"Content"`
+}
+
+func (provider *ses) buildRequest(ctx context.Context, mail apiMail) (*http.Request, error) {
+	message := gomail.NewMessage()
+	message.SetHeader("From", mail.From)
+	message.SetHeader("To", mail.To)
+	message.SetHeader("Subject", mail.Subject)
+
+	for _, plot := range mail.Plots {
+		data := plot.Data
+		message.Embed(plot.ContentID, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
+
+	message.SetBody(htmlContentType, mail.HTML)
+
+	raw := &bytes.Buffer{}
+	if _, err := message.WriteTo(raw); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(sesMail{
+		FromEmailAddress: mail.From,
+		Destination:      sesDestination{ToAddresses: []string{mail.To}},
+		Content:          sesContent{Raw: sesRawMessage{Data: raw.Bytes()}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if _, err = provider.signer.Sign(request, bytes.NewReader(body), sesSigningName, provider.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,161 @@
+package mail
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHTTPAPISenderInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	Convey("Init tests", t, func() {
+		validatorErr := validator.ValidationErrors{}
+
+		Convey("Unknown provider", func() {
+			sender := HTTPAPISender{}
+			err := sender.Init(map[string]interface{}{"provider": "postmark", "api_key": "key", "mail_from": "moira@example.com"}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("Mailgun without domain", func() {
+			sender := HTTPAPISender{}
+			err := sender.Init(map[string]interface{}{"provider": mailgunProvider, "api_key": "key", "mail_from": "moira@example.com"}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("SES without credentials", func() {
+			sender := HTTPAPISender{}
+			err := sender.Init(map[string]interface{}{"provider": sesProvider, "region": "eu-west-1", "mail_from": "moira@example.com"}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("SendGrid with default url", func() {
+			sender := HTTPAPISender{}
+			err := sender.Init(map[string]interface{}{"provider": sendgridProvider, "api_key": "key", "mail_from": "moira@example.com"}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.provider, ShouldResemble, &sendgrid{url: "https://api.sendgrid.com/v3/mail/send", apiKey: "key"})
+		})
+	})
+}
+
+func TestHTTPAPISenderSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Desc: "some text **bold text**"}
+	contact := moira.ContactData{Value: "user@example.com"}
+	events := generateTestEvents(2, trigger.ID)
+	plot := []byte{1, 0, 1}
+
+	var (
+		request     *http.Request
+		requestBody []byte
+		statusCode  int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		requestBody, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(`{"message":"stub"}`))
+	}))
+	defer server.Close()
+
+	initSender := func(settings map[string]interface{}) *HTTPAPISender {
+		settings["api_url"] = server.URL
+		settings["mail_from"] = "moira@example.com"
+		settings["front_uri"] = "http://moira.url"
+
+		sender := &HTTPAPISender{}
+		So(sender.Init(settings, logger, location, ""), ShouldBeNil)
+
+		return sender
+	}
+
+	Convey("Send events", t, func() {
+		statusCode = http.StatusAccepted
+
+		Convey("Through SendGrid", func() {
+			sender := initSender(map[string]interface{}{"provider": sendgridProvider, "api_key": "key"})
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{plot}, false)
+			So(err, ShouldBeNil)
+			So(request.URL.Path, ShouldEqual, sendgridSendPath)
+			So(request.Header.Get("Authorization"), ShouldEqual, "Bearer key")
+
+			var payload sendgridMail
+			So(json.Unmarshal(requestBody, &payload), ShouldBeNil)
+			So(payload.Personalizations[0].To[0].Email, ShouldEqual, contact.Value)
+			So(payload.From.Email, ShouldEqual, "moira@example.com")
+			So(payload.Subject, ShouldEqual, "TEST Name  (2)")
+			So(payload.Content[0].Value, ShouldContainSubstring, "<strong>bold text</strong>")
+			So(payload.Content[0].Value, ShouldContainSubstring, `src="cid:plot-t0.png"`)
+			So(payload.Attachments, ShouldResemble, []sendgridAttachment{{
+				Content:     base64.StdEncoding.EncodeToString(plot),
+				Type:        plotMimeType,
+				Filename:    "plot-t0.png",
+				Disposition: "inline",
+				ContentID:   "plot-t0.png",
+			}})
+		})
+
+		Convey("Through Mailgun", func() {
+			sender := initSender(map[string]interface{}{"provider": mailgunProvider, "api_key": "key", "domain": "mg.example.com"})
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{plot}, false)
+			So(err, ShouldBeNil)
+			So(request.URL.Path, ShouldEqual, "/v3/mg.example.com/messages")
+
+			user, password, ok := request.BasicAuth()
+			So(ok, ShouldBeTrue)
+			So(user, ShouldEqual, mailgunAPIUser)
+			So(password, ShouldEqual, "key")
+
+			So(strings.HasPrefix(request.Header.Get("Content-Type"), "multipart/form-data"), ShouldBeTrue)
+			So(string(requestBody), ShouldContainSubstring, `name="inline"; filename="plot-t0.png"`)
+			So(string(requestBody), ShouldContainSubstring, "user@example.com")
+		})
+
+		Convey("Through SES", func() {
+			sender := initSender(map[string]interface{}{
+				"provider":      sesProvider,
+				"region":        "eu-west-1",
+				"access_key_id": "id",
+				"access_key":    "secret",
+			})
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{plot}, true)
+			So(err, ShouldBeNil)
+			So(request.URL.Path, ShouldEqual, sesSendPath)
+			So(request.Header.Get("Authorization"), ShouldStartWith, "AWS4-HMAC-SHA256 Credential=id/")
+
+			var payload sesMail
+			So(json.Unmarshal(requestBody, &payload), ShouldBeNil)
+			So(payload.Destination.ToAddresses, ShouldResemble, []string{contact.Value})
+			So(string(payload.Content.Raw.Data), ShouldContainSubstring, "Content-ID: <plot-t0.png>")
+			So(string(payload.Content.Raw.Data), ShouldContainSubstring, "Subject: TEST Name  (2)")
+		})
+
+		Convey("API error", func() {
+			statusCode = http.StatusUnauthorized
+			sender := initSender(map[string]interface{}{"provider": sendgridProvider, "api_key": "key"})
+
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldResemble, errors.New(`mail api responded with a non 2xx code: 401, body: {"message":"stub"}`))
+		})
+	})
+}
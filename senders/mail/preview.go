@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of mails which the mail or the mail api sender with given settings sends,
+// both of them render mails with the same template. The preview has the subject and the html of the mail
+// separated by an empty line, settings of smtp servers and mail apis are not used.
+func NewPreview(senderSettings interface{}, location *time.Location, dateTimeFormat string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to mail config: %w", err)
+	}
+
+	sender := &HTTPAPISender{
+		FrontURI:       cfg.FrontURI,
+		location:       location,
+		dateTimeFormat: dateTimeFormat,
+	}
+
+	sender.TemplateName, sender.Template, err = parseTemplate(cfg.TemplateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		mail, err := sender.makeMail(events, contact, trigger, nil, false)
+		if err != nil {
+			return "", fmt.Errorf("failed to make mail: %w", err)
+		}
+
+		return mail.Subject + "\n\n" + mail.HTML, nil
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/smtp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/russross/blackfriday/v2"
 
@@ -44,6 +45,41 @@ func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.
 }
 
 func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) *gomail.Message {
+	subject, templateData := buildTemplateData(events, contact, trigger, throttled, sender.FrontURI, sender.location, sender.dateTimeFormat)
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender.From)
+	m.SetHeader("To", contact.Value)
+	m.SetHeader("Subject", subject)
+
+	if len(plots) > 0 {
+		for i, plot := range plots {
+			plotCID := plotContentID(i)
+			templateData.PlotCID = plotCID
+			m.Embed(plotCID, gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(plot)
+				return err
+			}))
+		}
+	}
+
+	m.AddAlternativeWriter("text/html", func(w io.Writer) error {
+		return sender.Template.ExecuteTemplate(w, sender.TemplateName, templateData)
+	})
+
+	return m
+}
+
+// buildTemplateData returns the subject of the mail and the data for the mail template.
+func buildTemplateData(
+	events moira.NotificationEvents,
+	contact moira.ContactData,
+	trigger moira.TriggerData,
+	throttled bool,
+	frontURI string,
+	location *time.Location,
+	dateTimeFormat string,
+) (string, triggerData) {
 	state := events.GetCurrentState(throttled)
 
 	tags := trigger.GetTags()
@@ -51,7 +87,7 @@ func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira
 	subject := fmt.Sprintf("%s %s %s (%d)", state, trigger.Name, tags, len(events))
 
 	templateData := triggerData{
-		Link:         trigger.GetTriggerURI(sender.FrontURI),
+		Link:         trigger.GetTriggerURI(frontURI),
 		Description:  formatDescription(trigger.Desc, contact.ExtraMessage),
 		Throttled:    throttled,
 		TriggerName:  trigger.Name,
@@ -63,37 +99,22 @@ func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira
 	for _, event := range events {
 		templateData.Items = append(templateData.Items, &templateRow{
 			Metric:     event.Metric,
-			Timestamp:  event.FormatTimestamp(sender.location, sender.dateTimeFormat),
+			Timestamp:  event.FormatTimestamp(location, dateTimeFormat),
 			Oldstate:   event.OldState,
 			State:      event.State,
 			Values:     event.GetMetricsValues(moira.DefaultNotificationSettings),
 			WarnValue:  strconv.FormatFloat(trigger.WarnValue, 'f', -1, 64),
 			ErrorValue: strconv.FormatFloat(trigger.ErrorValue, 'f', -1, 64),
-			Message:    event.CreateMessage(sender.location),
+			Message:    event.CreateMessage(location),
 		})
 	}
 
-	m := gomail.NewMessage()
-	m.SetHeader("From", sender.From)
-	m.SetHeader("To", contact.Value)
-	m.SetHeader("Subject", subject)
-
-	if len(plots) > 0 {
-		for i, plot := range plots {
-			plotCID := fmt.Sprintf("plot-t%d.png", i)
-			templateData.PlotCID = plotCID
-			m.Embed(plotCID, gomail.SetCopyFunc(func(w io.Writer) error {
-				_, err := w.Write(plot)
-				return err
-			}))
-		}
-	}
-
-	m.AddAlternativeWriter("text/html", func(w io.Writer) error {
-		return sender.Template.ExecuteTemplate(w, sender.TemplateName, templateData)
-	})
+	return subject, templateData
+}
 
-	return m
+// plotContentID returns the content id of the inline plot, which is referenced in the mail template.
+func plotContentID(plotIndex int) string {
+	return fmt.Sprintf("plot-t%d.png", plotIndex)
 }
 
 func formatDescription(desc string, extraMessage string) template.HTML {
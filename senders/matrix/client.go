@@ -0,0 +1,157 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	clientAPIPrefix = "/_matrix/client/v3"
+	mediaAPIPrefix  = "/_matrix/media/v3"
+
+	roomMessageEventType = "m.room.message"
+
+	errCodeForbidden = "M_FORBIDDEN"
+	errCodeNotFound  = "M_NOT_FOUND"
+)
+
+// Error represents an error response of the Matrix client-server API.
+type Error struct {
+	StatusCode int    `json:"-"`
+	ErrCode    string `json:"errcode"`
+	Message    string `json:"error"`
+}
+
+// Error implements error interface.
+func (err *Error) Error() string {
+	return fmt.Sprintf("matrix responded with %d %s: %s", err.StatusCode, err.ErrCode, err.Message)
+}
+
+// isRoomUnavailable returns true if the error means that the room does not exist or the bot is not joined to it.
+func isRoomUnavailable(err error) bool {
+	var matrixErr *Error
+	if !errors.As(err, &matrixErr) {
+		return false
+	}
+
+	return matrixErr.ErrCode == errCodeForbidden || matrixErr.ErrCode == errCodeNotFound
+}
+
+// client is a minimal Matrix client-server API client, authorized with an access token.
+type client struct {
+	homeserverURL string
+	accessToken   string
+	httpClient    *http.Client
+}
+
+type resolveAliasResponse struct {
+	RoomID string `json:"room_id"`
+}
+
+type sendEventResponse struct {
+	EventID string `json:"event_id"`
+}
+
+type uploadResponse struct {
+	ContentURI string `json:"content_uri"`
+}
+
+// ResolveRoomAlias returns the room id of the room with given alias.
+func (c *client) ResolveRoomAlias(ctx context.Context, alias string) (string, error) {
+	var response resolveAliasResponse
+
+	path := clientAPIPrefix + "/directory/room/" + url.PathEscape(alias)
+	if err := c.do(ctx, http.MethodGet, path, nil, "", nil, &response); err != nil {
+		return "", err
+	}
+
+	return response.RoomID, nil
+}
+
+// SendMessage sends m.room.message event to the room and returns the event id.
+func (c *client) SendMessage(ctx context.Context, roomID string, content any) (string, error) {
+	txnID, err := uuid.NewV4()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate transaction id: %w", err)
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	var response sendEventResponse
+
+	path := fmt.Sprintf("%s/rooms/%s/send/%s/%s", clientAPIPrefix, url.PathEscape(roomID), roomMessageEventType, txnID.String())
+	if err = c.do(ctx, http.MethodPut, path, nil, "application/json", body, &response); err != nil {
+		return "", err
+	}
+
+	return response.EventID, nil
+}
+
+// UploadMedia uploads the file to the media repository and returns its mxc:// uri.
+func (c *client) UploadMedia(ctx context.Context, fileName, contentType string, data []byte) (string, error) {
+	var response uploadResponse
+
+	query := url.Values{"filename": []string{fileName}}
+	if err := c.do(ctx, http.MethodPost, mediaAPIPrefix+"/upload", query, contentType, data, &response); err != nil {
+		return "", err
+	}
+
+	return response.ContentURI, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, contentType string, body []byte, result any) error {
+	requestURL := strings.TrimSuffix(c.homeserverURL, "/") + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+c.accessToken)
+	request.Header.Set("User-Agent", "Moira")
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		matrixErr := &Error{StatusCode: response.StatusCode}
+		if err = json.Unmarshal(responseBody, matrixErr); err != nil {
+			matrixErr.Message = string(responseBody)
+		}
+
+		return matrixErr
+	}
+
+	if err = json.Unmarshal(responseBody, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
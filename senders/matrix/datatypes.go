@@ -0,0 +1,50 @@
+package matrix
+
+const (
+	textMessageType  = "m.text"
+	imageMessageType = "m.image"
+	htmlFormat       = "org.matrix.custom.html"
+	threadRelType    = "m.thread"
+)
+
+// messageContent represents the content of m.room.message event.
+type messageContent struct {
+	MsgType       string     `json:"msgtype"`
+	Body          string     `json:"body"`
+	Format        string     `json:"format,omitempty"`
+	FormattedBody string     `json:"formatted_body,omitempty"`
+	URL           string     `json:"url,omitempty"`
+	Info          *imageInfo `json:"info,omitempty"`
+	RelatesTo     *relatesTo `json:"m.relates_to,omitempty"`
+}
+
+// imageInfo represents metadata of the uploaded image.
+type imageInfo struct {
+	MimeType string `json:"mimetype"`
+	Size     int    `json:"size"`
+}
+
+// relatesTo represents a thread relation, the reply fallback allows clients without threads support to show the message as a reply.
+type relatesTo struct {
+	RelType       string    `json:"rel_type"`
+	EventID       string    `json:"event_id"`
+	IsFallingBack bool      `json:"is_falling_back"`
+	InReplyTo     inReplyTo `json:"m.in_reply_to"`
+}
+
+type inReplyTo struct {
+	EventID string `json:"event_id"`
+}
+
+func newThreadRelation(rootEventID string) *relatesTo {
+	if rootEventID == "" {
+		return nil
+	}
+
+	return &relatesTo{
+		RelType:       threadRelType,
+		EventID:       rootEventID,
+		IsFallingBack: true,
+		InReplyTo:     inReplyTo{EventID: rootEventID},
+	}
+}
@@ -0,0 +1,2 @@
+// Package matrix is Moira sender for Matrix rooms (Element and other clients), it uses the client-server API.
+package matrix
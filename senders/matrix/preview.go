@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+)
+
+// NewPreview returns preview of Matrix messages which the sender with given settings sends.
+// Previewed messages are the contents of events posted to rooms, the homeserver url and the access token
+// are not used, so they may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to matrix config: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize matrix preview, err: %w", err)
+	}
+
+	sender := &Sender{}
+	sender.htmlFormatter, sender.plainFormatter = newMessageFormatters(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		content, err := json.Marshal(sender.buildMessage(events, contact, trigger, "", false))
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal message: %w", err)
+		}
+
+		return string(content), nil
+	}, nil
+}
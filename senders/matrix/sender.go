@@ -0,0 +1,337 @@
+package matrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+	"github.com/moira-alert/moira/senders/msgformat"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+const (
+	senderType      = "matrix"
+	roomIDPrefix    = "!"
+	roomAliasPrefix = "#"
+	plotMimeType    = "image/png"
+
+	messageMaxCharacters = 16_000
+	tooLongDescMessage   = "Description is too long for matrix sender.\n"
+)
+
+var (
+	htmlCodeBlockStart  = "<pre><code>"
+	htmlCodeBlockEnd    = "</code></pre>"
+	plainCodeBlockStart = ""
+	plainCodeBlockEnd   = ""
+
+	defaultClientTimeout = 30 * time.Second
+)
+
+// Structure that represents the Matrix configuration in the YAML file.
+type config struct {
+	HomeserverURL  string            `mapstructure:"homeserver_url" validate:"required,url"`
+	AccessToken    string            `mapstructure:"access_token" validate:"required"`
+	FrontURI       string            `mapstructure:"front_uri"`
+	UseEmoji       bool              `mapstructure:"use_emoji"`
+	DefaultEmoji   string            `mapstructure:"default_emoji"`
+	EmojiMap       map[string]string `mapstructure:"emoji_map"`
+	DisableThreads bool              `mapstructure:"disable_threads"`
+}
+
+// Sender posts messages to Matrix rooms.
+// It implements moira.Sender.
+// You must call Init method before SendEvents method.
+type Sender struct {
+	DataBase       moira.Database
+	logger         moira.Logger
+	client         *client
+	htmlFormatter  msgformat.MessageFormatter
+	plainFormatter msgformat.MessageFormatter
+	useThreads     bool
+}
+
+// Init configures Sender.
+func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, _ string) error {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to matrix config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("matrix config validation error: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return fmt.Errorf("cannot initialize matrix sender, err: %w", err)
+	}
+
+	sender.logger = logger
+	sender.useThreads = !cfg.DisableThreads
+	sender.client = &client{
+		homeserverURL: cfg.HomeserverURL,
+		accessToken:   cfg.AccessToken,
+		httpClient:    &http.Client{Timeout: defaultClientTimeout},
+	}
+	sender.htmlFormatter, sender.plainFormatter = newMessageFormatters(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+// newMessageFormatters returns formatters of the html and the plain text bodies of messages.
+func newMessageFormatters(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) (msgformat.MessageFormatter, msgformat.MessageFormatter) {
+	htmlFormatter := msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		htmlURIFormatter,
+		htmlDescriptionFormatter,
+		descriptionCutter,
+		htmlBoldFormatter,
+		htmlEventStringFormatter,
+		htmlCodeBlockStart,
+		htmlCodeBlockEnd)
+	plainFormatter := msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		plainURIFormatter,
+		plainDescriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		plainBoldFormatter,
+		plainEventStringFormatter,
+		plainCodeBlockStart,
+		plainCodeBlockEnd)
+
+	return htmlFormatter, plainFormatter
+}
+
+func htmlURIFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", triggerURI, html.EscapeString(triggerName))
+}
+
+func htmlDescriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := plainDescriptionFormatter(trigger, contact)
+	if desc == "" {
+		return ""
+	}
+
+	// Text constructions like <param> in trigger description should be shown as is, so escape them before blackfriday.Run.
+	replacer := strings.NewReplacer(
+		"<", "&lt;",
+		">", "&gt;",
+	)
+
+	return string(blackfriday.Run([]byte(replacer.Replace(desc))))
+}
+
+func descriptionCutter(_ string, maxSize int) string {
+	if utf8.RuneCountInString(tooLongDescMessage) <= maxSize {
+		return tooLongDescMessage
+	}
+
+	return ""
+}
+
+func htmlBoldFormatter(str string) string {
+	return fmt.Sprintf("<b>%s</b>", html.EscapeString(str))
+}
+
+func htmlEventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return html.EscapeString(plainEventStringFormatter(event, loc))
+}
+
+func plainURIFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("%s (%s)", triggerName, triggerURI)
+}
+
+func plainDescriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := trigger.Desc
+	if trigger.Desc != "" {
+		desc += "\n"
+	}
+
+	if contact.ExtraMessage != "" {
+		desc = contact.ExtraMessage + "\n" + desc
+	}
+
+	return desc
+}
+
+func plainBoldFormatter(str string) string {
+	return str
+}
+
+func plainEventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return fmt.Sprintf(
+		"%s: %s = %s (%s to %s)",
+		event.FormatTimestamp(loc, moira.DefaultTimeFormat),
+		event.Metric,
+		event.GetMetricsValues(moira.DefaultNotificationSettings),
+		event.OldState,
+		event.State)
+}
+
+// SendEvents implements moira.Sender interface.
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	ctx := context.Background()
+
+	roomID, err := sender.resolveRoomID(ctx, contact.Value)
+	if err != nil {
+		return err
+	}
+
+	threadRootID := sender.getThreadRoot(trigger.ID, roomID)
+
+	eventID, err := sender.client.SendMessage(ctx, roomID, sender.buildMessage(events, contact, trigger, threadRootID, throttled))
+	if err != nil {
+		if isRoomUnavailable(err) {
+			return moira.NewSenderBrokenContactError(err)
+		}
+
+		return fmt.Errorf("failed to send %s event message to matrix room [%s]: %w", trigger.ID, roomID, err)
+	}
+
+	if threadRootID == "" {
+		threadRootID = eventID
+		sender.setThreadRoot(trigger.ID, roomID, threadRootID)
+	}
+
+	if len(plots) > 0 {
+		if err = sender.sendPlots(ctx, plots, roomID, threadRootID, trigger.ID); err != nil {
+			sender.logger.Warning().
+				String(moira.LogFieldNameTriggerID, trigger.ID).
+				String("contact_value", contact.Value).
+				String("contact_type", contact.Type).
+				Error(err).
+				Msg("Failed to send plots to matrix room")
+		}
+	}
+
+	// The incident is over, so the next alert of the trigger starts a new thread.
+	if events.GetCurrentState(throttled) == moira.StateOK {
+		sender.removeThreadRoot(trigger.ID, roomID)
+	}
+
+	return nil
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, threadRootID string, throttled bool) messageContent {
+	params := msgformat.MessageFormatterParams{
+		Events:          events,
+		Trigger:         trigger,
+		Contact:         contact,
+		MessageMaxChars: messageMaxCharacters,
+		Throttled:       throttled,
+	}
+
+	return messageContent{
+		MsgType:       textMessageType,
+		Body:          sender.plainFormatter.Format(params),
+		Format:        htmlFormat,
+		FormattedBody: sender.htmlFormatter.Format(params),
+		RelatesTo:     newThreadRelation(threadRootID),
+	}
+}
+
+func (sender *Sender) sendPlots(ctx context.Context, plots [][]byte, roomID, threadRootID, triggerID string) error {
+	fileName := fmt.Sprintf("%s.png", triggerID)
+
+	for _, plot := range plots {
+		contentURI, err := sender.client.UploadMedia(ctx, fileName, plotMimeType, plot)
+		if err != nil {
+			return fmt.Errorf("failed to upload plot: %w", err)
+		}
+
+		_, err = sender.client.SendMessage(ctx, roomID, messageContent{
+			MsgType:   imageMessageType,
+			Body:      fileName,
+			URL:       contentURI,
+			Info:      &imageInfo{MimeType: plotMimeType, Size: len(plot)},
+			RelatesTo: newThreadRelation(threadRootID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send plot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resolveRoomID returns room id from the contact value, which may be either a room id or a room alias.
+func (sender *Sender) resolveRoomID(ctx context.Context, contactValue string) (string, error) {
+	switch {
+	case strings.HasPrefix(contactValue, roomIDPrefix):
+		return contactValue, nil
+	case strings.HasPrefix(contactValue, roomAliasPrefix):
+		roomID, err := sender.client.ResolveRoomAlias(ctx, contactValue)
+		if err != nil {
+			if isRoomUnavailable(err) {
+				return "", moira.NewSenderBrokenContactError(err)
+			}
+
+			return "", fmt.Errorf("failed to resolve matrix room alias %s: %w", contactValue, err)
+		}
+
+		return roomID, nil
+	default:
+		return "", moira.NewSenderBrokenContactError(
+			fmt.Errorf("invalid matrix room %s: must be a room id (!room:server) or a room alias (#room:server)", contactValue))
+	}
+}
+
+func (sender *Sender) getThreadRoot(triggerID, roomID string) string {
+	if !sender.useThreads || triggerID == "" {
+		return ""
+	}
+
+	rootEventID, err := sender.DataBase.GetNotificationThread(senderType, triggerID, roomID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		sender.logger.Warning().
+			String(moira.LogFieldNameTriggerID, triggerID).
+			Error(err).
+			Msg("Failed to get matrix thread, the message will be sent to the room")
+	}
+
+	return rootEventID
+}
+
+func (sender *Sender) setThreadRoot(triggerID, roomID, rootEventID string) {
+	if !sender.useThreads || triggerID == "" {
+		return
+	}
+
+	if err := sender.DataBase.SetNotificationThread(senderType, triggerID, roomID, rootEventID); err != nil {
+		sender.logger.Warning().
+			String(moira.LogFieldNameTriggerID, triggerID).
+			Error(err).
+			Msg("Failed to save matrix thread")
+	}
+}
+
+func (sender *Sender) removeThreadRoot(triggerID, roomID string) {
+	if !sender.useThreads || triggerID == "" {
+		return
+	}
+
+	if err := sender.DataBase.RemoveNotificationThread(senderType, triggerID, roomID); err != nil {
+		sender.logger.Warning().
+			String(moira.LogFieldNameTriggerID, triggerID).
+			Error(err).
+			Msg("Failed to remove matrix thread")
+	}
+}
@@ -0,0 +1,224 @@
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/h2non/gock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testHomeserverURL = "https://matrix.example.org"
+	testAccessToken   = "test-access-token"
+	testRoomID        = "!room:example.org"
+)
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	Convey("Init tests", t, func() {
+		validatorErr := validator.ValidationErrors{}
+
+		Convey("With empty access_token", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"homeserver_url": testHomeserverURL}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("With invalid homeserver_url", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"homeserver_url": "matrix", "access_token": testAccessToken}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("With full config", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{
+				"homeserver_url":  testHomeserverURL,
+				"access_token":    testAccessToken,
+				"disable_threads": true,
+			}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.useThreads, ShouldBeFalse)
+			So(sender.client.homeserverURL, ShouldEqual, testHomeserverURL)
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	sender := Sender{DataBase: dataBase}
+	err := sender.Init(map[string]interface{}{
+		"homeserver_url": testHomeserverURL,
+		"access_token":   testAccessToken,
+		"front_uri":      "http://moira.url",
+	}, logger, location, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	errorEvents := moira.NotificationEvents{{Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR, Values: map[string]float64{"t1": 1}}}
+	okEvents := moira.NotificationEvents{{Metric: "Metric", OldState: moira.StateERROR, State: moira.StateOK, Values: map[string]float64{"t1": 0}}}
+
+	matchThread := func(rootEventID string) gock.MatchFunc {
+		return func(request *http.Request, _ *gock.Request) (bool, error) {
+			var content messageContent
+			if err := json.NewDecoder(request.Body).Decode(&content); err != nil {
+				return false, err
+			}
+
+			if rootEventID == "" {
+				return content.RelatesTo == nil, nil
+			}
+
+			return content.RelatesTo != nil && content.RelatesTo.EventID == rootEventID && content.RelatesTo.RelType == threadRelType, nil
+		}
+	}
+
+	Convey("Send events", t, func() {
+		defer gock.Off()
+
+		Convey("First event of the trigger starts a thread and plots are uploaded into it", func() {
+			dataBase.EXPECT().GetNotificationThread(senderType, trigger.ID, testRoomID).Return("", database.ErrNil)
+			dataBase.EXPECT().SetNotificationThread(senderType, trigger.ID, testRoomID, "$root").Return(nil)
+
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/"+testRoomID+"/send/m.room.message/").
+				MatchHeader("Authorization", "Bearer "+testAccessToken).
+				AddMatcher(matchThread("")).
+				Reply(http.StatusOK).
+				JSON(map[string]string{"event_id": "$root"})
+			gock.New(testHomeserverURL).
+				Post("/_matrix/media/v3/upload").
+				MatchParam("filename", "TriggerID.png").
+				MatchHeader("Content-Type", plotMimeType).
+				Reply(http.StatusOK).
+				JSON(map[string]string{"content_uri": "mxc://example.org/plot"})
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/").
+				AddMatcher(matchThread("$root")).
+				Reply(http.StatusOK).
+				JSON(map[string]string{"event_id": "$plot"})
+
+			err := sender.SendEvents(errorEvents, moira.ContactData{Value: testRoomID}, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Follow-up event is sent to the thread, recovery closes it", func() {
+			dataBase.EXPECT().GetNotificationThread(senderType, trigger.ID, testRoomID).Return("$root", nil)
+			dataBase.EXPECT().RemoveNotificationThread(senderType, trigger.ID, testRoomID).Return(nil)
+
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/").
+				AddMatcher(matchThread("$root")).
+				Reply(http.StatusOK).
+				JSON(map[string]string{"event_id": "$reply"})
+
+			err := sender.SendEvents(okEvents, moira.ContactData{Value: testRoomID}, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Room alias is resolved", func() {
+			dataBase.EXPECT().GetNotificationThread(senderType, trigger.ID, testRoomID).Return("$root", nil)
+
+			gock.New(testHomeserverURL).
+				Get("/_matrix/client/v3/directory/room/").
+				Reply(http.StatusOK).
+				JSON(map[string]string{"room_id": testRoomID})
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/").
+				Reply(http.StatusOK).
+				JSON(map[string]string{"event_id": "$reply"})
+
+			err := sender.SendEvents(errorEvents, moira.ContactData{Value: "#alerts:example.org"}, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Forbidden room is a broken contact", func() {
+			dataBase.EXPECT().GetNotificationThread(senderType, trigger.ID, testRoomID).Return("", database.ErrNil)
+
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/").
+				Reply(http.StatusForbidden).
+				JSON(map[string]string{"errcode": errCodeForbidden, "error": "You are not in this room."})
+
+			err := sender.SendEvents(errorEvents, moira.ContactData{Value: testRoomID}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Server error is returned as is", func() {
+			dataBase.EXPECT().GetNotificationThread(senderType, trigger.ID, testRoomID).Return("", database.ErrNil)
+
+			gock.New(testHomeserverURL).
+				Put("/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/").
+				Reply(http.StatusInternalServerError).
+				BodyString("oops")
+
+			err := sender.SendEvents(errorEvents, moira.ContactData{Value: testRoomID}, trigger, nil, false)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "failed to send TriggerID event message to matrix room [!room:example.org]: matrix responded with 500 : oops")
+		})
+
+		Convey("Invalid contact value", func() {
+			err := sender.SendEvents(errorEvents, moira.ContactData{Value: "alerts"}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	sender := Sender{}
+	_ = sender.Init(map[string]interface{}{
+		"homeserver_url": testHomeserverURL,
+		"access_token":   testAccessToken,
+		"front_uri":      "http://moira.url",
+	}, logger, location, "")
+
+	Convey("Build message", t, func() {
+		events := moira.NotificationEvents{{
+			Values:    map[string]float64{"t1": 97},
+			Timestamp: 150000000,
+			Metric:    "<Metric>",
+			OldState:  moira.StateOK,
+			State:     moira.StateWARN,
+		}}
+		trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Desc: "**bold** <param>"}
+
+		message := sender.buildMessage(events, moira.ContactData{}, trigger, "", false)
+
+		So(message.MsgType, ShouldEqual, textMessageType)
+		So(message.Format, ShouldEqual, htmlFormat)
+		So(message.RelatesTo, ShouldBeNil)
+		So(message.Body, ShouldEqual,
+			"WARN Name (http://moira.url/trigger/TriggerID)\n**bold** <param>\n\n02:40 (GMT+00:00): <Metric> = 97 (OK to WARN)\n")
+		So(message.FormattedBody, ShouldEqual,
+			"<b>WARN</b> <a href=\"http://moira.url/trigger/TriggerID\">Name</a>\n<p><strong>bold</strong> &lt;param&gt;</p>\n"+
+				"<pre><code>\n02:40 (GMT+00:00): &lt;Metric&gt; = 97 (OK to WARN)\n</code></pre>")
+	})
+}
@@ -0,0 +1,35 @@
+package mattermost
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+)
+
+// NewPreview returns preview of Mattermost messages which the sender with given settings sends.
+// The url and api token are not used, so they may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to mattermost config: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize mattermost preview, err: %w", err)
+	}
+
+	sender := &Sender{
+		formatter: newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location),
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, trigger, contact, false), nil
+	}, nil
+}
@@ -77,10 +77,16 @@ func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, loca
 	}
 
 	sender.logger = logger
-	sender.formatter = msgformat.NewHighlightSyntaxFormatter(
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
 		emojiProvider,
-		cfg.UseEmoji,
-		cfg.FrontURI,
+		useEmoji,
+		frontURI,
 		location,
 		uriFormatter,
 		descriptionFormatter,
@@ -89,8 +95,6 @@ func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, loca
 		eventStringFormatter,
 		codeBlockStart,
 		codeBlockEnd)
-
-	return nil
 }
 
 func uriFormatter(triggerURI, triggerName string) string {
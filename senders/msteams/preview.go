@@ -0,0 +1,37 @@
+package msteams
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of MS Teams messages which the sender with given settings sends.
+// Previewed messages are the message cards posted to webhooks.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to msteams config: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI:  cfg.FrontURI,
+		maxEvents: cfg.MaxEvents,
+		location:  location,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		requestBody, err := json.Marshal(sender.buildMessage(events, trigger, contact, false))
+		if err != nil {
+			return "", err
+		}
+
+		return string(requestBody), nil
+	}, nil
+}
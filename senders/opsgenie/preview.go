@@ -0,0 +1,43 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of OpsGenie requests which the sender sends.
+// Every metric of the trigger is a separate alert, so the preview has a request to create or close an alert per line.
+// The settings of the sender have only the api key which is not used.
+func NewPreview(_ interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	sender := &Sender{
+		location: location,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		incidentEvents := events.GroupByIncident()
+		lines := make([]string, 0, len(incidentEvents))
+
+		for _, incidentEvent := range incidentEvents {
+			var request interface{}
+			if incidentEvent.IsIncidentResolved() {
+				request = makeCloseAlertRequest(incidentEvent)
+			} else {
+				request = sender.makeCreateAlertRequest(incidentEvent, contact, trigger, "", false)
+			}
+
+			line, err := json.Marshal(request)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal request: %w", err)
+			}
+
+			lines = append(lines, string(line))
+		}
+
+		return strings.Join(lines, "\n"), nil
+	}, nil
+}
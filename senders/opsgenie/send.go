@@ -18,22 +18,62 @@ const (
 )
 
 // SendEvents sends the events as an alert to opsgenie.
+// Every metric of the trigger is a separate alert with the stable alias,
+// so the alert is closed when the metric returns to OK.
 func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
-	createAlertRequest := sender.makeCreateAlertRequest(events, contact, trigger, plots, throttled)
+	ctx := context.Background()
+	imageLink := sender.storePlot(plots)
+
+	for _, incidentEvents := range events.GroupByIncident() {
+		if incidentEvents.IsIncidentResolved() {
+			_, err := sender.client.Close(ctx, makeCloseAlertRequest(incidentEvents))
+			if err != nil {
+				return fmt.Errorf("failed to close %s alert in opsgenie: %s", trigger.ID, err.Error())
+			}
 
-	_, err := sender.client.Create(context.Background(), createAlertRequest)
-	if err != nil {
-		return fmt.Errorf("failed to send %s event message to opsgenie: %s", trigger.ID, err.Error())
+			continue
+		}
+
+		createAlertRequest := sender.makeCreateAlertRequest(incidentEvents, contact, trigger, imageLink, throttled)
+
+		_, err := sender.client.Create(ctx, createAlertRequest)
+		if err != nil {
+			return fmt.Errorf("failed to send %s event message to opsgenie: %s", trigger.ID, err.Error())
+		}
 	}
 
 	return nil
 }
 
-func (sender *Sender) makeCreateAlertRequest(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) *alert.CreateAlertRequest {
+func makeCloseAlertRequest(events moira.NotificationEvents) *alert.CloseAlertRequest {
+	return &alert.CloseAlertRequest{
+		IdentifierType:  alert.ALIAS,
+		IdentifierValue: events[0].IncidentKey(),
+		Source:          "Moira",
+	}
+}
+
+// storePlot stores the first plot in the image store, opsgenie alert can show only one image.
+func (sender *Sender) storePlot(plots [][]byte) string {
+	if len(plots) == 0 || !sender.imageStoreConfigured {
+		return ""
+	}
+
+	imageLink, err := sender.imageStore.StoreImage(plots[0])
+	if err != nil {
+		sender.logger.Warning().
+			Error(err).
+			Msg("Could not store the plot image in the image store")
+	}
+
+	return imageLink
+}
+
+func (sender *Sender) makeCreateAlertRequest(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, imageLink string, throttled bool) *alert.CreateAlertRequest {
 	createAlertRequest := &alert.CreateAlertRequest{
 		Message:     sender.buildTitle(events, trigger, throttled),
 		Description: sender.buildMessage(events, throttled, trigger),
-		Alias:       trigger.ID,
+		Alias:       events[0].IncidentKey(),
 		Responders: []alert.Responder{
 			{Type: alert.EscalationResponder, Name: contact.Value},
 		},
@@ -42,16 +82,9 @@ func (sender *Sender) makeCreateAlertRequest(events moira.NotificationEvents, co
 		Priority: sender.getMessagePriority(events),
 	}
 
-	if len(plots) > 0 && sender.imageStoreConfigured {
-		imageLink, err := sender.imageStore.StoreImage(plots[0])
-		if err != nil {
-			sender.logger.Warning().
-				Error(err).
-				Msg("Could not store the plot image in the image store")
-		} else {
-			createAlertRequest.Details = map[string]string{
-				"image_url": imageLink,
-			}
+	if imageLink != "" {
+		createAlertRequest.Details = map[string]string{
+			"image_url": imageLink,
 		}
 	}
 
@@ -166,6 +166,7 @@ func TestMakeCreateAlertRequest(t *testing.T) {
 				Metric:    "Metric",
 				OldState:  moira.StateOK,
 				State:     moira.StateERROR,
+				TriggerID: "SomeID",
 			},
 		}
 		trigger := moira.TriggerData{
@@ -176,11 +177,11 @@ func TestMakeCreateAlertRequest(t *testing.T) {
 		contact := moira.ContactData{
 			Value: "123",
 		}
-		actual := sender.makeCreateAlertRequest(event, contact, trigger, [][]byte{[]byte(`test`)}, false)
+		actual := sender.makeCreateAlertRequest(event, contact, trigger, sender.storePlot([][]byte{[]byte(`test`)}), false)
 		expected := &alert.CreateAlertRequest{
 			Message:     sender.buildTitle(event, trigger, false),
 			Description: sender.buildMessage(event, false, trigger),
-			Alias:       "SomeID",
+			Alias:       "moira/SomeID/Metric",
 			Responders: []alert.Responder{
 				{Type: alert.EscalationResponder, Name: contact.Value},
 			},
@@ -193,4 +194,17 @@ func TestMakeCreateAlertRequest(t *testing.T) {
 		}
 		So(actual, ShouldResemble, expected)
 	})
+
+	Convey("Build CloseAlertRequest", t, func() {
+		events := moira.NotificationEvents{
+			{Metric: "Metric", TriggerID: "SomeID", OldState: moira.StateOK, State: moira.StateERROR},
+			{Metric: "Metric", TriggerID: "SomeID", OldState: moira.StateERROR, State: moira.StateOK},
+		}
+		So(events.IsIncidentResolved(), ShouldBeTrue)
+		So(makeCloseAlertRequest(events), ShouldResemble, &alert.CloseAlertRequest{
+			IdentifierType:  alert.ALIAS,
+			IdentifierValue: "moira/SomeID/Metric",
+			Source:          "Moira",
+		})
+	})
 }
@@ -0,0 +1,44 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of PagerDuty events which the sender with given settings sends.
+// Every metric of the trigger is a separate event, so the preview has an event per line.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to pagerduty config: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI: cfg.FrontURI,
+		location: location,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		incidentEvents := events.GroupByIncident()
+		lines := make([]string, 0, len(incidentEvents))
+
+		for _, incidentEvent := range incidentEvents {
+			event, err := json.Marshal(sender.buildEvent(incidentEvent, contact, trigger, nil, false))
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal event: %w", err)
+			}
+
+			lines = append(lines, string(event))
+		}
+
+		return strings.Join(lines, "\n"), nil
+	}, nil
+}
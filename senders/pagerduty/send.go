@@ -13,21 +13,42 @@ import (
 	"github.com/moira-alert/moira"
 )
 
-const summaryMaxChars = 1024
+const (
+	summaryMaxChars = 1024
+
+	actionTrigger = "trigger"
+	actionResolve = "resolve"
+)
 
 // SendEvents implements Sender interface Send.
+// Every metric of the trigger is a separate PagerDuty alert with the stable dedup key,
+// so the alert is resolved when the metric returns to OK.
 func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
-	event := sender.buildEvent(events, contact, trigger, plots, throttled)
+	images := sender.storePlots(plots)
+
+	for _, incidentEvents := range events.GroupByIncident() {
+		event := sender.buildEvent(incidentEvents, contact, trigger, images, throttled)
 
-	_, err := pagerduty.ManageEventWithContext(context.Background(), event)
-	if err != nil {
-		return fmt.Errorf("failed to post the event to the pagerduty contact %s : %w. ", contact.Value, err)
+		_, err := pagerduty.ManageEventWithContext(context.Background(), event)
+		if err != nil {
+			return fmt.Errorf("failed to post the event to the pagerduty contact %s : %w. ", contact.Value, err)
+		}
 	}
 
 	return nil
 }
 
-func (sender *Sender) buildEvent(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) pagerduty.V2Event {
+func (sender *Sender) buildEvent(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, images []interface{}, throttled bool) pagerduty.V2Event {
+	dedupKey := events[0].IncidentKey()
+
+	if events.IsIncidentResolved() {
+		return pagerduty.V2Event{
+			RoutingKey: contact.Value,
+			Action:     actionResolve,
+			DedupKey:   dedupKey,
+		}
+	}
+
 	summary := sender.buildSummary(events, trigger, throttled)
 	details := make(map[string]interface{})
 
@@ -71,30 +92,38 @@ func (sender *Sender) buildEvent(events moira.NotificationEvents, contact moira.
 		Details:   details,
 	}
 
-	event := pagerduty.V2Event{
+	return pagerduty.V2Event{
 		RoutingKey: contact.Value,
-		Action:     "trigger",
+		Action:     actionTrigger,
+		DedupKey:   dedupKey,
 		Payload:    payload,
+		Images:     images,
+	}
+}
+
+func (sender *Sender) storePlots(plots [][]byte) []interface{} {
+	if len(plots) == 0 || !sender.imageStoreConfigured {
+		return nil
 	}
 
-	if len(plots) > 0 && sender.imageStoreConfigured {
-		for i, plot := range plots {
-			imageLink, err := sender.imageStore.StoreImage(plot)
-			if err != nil {
-				sender.logger.Warning().
-					Error(err).
-					Msg("could not store the plot image in the image store")
-			} else {
-				imageDetails := map[string]string{
-					"src": imageLink,
-					"alt": fmt.Sprintf("Plot-%d", i),
-				}
-				event.Images = append(event.Images, imageDetails)
+	var images []interface{}
+
+	for i, plot := range plots {
+		imageLink, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.logger.Warning().
+				Error(err).
+				Msg("could not store the plot image in the image store")
+		} else {
+			imageDetails := map[string]string{
+				"src": imageLink,
+				"alt": fmt.Sprintf("Plot-%d", i),
 			}
+			images = append(images, imageDetails)
 		}
 	}
 
-	return event
+	return images
 }
 
 func (sender *Sender) getSeverity(events moira.NotificationEvents) string {
@@ -44,6 +44,7 @@ func TestBuildEvent(t *testing.T) {
 		baseExpected := pagerduty.V2Event{
 			RoutingKey: contact.Value,
 			Action:     "trigger",
+			DedupKey:   "moira/TriggerID/Metric name",
 			Payload: &pagerduty.V2Payload{
 				Summary:   "NODATA Trigger Name [tag1][tag2]",
 				Severity:  "warning",
@@ -58,7 +59,7 @@ func TestBuildEvent(t *testing.T) {
 		}
 
 		Convey("Build pagerduty event with one moira event", func() {
-			actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, [][]byte{}, false)
+			actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, nil, false)
 			expected := baseExpected
 			details := map[string]interface{}{
 				"Events":       "\n02:40 (GMT+00:00): Metric name = 97.4458331200185 (OK to NODATA)",
@@ -75,7 +76,7 @@ func TestBuildEvent(t *testing.T) {
 				imageStore.EXPECT().StoreImage([]byte("test")).Return("test", nil)
 				sender.imageStore = imageStore
 				sender.imageStoreConfigured = true
-				actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, [][]byte{[]byte("test")}, false)
+				actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, sender.storePlots([][]byte{[]byte("test")}), false)
 				expected := baseExpected
 				details := map[string]interface{}{
 					"Events":       "\n02:40 (GMT+00:00): Metric name = 97.4458331200185 (OK to NODATA)",
@@ -103,7 +104,7 @@ func TestBuildEvent(t *testing.T) {
 					moira.NotificationEvents{event},
 					contact,
 					trigger,
-					[][]byte{[]byte("plot0"), []byte("plot1"), []byte("plot2")},
+					sender.storePlots([][]byte{[]byte("plot0"), []byte("plot1"), []byte("plot2")}),
 					false,
 				)
 				expected := baseExpected
@@ -132,8 +133,21 @@ func TestBuildEvent(t *testing.T) {
 			})
 		})
 
+		Convey("Build pagerduty resolve event when the metric is OK", func() {
+			okEvent := event
+			okEvent.OldState = moira.StateNODATA
+			okEvent.State = moira.StateOK
+
+			actual := sender.buildEvent(moira.NotificationEvents{event, okEvent}, contact, trigger, nil, false)
+			So(actual, ShouldResemble, pagerduty.V2Event{
+				RoutingKey: contact.Value,
+				Action:     "resolve",
+				DedupKey:   "moira/TriggerID/Metric name",
+			})
+		})
+
 		Convey("Build pagerduty event with one event and throttled", func() {
-			actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, [][]byte{}, true)
+			actual := sender.buildEvent(moira.NotificationEvents{event}, contact, trigger, nil, true)
 			expected := baseExpected
 			details := map[string]interface{}{
 				"Events":       "\n02:40 (GMT+00:00): Metric name = 97.4458331200185 (OK to NODATA)",
@@ -152,7 +166,7 @@ func TestBuildEvent(t *testing.T) {
 				events = append(events, event)
 			}
 
-			actual := sender.buildEvent(events, contact, trigger, [][]byte{}, true)
+			actual := sender.buildEvent(events, contact, trigger, nil, true)
 			expected := baseExpected
 			details := map[string]interface{}{
 				"Events": `
@@ -0,0 +1,8 @@
+package senders
+
+import (
+	"github.com/moira-alert/moira"
+)
+
+// PreviewFunc returns the message which the sender sends to the contact about the events of the trigger, without sending it.
+type PreviewFunc func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error)
@@ -0,0 +1,117 @@
+// Package preview renders notification messages the way senders format them without sending anything.
+package preview
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/discord"
+	"github.com/moira-alert/moira/senders/googlechat"
+	"github.com/moira-alert/moira/senders/mail"
+	"github.com/moira-alert/moira/senders/matrix"
+	"github.com/moira-alert/moira/senders/mattermost"
+	"github.com/moira-alert/moira/senders/msteams"
+	"github.com/moira-alert/moira/senders/opsgenie"
+	"github.com/moira-alert/moira/senders/pagerduty"
+	"github.com/moira-alert/moira/senders/pushover"
+	"github.com/moira-alert/moira/senders/rocketchat"
+	"github.com/moira-alert/moira/senders/slack"
+	"github.com/moira-alert/moira/senders/teamsworkflows"
+	"github.com/moira-alert/moira/senders/telegram"
+	"github.com/moira-alert/moira/senders/twilio"
+	"github.com/moira-alert/moira/senders/victorops"
+	"github.com/moira-alert/moira/senders/webhook"
+	"github.com/moira-alert/moira/senders/zulip"
+)
+
+// newPreviewFunc creates preview of messages of the sender with given settings.
+type newPreviewFunc func(senderSettings interface{}, location *time.Location, dateTimeFormat string) (senders.PreviewFunc, error)
+
+// previews are the constructors of previews by types of senders, they are the same as types of senders in notifier config.
+// Messages of script and twilio voice senders are not previewed, they are not messages to read.
+var previews = map[string]newPreviewFunc{
+	"mail":              mail.NewPreview,
+	"mail api":          mail.NewPreview,
+	"pushover":          pushover.NewPreview,
+	"discord":           discord.NewPreview,
+	"slack":             slack.NewPreview,
+	"telegram":          telegram.NewPreview,
+	"twilio sms":        twilio.NewSMSPreview,
+	"webhook":           webhook.NewPreview,
+	"opsgenie":          opsgenie.NewPreview,
+	"victorops":         victorops.NewPreview,
+	"pagerduty":         pagerduty.NewPreview,
+	"msteams":           msteams.NewPreview,
+	"mattermost":        mattermost.NewPreview,
+	"msteams workflows": teamsworkflows.NewPreview,
+	"google chat":       googlechat.NewPreview,
+	"matrix":            matrix.NewPreview,
+	"zulip":             zulip.NewPreview,
+	"rocketchat":        rocketchat.NewPreview,
+}
+
+// Renderer renders messages of notifications with the code of senders.
+type Renderer struct {
+	previews map[string]senders.PreviewFunc
+}
+
+// NewRenderer creates Renderer of messages of senders with given settings, they are the same as senders settings of notifier,
+// so the messages are the same as sent ones. Messages are previewed by contact types of senders,
+// links to triggers are prefixed with frontURI and time of events is in location, UTC if location is nil.
+func NewRenderer(sendersSettings []map[string]interface{}, frontURI string, location *time.Location, dateTimeFormat string) (*Renderer, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	renderer := &Renderer{
+		previews: make(map[string]senders.PreviewFunc, len(sendersSettings)),
+	}
+
+	for _, senderSettings := range sendersSettings {
+		senderType, ok := senderSettings["sender_type"].(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to retrieve sender type from sender settings")
+		}
+
+		contactType, ok := senderSettings["contact_type"].(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to retrieve sender contact type from sender settings")
+		}
+
+		newPreview, ok := previews[senderType]
+		if !ok {
+			continue
+		}
+
+		settings := maps.Clone(senderSettings)
+		settings["front_uri"] = frontURI
+
+		preview, err := newPreview(settings, location, dateTimeFormat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create preview of messages of %s contacts: %w", contactType, err)
+		}
+
+		renderer.previews[contactType] = preview
+	}
+
+	return renderer, nil
+}
+
+// Render returns message about events of the trigger which the contact gets.
+// It returns false if messages of the contact type can not be previewed.
+func (renderer *Renderer) Render(contact moira.ContactData, trigger moira.TriggerData, events moira.NotificationEvents) (string, bool, error) {
+	preview, ok := renderer.previews[contact.Type]
+	if !ok {
+		return "", false, nil
+	}
+
+	message, err := preview(events, contact, trigger)
+	if err != nil {
+		return "", true, err
+	}
+
+	return message, true, nil
+}
@@ -0,0 +1,114 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewRenderer(t *testing.T) {
+	Convey("NewRenderer", t, func() {
+		Convey("senders without previews are skipped", func() {
+			renderer, err := NewRenderer([]map[string]interface{}{
+				{"sender_type": "script", "contact_type": "script"},
+			}, "", nil, "")
+			So(err, ShouldBeNil)
+			So(renderer.previews, ShouldBeEmpty)
+		})
+
+		Convey("sender without contact type is invalid", func() {
+			_, err := NewRenderer([]map[string]interface{}{{"sender_type": "slack"}}, "", nil, "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("sender with invalid settings is invalid", func() {
+			_, err := NewRenderer([]map[string]interface{}{
+				{"sender_type": "slack", "contact_type": "slack", "emoji_map": map[string]string{"UNKNOWN": ":x:"}},
+			}, "", nil, "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("settings of senders are not changed", func() {
+			settings := map[string]interface{}{"sender_type": "slack", "contact_type": "slack"}
+
+			_, err := NewRenderer([]map[string]interface{}{settings}, "https://moira.example.com", nil, "")
+			So(err, ShouldBeNil)
+			So(settings, ShouldNotContainKey, "front_uri")
+		})
+	})
+}
+
+func TestRenderer_Render(t *testing.T) {
+	Convey("Render", t, func() {
+		renderer, err := NewRenderer([]map[string]interface{}{
+			{"sender_type": "slack", "contact_type": "slack-alerts", "api_token": "", "use_emoji": false},
+			{"sender_type": "webhook", "contact_type": "webhook", "url": "https://example.com"},
+			{"sender_type": "mail", "contact_type": "mail"},
+			{"sender_type": "twilio sms", "contact_type": "sms"},
+		}, "https://moira.example.com", nil, "15:04 02.01.2006")
+		So(err, ShouldBeNil)
+
+		trigger := moira.TriggerData{ID: "trigger1", Name: "Disk usage", Tags: []string{"disk"}}
+		events := moira.NotificationEvents{
+			{Metric: "disk.web1", OldState: moira.StateOK, State: moira.StateERROR, Timestamp: 1704067200},
+		}
+
+		Convey("message is rendered by the sender of the contact type", func() {
+			message, ok, err := renderer.Render(moira.ContactData{Type: "slack-alerts"}, trigger, events)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(message, ShouldEqual, "*ERROR* <https://moira.example.com/trigger/trigger1|Disk usage> [disk]\n```\n00:00 (GMT+00:00): disk.web1 = — (OK to ERROR)\n```")
+		})
+
+		Convey("webhook message is the body of request", func() {
+			message, ok, err := renderer.Render(moira.ContactData{Type: "webhook", Value: "https://example.com"}, trigger, events)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(message, ShouldStartWith, `{"trigger":{"id":"trigger1","name":"Disk usage","description":"","tags":["disk"]},"events":[{"metric":"disk.web1"`)
+		})
+
+		Convey("webhook message with invalid body template of the contact is not rendered", func() {
+			_, ok, err := renderer.Render(moira.ContactData{Type: "webhook", BodyTemplate: "{{ .Trigger.Name"}, trigger, events)
+			So(err, ShouldNotBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("mail message has the subject and the html", func() {
+			message, ok, err := renderer.Render(moira.ContactData{Type: "mail", Value: "devops@example.com"}, trigger, events)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(message, ShouldStartWith, "ERROR Disk usage [disk] (1)\n\n")
+			So(message, ShouldContainSubstring, "https://moira.example.com/trigger/trigger1")
+			So(message, ShouldContainSubstring, "00:00 01.01.2024")
+		})
+
+		Convey("sms message is rendered", func() {
+			message, ok, err := renderer.Render(moira.ContactData{Type: "sms"}, trigger, events)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(message, ShouldEqual, "ERROR Disk usage [disk] (1)\n\n00:00 (GMT+00:00): disk.web1 = — (OK to ERROR)")
+		})
+
+		Convey("messages of all senders with previews are rendered", func() {
+			for senderType := range previews {
+				renderer, err := NewRenderer([]map[string]interface{}{
+					{"sender_type": senderType, "contact_type": senderType},
+				}, "https://moira.example.com", nil, "15:04 02.01.2006")
+				So(err, ShouldBeNil)
+
+				message, ok, err := renderer.Render(moira.ContactData{Type: senderType}, trigger, events)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(message, ShouldContainSubstring, "disk.web1")
+			}
+		})
+
+		Convey("message of contact type without configured sender is not rendered", func() {
+			message, ok, err := renderer.Render(moira.ContactData{Type: "slack"}, trigger, events)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+			So(message, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package pushover
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of Pushover messages which the sender with given settings sends.
+// The preview has the title, the message and the url of the trigger on separate lines,
+// the api token is not used, so it may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to pushover config: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI: cfg.FrontURI,
+		location: location,
+	}
+
+	return func(events moira.NotificationEvents, _ moira.ContactData, trigger moira.TriggerData) (string, error) {
+		pushoverMessage := sender.makePushoverMessage(events, trigger, nil, false)
+
+		var preview strings.Builder
+
+		preview.WriteString(pushoverMessage.Title)
+		preview.WriteString("\n\n")
+		preview.WriteString(pushoverMessage.Message)
+
+		if pushoverMessage.URL != "" {
+			preview.WriteString("\n")
+			preview.WriteString(pushoverMessage.URL)
+		}
+
+		return preview.String(), nil
+	}, nil
+}
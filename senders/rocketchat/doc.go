@@ -0,0 +1,2 @@
+// Package rocketchat is Moira sender for Rocket.Chat, it supports both incoming webhooks and REST API.
+package rocketchat
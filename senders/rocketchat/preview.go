@@ -0,0 +1,35 @@
+package rocketchat
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+)
+
+// NewPreview returns preview of Rocket.Chat messages which the sender with given settings sends.
+// The url, user id and auth token are not used, so they may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to rocketchat config: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize rocketchat preview, err: %w", err)
+	}
+
+	sender := &Sender{
+		formatter: newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location),
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, contact, trigger, false).Text, nil
+	}, nil
+}
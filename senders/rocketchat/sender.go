@@ -0,0 +1,287 @@
+package rocketchat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+	"github.com/moira-alert/moira/senders/msgformat"
+)
+
+const (
+	postMessagePath = "/api/v1/chat.postMessage"
+	plotTitle       = "Plot"
+
+	messageMaxCharacters = 5_000
+)
+
+var (
+	codeBlockStart = "```"
+	codeBlockEnd   = "```"
+
+	errRESTNotConfigured = errors.New("rocket.chat url, user_id and auth_token must be configured to send messages to channels")
+
+	defaultClientTimeout = 30 * time.Second
+)
+
+// Structure that represents the Rocket.Chat configuration in the YAML file.
+type config struct {
+	URL          string            `mapstructure:"url" validate:"omitempty,url"`
+	UserID       string            `mapstructure:"user_id"`
+	AuthToken    string            `mapstructure:"auth_token"`
+	FrontURI     string            `mapstructure:"front_uri"`
+	UseEmoji     bool              `mapstructure:"use_emoji"`
+	DefaultEmoji string            `mapstructure:"default_emoji"`
+	EmojiMap     map[string]string `mapstructure:"emoji_map"`
+	ImageStore   string            `mapstructure:"image_store"`
+}
+
+// Sender posts messages to Rocket.Chat.
+// If the contact value is an incoming webhook url the message is posted to it,
+// otherwise the contact value is treated as a channel (#channel) or a user (@user) and the message is posted through REST API.
+type Sender struct {
+	ImageStores          map[string]moira.ImageStore
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	url                  string
+	userID               string
+	authToken            string
+	logger               moira.Logger
+	client               *http.Client
+	formatter            msgformat.MessageFormatter
+}
+
+// message represents Rocket.Chat message payload, it is the same for webhooks and chat.postMessage.
+type message struct {
+	Channel     string       `json:"channel,omitempty"`
+	Text        string       `json:"text"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type attachment struct {
+	Title    string `json:"title,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+type response struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// Init configures Sender.
+func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, _ string) error {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to rocketchat config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("rocketchat config validation error: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return fmt.Errorf("cannot initialize rocketchat sender, err: %w", err)
+	}
+
+	if cfg.ImageStore != "" {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+
+	sender.url = strings.TrimSuffix(cfg.URL, "/")
+	sender.userID = cfg.UserID
+	sender.authToken = cfg.AuthToken
+	sender.logger = logger
+	sender.client = &http.Client{
+		Timeout: defaultClientTimeout,
+	}
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		uriFormatter,
+		descriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		boldFormatter,
+		eventStringFormatter,
+		codeBlockStart,
+		codeBlockEnd)
+}
+
+func uriFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("[%s](%s)", triggerName, triggerURI)
+}
+
+func descriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := trigger.Desc
+	if trigger.Desc != "" {
+		desc += "\n"
+	}
+
+	if contact.ExtraMessage != "" {
+		desc = contact.ExtraMessage + "\n" + desc
+	}
+
+	return desc
+}
+
+func boldFormatter(str string) string {
+	return fmt.Sprintf("*%s*", str)
+}
+
+func eventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return fmt.Sprintf(
+		"%s: %s = %s (%s to %s)",
+		event.FormatTimestamp(loc, moira.DefaultTimeFormat),
+		event.Metric,
+		event.GetMetricsValues(moira.DefaultNotificationSettings),
+		event.OldState,
+		event.State)
+}
+
+// SendEvents implements moira.Sender interface.
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	msg := sender.buildMessage(events, contact, trigger, throttled)
+	msg.Attachments = sender.buildPlotAttachments(plots, trigger, events.GetCurrentState(throttled))
+
+	requestURL, requestHeaders, err := sender.buildRequestTarget(contact.Value, &msg)
+	if err != nil {
+		return moira.NewSenderBrokenContactError(err)
+	}
+
+	requestBody, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, requestURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for k, v := range requestHeaders {
+		request.Header.Set(k, v)
+	}
+
+	resp, err := sender.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result response
+	if err = json.Unmarshal(responseBody, &result); err != nil || !result.Success {
+		sendErr := fmt.Errorf("rocket.chat responded with %d: %s", resp.StatusCode, string(responseBody))
+		if resp.StatusCode == http.StatusNotFound || strings.Contains(result.Error, "error-invalid-channel") {
+			return moira.NewSenderBrokenContactError(sendErr)
+		}
+
+		return fmt.Errorf("failed to send %s event message to rocket.chat [%s]: %w", trigger.ID, contact.Value, sendErr)
+	}
+
+	return nil
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) message {
+	return message{
+		Text: sender.formatter.Format(msgformat.MessageFormatterParams{
+			Events:          events,
+			Trigger:         trigger,
+			Contact:         contact,
+			MessageMaxChars: messageMaxCharacters,
+			Throttled:       throttled,
+		}),
+	}
+}
+
+// buildRequestTarget returns the url and headers of the request depending on the contact value.
+func (sender *Sender) buildRequestTarget(contactValue string, msg *message) (string, map[string]string, error) {
+	headers := map[string]string{
+		"User-Agent":   "Moira",
+		"Content-Type": "application/json",
+	}
+
+	if strings.HasPrefix(contactValue, "https://") || strings.HasPrefix(contactValue, "http://") {
+		return contactValue, headers, nil
+	}
+
+	if sender.url == "" || sender.userID == "" || sender.authToken == "" {
+		return "", nil, errRESTNotConfigured
+	}
+
+	if !strings.HasPrefix(contactValue, "#") && !strings.HasPrefix(contactValue, "@") {
+		return "", nil, fmt.Errorf("invalid rocket.chat channel %s: must start with # or @", contactValue)
+	}
+
+	headers["X-User-Id"] = sender.userID
+	headers["X-Auth-Token"] = sender.authToken
+	msg.Channel = contactValue
+
+	return sender.url + postMessagePath, headers, nil
+}
+
+func (sender *Sender) buildPlotAttachments(plots [][]byte, trigger moira.TriggerData, state moira.State) []attachment {
+	if !sender.imageStoreConfigured {
+		return nil
+	}
+
+	attachments := make([]attachment, 0, len(plots))
+
+	for _, plot := range plots {
+		plotURL, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.logger.Warning().
+				String(moira.LogFieldNameTriggerID, trigger.ID).
+				Error(err).
+				Msg("Could not store the plot image in the image store")
+
+			continue
+		}
+
+		attachments = append(attachments, attachment{
+			Title:    plotTitle,
+			ImageURL: plotURL,
+			Color:    getColourForState(state),
+		})
+	}
+
+	return attachments
+}
+
+func getColourForState(state moira.State) string {
+	switch state {
+	case moira.StateOK:
+		return "#2ECC71"
+	case moira.StateWARN:
+		return "#F39C12"
+	case moira.StateERROR, moira.StateEXCEPTION:
+		return "#E74C3C"
+	default:
+		return "#95A5A6"
+	}
+}
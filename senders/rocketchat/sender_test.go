@@ -0,0 +1,124 @@
+package rocketchat
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testRocketChatURL = "https://rocket.example.org"
+	testWebhookURL    = testRocketChatURL + "/hooks/id/token"
+)
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+	imageStore.EXPECT().IsEnabled().Return(true)
+
+	sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+	err := sender.Init(map[string]interface{}{
+		"url":         testRocketChatURL,
+		"user_id":     "user",
+		"auth_token":  "token",
+		"front_uri":   "http://moira.url",
+		"image_store": "s3",
+	}, logger, location, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := moira.NotificationEvents{{Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR, Values: map[string]float64{"t1": 1}}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+
+	matchMessage := func(channel string, attachments int) gock.MatchFunc {
+		return func(request *http.Request, _ *gock.Request) (bool, error) {
+			var msg message
+			if err := json.NewDecoder(request.Body).Decode(&msg); err != nil {
+				return false, err
+			}
+
+			return msg.Channel == channel && len(msg.Attachments) == attachments && msg.Text != "", nil
+		}
+	}
+
+	Convey("Send events", t, func() {
+		defer gock.Off()
+
+		Convey("To incoming webhook with plot", func() {
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("https://images.url/plot.png", nil)
+
+			gock.New(testWebhookURL).
+				Post("").
+				AddMatcher(matchMessage("", 1)).
+				Reply(http.StatusOK).
+				JSON(map[string]bool{"success": true})
+
+			err := sender.SendEvents(events, moira.ContactData{Value: testWebhookURL}, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("To channel through REST API", func() {
+			gock.New(testRocketChatURL).
+				Post(postMessagePath).
+				MatchHeader("X-User-Id", "user").
+				MatchHeader("X-Auth-Token", "token").
+				AddMatcher(matchMessage("#alerts", 0)).
+				Reply(http.StatusOK).
+				JSON(map[string]bool{"success": true})
+
+			err := sender.SendEvents(events, moira.ContactData{Value: "#alerts"}, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Invalid channel is a broken contact", func() {
+			gock.New(testRocketChatURL).
+				Post(postMessagePath).
+				Reply(http.StatusBadRequest).
+				JSON(map[string]interface{}{"success": false, "error": "[error-invalid-channel]"})
+
+			err := sender.SendEvents(events, moira.ContactData{Value: "#unknown"}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Server error is returned", func() {
+			gock.New(testRocketChatURL).
+				Post(postMessagePath).
+				Reply(http.StatusInternalServerError).
+				BodyString("oops")
+
+			err := sender.SendEvents(events, moira.ContactData{Value: "#alerts"}, trigger, nil, false)
+			So(err.Error(), ShouldEqual, "failed to send TriggerID event message to rocket.chat [#alerts]: rocket.chat responded with 500: oops")
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Contact without prefix is a broken contact", func() {
+			err := sender.SendEvents(events, moira.ContactData{Value: "alerts"}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+		})
+	})
+
+	Convey("Channels require REST API credentials", t, func() {
+		webhookOnly := Sender{}
+		_ = webhookOnly.Init(map[string]interface{}{}, logger, location, "")
+
+		err := webhookOnly.SendEvents(events, moira.ContactData{Value: "#alerts"}, trigger, nil, false)
+		So(err, ShouldResemble, moira.NewSenderBrokenContactError(errRESTNotConfigured))
+	})
+}
@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+)
+
+// NewPreview returns preview of Slack messages which the sender with given settings sends.
+// The api token is not used, so it may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to slack config: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize slack preview, err: %w", err)
+	}
+
+	sender := &Sender{
+		emojiProvider: emojiProvider,
+		formatter:     newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location),
+	}
+
+	return func(events moira.NotificationEvents, _ moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, trigger, false), nil
+	}, nil
+}
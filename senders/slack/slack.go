@@ -67,18 +67,7 @@ func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, loca
 
 	sender.logger = logger
 	sender.emojiProvider = emojiProvider
-	sender.formatter = msgformat.NewHighlightSyntaxFormatter(
-		emojiProvider,
-		cfg.UseEmoji,
-		cfg.FrontURI,
-		location,
-		uriFormatter,
-		descriptionFormatter,
-		msgformat.DefaultDescriptionCutter,
-		boldFormatter,
-		eventStringFormatter,
-		codeBlockStart,
-		codeBlockEnd)
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
 
 	sender.client = slack_client.New(cfg.APIToken)
 
@@ -121,6 +110,21 @@ func (sender *Sender) buildMessage(events moira.NotificationEvents, trigger moir
 	})
 }
 
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		uriFormatter,
+		descriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		boldFormatter,
+		eventStringFormatter,
+		codeBlockStart,
+		codeBlockEnd)
+}
+
 func uriFormatter(triggerURI, triggerName string) string {
 	return fmt.Sprintf("<%s|%s>", triggerURI, triggerName)
 }
@@ -0,0 +1,48 @@
+package teamsworkflows
+
+// Message represents a Teams Workflows webhook message that carries an Adaptive Card.
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment represents an Adaptive Card attachment of the message.
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard represents the Adaptive Card payload.
+type AdaptiveCard struct {
+	Schema  string          `json:"$schema"`
+	Type    string          `json:"type"`
+	Version string          `json:"version"`
+	MSTeams *MSTeamsOptions `json:"msteams,omitempty"`
+	Body    []Element       `json:"body"`
+	Actions []Action        `json:"actions,omitempty"`
+}
+
+// MSTeamsOptions contains Teams specific options of the card.
+type MSTeamsOptions struct {
+	Width string `json:"width"`
+}
+
+// Element represents an Adaptive Card body element (Container, TextBlock or Image).
+type Element struct {
+	Type    string    `json:"type"`
+	Style   string    `json:"style,omitempty"`
+	Bleed   bool      `json:"bleed,omitempty"`
+	Items   []Element `json:"items,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Wrap    bool      `json:"wrap,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	AltText string    `json:"altText,omitempty"`
+}
+
+// Action represents an Adaptive Card action.
+type Action struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
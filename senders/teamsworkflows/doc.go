@@ -0,0 +1,2 @@
+// Package teamsworkflows is Moira sender for Microsoft Teams Workflows (Power Automate) webhooks, which accept Adaptive Cards.
+package teamsworkflows
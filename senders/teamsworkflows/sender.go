@@ -0,0 +1,301 @@
+package teamsworkflows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+	"github.com/moira-alert/moira/senders/msgformat"
+)
+
+const (
+	messageType          = "message"
+	adaptiveCardType     = "AdaptiveCard"
+	adaptiveCardSchema   = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion  = "1.4"
+	adaptiveCardMimeType = "application/vnd.microsoft.card.adaptive"
+	fullWidth            = "Full"
+	containerType        = "Container"
+	textBlockType        = "TextBlock"
+	imageType            = "Image"
+	openURLAction        = "Action.OpenUrl"
+	openURLTitle         = "View in Moira"
+	plotAltText          = "Trigger plot"
+
+	messageMaxCharacters = 10_000
+)
+
+var (
+	codeBlockStart = ""
+	codeBlockEnd   = ""
+
+	headers = map[string]string{
+		"User-Agent":   "Moira",
+		"Content-Type": "application/json",
+	}
+
+	defaultClientTimeout = 30 * time.Second
+)
+
+// Structure that represents the Teams Workflows configuration in the YAML file.
+type config struct {
+	FrontURI     string            `mapstructure:"front_uri"`
+	UseEmoji     bool              `mapstructure:"use_emoji"`
+	DefaultEmoji string            `mapstructure:"default_emoji"`
+	EmojiMap     map[string]string `mapstructure:"emoji_map"`
+	ImageStore   string            `mapstructure:"image_store"`
+}
+
+// Sender implements moira sender interface via Microsoft Teams Workflows webhooks.
+type Sender struct {
+	ImageStores          map[string]moira.ImageStore
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	frontURI             string
+	logger               moira.Logger
+	client               *http.Client
+	formatter            msgformat.MessageFormatter
+}
+
+// Init initialises settings required for full functionality.
+func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, _ string) error {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to teams workflows config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("teams workflows config validation error: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return fmt.Errorf("cannot initialize teams workflows sender, err: %w", err)
+	}
+
+	if cfg.ImageStore != "" {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+
+	sender.logger = logger
+	sender.frontURI = cfg.FrontURI
+	sender.client = &http.Client{
+		Timeout: defaultClientTimeout,
+	}
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		uriFormatter,
+		descriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		boldFormatter,
+		eventStringFormatter,
+		codeBlockStart,
+		codeBlockEnd)
+}
+
+func uriFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("[%s](%s)", triggerName, triggerURI)
+}
+
+func descriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := trigger.Desc
+	if trigger.Desc != "" {
+		desc += "\n"
+	}
+
+	if contact.ExtraMessage != "" {
+		desc = contact.ExtraMessage + "\n" + desc
+	}
+
+	return desc
+}
+
+func boldFormatter(str string) string {
+	return fmt.Sprintf("**%s**", str)
+}
+
+func eventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return fmt.Sprintf(
+		"- %s: `%s` = %s (%s to %s)",
+		event.FormatTimestamp(loc, moira.DefaultTimeFormat),
+		event.Metric,
+		event.GetMetricsValues(moira.DefaultNotificationSettings),
+		event.OldState,
+		event.State)
+}
+
+// SendEvents implements Sender interface Send.
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	if err := isValidWebhookURL(contact.Value); err != nil {
+		return moira.NewSenderBrokenContactError(err)
+	}
+
+	message := sender.buildMessage(events, contact, trigger, sender.storePlots(plots, trigger), throttled)
+
+	requestBody, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, contact.Value, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer response.Body.Close()
+
+	// read the entire response as required by https://golang.org/pkg/net/http/#Client.Do
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("server responded with a non 2xx code: %d, body: %s", response.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plotURLs []string, throttled bool) Message {
+	text := sender.formatter.Format(msgformat.MessageFormatterParams{
+		Events:          events,
+		Trigger:         trigger,
+		Contact:         contact,
+		MessageMaxChars: messageMaxCharacters,
+		Throttled:       throttled,
+	})
+
+	items := []Element{
+		{
+			Type: textBlockType,
+			// Adaptive Cards markdown treats a single newline as a space, so lines are separated by paragraphs.
+			Text: strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n\n"),
+			Wrap: true,
+		},
+	}
+
+	for _, plotURL := range plotURLs {
+		items = append(items, Element{
+			Type:    imageType,
+			URL:     plotURL,
+			AltText: plotAltText,
+		})
+	}
+
+	var actions []Action
+	if triggerURI := trigger.GetTriggerURI(sender.frontURI); triggerURI != "" {
+		actions = append(actions, Action{
+			Type:  openURLAction,
+			Title: openURLTitle,
+			URL:   triggerURI,
+		})
+	}
+
+	return Message{
+		Type: messageType,
+		Attachments: []Attachment{
+			{
+				ContentType: adaptiveCardMimeType,
+				Content: AdaptiveCard{
+					Schema:  adaptiveCardSchema,
+					Type:    adaptiveCardType,
+					Version: adaptiveCardVersion,
+					MSTeams: &MSTeamsOptions{Width: fullWidth},
+					Body: []Element{
+						{
+							Type:  containerType,
+							Style: getContainerStyle(events.GetCurrentState(throttled)),
+							Bleed: true,
+							Items: items,
+						},
+					},
+					Actions: actions,
+				},
+			},
+		},
+	}
+}
+
+// storePlots uploads plots to the configured image store and returns their public URLs.
+func (sender *Sender) storePlots(plots [][]byte, trigger moira.TriggerData) []string {
+	if !sender.imageStoreConfigured {
+		return nil
+	}
+
+	plotURLs := make([]string, 0, len(plots))
+
+	for _, plot := range plots {
+		plotURL, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.logger.Warning().
+				String(moira.LogFieldNameTriggerID, trigger.ID).
+				Error(err).
+				Msg("Could not store the plot image in the image store")
+
+			continue
+		}
+
+		plotURLs = append(plotURLs, plotURL)
+	}
+
+	return plotURLs
+}
+
+func isValidWebhookURL(webhookURL string) error {
+	parsedURL, err := url.ParseRequestURI(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid teams workflows webhook url %s: %w", webhookURL, err)
+	}
+
+	if parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return fmt.Errorf("invalid teams workflows webhook url %s: only absolute https urls are allowed", webhookURL)
+	}
+
+	return nil
+}
+
+// getContainerStyle returns Adaptive Card container style, which highlights the card with the state colour.
+func getContainerStyle(state moira.State) string {
+	switch state {
+	case moira.StateOK:
+		return "good"
+	case moira.StateWARN:
+		return "warning"
+	case moira.StateERROR, moira.StateNODATA, moira.StateEXCEPTION:
+		return "attention"
+	case moira.StateTEST:
+		return "accent"
+	default:
+		return "default"
+	}
+}
@@ -0,0 +1,168 @@
+package teamsworkflows
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+const testWebhookURL = "https://prod-00.westeurope.logic.azure.com/workflows/foo/triggers/manual/paths/invoke"
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+
+	Convey("Init tests", t, func() {
+		Convey("Without image store", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"front_uri": "http://moira.url"}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.frontURI, ShouldEqual, "http://moira.url")
+			So(sender.imageStoreConfigured, ShouldBeFalse)
+		})
+
+		Convey("With image store", func() {
+			imageStore.EXPECT().IsEnabled().Return(true)
+
+			sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+			err := sender.Init(map[string]interface{}{"image_store": "s3"}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.imageStoreConfigured, ShouldBeTrue)
+		})
+
+		Convey("With wrong emoji map", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"emoji_map": map[string]string{"UNKNOWN": ":unknown:"}}, logger, location, "")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+	imageStore.EXPECT().IsEnabled().Return(true)
+
+	sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+	err := sender.Init(map[string]interface{}{"front_uri": "http://moira.url", "image_store": "s3"}, logger, location, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := moira.NotificationEvents{{
+		TriggerID: "TriggerID",
+		Values:    map[string]float64{"t1": 123},
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateERROR,
+	}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Tags: []string{"tag1"}}
+	contact := moira.ContactData{Value: testWebhookURL}
+
+	Convey("Send events", t, func() {
+		defer gock.Off()
+
+		Convey("With plot", func() {
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("https://images.url/plot.png", nil)
+
+			gock.New(testWebhookURL).
+				Post("").
+				MatchHeader("Content-Type", "application/json").
+				AddMatcher(func(request *http.Request, _ *gock.Request) (bool, error) {
+					var message Message
+					if err := json.NewDecoder(request.Body).Decode(&message); err != nil {
+						return false, err
+					}
+
+					card := message.Attachments[0].Content
+					container := card.Body[0]
+
+					return message.Type == messageType &&
+						message.Attachments[0].ContentType == adaptiveCardMimeType &&
+						container.Style == "attention" &&
+						container.Items[1].URL == "https://images.url/plot.png" &&
+						card.Actions[0].URL == "http://moira.url/trigger/TriggerID", nil
+				}).
+				Reply(http.StatusAccepted)
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Image store error does not break sending", func() {
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("", errors.New("store error"))
+
+			gock.New(testWebhookURL).
+				Post("").
+				Reply(http.StatusAccepted)
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Non 2xx response", func() {
+			gock.New(testWebhookURL).
+				Post("").
+				Reply(http.StatusBadRequest).
+				BodyString("bad card")
+
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldResemble, errors.New("server responded with a non 2xx code: 400, body: bad card"))
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Invalid webhook url", func() {
+			err := sender.SendEvents(events, moira.ContactData{Value: "http://insecure.url"}, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	sender := Sender{}
+	_ = sender.Init(map[string]interface{}{"front_uri": "http://moira.url"}, logger, location, "")
+
+	Convey("Build message", t, func() {
+		events := moira.NotificationEvents{{
+			Values:    map[string]float64{"t1": 97},
+			Timestamp: 150000000,
+			Metric:    "Metric",
+			OldState:  moira.StateOK,
+			State:     moira.StateWARN,
+		}}
+		trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Desc: "Description", Tags: []string{"tag1"}}
+
+		message := sender.buildMessage(events, moira.ContactData{}, trigger, nil, false)
+		body := message.Attachments[0].Content.Body[0]
+
+		So(body.Style, ShouldEqual, "warning")
+		So(body.Items, ShouldHaveLength, 1)
+		So(body.Items[0].Text, ShouldEqual,
+			"**WARN** [Name](http://moira.url/trigger/TriggerID) [tag1]\n\nDescription\n\n\n\n- 02:40 (GMT+00:00): `Metric` = 97 (OK to WARN)")
+	})
+}
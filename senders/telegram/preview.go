@@ -0,0 +1,35 @@
+package telegram
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of Telegram messages which the sender with given settings sends.
+// The api token is not used, so it may be omitted from the settings.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to telegram config: %w", err)
+	}
+
+	sender := &Sender{
+		formatter: NewTelegramMessageFormatter(
+			telegramEmojiProvider{},
+			true,
+			cfg.FrontURI,
+			location,
+			cfg.DropDescription,
+		),
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, trigger, contact, false, characterLimits[Message]), nil
+	}, nil
+}
@@ -0,0 +1,22 @@
+package twilio
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewSMSPreview returns preview of Twilio SMS which the sender sends.
+// Settings of the sender have only credentials and the phone which are not used.
+func NewSMSPreview(_ interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	sender := &twilioSenderSms{
+		twilioSender: twilioSender{
+			location: location,
+		},
+	}
+
+	return func(events moira.NotificationEvents, _ moira.ContactData, trigger moira.TriggerData) (string, error) {
+		return sender.buildMessage(events, trigger, false), nil
+	}, nil
+}
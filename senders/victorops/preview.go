@@ -0,0 +1,38 @@
+package victorops
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of VictorOps alerts which the sender with given settings sends.
+// Previewed messages are the alerts posted to the routing url without plots.
+func NewPreview(senderSettings interface{}, location *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to victorops config: %w", err)
+	}
+
+	sender := &Sender{
+		frontURI: cfg.FrontURI,
+		location: location,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		createAlertRequest := sender.buildCreateAlertRequest(events, trigger, contact, false, nil, time.Now().Unix())
+
+		requestBody, err := json.Marshal(createAlertRequest)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal alert: %w", err)
+		}
+
+		return string(requestBody), nil
+	}, nil
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+)
+
+// NewPreview returns preview of requests which the webhook sender with given settings sends.
+// Previewed messages are the bodies of requests without plots, they are rendered with the body template
+// of the contact or the sender like sent ones.
+func NewPreview(senderSettings interface{}, _ *time.Location, _ string) (senders.PreviewFunc, error) {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode senderSettings to webhook config: %w", err)
+	}
+
+	sender := &Sender{
+		body:     cfg.Body,
+		frontURI: cfg.FrontURI,
+	}
+
+	return func(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData) (string, error) {
+		requestBody, err := sender.buildSendAlertRequestBody(events, contact, trigger, nil, false)
+		if err != nil {
+			return "", err
+		}
+
+		return string(requestBody), nil
+	}, nil
+}
@@ -35,13 +35,24 @@ func (sender *Sender) buildSendAlertRequestBody(
 	plots [][]byte,
 	throttled bool,
 ) ([]byte, error) {
-	if sender.body == "" {
+	bodyTemplate := sender.body
+	if contact.BodyTemplate != "" {
+		bodyTemplate = contact.BodyTemplate
+	}
+
+	if bodyTemplate == "" {
 		return buildDefaultSendAlertRequestBody(events, contact, trigger, plots, throttled)
 	}
 
-	webhookBodyPopulater := templating.NewWebhookBodyPopulater(contact.ToTemplateContact())
+	webhookBodyPopulater := templating.NewWebhookBodyPopulater(
+		contact.ToTemplateContact(),
+		trigger.ToTemplateTrigger(sender.frontURI),
+		events.ToTemplateEvents(),
+		throttled,
+		sender.storePlots(plots, trigger.ID),
+	)
 
-	populatedBody, err := webhookBodyPopulater.Populate(sender.body)
+	populatedBody, err := webhookBodyPopulater.Populate(bodyTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +60,30 @@ func (sender *Sender) buildSendAlertRequestBody(
 	return []byte(html.UnescapeString(populatedBody)), nil
 }
 
+// storePlots stores plots in the configured image store and returns their urls.
+func (sender *Sender) storePlots(plots [][]byte, triggerID string) []string {
+	plotURLs := make([]string, 0, len(plots))
+	if !sender.imageStoreConfigured {
+		return plotURLs
+	}
+
+	for _, plot := range plots {
+		plotURL, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.log.Warning().
+				String(moira.LogFieldNameTriggerID, triggerID).
+				Error(err).
+				Msg("Could not store the plot image in the image store")
+
+			continue
+		}
+
+		plotURLs = append(plotURLs, plotURL)
+	}
+
+	return plotURLs
+}
+
 func buildDefaultSendAlertRequestBody(
 	events moira.NotificationEvents,
 	contact moira.ContactData,
@@ -244,6 +244,21 @@ func TestBuildRequestBody(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(string(requestBody), ShouldResemble, fmt.Sprintf("Contact.Type: %s\nContact.Value: %s", testContactType, testContactValue))
 		})
+
+		Convey("With body template overridden by contact", func() {
+			contact := moira.ContactData{
+				Value:        testContactValue,
+				Type:         testContactType,
+				BodyTemplate: `{"summary": "{{ jsonEscape .Trigger.Name }}", "tags": {{ toJSON .Trigger.Tags }}, "events": [{{ range $i, $event := .Events }}{{ if $i }}, {{ end }}"{{ $event.Metric }} {{ $event.OldState }}->{{ $event.State }}"{{ end }}], "throttled": {{ .Throttled }}}`,
+			}
+			events := moira.NotificationEvents{testEvents[0], testEvents[1]}
+			trigger := testTrigger
+			trigger.Name = `Disk "/var" is full`
+
+			requestBody, err := sender.buildSendAlertRequestBody(events, contact, trigger, make([][]byte, 0), true)
+			So(err, ShouldBeNil)
+			So(string(requestBody), ShouldResemble, `{"summary": "Disk \"/var\" is full", "tags": ["triggerTag1","triggerTag2"], "events": ["metricName1 ERROR->OK", "metricName2 ERROR->OK"], "throttled": true}`)
+		})
 	})
 }
 
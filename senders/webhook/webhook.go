@@ -11,6 +11,7 @@ import (
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/clock"
 	"github.com/moira-alert/moira/metrics"
+	"github.com/moira-alert/moira/senders"
 	"github.com/moira-alert/moira/senders/delivery"
 )
 
@@ -24,6 +25,8 @@ type config struct {
 	Timeout       int                 `mapstructure:"timeout"`
 	InsecureTLS   bool                `mapstructure:"insecure_tls"`
 	DeliveryCheck deliveryCheckConfig `mapstructure:"delivery_check"`
+	FrontURI      string              `mapstructure:"front_uri"`
+	ImageStore    string              `mapstructure:"image_store"`
 }
 
 // Sender implements moira sender interface via webhook.
@@ -40,6 +43,11 @@ type Sender struct {
 	Controller          *delivery.ChecksController
 	clock               moira.Clock
 	deliveryCheckConfig deliveryCheckConfig
+	// ImageStores are used to store plots, so their urls can be used in the body template.
+	ImageStores          map[string]moira.ImageStore
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	frontURI             string
 }
 
 func getDefaultDeliveryCheckConfig() deliveryCheckConfig {
@@ -111,6 +119,7 @@ func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, loca
 
 	sender.url = cfg.URL
 	sender.body = cfg.Body
+	sender.frontURI = cfg.FrontURI
 	sender.user, sender.password = cfg.User, cfg.Password
 
 	sender.headers = map[string]string{
@@ -130,6 +139,11 @@ func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, loca
 	}
 
 	sender.log = logger
+
+	if cfg.ImageStore != "" {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+
 	sender.client = &http.Client{
 		Timeout: time.Duration(timeout) * time.Second,
 		Transport: &http.Transport{
@@ -0,0 +1,2 @@
+// Package zulip is Moira sender for Zulip, it posts messages to a stream topic per trigger.
+package zulip
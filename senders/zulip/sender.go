@@ -0,0 +1,280 @@
+package zulip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders/emoji_provider"
+	"github.com/moira-alert/moira/senders/msgformat"
+)
+
+const (
+	sendMessagePath = "/api/v1/messages"
+	uploadFilePath  = "/api/v1/user_uploads"
+	streamType      = "stream"
+	resultSuccess   = "success"
+
+	errCodeStreamDoesNotExist = "STREAM_DOES_NOT_EXIST"
+
+	topicMaxCharacters   = 60
+	messageMaxCharacters = 10_000
+)
+
+var (
+	codeBlockStart = "```text"
+	codeBlockEnd   = "```"
+
+	defaultClientTimeout = 30 * time.Second
+)
+
+// Structure that represents the Zulip configuration in the YAML file.
+type config struct {
+	URL          string            `mapstructure:"url" validate:"required,url"`
+	BotEmail     string            `mapstructure:"bot_email" validate:"required"`
+	APIKey       string            `mapstructure:"api_key" validate:"required"`
+	FrontURI     string            `mapstructure:"front_uri"`
+	UseEmoji     bool              `mapstructure:"use_emoji"`
+	DefaultEmoji string            `mapstructure:"default_emoji"`
+	EmojiMap     map[string]string `mapstructure:"emoji_map"`
+}
+
+// Sender posts messages to Zulip streams.
+// Every trigger gets its own topic in the stream, so all notifications of the trigger are grouped together.
+type Sender struct {
+	url       string
+	botEmail  string
+	apiKey    string
+	logger    moira.Logger
+	client    *http.Client
+	formatter msgformat.MessageFormatter
+}
+
+type response struct {
+	Result string `json:"result"`
+	Msg    string `json:"msg"`
+	Code   string `json:"code"`
+	URI    string `json:"uri"`
+}
+
+// Init configures Sender.
+func (sender *Sender) Init(senderSettings interface{}, logger moira.Logger, location *time.Location, _ string) error {
+	var cfg config
+
+	err := mapstructure.Decode(senderSettings, &cfg)
+	if err != nil {
+		return fmt.Errorf("failed to decode senderSettings to zulip config: %w", err)
+	}
+
+	if err = moira.ValidateStruct(cfg); err != nil {
+		return fmt.Errorf("zulip config validation error: %w", err)
+	}
+
+	emojiProvider, err := emoji_provider.NewEmojiProvider(cfg.DefaultEmoji, cfg.EmojiMap)
+	if err != nil {
+		return fmt.Errorf("cannot initialize zulip sender, err: %w", err)
+	}
+
+	sender.url = strings.TrimSuffix(cfg.URL, "/")
+	sender.botEmail = cfg.BotEmail
+	sender.apiKey = cfg.APIKey
+	sender.logger = logger
+	sender.client = &http.Client{
+		Timeout: defaultClientTimeout,
+	}
+	sender.formatter = newMessageFormatter(emojiProvider, cfg.UseEmoji, cfg.FrontURI, location)
+
+	return nil
+}
+
+func newMessageFormatter(emojiProvider emoji_provider.StateEmojiGetter, useEmoji bool, frontURI string, location *time.Location) msgformat.MessageFormatter {
+	return msgformat.NewHighlightSyntaxFormatter(
+		emojiProvider,
+		useEmoji,
+		frontURI,
+		location,
+		uriFormatter,
+		descriptionFormatter,
+		msgformat.DefaultDescriptionCutter,
+		boldFormatter,
+		eventStringFormatter,
+		codeBlockStart,
+		codeBlockEnd)
+}
+
+func uriFormatter(triggerURI, triggerName string) string {
+	return fmt.Sprintf("[%s](%s)", triggerName, triggerURI)
+}
+
+func descriptionFormatter(trigger moira.TriggerData, contact moira.ContactData) string {
+	desc := trigger.Desc
+	if trigger.Desc != "" {
+		desc += "\n"
+	}
+
+	if contact.ExtraMessage != "" {
+		desc = contact.ExtraMessage + "\n" + desc
+	}
+
+	return desc
+}
+
+func boldFormatter(str string) string {
+	return fmt.Sprintf("**%s**", str)
+}
+
+func eventStringFormatter(event moira.NotificationEvent, loc *time.Location) string {
+	return fmt.Sprintf(
+		"%s: %s = %s (%s to %s)",
+		event.FormatTimestamp(loc, moira.DefaultTimeFormat),
+		event.Metric,
+		event.GetMetricsValues(moira.DefaultNotificationSettings),
+		event.OldState,
+		event.State)
+}
+
+// SendEvents implements moira.Sender interface.
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plots [][]byte, throttled bool) error {
+	ctx := context.Background()
+
+	message := sender.buildMessage(events, contact, trigger, throttled)
+
+	for i, plot := range plots {
+		plotURI, err := sender.uploadPlot(ctx, plot, trigger.ID)
+		if err != nil {
+			sender.logger.Warning().
+				String(moira.LogFieldNameTriggerID, trigger.ID).
+				String("contact_value", contact.Value).
+				String("contact_type", contact.Type).
+				Error(err).
+				Msg("Failed to upload plot to zulip")
+
+			continue
+		}
+
+		message += fmt.Sprintf("\n[Plot %d](%s)", i+1, plotURI)
+	}
+
+	form := url.Values{
+		"type":    []string{streamType},
+		"to":      []string{contact.Value},
+		"topic":   []string{buildTopic(trigger)},
+		"content": []string{message},
+	}
+
+	_, err := sender.do(ctx, sendMessagePath, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		var zulipErr zulipError
+		if errors.As(err, &zulipErr) && zulipErr.code == errCodeStreamDoesNotExist {
+			return moira.NewSenderBrokenContactError(err)
+		}
+
+		return fmt.Errorf("failed to send %s event message to zulip stream [%s]: %w", trigger.ID, contact.Value, err)
+	}
+
+	return nil
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) string {
+	return sender.formatter.Format(msgformat.MessageFormatterParams{
+		Events:          events,
+		Trigger:         trigger,
+		Contact:         contact,
+		MessageMaxChars: messageMaxCharacters,
+		Throttled:       throttled,
+	})
+}
+
+// buildTopic returns the topic name of the trigger, it is limited by the maximum topic length of Zulip.
+func buildTopic(trigger moira.TriggerData) string {
+	topic := trigger.Name
+	if topic == "" {
+		topic = trigger.ID
+	}
+
+	if utf8.RuneCountInString(topic) > topicMaxCharacters {
+		topic = string([]rune(topic)[:topicMaxCharacters-len("...")]) + "..."
+	}
+
+	return topic
+}
+
+func (sender *Sender) uploadPlot(ctx context.Context, plot []byte, triggerID string) (string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("filename", triggerID+".png")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = part.Write(plot); err != nil {
+		return "", err
+	}
+
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	resp, err := sender.do(ctx, uploadFilePath, writer.FormDataContentType(), body)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.URI, nil
+}
+
+type zulipError struct {
+	statusCode int
+	code       string
+	msg        string
+}
+
+func (err zulipError) Error() string {
+	return fmt.Sprintf("zulip responded with %d %s: %s", err.statusCode, err.code, err.msg)
+}
+
+func (sender *Sender) do(ctx context.Context, path, contentType string, body io.Reader) (response, error) {
+	var result response
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sender.url+path, body)
+	if err != nil {
+		return result, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	request.SetBasicAuth(sender.botEmail, sender.apiKey)
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Moira")
+
+	resp, err := sender.client.Do(request)
+	if err != nil {
+		return result, fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if err = json.Unmarshal(responseBody, &result); err != nil {
+		return result, zulipError{statusCode: resp.StatusCode, msg: string(responseBody)}
+	}
+
+	if resp.StatusCode != http.StatusOK || result.Result != resultSuccess {
+		return result, zulipError{statusCode: resp.StatusCode, code: result.Code, msg: result.Msg}
+	}
+
+	return result, nil
+}
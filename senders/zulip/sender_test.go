@@ -0,0 +1,124 @@
+package zulip
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/h2non/gock"
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testZulipURL = "https://zulip.example.org"
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	Convey("Init tests", t, func() {
+		validatorErr := validator.ValidationErrors{}
+
+		Convey("Without api_key", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{"url": testZulipURL, "bot_email": "moira-bot@zulip.example.org"}, logger, location, "")
+			So(errors.As(err, &validatorErr), ShouldBeTrue)
+		})
+
+		Convey("With full config", func() {
+			sender := Sender{}
+			err := sender.Init(map[string]interface{}{
+				"url":       testZulipURL + "/",
+				"bot_email": "moira-bot@zulip.example.org",
+				"api_key":   "key",
+			}, logger, location, "")
+			So(err, ShouldBeNil)
+			So(sender.url, ShouldEqual, testZulipURL)
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test", true)
+	location, _ := time.LoadLocation("UTC")
+
+	sender := Sender{}
+	err := sender.Init(map[string]interface{}{
+		"url":       testZulipURL,
+		"bot_email": "moira-bot@zulip.example.org",
+		"api_key":   "key",
+		"front_uri": "http://moira.url",
+	}, logger, location, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := moira.NotificationEvents{{Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR, Values: map[string]float64{"t1": 1}}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	contact := moira.ContactData{Value: "alerts"}
+
+	Convey("Send events", t, func() {
+		defer gock.Off()
+
+		Convey("Message with plot is posted to the trigger topic", func() {
+			gock.New(testZulipURL).
+				Post(uploadFilePath).
+				BasicAuth("moira-bot@zulip.example.org", "key").
+				Reply(http.StatusOK).
+				JSON(map[string]string{"result": "success", "uri": "/user_uploads/1/plot.png"})
+			gock.New(testZulipURL).
+				Post(sendMessagePath).
+				BasicAuth("moira-bot@zulip.example.org", "key").
+				AddMatcher(func(request *http.Request, _ *gock.Request) (bool, error) {
+					if err := request.ParseForm(); err != nil {
+						return false, err
+					}
+
+					return request.PostForm.Get("type") == streamType &&
+						request.PostForm.Get("to") == "alerts" &&
+						request.PostForm.Get("topic") == "Name" &&
+						strings.HasSuffix(request.PostForm.Get("content"), "\n[Plot 1](/user_uploads/1/plot.png)"), nil
+				}).
+				Reply(http.StatusOK).
+				JSON(map[string]interface{}{"result": "success", "id": 42})
+
+			err := sender.SendEvents(events, contact, trigger, [][]byte{[]byte("plot")}, false)
+			So(err, ShouldBeNil)
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Unknown stream is a broken contact", func() {
+			gock.New(testZulipURL).
+				Post(sendMessagePath).
+				Reply(http.StatusBadRequest).
+				JSON(map[string]string{"result": "error", "code": errCodeStreamDoesNotExist, "msg": "Stream 'alerts' does not exist"})
+
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldHaveSameTypeAs, moira.SenderBrokenContactError{})
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+
+		Convey("Other errors are returned", func() {
+			gock.New(testZulipURL).
+				Post(sendMessagePath).
+				Reply(http.StatusTooManyRequests).
+				JSON(map[string]string{"result": "error", "code": "RATE_LIMIT_HIT", "msg": "API usage exceeded rate limit"})
+
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err.Error(), ShouldEqual, "failed to send TriggerID event message to zulip stream [alerts]: zulip responded with 429 RATE_LIMIT_HIT: API usage exceeded rate limit")
+			So(gock.IsDone(), ShouldBeTrue)
+		})
+	})
+}
+
+func TestBuildTopic(t *testing.T) {
+	Convey("Build topic", t, func() {
+		So(buildTopic(moira.TriggerData{ID: "TriggerID", Name: "Name"}), ShouldEqual, "Name")
+		So(buildTopic(moira.TriggerData{ID: "TriggerID"}), ShouldEqual, "TriggerID")
+		So(buildTopic(moira.TriggerData{Name: strings.Repeat("ы", 100)}), ShouldEqual, strings.Repeat("ы", 57)+"...")
+	})
+}
@@ -0,0 +1,114 @@
+package moira
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SilenceMatcherType is a property of trigger or metric which silence matcher is applied to.
+type SilenceMatcherType string
+
+// Available types of silence matchers.
+const (
+	// SilenceMatcherTag matches if any tag of the trigger matches, negative operators match if no tag matches.
+	SilenceMatcherTag SilenceMatcherType = "tag"
+	// SilenceMatcherTriggerID matches id of the trigger. Several matchers with operator "=" make a list of triggers,
+	// the silence matches any of them.
+	SilenceMatcherTriggerID SilenceMatcherType = "trigger_id"
+	// SilenceMatcherTriggerName matches name of the trigger.
+	SilenceMatcherTriggerName SilenceMatcherType = "trigger_name"
+	// SilenceMatcherMetric matches metric name, operator "=" uses graphite-like glob pattern.
+	SilenceMatcherMetric SilenceMatcherType = "metric"
+	// SilenceMatcherLabel matches value of seriesByTag label of the metric, "name" label is the name of the series.
+	SilenceMatcherLabel SilenceMatcherType = "label"
+)
+
+// SilenceMatchOperator is an operator of silence matcher.
+type SilenceMatchOperator string
+
+// Available operators of silence matchers.
+const (
+	SilenceMatchEqual     SilenceMatchOperator = "="
+	SilenceMatchNotEqual  SilenceMatchOperator = "!="
+	SilenceMatchRegexp    SilenceMatchOperator = "=~"
+	SilenceMatchNotRegexp SilenceMatchOperator = "!~"
+)
+
+// Silence mutes notifications about events of all triggers and metrics matching all its matchers from start till expiry,
+// except trigger_id matchers with operator "=", any of which has to match.
+// Unlike maintenance, silence does not change state of triggers, events are still saved to history but not sent.
+type Silence struct {
+	ID        string           `json:"id" example:"d5d98eb3-ee18-4f75-9364-244f67e23b54"`
+	Matchers  []SilenceMatcher `json:"matchers"`
+	Comment   string           `json:"comment" example:"Replacing disks of web1"`
+	CreatedBy string           `json:"created_by" example:"john"`
+	CreatedAt int64            `json:"created_at" example:"1704067200" format:"int64"`
+	StartsAt  int64            `json:"starts_at" example:"1704067200" format:"int64"`
+	ExpiresAt int64            `json:"expires_at" example:"1704078000" format:"int64"`
+}
+
+// SilenceMatcher is a single condition of silence.
+type SilenceMatcher struct {
+	Type SilenceMatcherType `json:"type" swaggertype:"string" example:"label"`
+	// Label is a name of seriesByTag label, it is used only by matchers of label type.
+	Label    string               `json:"label,omitempty" example:"host"`
+	Operator SilenceMatchOperator `json:"operator" swaggertype:"string" example:"="`
+	Value    string               `json:"value" example:"web1"`
+}
+
+// IsActive returns true if silence mutes notifications at the given time.
+func (silence *Silence) IsActive(timestamp int64) bool {
+	return silence.StartsAt <= timestamp && timestamp < silence.ExpiresAt
+}
+
+// GetTriggerIDs returns ids of triggers which the silence is limited to by matchers of trigger_id type with operator "=",
+// the silence matches events of any of these triggers.
+func (silence *Silence) GetTriggerIDs() []string {
+	triggerIDs := make([]string, 0)
+
+	for _, matcher := range silence.Matchers {
+		if matcher.Type == SilenceMatcherTriggerID && matcher.Operator == SilenceMatchEqual {
+			triggerIDs = append(triggerIDs, matcher.Value)
+		}
+	}
+
+	return triggerIDs
+}
+
+// Validate checks that matcher has known type and operator and its regular expression compiles.
+func (matcher *SilenceMatcher) Validate() error {
+	switch matcher.Type {
+	case SilenceMatcherTag, SilenceMatcherTriggerID, SilenceMatcherTriggerName, SilenceMatcherMetric:
+	case SilenceMatcherLabel:
+		if matcher.Label == "" {
+			return fmt.Errorf("silence matcher of type '%s' must have label", matcher.Type)
+		}
+	default:
+		return fmt.Errorf("unknown silence matcher type '%s'", matcher.Type)
+	}
+
+	switch matcher.Operator {
+	case SilenceMatchEqual, SilenceMatchNotEqual:
+		return nil
+	case SilenceMatchRegexp, SilenceMatchNotRegexp:
+		_, err := matcher.CompileRegexp()
+		return err
+	default:
+		return fmt.Errorf("unknown silence matcher operator '%s'", matcher.Operator)
+	}
+}
+
+// IsNegative returns true if the matcher matches values which do not match its value.
+func (matcher *SilenceMatcher) IsNegative() bool {
+	return matcher.Operator == SilenceMatchNotEqual || matcher.Operator == SilenceMatchNotRegexp
+}
+
+// CompileRegexp compiles regular expression of matcher with operator "=~" or "!~", it must match the whole value.
+func (matcher *SilenceMatcher) CompileRegexp() (*regexp.Regexp, error) {
+	expression, err := regexp.Compile("^(?:" + matcher.Value + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid silence matcher regular expression %q: %w", matcher.Value, err)
+	}
+
+	return expression, nil
+}
@@ -0,0 +1,39 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSilenceMatcher_Validate(t *testing.T) {
+	Convey("Test silence matcher validation", t, func() {
+		So((&SilenceMatcher{Type: SilenceMatcherTag, Operator: SilenceMatchEqual, Value: "disk"}).Validate(), ShouldBeNil)
+		So((&SilenceMatcher{Type: "host", Operator: SilenceMatchEqual}).Validate(), ShouldNotBeNil)
+		So((&SilenceMatcher{Type: SilenceMatcherTag, Operator: "=="}).Validate(), ShouldNotBeNil)
+		So((&SilenceMatcher{Type: SilenceMatcherLabel, Operator: SilenceMatchEqual}).Validate(), ShouldNotBeNil)
+		So((&SilenceMatcher{Type: SilenceMatcherTag, Operator: SilenceMatchRegexp, Value: "(disk"}).Validate(), ShouldNotBeNil)
+		So((&SilenceMatcher{Type: SilenceMatcherTriggerID, Operator: SilenceMatchEqual, Value: "trigger1"}).Validate(), ShouldBeNil)
+	})
+}
+
+func TestSilence_GetTriggerIDs(t *testing.T) {
+	Convey("Test trigger ids of silence", t, func() {
+		silence := &Silence{Matchers: []SilenceMatcher{
+			{Type: SilenceMatcherTriggerID, Operator: SilenceMatchEqual, Value: "trigger1"},
+			{Type: SilenceMatcherTriggerID, Operator: SilenceMatchNotEqual, Value: "trigger2"},
+			{Type: SilenceMatcherTag, Operator: SilenceMatchEqual, Value: "disk"},
+		}}
+		So(silence.GetTriggerIDs(), ShouldResemble, []string{"trigger1"})
+		So((&Silence{}).GetTriggerIDs(), ShouldBeEmpty)
+	})
+}
+
+func TestSilence_IsActive(t *testing.T) {
+	Convey("Test silence is active from start till expiry", t, func() {
+		silence := &Silence{StartsAt: 100, ExpiresAt: 200}
+		So(silence.IsActive(99), ShouldBeFalse)
+		So(silence.IsActive(100), ShouldBeTrue)
+		So(silence.IsActive(200), ShouldBeFalse)
+	})
+}
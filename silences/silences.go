@@ -0,0 +1,150 @@
+// Package silences matches events of triggers and metrics with silences of notifications.
+package silences
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/filter"
+)
+
+const (
+	seriesByTagNameLabel      = "name"
+	seriesByTagLabelSeparator = ";"
+)
+
+// Silence is a silence of notifications with compiled matchers, so it can be matched with many events.
+type Silence struct {
+	*moira.Silence
+	matchers []matcher
+}
+
+type matcher struct {
+	moira.SilenceMatcher
+	match func(value string) bool
+}
+
+// Compile compiles matchers of the silence. Patterns of metric matchers are matched like patterns of trigger targets by filter.
+// Matchers of trigger_id type with operator "=" are compiled into one matcher of any of their triggers.
+func Compile(logger moira.Logger, silence *moira.Silence) (*Silence, error) {
+	compiled := &Silence{
+		Silence:  silence,
+		matchers: make([]matcher, 0, len(silence.Matchers)),
+	}
+
+	triggerIDs := make(map[string]struct{})
+
+	for _, silenceMatcher := range silence.Matchers {
+		if err := silenceMatcher.Validate(); err != nil {
+			return nil, err
+		}
+
+		if silenceMatcher.Type == moira.SilenceMatcherTriggerID && silenceMatcher.Operator == moira.SilenceMatchEqual {
+			triggerIDs[silenceMatcher.Value] = struct{}{}
+			continue
+		}
+
+		match, err := compileMatchFunc(logger, silenceMatcher)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled.matchers = append(compiled.matchers, matcher{SilenceMatcher: silenceMatcher, match: match})
+	}
+
+	if len(triggerIDs) != 0 {
+		compiled.matchers = append(compiled.matchers, matcher{
+			SilenceMatcher: moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual},
+			match: func(value string) bool {
+				_, ok := triggerIDs[value]
+				return ok
+			},
+		})
+	}
+
+	return compiled, nil
+}
+
+// IsMatched returns true if all matchers of silence match the trigger and its metric.
+// Metric is empty for events of the whole trigger, so matchers of metric and label types compare empty strings.
+func (silence *Silence) IsMatched(trigger *moira.Trigger, metric string) bool {
+	for _, matcher := range silence.matchers {
+		if !matcher.isMatched(trigger, metric) {
+			return false
+		}
+	}
+
+	return len(silence.matchers) != 0
+}
+
+func (matcher *matcher) isMatched(trigger *moira.Trigger, metric string) bool {
+	var values []string
+
+	switch matcher.Type {
+	case moira.SilenceMatcherTag:
+		values = trigger.Tags
+	case moira.SilenceMatcherTriggerID:
+		values = []string{trigger.ID}
+	case moira.SilenceMatcherTriggerName:
+		values = []string{trigger.Name}
+	case moira.SilenceMatcherMetric:
+		values = []string{metric}
+	case moira.SilenceMatcherLabel:
+		values = []string{getMetricLabel(metric, matcher.Label)}
+	}
+
+	for _, value := range values {
+		if matcher.match(value) {
+			return !matcher.IsNegative()
+		}
+	}
+
+	return matcher.IsNegative()
+}
+
+// compileMatchFunc returns function which checks value against the matcher ignoring negation of the operator.
+func compileMatchFunc(logger moira.Logger, silenceMatcher moira.SilenceMatcher) (func(value string) bool, error) {
+	switch silenceMatcher.Operator {
+	case moira.SilenceMatchEqual, moira.SilenceMatchNotEqual:
+		if silenceMatcher.Type == moira.SilenceMatcherMetric {
+			patternIndex := filter.NewPatternIndex(logger, []string{silenceMatcher.Value}, filter.Compatibility{})
+
+			return func(value string) bool {
+				return len(patternIndex.MatchPatterns(value)) != 0
+			}, nil
+		}
+
+		return func(value string) bool {
+			return value == silenceMatcher.Value
+		}, nil
+	case moira.SilenceMatchRegexp, moira.SilenceMatchNotRegexp:
+		expression, err := silenceMatcher.CompileRegexp()
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.MatchString, nil
+	default:
+		return nil, fmt.Errorf("unknown silence matcher operator '%s'", silenceMatcher.Operator)
+	}
+}
+
+// getMetricLabel returns value of the label of seriesByTag metric like "cpu.user;host=web1;dc=west", empty if there is no such label.
+func getMetricLabel(metric, label string) string {
+	name, labels, _ := strings.Cut(metric, seriesByTagLabelSeparator)
+	if label == seriesByTagNameLabel {
+		return name
+	}
+
+	for labels != "" {
+		var pair string
+
+		pair, labels, _ = strings.Cut(labels, seriesByTagLabelSeparator)
+		if key, value, ok := strings.Cut(pair, "="); ok && key == label {
+			return value
+		}
+	}
+
+	return ""
+}
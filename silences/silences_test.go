@@ -0,0 +1,106 @@
+package silences
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSilence_IsMatched(t *testing.T) {
+	logger, _ := logging.GetLogger("Silences")
+	trigger := &moira.Trigger{ID: "trigger1", Name: "Disk usage", Tags: []string{"servers", "disk"}}
+
+	compile := func(matchers ...moira.SilenceMatcher) *Silence {
+		silence, err := Compile(logger, &moira.Silence{Matchers: matchers})
+		So(err, ShouldBeNil)
+
+		return silence
+	}
+
+	Convey("Test silence matching", t, func() {
+		Convey("Tag matchers", func() {
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"}).IsMatched(trigger, ""), ShouldBeTrue)
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchNotEqual, Value: "disk"}).IsMatched(trigger, ""), ShouldBeFalse)
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchRegexp, Value: "serv.*"}).IsMatched(trigger, ""), ShouldBeTrue)
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchNotRegexp, Value: "db-.*"}).IsMatched(trigger, ""), ShouldBeTrue)
+		})
+
+		Convey("Trigger id matchers", func() {
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: "trigger1"}).IsMatched(trigger, ""), ShouldBeTrue)
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: "trigger2"}).IsMatched(trigger, ""), ShouldBeFalse)
+		})
+
+		Convey("Several trigger id matchers match any of the triggers", func() {
+			silence := compile(
+				moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: "trigger1"},
+				moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerID, Operator: moira.SilenceMatchEqual, Value: "trigger2"},
+				moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"},
+			)
+			So(silence.IsMatched(trigger, ""), ShouldBeTrue)
+			So(silence.IsMatched(&moira.Trigger{ID: "trigger2", Tags: []string{"disk"}}, ""), ShouldBeTrue)
+			So(silence.IsMatched(&moira.Trigger{ID: "trigger2", Tags: []string{"cpu"}}, ""), ShouldBeFalse)
+			So(silence.IsMatched(&moira.Trigger{ID: "trigger3", Tags: []string{"disk"}}, ""), ShouldBeFalse)
+		})
+
+		Convey("Trigger name matchers", func() {
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerName, Operator: moira.SilenceMatchEqual, Value: "Disk usage"}).IsMatched(trigger, ""), ShouldBeTrue)
+			So(compile(moira.SilenceMatcher{Type: moira.SilenceMatcherTriggerName, Operator: moira.SilenceMatchRegexp, Value: "Disk"}).IsMatched(trigger, ""), ShouldBeFalse)
+		})
+
+		Convey("Metric matchers", func() {
+			silence := compile(moira.SilenceMatcher{Type: moira.SilenceMatcherMetric, Operator: moira.SilenceMatchEqual, Value: "servers.web1.*"})
+			So(silence.IsMatched(trigger, "servers.web1.disk"), ShouldBeTrue)
+			So(silence.IsMatched(trigger, "servers.web2.disk"), ShouldBeFalse)
+			So(silence.IsMatched(trigger, "servers.web1.disk.used"), ShouldBeFalse)
+			So(silence.IsMatched(trigger, ""), ShouldBeFalse)
+
+			silence = compile(moira.SilenceMatcher{Type: moira.SilenceMatcherMetric, Operator: moira.SilenceMatchNotEqual, Value: "servers.{web1,web2}.disk"})
+			So(silence.IsMatched(trigger, "servers.web2.disk"), ShouldBeFalse)
+			So(silence.IsMatched(trigger, "servers.web3.disk"), ShouldBeTrue)
+		})
+
+		Convey("Label matchers", func() {
+			silence := compile(moira.SilenceMatcher{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchRegexp, Value: "web[12]"})
+			So(silence.IsMatched(trigger, "disk.used;dc=west;host=web2"), ShouldBeTrue)
+			So(silence.IsMatched(trigger, "disk.used;dc=west;host=web3"), ShouldBeFalse)
+
+			silence = compile(moira.SilenceMatcher{Type: moira.SilenceMatcherLabel, Label: "name", Operator: moira.SilenceMatchEqual, Value: "disk.used"})
+			So(silence.IsMatched(trigger, "disk.used;host=web2"), ShouldBeTrue)
+		})
+
+		Convey("All matchers must match", func() {
+			silence := compile(
+				moira.SilenceMatcher{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"},
+				moira.SilenceMatcher{Type: moira.SilenceMatcherLabel, Label: "host", Operator: moira.SilenceMatchEqual, Value: "web1"},
+			)
+			So(silence.IsMatched(trigger, "disk.used;host=web1"), ShouldBeTrue)
+			So(silence.IsMatched(trigger, "disk.used;host=web2"), ShouldBeFalse)
+			So(silence.IsMatched(&moira.Trigger{Tags: []string{"cpu"}}, "disk.used;host=web1"), ShouldBeFalse)
+		})
+
+		Convey("Silence without matchers matches nothing", func() {
+			So(compile().IsMatched(trigger, ""), ShouldBeFalse)
+		})
+	})
+}
+
+func TestCompile(t *testing.T) {
+	logger, _ := logging.GetLogger("Silences")
+
+	Convey("Test silence compilation", t, func() {
+		Convey("Silence with invalid matcher is not compiled", func() {
+			silence := &moira.Silence{Matchers: []moira.SilenceMatcher{{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchRegexp, Value: "(disk"}}}
+			_, err := Compile(logger, silence)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Compiled silence keeps silence data", func() {
+			silence := &moira.Silence{ID: "silence1", Matchers: []moira.SilenceMatcher{{Type: moira.SilenceMatcherTag, Operator: moira.SilenceMatchEqual, Value: "disk"}}}
+			compiled, err := Compile(logger, silence)
+			So(err, ShouldBeNil)
+			So(compiled.ID, ShouldEqual, "silence1")
+		})
+	})
+}
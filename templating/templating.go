@@ -2,6 +2,7 @@ package templating
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"strings"
@@ -32,7 +33,29 @@ func filterKeys(source template.FuncMap, keys []string) template.FuncMap {
 	return result
 }
 
+// jsonEscape escapes the given string to be placed inside JSON string quotes.
+func jsonEscape(str string) (string, error) {
+	escaped, err := json.Marshal(str)
+	if err != nil {
+		return "", err
+	}
+
+	return string(escaped[1 : len(escaped)-1]), nil
+}
+
+// toJSON encodes the given value to JSON, strings are encoded with quotes.
+func toJSON(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
 var funcMap = template.FuncMap{
+	"jsonEscape":        jsonEscape,
+	"toJSON":            toJSON,
 	"date":              date,
 	"formatDate":        formatDate,
 	"stringsReplace":    strings.Replace,
@@ -10,7 +10,11 @@ type Event struct {
 	MetricElements []string
 	Timestamp      int64
 	Value          *float64
+	Values         map[string]float64
 	State          string
+	OldState       string
+	IsTriggerEvent bool
+	Message        string
 }
 
 // TimestampDecrease decreases the timestamp of the event by the given number of seconds.
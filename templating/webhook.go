@@ -6,18 +6,39 @@ type Contact struct {
 	Value string
 }
 
+// Trigger represents a template trigger with fields allowed for use in webhook body templates.
+type Trigger struct {
+	ID         string
+	Name       string
+	Desc       string
+	Tags       []string
+	Targets    []string
+	WarnValue  float64
+	ErrorValue float64
+	URL        string
+}
+
 type webhookBodyPopulater struct {
-	Contact *Contact
+	Contact   *Contact
+	Trigger   *Trigger
+	Events    []Event
+	Throttled bool
+	PlotURLs  []string
 }
 
-// NewWebhookBodyPopulater creates a new webhook body populater with provided template contact.
-func NewWebhookBodyPopulater(contact *Contact) *webhookBodyPopulater {
+// NewWebhookBodyPopulater creates a new webhook body populater with provided template contact,
+// trigger, events, throttled flag and urls of the stored plots.
+func NewWebhookBodyPopulater(contact *Contact, trigger *Trigger, events []Event, throttled bool, plotURLs []string) *webhookBodyPopulater {
 	return &webhookBodyPopulater{
-		Contact: contact,
+		Contact:   contact,
+		Trigger:   trigger,
+		Events:    events,
+		Throttled: throttled,
+		PlotURLs:  plotURLs,
 	}
 }
 
-// Populate populates the given template with contact data.
+// Populate populates the given template with contact, trigger and events data.
 func (templateData *webhookBodyPopulater) Populate(tmpl string) (string, error) {
 	return populate(tmpl, templateData)
 }
@@ -13,7 +13,7 @@ func Test_TemplateWebhookBody(t *testing.T) {
 			"Contact Value: {{ .Contact.Value }}"
 
 		Convey("Test with nil data", func() {
-			webhookPopulater := NewWebhookBodyPopulater(nil)
+			webhookPopulater := NewWebhookBodyPopulater(nil, nil, nil, false, nil)
 
 			actual, err := webhookPopulater.Populate(template)
 			So(err, ShouldNotBeNil)
@@ -21,7 +21,7 @@ func Test_TemplateWebhookBody(t *testing.T) {
 		})
 
 		Convey("Test with empty data", func() {
-			webhookPopulater := NewWebhookBodyPopulater(&Contact{})
+			webhookPopulater := NewWebhookBodyPopulater(&Contact{}, nil, nil, false, nil)
 			expected := "" +
 				"Contact Type: \n" +
 				"Contact Value:"
@@ -34,7 +34,7 @@ func Test_TemplateWebhookBody(t *testing.T) {
 		Convey("Test with empty value", func() {
 			webhookPopulater := NewWebhookBodyPopulater(&Contact{
 				Type: "slack",
-			})
+			}, nil, nil, false, nil)
 			expected := "" +
 				"Contact Type: slack\n" +
 				"Contact Value:"
@@ -47,7 +47,7 @@ func Test_TemplateWebhookBody(t *testing.T) {
 		Convey("Test with empty type", func() {
 			webhookPopulater := NewWebhookBodyPopulater(&Contact{
 				Value: "#test_channel",
-			})
+			}, nil, nil, false, nil)
 			expected := "" +
 				"Contact Type: \n" +
 				"Contact Value: #test_channel"
@@ -61,7 +61,7 @@ func Test_TemplateWebhookBody(t *testing.T) {
 			webhookPopulater := NewWebhookBodyPopulater(&Contact{
 				Type:  "slack",
 				Value: "#test_channel",
-			})
+			}, nil, nil, false, nil)
 			expected := "" +
 				"Contact Type: slack\n" +
 				"Contact Value: #test_channel"
@@ -73,6 +73,62 @@ func Test_TemplateWebhookBody(t *testing.T) {
 	})
 }
 
+func Test_TemplateWebhookBodyWithTriggerAndEvents(t *testing.T) {
+	Convey("Test webhook body populater with trigger, events and plots", t, func() {
+		value := 12.5
+		contact := &Contact{Type: "webhook", Value: "ops"}
+		trigger := &Trigger{
+			ID:         "trigger-id",
+			Name:       "Disk \"/var\" is full",
+			Desc:       "line1\nline2",
+			Tags:       []string{"disk", "prod"},
+			Targets:    []string{"server.*.disk.free"},
+			WarnValue:  10,
+			ErrorValue: 5,
+			URL:        "https://moira.example.com/trigger/trigger-id",
+		}
+		events := []Event{
+			{
+				Metric:         "server.web1.disk.free",
+				Timestamp:      1700000000,
+				Value:          &value,
+				Values:         map[string]float64{"t1": 12.5},
+				State:          "WARN",
+				OldState:       "OK",
+				IsTriggerEvent: false,
+			},
+		}
+		plotURLs := []string{"https://images.example.com/plot.png"}
+
+		Convey("with trigger fields and event states", func() {
+			template := "" +
+				"{{ .Trigger.ID }} {{ .Trigger.WarnValue }}/{{ .Trigger.ErrorValue }} {{ .Trigger.URL }}\n" +
+				"{{ range .Trigger.Targets }}{{ . }}{{ end }}\n" +
+				"{{ range .Events }}{{ .Metric }} {{ .OldState }}->{{ .State }} {{ index .Values \"t1\" }}{{ end }}\n" +
+				"{{ .Throttled }} {{ index .PlotURLs 0 }}"
+			expected := "" +
+				"trigger-id 10/5 https://moira.example.com/trigger/trigger-id\n" +
+				"server.*.disk.free\n" +
+				"server.web1.disk.free OK->WARN 12.5\n" +
+				"true https://images.example.com/plot.png"
+
+			actual, err := NewWebhookBodyPopulater(contact, trigger, events, true, plotURLs).Populate(template)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+		})
+
+		Convey("with json helpers", func() {
+			template := `{"name": "{{ jsonEscape .Trigger.Name }}", "desc": {{ toJSON .Trigger.Desc }}, "tags": {{ toJSON .Trigger.Tags }}}`
+			// Values are html escaped here, the webhook sender unescapes the populated body.
+			expected := `{"name": "Disk \&#34;/var\&#34; is full", "desc": &#34;line1\nline2&#34;, "tags": [&#34;disk&#34;,&#34;prod&#34;]}`
+
+			actual, err := NewWebhookBodyPopulater(contact, trigger, events, false, plotURLs).Populate(template)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+		})
+	})
+}
+
 func Test_TemplateWebhookDeliveryCheckURL(t *testing.T) {
 	Convey("Test populating webhook delivery check url template", t, func() {
 		template := "https://example.url/delivery/check/{{ .SendAlertResponse.requestID }}/{{ .Contact.Type }}/{{ .TriggerID }}"